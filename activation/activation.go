@@ -30,10 +30,31 @@ type PoetConfig struct {
 	PhaseShift  time.Duration `mapstructure:"phase-shift"`
 	CycleGap    time.Duration `mapstructure:"cycle-gap"`
 	GracePeriod time.Duration `mapstructure:"grace-period"`
+
+	// Policy is the retry and health check policy of poet endpoints.
+	Policy PoetPolicy `mapstructure:"poet-policy"`
+	// Policies overrides Policy for specific endpoints.
+	Policies map[string]PoetPolicy `mapstructure:"poet-policies"`
+
+	// Local enables the in-process PoET, for tests and local networks without poet servers.
+	Local bool `mapstructure:"poet-local"`
+	// LocalLeaves is the number of leaves in every proof of the in-process PoET.
+	LocalLeaves uint64 `mapstructure:"poet-local-leaves"`
 }
 
 func DefaultPoetConfig() PoetConfig {
-	return PoetConfig{}
+	return PoetConfig{
+		Policy:      DefaultPoetPolicy(),
+		LocalLeaves: defaultLocalPoetLeaves,
+	}
+}
+
+// PolicyFor returns the retry and health check policy of the endpoint.
+func (cfg PoetConfig) PolicyFor(endpoint string) PoetPolicy {
+	if policy, exists := cfg.Policies[endpoint]; exists {
+		return policy
+	}
+	return cfg.Policy
 }
 
 const defaultPoetRetryInterval = 5 * time.Second
@@ -152,27 +173,31 @@ func NewBuilder(
 	opts ...BuilderOption,
 ) *Builder {
 	b := &Builder{
-		parentCtx:             context.Background(),
-		signer:                signer,
-		nodeID:                nodeID,
-		coinbaseAccount:       conf.CoinbaseAccount,
-		goldenATXID:           conf.GoldenATXID,
-		layersPerEpoch:        conf.LayersPerEpoch,
-		cdb:                   cdb,
-		atxHandler:            hdlr,
-		publisher:             publisher,
-		nipostBuilder:         nipostBuilder,
-		postSetupProvider:     postSetupProvider,
-		layerClock:            layerClock,
-		syncer:                syncer,
-		started:               atomic.NewBool(false),
-		log:                   log,
-		poetRetryInterval:     defaultPoetRetryInterval,
-		poetClientInitializer: defaultPoetClientFunc,
+		parentCtx:         context.Background(),
+		signer:            signer,
+		nodeID:            nodeID,
+		coinbaseAccount:   conf.CoinbaseAccount,
+		goldenATXID:       conf.GoldenATXID,
+		layersPerEpoch:    conf.LayersPerEpoch,
+		cdb:               cdb,
+		atxHandler:        hdlr,
+		publisher:         publisher,
+		nipostBuilder:     nipostBuilder,
+		postSetupProvider: postSetupProvider,
+		layerClock:        layerClock,
+		syncer:            syncer,
+		started:           atomic.NewBool(false),
+		log:               log,
+		poetRetryInterval: defaultPoetRetryInterval,
 	}
 	for _, opt := range opts {
 		opt(b)
 	}
+	if b.poetClientInitializer == nil {
+		b.poetClientInitializer = func(target string) PoetProvingServiceClient {
+			return NewPoetClient(target, NewHTTPPoetClient(target), b.poetCfg.PolicyFor(target), b.log)
+		}
+	}
 	return b
 }
 
@@ -446,7 +471,7 @@ func (b *Builder) buildNIPostChallenge(ctx context.Context) error {
 		challenge.Sequence = prevAtx.Sequence + 1
 	}
 	b.challenge = challenge
-	if err := kvstore.AddNIPostChallenge(b.cdb, b.challenge); err != nil {
+	if err := kvstore.AddNIPostChallenge(b.cdb, b.nodeID, b.challenge); err != nil {
 		return fmt.Errorf("failed to store nipost challenge: %w", err)
 	}
 	return nil
@@ -495,7 +520,7 @@ func (b *Builder) Coinbase() types.Address {
 }
 
 func (b *Builder) loadChallenge() error {
-	nipost, err := kvstore.GetNIPostChallenge(b.cdb)
+	nipost, err := kvstore.GetNIPostChallenge(b.cdb, b.nodeID)
 	if err != nil {
 		return err
 	}
@@ -716,7 +741,7 @@ func (b *Builder) currentEpoch() types.EpochID {
 func (b *Builder) discardChallenge() {
 	b.challenge = nil
 	b.pendingATX = nil
-	if err := kvstore.ClearNIPostChallenge(b.cdb); err != nil {
+	if err := kvstore.ClearNIPostChallenge(b.cdb, b.nodeID); err != nil {
 		b.log.Error("failed to discard NIPost challenge: %w", err)
 	}
 }
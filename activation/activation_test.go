@@ -951,7 +951,7 @@ func TestBuilder_NIPostPublishRecovery(t *testing.T) {
 	require.NotNil(t, built)
 
 	// the challenge remains
-	got, err := kvstore.GetNIPostChallenge(tab.cdb)
+	got, err := kvstore.GetNIPostChallenge(tab.cdb, tab.nodeID)
 	require.NoError(t, err)
 	require.NotEmpty(t, got)
 
@@ -973,7 +973,7 @@ func TestBuilder_NIPostPublishRecovery(t *testing.T) {
 		})
 	// This 👇 ensures that handing of the challenge succeeded and the code moved on to the next part
 	require.ErrorIs(t, tab.PublishActivationTx(context.Background()), ErrATXChallengeExpired)
-	got, err = kvstore.GetNIPostChallenge(tab.cdb)
+	got, err = kvstore.GetNIPostChallenge(tab.cdb, tab.nodeID)
 	require.ErrorIs(t, err, sql.ErrNotFound)
 	require.Empty(t, got)
 
@@ -989,7 +989,7 @@ func TestBuilder_NIPostPublishRecovery(t *testing.T) {
 	require.NotEqual(t, built.NIPost, built2.NIPost)
 	require.Equal(t, built.TargetEpoch()+1, built2.TargetEpoch())
 
-	got, err = kvstore.GetNIPostChallenge(tab.cdb)
+	got, err = kvstore.GetNIPostChallenge(tab.cdb, tab.nodeID)
 	require.ErrorIs(t, err, sql.ErrNotFound)
 	require.Empty(t, got)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spacemeshos/post/shared"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -17,12 +18,13 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
 var (
 	errKnownAtx      = errors.New("known atx")
-	errMalformedData = errors.New("malformed data")
+	errMalformedData = fmt.Errorf("%w: malformed data", pubsub.ErrValidationReject)
 )
 
 type atxChan struct {
@@ -44,10 +46,11 @@ type Handler struct {
 	processAtxMutex sync.Mutex
 	atxChannels     map[types.ATXID]*atxChan
 	fetcher         system.Fetcher
+	publisher       pubsub.Publisher
 }
 
 // NewHandler returns a data handler for ATX.
-func NewHandler(cdb *datastore.CachedDB, fetcher system.Fetcher, layersPerEpoch uint32, tickSize uint64, goldenATXID types.ATXID, nipostValidator nipostValidator, atxReceiver atxReceiver, log log.Log) *Handler {
+func NewHandler(cdb *datastore.CachedDB, fetcher system.Fetcher, publisher pubsub.Publisher, layersPerEpoch uint32, tickSize uint64, goldenATXID types.ATXID, nipostValidator nipostValidator, atxReceiver atxReceiver, log log.Log) *Handler {
 	return &Handler{
 		cdb:             cdb,
 		layersPerEpoch:  layersPerEpoch,
@@ -58,6 +61,7 @@ func NewHandler(cdb *datastore.CachedDB, fetcher system.Fetcher, layersPerEpoch
 		log:             log,
 		atxChannels:     make(map[types.ATXID]*atxChan),
 		fetcher:         fetcher,
+		publisher:       publisher,
 	}
 }
 
@@ -129,12 +133,65 @@ func (h *Handler) ProcessAtx(ctx context.Context, atx *types.VerifiedActivationT
 	} else {
 		h.log.WithContext(ctx).With().Info("atx is valid", atx.ID())
 	}
+	proof, err := h.checkMalicious(ctx, atx)
+	if err != nil {
+		return fmt.Errorf("check malicious atx %s: %w", atx.ShortString(), err)
+	}
 	if err := h.StoreAtx(ctx, atx); err != nil {
 		return fmt.Errorf("cannot store atx %s: %w", atx.ShortString(), err)
 	}
+	if proof != nil {
+		encoded, err := codec.Encode(proof)
+		if err != nil {
+			h.log.With().Fatal("failed to encode malfeasance proof", log.Err(err))
+		}
+		if err := h.publisher.Publish(ctx, pubsub.MalfeasanceProtocol, encoded); err != nil {
+			h.log.WithContext(ctx).With().Error("failed to broadcast malfeasance proof", log.Err(err))
+		}
+	}
 	return nil
 }
 
+// checkMalicious records a malfeasance proof if the smesher already published an atx in the same epoch.
+// The proof is returned only when it wasn't known before.
+func (h *Handler) checkMalicious(ctx context.Context, atx *types.VerifiedActivationTx) (*types.MalfeasanceProof, error) {
+	prev, err := atxs.GetIDByEpochAndNodeID(h.cdb, atx.PublishEpoch(), atx.NodeID())
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err := identities.GetMalfeasanceProof(h.cdb, atx.NodeID().Bytes()); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return nil, err
+	}
+	prevAtx, err := atxs.Get(h.cdb, prev)
+	if err != nil {
+		return nil, err
+	}
+	proof := &types.MalfeasanceProof{
+		Layer: atx.PubLayerID,
+		MultipleATXs: &types.AtxProof{
+			Messages: [2]types.ActivationTx{*prevAtx.ActivationTx, *atx.ActivationTx},
+		},
+	}
+	encoded, err := codec.Encode(proof)
+	if err != nil {
+		h.log.With().Fatal("failed to encode malfeasance proof", log.Err(err))
+	}
+	if err := identities.SetMalfeasanceProof(h.cdb, atx.NodeID().Bytes(), encoded); err != nil {
+		return nil, err
+	}
+	h.log.WithContext(ctx).With().Warning("smesher produced more than one atx in the same epoch",
+		log.Stringer("smesher", atx.NodeID()),
+		log.FieldNamed("prev", prev),
+		atx.ID(),
+		atx.PublishEpoch(),
+	)
+	return proof, nil
+}
+
 // SyntacticallyValidateAtx ensures the following conditions apply, otherwise it returns an error.
 //
 //   - If the sequence number is non-zero: PrevATX points to a syntactically valid ATX whose sequence number is one less
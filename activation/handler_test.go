@@ -19,9 +19,11 @@ import (
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/system/mocks"
 )
 
@@ -52,7 +54,7 @@ func TestHandler_StoreAtx(t *testing.T) {
 	sig, err := signing.NewEdSigner()
 	r.NoError(err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, lg)
 
 	coinbase1 := types.GenerateAddress([]byte("aaaa"))
 
@@ -88,7 +90,8 @@ func TestHandler_processBlockATXs(t *testing.T) {
 	sig, err := signing.NewEdSigner()
 	r.NoError(err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, lg)
+	publisher := pubsubmocks.NewMockPublisher(ctrl)
+	atxHdlr := NewHandler(cdb, nil, publisher, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, lg)
 
 	coinbase1 := types.GenerateAddress([]byte("aaaa"))
 	coinbase2 := types.GenerateAddress([]byte("bbbb"))
@@ -108,11 +111,26 @@ func TestHandler_processBlockATXs(t *testing.T) {
 		newActivationTx(t, sig, &nid, 0, *types.EmptyATXID, posATX.ID(), nil, types.NewLayerID(1300), 0, numTicks, coinbase2, numUnits, &types.NIPost{}),
 		newActivationTx(t, sig, &nid, 0, *types.EmptyATXID, posATX.ID(), nil, types.NewLayerID(1435), 0, numTicks, coinbase3, numUnits, &types.NIPost{}),
 	}
+	// all atxs are published by the same smesher in the same epoch, the proof is gossiped only once
+	var published []byte
+	publisher.EXPECT().Publish(gomock.Any(), pubsub.MalfeasanceProtocol, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, msg []byte) error {
+			published = msg
+			return nil
+		}).Times(1)
 	for _, atx := range atxList {
 		atx.NIPost = newNIPostWithChallenge(atx.NIPostChallenge.Hash(), poetRef)
 
 		r.NoError(atxHdlr.ProcessAtx(context.Background(), atx))
 	}
+	stored, err := identities.GetMalfeasanceProof(cdb, nid.Bytes())
+	r.NoError(err)
+	r.Equal(published, stored)
+	var proof types.MalfeasanceProof
+	r.NoError(codec.Decode(stored, &proof))
+	r.NotNil(proof.MultipleATXs)
+	r.Equal(posATX.NIPostChallenge, proof.MultipleATXs.Messages[0].NIPostChallenge)
+	r.Equal(atxList[0].NIPostChallenge, proof.MultipleATXs.Messages[1].NIPostChallenge)
 
 	// check that further atxList don't affect current epoch count
 	atxList2 := []*types.VerifiedActivationTx{
@@ -154,7 +172,7 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 	otherSig, err := signing.NewEdSigner()
 	require.NoError(t, err)
 	otherNid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, lg)
 
 	coinbase := types.GenerateAddress([]byte("aaaa"))
 
@@ -451,7 +469,7 @@ func TestHandler_ContextuallyValidateAtx(t *testing.T) {
 	sig, err := signing.NewEdSigner()
 	require.NoError(t, err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 
 	sig1, err := signing.NewEdSigner()
 	require.NoError(t, err)
@@ -549,7 +567,7 @@ func TestHandler_ProcessAtx(t *testing.T) {
 	sig, err := signing.NewEdSigner()
 	require.NoError(t, err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, lg)
 
 	coinbase := types.GenerateAddress([]byte("aaaa"))
 
@@ -574,7 +592,7 @@ func BenchmarkActivationDb_SyntacticallyValidateAtx(b *testing.B) {
 	sig, err := signing.NewEdSigner()
 	r.NoError(err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 
 	const (
 		activesetSize         = 300
@@ -629,7 +647,7 @@ func BenchmarkNewActivationDb(b *testing.B) {
 	sig, err := signing.NewEdSigner()
 	r.NoError(err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 
 	const (
 		numOfMiners = 300
@@ -690,7 +708,7 @@ func TestHandler_GetPosAtx(t *testing.T) {
 	r.NoError(err)
 	nid := sig.NodeID()
 	coinbase := types.Address{2, 4, 5}
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 
 	// Act & Assert
 
@@ -732,7 +750,7 @@ func TestHandler_AwaitAtx(t *testing.T) {
 	r.NoError(err)
 	nid := sig.NodeID()
 	coinbase := types.Address{2, 4, 5}
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 	// Act & Assert
 
 	atx := newActivationTx(t, sig, &nid, 0, *types.EmptyATXID, *types.EmptyATXID, nil, types.NewLayerID(1), 0, 100, coinbase, 100, &types.NIPost{})
@@ -785,7 +803,7 @@ func TestHandler_HandleAtxData(t *testing.T) {
 	require.NoError(t, err)
 	nid := sig.NodeID()
 	coinbase := types.Address{2, 4, 5}
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 
 	// Act & Assert
 
@@ -817,7 +835,7 @@ func BenchmarkGetAtxHeaderWithConcurrentStoreAtx(b *testing.B) {
 	sig, err := signing.NewEdSigner()
 	require.NoError(b, err)
 	nid := sig.NodeID()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 
 	var (
 		stop uint64
@@ -867,7 +885,7 @@ func TestHandler_FetchAtxReferences(t *testing.T) {
 	nid := sig.NodeID()
 	coinbase := types.Address{2, 4, 5}
 
-	atxHdlr := NewHandler(cdb, mockFetch, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
+	atxHdlr := NewHandler(cdb, mockFetch, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, lg)
 	challenge := newChallenge(1, types.ATXID{1, 2, 3}, types.ATXID{1, 2, 3}, types.NewLayerID(22), nil)
 	nipost := newNIPostWithChallenge(types.HexToHash32("55555"), []byte("66666"))
 	atx1 := newAtx(t, sig, &nid, challenge, nipost, 2, coinbase)
@@ -924,7 +942,7 @@ func TestHandler_AtxWeight(t *testing.T) {
 	goldenATXID := types.ATXID{2, 3, 4}
 	sig, err := signing.NewEdSigner()
 	require.NoError(t, err)
-	handler := NewHandler(cdb, mfetch, layersPerEpoch, tickSize, goldenATXID, mvalidator, receiver, lg)
+	handler := NewHandler(cdb, mfetch, nil, layersPerEpoch, tickSize, goldenATXID, mvalidator, receiver, lg)
 
 	nonce := types.VRFPostIndex(1)
 	atx1 := &types.ActivationTx{
@@ -15,6 +15,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
 )
 
 //go:generate mockgen -package=activation -destination=./nipost_mocks.go -source=./nipost.go PoetProvingServiceClient
@@ -32,8 +33,19 @@ type PoetProvingServiceClient interface {
 	GetProof(ctx context.Context, roundID string) (*types.PoetProofMessage, error)
 }
 
+// poetHealthChecker is implemented by poet clients that track health of their endpoint, such as PoetClient.
+// Challenges are not submitted to the unhealthy poets.
+type poetHealthChecker interface {
+	Healthy(context.Context) bool
+}
+
 func (nb *NIPostBuilder) load(challenge types.Hash32) {
-	state, err := kvstore.GetNIPostBuilderState(nb.db)
+	// only one challenge is built at a time, submissions of challenges that were discarded
+	// will never be used
+	if err := poets.DeleteOtherSubmissions(nb.db, nb.minerID, challenge); err != nil {
+		nb.log.With().Warning("failed to prune poet submissions", log.Err(err))
+	}
+	state, err := kvstore.GetNIPostBuilderState(nb.db, nb.minerID)
 	if err != nil {
 		nb.log.With().Warning("cannot load nipost state", log.Err(err))
 		return
@@ -46,14 +58,14 @@ func (nb *NIPostBuilder) load(challenge types.Hash32) {
 }
 
 func (nb *NIPostBuilder) persist() {
-	if err := kvstore.AddNIPostBuilderState(nb.db, nb.state); err != nil {
+	if err := kvstore.AddNIPostBuilderState(nb.db, nb.minerID, nb.state); err != nil {
 		nb.log.With().Warning("cannot store nipost state", log.Err(err))
 	}
 }
 
 // NIPostBuilder holds the required state and dependencies to create Non-Interactive Proofs of Space-Time (NIPost).
 type NIPostBuilder struct {
-	minerID           []byte
+	minerID           types.NodeID
 	db                *sql.Database
 	postSetupProvider postSetupProvider
 	poetProvers       []PoetProvingServiceClient
@@ -79,7 +91,7 @@ func NewNIPostBuilder(
 	signer signer,
 ) *NIPostBuilder {
 	return &NIPostBuilder{
-		minerID:           minerID.Bytes(),
+		minerID:           minerID,
 		postSetupProvider: postSetupProvider,
 		poetProvers:       poetProvers,
 		poetDB:            poetDB,
@@ -120,7 +132,7 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.PoetC
 			return nil, 0, err
 		}
 		signature := nb.signer.Sign(challenge)
-		poetRequests := nb.submitPoetChallenges(ctx, challenge, signature)
+		poetRequests := nb.submitPoetChallenges(ctx, challengeHash, challenge, signature)
 		if ctx.Err() != nil {
 			return nil, 0, ctx.Err()
 		}
@@ -183,6 +195,10 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.PoetC
 
 	nb.log.Info("finished nipost construction")
 
+	if err := poets.DeleteSubmissions(nb.db, nb.minerID, challengeHash); err != nil {
+		nb.log.With().Warning("failed to delete poet submissions", log.Err(err))
+	}
+
 	nb.state = &types.NIPostBuilderState{
 		NIPost: &types.NIPost{},
 	}
@@ -191,11 +207,7 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.PoetC
 }
 
 // Submit the challenge to a single PoET.
-func (nb *NIPostBuilder) submitPoetChallenge(ctx context.Context, poet PoetProvingServiceClient, challenge []byte, signature []byte) (*types.PoetRequest, error) {
-	poetServiceID, err := poet.PoetServiceID(ctx)
-	if err != nil {
-		return nil, &PoetSvcUnstableError{msg: "failed to get PoET service ID", source: err}
-	}
+func (nb *NIPostBuilder) submitPoetChallenge(ctx context.Context, poet PoetProvingServiceClient, poetServiceID types.PoetServiceID, challenge []byte, signature []byte) (*types.PoetRequest, error) {
 	logger := nb.log.WithFields(log.String("poet_id", hex.EncodeToString(poetServiceID)))
 	logger.Debug("submitting challenge to poet proving service")
 
@@ -213,17 +225,46 @@ func (nb *NIPostBuilder) submitPoetChallenge(ctx context.Context, poet PoetProvi
 }
 
 // Submit the challenge to all registered PoETs.
-func (nb *NIPostBuilder) submitPoetChallenges(ctx context.Context, challenge []byte, signature []byte) []types.PoetRequest {
+// Challenge is not submitted again to PoETs that accepted it before, e.g. before the node restarted.
+func (nb *NIPostBuilder) submitPoetChallenges(ctx context.Context, challengeHash types.Hash32, challenge []byte, signature []byte) []types.PoetRequest {
+	submitted, err := poets.Submissions(nb.db, nb.minerID, challengeHash)
+	if err != nil {
+		nb.log.With().Warning("failed to load poet submissions", log.Err(err))
+	}
 	g, ctx := errgroup.WithContext(ctx)
 	poetRequestsChannel := make(chan types.PoetRequest, len(nb.poetProvers))
 	for _, poetProver := range nb.poetProvers {
 		poet := poetProver
 		g.Go(func() error {
-			if poetRequest, err := nb.submitPoetChallenge(ctx, poet, challenge, signature); err == nil {
-				poetRequestsChannel <- *poetRequest
-			} else {
+			if checker, ok := poet.(poetHealthChecker); ok && !checker.Healthy(ctx) {
+				nb.log.Warning("not submitting challenge to unhealthy PoET")
+				return nil
+			}
+			poetServiceID, err := poet.PoetServiceID(ctx)
+			if err != nil {
+				nb.log.With().Warning("failed to submit challenge to PoET",
+					log.Err(&PoetSvcUnstableError{msg: "failed to get PoET service ID", source: err}))
+				return nil
+			}
+			for _, req := range submitted {
+				if bytes.Equal(req.PoetServiceID, poetServiceID) {
+					nb.log.With().Info("challenge was already submitted to PoET",
+						log.String("poet_id", hex.EncodeToString(poetServiceID)),
+						log.String("round", req.PoetRound.ID),
+					)
+					poetRequestsChannel <- req
+					return nil
+				}
+			}
+			poetRequest, err := nb.submitPoetChallenge(ctx, poet, poetServiceID, challenge, signature)
+			if err != nil {
 				nb.log.With().Warning("failed to submit challenge to PoET", log.Err(err))
+				return nil
+			}
+			if err := poets.AddSubmission(nb.db, nb.minerID, challengeHash, poetRequest); err != nil {
+				nb.log.With().Warning("failed to record poet submission", log.Err(err))
 			}
+			poetRequestsChannel <- *poetRequest
 			return nil
 		})
 	}
@@ -324,22 +365,32 @@ func (nb *NIPostBuilder) getBestProof(ctx context.Context, challenge *types.Hash
 	}
 	close(proofs)
 
-	var bestProof *types.PoetProofMessage
-
+	var (
+		bestProof *types.PoetProofMessage
+		bestRef   types.PoetProofRef
+	)
 	for proof := range proofs {
-		nb.log.With().Info("Got a new PoET proof", log.Uint64("leafCount", proof.LeafCount))
-		if bestProof == nil || bestProof.LeafCount < proof.LeafCount {
+		ref, err := proof.Ref()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proof ref: %w", err)
+		}
+		nb.log.With().Info("Got a new PoET proof",
+			log.String("poet_id", hex.EncodeToString(proof.PoetServiceID)),
+			log.Uint64("leafCount", proof.LeafCount),
+			log.Binary("ref", ref),
+		)
+		// proofs with more leaves give more ticks to the atx. ties are broken by the ref,
+		// so that the selected proof doesn't depend on the order in which poets responded.
+		if bestProof == nil || bestProof.LeafCount < proof.LeafCount ||
+			(bestProof.LeafCount == proof.LeafCount && bytes.Compare(ref, bestRef) < 0) {
 			bestProof = proof
+			bestRef = ref
 		}
 	}
 
 	if bestProof != nil {
-		ref, err := bestProof.Ref()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get proof ref: %w", err)
-		}
-		nb.log.With().Info("Selected the best proof", log.Uint64("leafCount", bestProof.LeafCount), log.Binary("ref", ref))
-		return ref, nil
+		nb.log.With().Info("Selected the best proof", log.Uint64("leafCount", bestProof.LeafCount), log.Binary("ref", bestRef))
+		return bestRef, nil
 	}
 
 	return nil, ErrPoetProofNotReceived
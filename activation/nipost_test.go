@@ -1,6 +1,7 @@
 package activation
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -23,6 +24,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
 )
 
 func getPostSetupOpts(tb testing.TB) PostSetupOpts {
@@ -454,6 +456,119 @@ func TestNIPostBuilder_ManyPoETs_AllFinished(t *testing.T) {
 	req.EqualValues(ref, nipost.PostMetadata.Challenge)
 }
 
+func TestNIPostBuilder_ManyPoETs_SameLeafCount(t *testing.T) {
+	t.Parallel()
+	req := require.New(t)
+
+	challenge := types.PoetChallenge{NIPostChallenge: &types.NIPostChallenge{}}
+	challengeHash := challenge.Hash()
+
+	proofs := []*types.PoetProofMessage{
+		{PoetProof: types.PoetProof{Members: [][]byte{challengeHash.Bytes()}, LeafCount: 100}, PoetServiceID: []byte("poet0")},
+		{PoetProof: types.PoetProof{Members: [][]byte{challengeHash.Bytes()}, LeafCount: 100}, PoetServiceID: []byte("poet1")},
+	}
+	best, err := proofs[0].Ref()
+	req.NoError(err)
+	for _, proof := range proofs[1:] {
+		ref, err := proof.Ref()
+		req.NoError(err)
+		if bytes.Compare(ref, best) < 0 {
+			best = ref
+		}
+	}
+
+	poetDb := NewMockpoetDbAPI(gomock.NewController(t))
+	poetDb.EXPECT().ValidateAndStore(gomock.Any(), gomock.Any()).Times(len(proofs)).Return(nil)
+	poets := make([]PoetProvingServiceClient, 0, len(proofs))
+	for _, proof := range proofs {
+		poet := defaultPoetServiceMock(t, proof.PoetServiceID)
+		poet.EXPECT().GetProof(gomock.Any(), "").Return(proof, nil)
+		poets = append(poets, poet)
+	}
+
+	sig, err := signing.NewEdSigner()
+	req.NoError(err)
+	nb := NewNIPostBuilder(types.NodeID{1}, &postSetupProviderMock{}, poets, poetDb, sql.InMemory(), logtest.New(t), sig)
+	nipost, _, err := nb.BuildNIPost(context.Background(), &challenge, time.Now().Add(time.Hour))
+	req.NoError(err)
+	req.EqualValues(best, nipost.PostMetadata.Challenge)
+}
+
+func TestNIPostBuilder_SubmittedPoETs(t *testing.T) {
+	t.Parallel()
+	req := require.New(t)
+
+	challenge := types.PoetChallenge{NIPostChallenge: &types.NIPostChallenge{}}
+	challengeHash := challenge.Hash()
+	nodeID := types.NodeID{1}
+	db := sql.InMemory()
+
+	// challenge was submitted to poet0 before restart, but the state wasn't persisted
+	submitted := types.PoetRequest{
+		PoetServiceID: []byte("poet0"),
+		PoetRound:     &types.PoetRound{ID: "3", ChallengeHash: challengeHash, End: types.RoundEnd(time.Now())},
+	}
+	req.NoError(poets.AddSubmission(db, nodeID, challengeHash, &submitted))
+	// submission of the challenge that was discarded
+	discarded := types.Hash32{1, 2, 3}
+	req.NoError(poets.AddSubmission(db, nodeID, discarded, &submitted))
+
+	proof := &types.PoetProofMessage{PoetProof: types.PoetProof{Members: [][]byte{challengeHash.Bytes()}}}
+	poet0 := NewMockPoetProvingServiceClient(gomock.NewController(t))
+	poet0.EXPECT().PoetServiceID(gomock.Any()).AnyTimes().Return(submitted.PoetServiceID, nil)
+	poet0.EXPECT().GetProof(gomock.Any(), "3").Return(proof, nil)
+	poet1 := defaultPoetServiceMock(t, []byte("poet1"))
+	poet1.EXPECT().GetProof(gomock.Any(), "").Return(proof, nil)
+
+	poetDb := NewMockpoetDbAPI(gomock.NewController(t))
+	poetDb.EXPECT().ValidateAndStore(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	sig, err := signing.NewEdSigner()
+	req.NoError(err)
+	nb := NewNIPostBuilder(nodeID, &postSetupProviderMock{}, []PoetProvingServiceClient{poet0, poet1}, poetDb, db, logtest.New(t), sig)
+	_, _, err = nb.BuildNIPost(context.Background(), &challenge, time.Now().Add(time.Hour))
+	req.NoError(err)
+
+	reqs, err := poets.Submissions(db, nodeID, challengeHash)
+	req.NoError(err)
+	req.Empty(reqs, "submissions are deleted once nipost is built")
+	reqs, err = poets.Submissions(db, nodeID, discarded)
+	req.NoError(err)
+	req.Empty(reqs, "submissions of discarded challenges are deleted")
+}
+
+func TestNIPostBuilder_UnhealthyPoET(t *testing.T) {
+	t.Parallel()
+	req := require.New(t)
+
+	challenge := types.PoetChallenge{NIPostChallenge: &types.NIPostChallenge{}}
+	challengeHash := challenge.Hash()
+
+	unhealthy := NewMockPoetProvingServiceClient(gomock.NewController(t))
+	unhealthy.EXPECT().PoetServiceID(gomock.Any()).Return(nil, ErrUnavailable).MinTimes(1)
+	policy := DefaultPoetPolicy()
+	policy.MaxRetries = 0
+	policy.UnhealthyAfter = 1
+	unhealthyClient := NewPoetClient("unhealthy", unhealthy, policy, logtest.New(t))
+	_, err := unhealthyClient.PoetServiceID(context.Background())
+	req.Error(err)
+
+	proof := &types.PoetProofMessage{PoetProof: types.PoetProof{Members: [][]byte{challengeHash.Bytes()}}}
+	healthy := defaultPoetServiceMock(t, []byte("poet1"))
+	healthy.EXPECT().GetProof(gomock.Any(), "").Return(proof, nil)
+
+	poetDb := NewMockpoetDbAPI(gomock.NewController(t))
+	poetDb.EXPECT().ValidateAndStore(gomock.Any(), gomock.Any()).Return(nil)
+
+	sig, err := signing.NewEdSigner()
+	req.NoError(err)
+	nb := NewNIPostBuilder(types.NodeID{1}, &postSetupProviderMock{},
+		[]PoetProvingServiceClient{unhealthyClient, healthy}, poetDb, sql.InMemory(), logtest.New(t), sig)
+	_, _, err = nb.BuildNIPost(context.Background(), &challenge, time.Now().Add(time.Hour))
+	req.NoError(err)
+	req.Len(nb.state.PoetRequests, 0, "state is reset after nipost is built")
+}
+
 func TestValidator_Validate(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
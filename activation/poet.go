@@ -91,10 +91,6 @@ type HTTPPoetClient struct {
 	poetServiceID *types.PoetServiceID
 }
 
-func defaultPoetClientFunc(target string) PoetProvingServiceClient {
-	return NewHTTPPoetClient(target)
-}
-
 // NewHTTPPoetClient returns new instance of HTTPPoetClient for the specified target.
 func NewHTTPPoetClient(target string) *HTTPPoetClient {
 	return &HTTPPoetClient{
@@ -0,0 +1,163 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// PoetPolicy configures retries and health checks of a poet endpoint.
+type PoetPolicy struct {
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int `mapstructure:"max-retries"`
+	// InitialBackoff is the delay before the first retry, it is doubled after every retry up to MaxBackoff.
+	InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max-backoff"`
+	// UnhealthyAfter is the number of consecutive failed requests after which the endpoint is considered unhealthy.
+	// Challenges are not submitted to the unhealthy endpoint until it passes a health check.
+	// Zero disables health checks.
+	UnhealthyAfter int `mapstructure:"unhealthy-after"`
+	// HealthCheckInterval is the minimal interval between health checks of the unhealthy endpoint.
+	HealthCheckInterval time.Duration `mapstructure:"health-check-interval"`
+}
+
+// DefaultPoetPolicy returns the default retry and health check policy of a poet endpoint.
+func DefaultPoetPolicy() PoetPolicy {
+	return PoetPolicy{
+		MaxRetries:          3,
+		InitialBackoff:      time.Second,
+		MaxBackoff:          10 * time.Second,
+		UnhealthyAfter:      3,
+		HealthCheckInterval: 30 * time.Second,
+	}
+}
+
+func (p PoetPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 0; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// PoetClient implements PoetProvingServiceClient interface on top of another client,
+// retrying failed requests and tracking health of the endpoint according to the PoetPolicy.
+type PoetClient struct {
+	endpoint string
+	client   PoetProvingServiceClient
+	policy   PoetPolicy
+	logger   log.Log
+
+	mu        sync.Mutex
+	failures  int
+	lastCheck time.Time
+}
+
+// NewPoetClient returns a new instance of PoetClient for the client of the endpoint.
+func NewPoetClient(endpoint string, client PoetProvingServiceClient, policy PoetPolicy, logger log.Log) *PoetClient {
+	return &PoetClient{
+		endpoint: endpoint,
+		client:   client,
+		policy:   policy,
+		logger:   logger.WithFields(log.String("poet_endpoint", endpoint)),
+	}
+}
+
+// Submit registers a challenge in the proving service current open round.
+func (c *PoetClient) Submit(ctx context.Context, challenge, signature []byte) (*types.PoetRound, error) {
+	var round *types.PoetRound
+	err := c.retry(ctx, "submit", func() (err error) {
+		round, err = c.client.Submit(ctx, challenge, signature)
+		return err
+	})
+	return round, err
+}
+
+// PoetServiceID returns the public key of the PoET proving service.
+func (c *PoetClient) PoetServiceID(ctx context.Context) (types.PoetServiceID, error) {
+	var id types.PoetServiceID
+	err := c.retry(ctx, "poet service id", func() (err error) {
+		id, err = c.client.PoetServiceID(ctx)
+		return err
+	})
+	return id, err
+}
+
+// GetProof returns the proof of the round. It is not retried, as the caller polls for the proof
+// until the round is over, but failures are accounted in the health of the endpoint.
+func (c *PoetClient) GetProof(ctx context.Context, roundID string) (*types.PoetProofMessage, error) {
+	proof, err := c.client.GetProof(ctx, roundID)
+	c.report(err)
+	return proof, err
+}
+
+// Healthy returns false if the endpoint failed consecutive requests and didn't pass a health check since.
+// The health check is performed at most once per PoetPolicy.HealthCheckInterval.
+func (c *PoetClient) Healthy(ctx context.Context) bool {
+	c.mu.Lock()
+	if c.policy.UnhealthyAfter == 0 || c.failures < c.policy.UnhealthyAfter {
+		c.mu.Unlock()
+		return true
+	}
+	if time.Since(c.lastCheck) < c.policy.HealthCheckInterval {
+		c.mu.Unlock()
+		return false
+	}
+	c.lastCheck = time.Now()
+	c.mu.Unlock()
+
+	_, err := c.client.PoetServiceID(ctx)
+	c.report(err)
+	if err != nil {
+		c.logger.With().Info("poet endpoint failed health check", log.Err(err))
+		return false
+	}
+	c.logger.Info("poet endpoint passed health check")
+	return true
+}
+
+func (c *PoetClient) report(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case err == nil || errors.Is(err, ErrNotFound):
+		c.failures = 0
+	case errors.Is(err, context.Canceled):
+	default:
+		c.failures++
+		if c.failures == c.policy.UnhealthyAfter {
+			c.lastCheck = time.Now()
+			c.logger.With().Warning("poet endpoint is unhealthy", log.Int("failures", c.failures), log.Err(err))
+		}
+	}
+}
+
+func (c *PoetClient) retry(ctx context.Context, request string, f func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := f()
+		c.report(err)
+		if err == nil || ctx.Err() != nil || errors.Is(err, ErrNotFound) || attempt >= c.policy.MaxRetries {
+			return err
+		}
+		backoff := c.policy.backoff(attempt)
+		c.logger.With().Debug("poet request failed, retrying",
+			log.String("request", request),
+			log.Int("attempt", attempt+1),
+			log.Duration("backoff", backoff),
+			log.Err(err),
+		)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("retry was canceled: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+func testPoetPolicy() PoetPolicy {
+	return PoetPolicy{
+		MaxRetries:          2,
+		InitialBackoff:      time.Millisecond,
+		MaxBackoff:          2 * time.Millisecond,
+		UnhealthyAfter:      3,
+		HealthCheckInterval: time.Hour,
+	}
+}
+
+func TestPoetClient_Retries(t *testing.T) {
+	challenge := []byte("challenge")
+	round := &types.PoetRound{ID: "1"}
+
+	t.Run("recovered", func(t *testing.T) {
+		mock := NewMockPoetProvingServiceClient(gomock.NewController(t))
+		client := NewPoetClient("poet", mock, testPoetPolicy(), logtest.New(t))
+		gomock.InOrder(
+			mock.EXPECT().Submit(gomock.Any(), challenge, nil).Return(nil, ErrUnavailable).Times(2),
+			mock.EXPECT().Submit(gomock.Any(), challenge, nil).Return(round, nil),
+		)
+		got, err := client.Submit(context.Background(), challenge, nil)
+		require.NoError(t, err)
+		require.Equal(t, round, got)
+		require.True(t, client.Healthy(context.Background()))
+	})
+	t.Run("exhausted", func(t *testing.T) {
+		mock := NewMockPoetProvingServiceClient(gomock.NewController(t))
+		client := NewPoetClient("poet", mock, testPoetPolicy(), logtest.New(t))
+		mock.EXPECT().PoetServiceID(gomock.Any()).Return(nil, ErrUnavailable).Times(3)
+		_, err := client.PoetServiceID(context.Background())
+		require.ErrorIs(t, err, ErrUnavailable)
+	})
+	t.Run("not found", func(t *testing.T) {
+		mock := NewMockPoetProvingServiceClient(gomock.NewController(t))
+		client := NewPoetClient("poet", mock, testPoetPolicy(), logtest.New(t))
+		mock.EXPECT().Submit(gomock.Any(), challenge, nil).Return(nil, ErrNotFound)
+		_, err := client.Submit(context.Background(), challenge, nil)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		mock := NewMockPoetProvingServiceClient(gomock.NewController(t))
+		policy := testPoetPolicy()
+		policy.InitialBackoff = time.Hour
+		client := NewPoetClient("poet", mock, policy, logtest.New(t))
+		ctx, cancel := context.WithCancel(context.Background())
+		mock.EXPECT().Submit(gomock.Any(), challenge, nil).DoAndReturn(
+			func(context.Context, []byte, []byte) (*types.PoetRound, error) {
+				cancel()
+				return nil, ErrUnavailable
+			})
+		_, err := client.Submit(ctx, challenge, nil)
+		require.ErrorIs(t, err, ErrUnavailable)
+	})
+}
+
+func TestPoetClient_Health(t *testing.T) {
+	mock := NewMockPoetProvingServiceClient(gomock.NewController(t))
+	policy := testPoetPolicy()
+	policy.MaxRetries = 0
+	policy.HealthCheckInterval = 0
+	client := NewPoetClient("poet", mock, policy, logtest.New(t))
+
+	mock.EXPECT().GetProof(gomock.Any(), "1").Return(nil, ErrNotFound).Times(5)
+	for i := 0; i < 5; i++ {
+		_, err := client.GetProof(context.Background(), "1")
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	require.True(t, client.Healthy(context.Background()), "proof not ready isn't a failure")
+
+	mock.EXPECT().GetProof(gomock.Any(), "1").Return(nil, errors.New("connection refused")).Times(3)
+	for i := 0; i < 3; i++ {
+		_, err := client.GetProof(context.Background(), "1")
+		require.Error(t, err)
+	}
+	mock.EXPECT().PoetServiceID(gomock.Any()).Return(nil, ErrUnavailable)
+	require.False(t, client.Healthy(context.Background()))
+
+	mock.EXPECT().PoetServiceID(gomock.Any()).Return(types.PoetServiceID("poet"), nil)
+	require.True(t, client.Healthy(context.Background()))
+	require.True(t, client.Healthy(context.Background()), "healthy without another check")
+}
+
+func TestPoetClient_HealthCheckInterval(t *testing.T) {
+	mock := NewMockPoetProvingServiceClient(gomock.NewController(t))
+	policy := testPoetPolicy()
+	policy.MaxRetries = 0
+	client := NewPoetClient("poet", mock, policy, logtest.New(t))
+
+	mock.EXPECT().PoetServiceID(gomock.Any()).Return(nil, ErrUnavailable).Times(3)
+	for i := 0; i < 3; i++ {
+		_, err := client.PoetServiceID(context.Background())
+		require.Error(t, err)
+	}
+	// not checked again until the interval passes
+	require.False(t, client.Healthy(context.Background()))
+}
+
+func TestPoetConfig_PolicyFor(t *testing.T) {
+	cfg := DefaultPoetConfig()
+	custom := testPoetPolicy()
+	cfg.Policies = map[string]PoetPolicy{"poet1": custom}
+	require.Equal(t, custom, cfg.PolicyFor("poet1"))
+	require.Equal(t, DefaultPoetPolicy(), cfg.PolicyFor("poet2"))
+}
+
+func TestPoetPolicy_Backoff(t *testing.T) {
+	policy := PoetPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		require.Equal(t, expected, policy.backoff(attempt), attempt)
+	}
+}
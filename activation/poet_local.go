@@ -0,0 +1,241 @@
+package activation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spacemeshos/merkle-tree"
+	"github.com/spacemeshos/merkle-tree/cache"
+	"github.com/spacemeshos/poet/hash"
+	"github.com/spacemeshos/poet/shared"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
+)
+
+const defaultLocalPoetLeaves = 1 << 10
+
+// LocalPoetOpt configures LocalPoet.
+type LocalPoetOpt func(*LocalPoet)
+
+// WithLocalPoetLeaves sets the number of leaves in every proof.
+func WithLocalPoetLeaves(leaves uint64) LocalPoetOpt {
+	return func(p *LocalPoet) {
+		p.leaves = leaves
+	}
+}
+
+// WithLocalPoetClock overrides the source of the current time.
+func WithLocalPoetClock(now func() time.Time) LocalPoetOpt {
+	return func(p *LocalPoet) {
+		p.now = now
+	}
+}
+
+// WithLocalPoetLogger sets the logger.
+func WithLocalPoetLogger(logger log.Log) LocalPoetOpt {
+	return func(p *LocalPoet) {
+		p.logger = logger
+	}
+}
+
+// LocalPoet is an in-process PoET service that implements PoetProvingServiceClient interface.
+// It is meant for tests and local networks that run without poet servers.
+//
+// Rounds follow the schedule of a poet server with the same genesis, epoch duration, phase shift
+// and cycle gap: round N ends at the start of epoch N+1 plus phase shift minus cycle gap.
+// Instead of running for the duration of the round, proofs are generated for a fixed number of leaves
+// once the round ended. The proof of a round depends only on the challenges submitted to it.
+//
+// Submitted challenges are stored in the database, so that rounds survive restarts of the node.
+type LocalPoet struct {
+	id       types.PoetServiceID
+	verifier ChallengeVerifier
+	db       sql.Executor
+	cfg      PoetConfig
+	genesis  time.Time
+	epoch    time.Duration
+	leaves   uint64
+	now      func() time.Time
+	logger   log.Log
+
+	mu     sync.Mutex
+	proofs map[string]*types.PoetProofMessage
+}
+
+// NewLocalPoet returns a new instance of LocalPoet with the id.
+// Submitted challenges are verified with the verifier, same as with a poet server.
+func NewLocalPoet(
+	id types.PoetServiceID,
+	verifier ChallengeVerifier,
+	db sql.Executor,
+	cfg PoetConfig,
+	genesis time.Time,
+	epoch time.Duration,
+	opts ...LocalPoetOpt,
+) *LocalPoet {
+	p := &LocalPoet{
+		id:       id,
+		verifier: verifier,
+		db:       db,
+		cfg:      cfg,
+		genesis:  genesis,
+		epoch:    epoch,
+		leaves:   defaultLocalPoetLeaves,
+		now:      time.Now,
+		logger:   log.NewNop(),
+		proofs:   map[string]*types.PoetProofMessage{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.leaves <= uint64(shared.T) {
+		p.leaves = uint64(shared.T) + 1
+	}
+	return p
+}
+
+// Submit registers a challenge in the round that is open at the current time.
+func (p *LocalPoet) Submit(ctx context.Context, challenge, signature []byte) (*types.PoetRound, error) {
+	result, err := p.verifier.Verify(ctx, challenge, signature)
+	if err != nil {
+		return nil, fmt.Errorf("verify challenge: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	id, end := p.openRound(now)
+	if err := poets.AddLocalMember(p.db, p.id, id, result.Hash, end); err != nil {
+		return nil, err
+	}
+	p.prune(now)
+	p.logger.With().Debug("challenge submitted to local poet",
+		log.String("round", id),
+		result.Hash,
+		result.NodeID,
+	)
+	return &types.PoetRound{ID: id, ChallengeHash: result.Hash, End: types.RoundEnd(end)}, nil
+}
+
+// PoetServiceID returns the id of the local poet.
+func (p *LocalPoet) PoetServiceID(context.Context) (types.PoetServiceID, error) {
+	return p.id, nil
+}
+
+// GetProof returns the proof of the round, once the round ended.
+func (p *LocalPoet) GetProof(_ context.Context, roundID string) (*types.PoetProofMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if proof, exists := p.proofs[roundID]; exists {
+		return proof, nil
+	}
+	round, err := strconv.ParseInt(roundID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: round %s", ErrNotFound, roundID)
+	}
+	if p.now().Before(p.roundEnd(round)) {
+		return nil, fmt.Errorf("%w: round %s is in progress", ErrNotFound, roundID)
+	}
+	members, err := poets.LocalMembers(p.db, p.id, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%w: round %s", ErrNotFound, roundID)
+	}
+	proof, err := generateLocalPoetProof(members, p.leaves)
+	if err != nil {
+		return nil, fmt.Errorf("generate proof for round %s: %w", roundID, err)
+	}
+	msg := &types.PoetProofMessage{
+		PoetProof: types.PoetProof{
+			MerkleProof: *proof,
+			Members:     members,
+			LeafCount:   p.leaves,
+		},
+		PoetServiceID: p.id,
+		RoundID:       roundID,
+	}
+	p.proofs[roundID] = msg
+	p.logger.With().Info("local poet generated proof",
+		log.String("round", roundID),
+		log.Int("members", len(members)),
+		log.Uint64("leaves", p.leaves),
+	)
+	return msg, nil
+}
+
+// openRound returns the id and the end of the round that accepts challenges at the time.
+func (p *LocalPoet) openRound(now time.Time) (string, time.Time) {
+	var round int64
+	if elapsed := now.Sub(p.genesis.Add(p.cfg.PhaseShift)); elapsed > 0 {
+		round = int64(elapsed / p.epoch)
+	}
+	end := p.roundEnd(round)
+	if !now.Before(end) {
+		// challenges submitted during the cycle gap are accepted by the next round
+		round++
+		end = p.roundEnd(round)
+	}
+	return strconv.FormatInt(round, 10), end
+}
+
+func (p *LocalPoet) roundEnd(round int64) time.Time {
+	return p.genesis.Add(time.Duration(round+1) * p.epoch).Add(p.cfg.PhaseShift).Add(-p.cfg.CycleGap)
+}
+
+// prune drops rounds that ended more than an epoch ago.
+func (p *LocalPoet) prune(now time.Time) {
+	before := now.Add(-p.epoch)
+	if err := poets.DeleteLocalRounds(p.db, before); err != nil {
+		p.logger.With().Warning("failed to prune local poet rounds", log.Err(err))
+	}
+	for id := range p.proofs {
+		if round, err := strconv.ParseInt(id, 10, 64); err != nil || p.roundEnd(round).Before(before) {
+			delete(p.proofs, id)
+		}
+	}
+}
+
+// generateLocalPoetProof computes the PoET DAG with the given number of leaves over the membership root
+// and generates a merkle proof for the leaves selected by Fiat-Shamir, same as the poet prover.
+func generateLocalPoetProof(members [][]byte, leaves uint64) (*shared.MerkleProof, error) {
+	membershipRoot, err := calcRoot(members)
+	if err != nil {
+		return nil, err
+	}
+	labelHashFunc := hash.GenLabelHashFunc(membershipRoot)
+	merkleHashFunc := hash.GenMerkleHashFunc(membershipRoot)
+
+	treeCache := cache.NewWriter(cache.MinHeightPolicy(0), cache.MakeSliceReadWriterFactory())
+	tree, err := merkle.NewTreeBuilder().WithHashFunc(merkleHashFunc).WithCacheWriter(treeCache).Build()
+	if err != nil {
+		return nil, fmt.Errorf("build tree: %w", err)
+	}
+	makeLabel := shared.MakeLabelFunc()
+	for leaf := uint64(0); leaf < leaves; leaf++ {
+		if err := tree.AddLeaf(makeLabel(labelHashFunc, leaf, tree.GetParkedNodes())); err != nil {
+			return nil, fmt.Errorf("add leaf: %w", err)
+		}
+	}
+	root := tree.Root()
+	reader, err := treeCache.GetReader()
+	if err != nil {
+		return nil, fmt.Errorf("tree cache reader: %w", err)
+	}
+	_, provenLeaves, proofNodes, err := merkle.GenerateProof(shared.FiatShamir(root, leaves, shared.T), reader)
+	if err != nil {
+		return nil, fmt.Errorf("merkle proof: %w", err)
+	}
+	return &shared.MerkleProof{
+		Root:         root,
+		ProvenLeaves: provenLeaves,
+		ProofNodes:   proofNodes,
+	}, nil
+}
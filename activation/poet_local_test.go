@@ -0,0 +1,142 @@
+package activation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+type decodingVerifier struct{}
+
+func (decodingVerifier) Verify(_ context.Context, challenge, _ []byte) (*ChallengeVerificationResult, error) {
+	var decoded types.PoetChallenge
+	if err := codec.Decode(challenge, &decoded); err != nil {
+		return nil, err
+	}
+	return &ChallengeVerificationResult{Hash: decoded.Hash(), NodeID: types.NodeID{1}}, nil
+}
+
+func encodedPoetChallenge(tb testing.TB, sequence uint64) []byte {
+	tb.Helper()
+	data, err := codec.Encode(&types.PoetChallenge{NIPostChallenge: &types.NIPostChallenge{Sequence: sequence}})
+	require.NoError(tb, err)
+	return data
+}
+
+func TestLocalPoet_Rounds(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	cfg := PoetConfig{PhaseShift: 5 * time.Second, CycleGap: 2 * time.Second}
+	epoch := 10 * time.Second
+
+	now := genesis
+	poet := NewLocalPoet(types.PoetServiceID("local"), decodingVerifier{}, sql.InMemory(), cfg, genesis, epoch,
+		WithLocalPoetClock(func() time.Time { return now }),
+		WithLocalPoetLeaves(256),
+		WithLocalPoetLogger(logtest.New(t)),
+	)
+	for _, tc := range []struct {
+		at    time.Duration
+		round string
+		end   time.Duration
+	}{
+		{at: 0, round: "0", end: 13 * time.Second},
+		{at: 12 * time.Second, round: "0", end: 13 * time.Second},
+		// cycle gap
+		{at: 14 * time.Second, round: "1", end: 23 * time.Second},
+		{at: 15 * time.Second, round: "1", end: 23 * time.Second},
+		{at: 33 * time.Second, round: "3", end: 43 * time.Second},
+	} {
+		now = genesis.Add(tc.at)
+		round, err := poet.Submit(context.Background(), encodedPoetChallenge(t, 1), nil)
+		require.NoError(t, err)
+		require.Equal(t, tc.round, round.ID, tc.at)
+		require.Equal(t, genesis.Add(tc.end), round.End.IntoTime(), tc.at)
+	}
+}
+
+func TestLocalPoet_Proof(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	epoch := 10 * time.Second
+	id := types.PoetServiceID("local poet")
+
+	now := genesis
+	newPoet := func() *LocalPoet {
+		return NewLocalPoet(id, decodingVerifier{}, sql.InMemory(), PoetConfig{}, genesis, epoch,
+			WithLocalPoetClock(func() time.Time { return now }),
+			WithLocalPoetLeaves(256),
+		)
+	}
+	poet := newPoet()
+	other := newPoet()
+
+	var members [][]byte
+	for i := uint64(0); i < 3; i++ {
+		round, err := poet.Submit(context.Background(), encodedPoetChallenge(t, i), nil)
+		require.NoError(t, err)
+		members = append(members, round.ChallengeHash.Bytes())
+		// challenges are submitted to another instance in the reverse order
+		_, err = other.Submit(context.Background(), encodedPoetChallenge(t, 2-i), nil)
+		require.NoError(t, err)
+	}
+
+	_, err := poet.GetProof(context.Background(), "0")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = poet.GetProof(context.Background(), "1")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	now = genesis.Add(epoch)
+	proof, err := poet.GetProof(context.Background(), "0")
+	require.NoError(t, err)
+	require.EqualValues(t, 256, proof.LeafCount)
+	require.Equal(t, id, types.PoetServiceID(proof.PoetServiceID))
+	require.ElementsMatch(t, members, proof.Members)
+
+	db := NewPoetDb(sql.InMemory(), logtest.New(t))
+	require.NoError(t, db.Validate(proof.PoetProof, proof.PoetServiceID, proof.RoundID, proof.Signature))
+
+	otherProof, err := other.GetProof(context.Background(), "0")
+	require.NoError(t, err)
+	require.Equal(t, proof, otherProof)
+}
+
+func TestLocalPoet_Restart(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	epoch := 10 * time.Second
+	id := types.PoetServiceID("local poet")
+	db := sql.InMemory()
+
+	now := genesis
+	newPoet := func() *LocalPoet {
+		return NewLocalPoet(id, decodingVerifier{}, db, PoetConfig{}, genesis, epoch,
+			WithLocalPoetClock(func() time.Time { return now }),
+			WithLocalPoetLeaves(256),
+		)
+	}
+	poet := newPoet()
+	round, err := poet.Submit(context.Background(), encodedPoetChallenge(t, 1), nil)
+	require.NoError(t, err)
+
+	// submitted challenges survive restart
+	now = genesis.Add(epoch)
+	restarted := newPoet()
+	proof, err := restarted.GetProof(context.Background(), round.ID)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{round.ChallengeHash.Bytes()}, proof.Members)
+
+	_, err = restarted.GetProof(context.Background(), "1")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// rounds that ended more than an epoch ago are pruned
+	now = genesis.Add(3 * epoch)
+	_, err = newPoet().Submit(context.Background(), encodedPoetChallenge(t, 2), nil)
+	require.NoError(t, err)
+	_, err = newPoet().GetProof(context.Background(), round.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/poets"
 )
@@ -48,7 +49,7 @@ func (db *PoetDb) ValidateAndStore(ctx context.Context, proofMessage *types.Poet
 
 	if err := db.Validate(proofMessage.PoetProof, proofMessage.PoetServiceID,
 		proofMessage.RoundID, proofMessage.Signature); err != nil {
-		return err
+		return fmt.Errorf("%w: %v", pubsub.ErrValidationReject, err)
 	}
 
 	return db.StoreProof(ctx, ref, proofMessage)
@@ -58,7 +59,7 @@ func (db *PoetDb) ValidateAndStore(ctx context.Context, proofMessage *types.Poet
 func (db *PoetDb) ValidateAndStoreMsg(ctx context.Context, data []byte) error {
 	var proofMessage types.PoetProofMessage
 	if err := codec.Decode(data, &proofMessage); err != nil {
-		return fmt.Errorf("parse message: %w", err)
+		return fmt.Errorf("%w: parse message: %v", pubsub.ErrValidationReject, err)
 	}
 	return db.ValidateAndStore(ctx, &proofMessage)
 }
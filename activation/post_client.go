@@ -0,0 +1,258 @@
+package activation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// PostClientConfig is a configuration of HTTPPostClient.
+type PostClientConfig struct {
+	// Token is a shared secret of the post service.
+	Token string
+	// ProofTimeout limits the time the service may spend generating a proof.
+	ProofTimeout time.Duration
+}
+
+// HTTPPostClient implements postSetupProvider interface for the post data served by a remote PostService.
+// It can be used by Builder and NIPostBuilder instead of the local PostSetupManager.
+type HTTPPostClient struct {
+	id           types.NodeID
+	baseURL      string
+	cfg          PostClientConfig
+	client       *http.Client
+	logger       log.Log
+	ctxFactory   func(ctx context.Context) (context.Context, context.CancelFunc)
+	pollInterval time.Duration
+
+	mu       sync.Mutex
+	lastOpts *PostSetupOpts
+	postCfg  *PostConfig
+}
+
+// NewHTTPPostClient returns new instance of HTTPPostClient for the post service of the identity at the specified target.
+func NewHTTPPostClient(target string, id types.NodeID, cfg PostClientConfig, logger log.Log) *HTTPPostClient {
+	return &HTTPPostClient{
+		id:      id,
+		baseURL: fmt.Sprintf("http://%s/v1", target),
+		cfg:     cfg,
+		client:  &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()},
+		logger:  logger,
+		ctxFactory: func(ctx context.Context) (context.Context, context.CancelFunc) {
+			return context.WithTimeout(ctx, 10*time.Second)
+		},
+		pollInterval: time.Second,
+	}
+}
+
+// Status returns the setup current status of the remote service.
+func (c *HTTPPostClient) Status() *PostSetupStatus {
+	status, err := c.status(context.Background())
+	if err != nil {
+		c.logger.With().Warning("failed to query post service status", log.Err(err))
+		return &PostSetupStatus{State: PostSetupStateError, LastOpts: c.LastOpts()}
+	}
+	return &PostSetupStatus{
+		State:            status.State,
+		NumLabelsWritten: status.NumLabelsWritten,
+		LastOpts:         status.LastOpts,
+	}
+}
+
+// ComputeProviders returns a list of compute providers available to the remote service.
+func (c *HTTPPostClient) ComputeProviders() []PostSetupComputeProvider {
+	var providers []PostSetupComputeProvider
+	if err := c.reqWithTimeout(context.Background(), http.MethodGet, "/providers", nil, &providers); err != nil {
+		c.logger.With().Warning("failed to query post service compute providers", log.Err(err))
+		return nil
+	}
+	return providers
+}
+
+// Benchmark runs a short benchmarking session for a given provider of the remote service.
+func (c *HTTPPostClient) Benchmark(p PostSetupComputeProvider) (int, error) {
+	var resp postBenchmarkResponse
+	if err := c.req(context.Background(), http.MethodPost, "/benchmark", &postBenchmarkRequest{Provider: p}, &resp); err != nil {
+		return 0, fmt.Errorf("benchmark: %w", err)
+	}
+	return resp.Score, nil
+}
+
+// StartSession starts (or continues) a data creation session on the remote service
+// and blocks until it completes. The session is stopped if ctx is canceled.
+// DataDir in opts is ignored, the service creates the data in its own data dir.
+func (c *HTTPPostClient) StartSession(ctx context.Context, opts PostSetupOpts, commitmentAtx types.ATXID) error {
+	if _, err := c.status(ctx); err != nil {
+		return err
+	}
+	req := postSessionRequest{Opts: opts, CommitmentAtx: commitmentAtx.Bytes()}
+	if err := c.reqWithTimeout(ctx, http.MethodPost, "/session", &req, nil); err != nil {
+		return fmt.Errorf("start session: %w", err)
+	}
+	c.mu.Lock()
+	c.lastOpts = &opts
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := c.reqWithTimeout(context.Background(), http.MethodDelete, "/session", nil, nil); err != nil {
+				c.logger.With().Warning("failed to stop post service session", log.Err(err))
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		status, err := c.status(ctx)
+		if err != nil {
+			c.logger.With().Warning("failed to query post service status", log.Err(err))
+			continue
+		}
+		if status.Session {
+			continue
+		}
+		if status.Error != "" {
+			return fmt.Errorf("post service session: %s", status.Error)
+		}
+		if status.State != PostSetupStateComplete {
+			return fmt.Errorf("post service session finished in state %d", status.State)
+		}
+		return nil
+	}
+}
+
+// Reset deletes the data file(s) of the remote service.
+func (c *HTTPPostClient) Reset() error {
+	if err := c.reqWithTimeout(context.Background(), http.MethodPost, "/reset", nil, nil); err != nil {
+		return fmt.Errorf("reset: %w", err)
+	}
+	return nil
+}
+
+// GenerateProof requests a new Post from the remote service.
+// Proving takes a while, so the request is limited by ProofTimeout instead of the client timeout.
+func (c *HTTPPostClient) GenerateProof(challenge []byte) (*types.Post, *types.PostMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.ProofTimeout)
+	defer cancel()
+	var resp postProofResponse
+	if err := c.req(ctx, http.MethodPost, "/proof", &postProofRequest{Challenge: challenge}, &resp); err != nil {
+		return nil, nil, fmt.Errorf("generate proof: %w", err)
+	}
+	if resp.Post == nil || resp.Metadata == nil {
+		return nil, nil, errors.New("generate proof: empty response")
+	}
+	return resp.Post, resp.Metadata, nil
+}
+
+// VRFNonce returns the VRF nonce found by the remote service during initialization.
+func (c *HTTPPostClient) VRFNonce() (*types.VRFPostIndex, error) {
+	var resp postNonceResponse
+	if err := c.reqWithTimeout(context.Background(), http.MethodGet, "/nonce", nil, &resp); err != nil {
+		return nil, fmt.Errorf("vrf nonce: %w", err)
+	}
+	return &resp.Nonce, nil
+}
+
+// LastOpts returns the Post setup last session options.
+func (c *HTTPPostClient) LastOpts() *PostSetupOpts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastOpts
+}
+
+// Config returns the Post protocol config of the remote service.
+func (c *HTTPPostClient) Config() PostConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.postCfg != nil {
+		return *c.postCfg
+	}
+	var cfg PostConfig
+	if err := c.reqWithTimeout(context.Background(), http.MethodGet, "/config", nil, &cfg); err != nil {
+		c.logger.With().Warning("failed to query post service config", log.Err(err))
+		return cfg
+	}
+	c.postCfg = &cfg
+	return cfg
+}
+
+func (c *HTTPPostClient) status(ctx context.Context) (*postStatusResponse, error) {
+	var status postStatusResponse
+	if err := c.reqWithTimeout(ctx, http.MethodGet, "/status", nil, &status); err != nil {
+		return nil, fmt.Errorf("status: %w", err)
+	}
+	if id := types.BytesToNodeID(status.NodeID); id != c.id {
+		return nil, fmt.Errorf("post service holds data of %s instead of %s", id, c.id)
+	}
+	return &status, nil
+}
+
+func (c *HTTPPostClient) reqWithTimeout(ctx context.Context, method, endURL string, reqBody, resBody any) error {
+	ctx, cancel := c.ctxFactory(ctx)
+	defer cancel()
+	return c.req(ctx, method, endURL, reqBody, resBody)
+}
+
+func (c *HTTPPostClient) req(ctx context.Context, method, endURL string, reqBody, resBody any) error {
+	var body io.Reader
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("request json marshal failure: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	url := fmt.Sprintf("%s%s", c.baseURL, endURL)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearerPrefix+c.cfg.Token)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("perform request: %w", err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body (%w)", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var errResp postErrorResponse
+		if err := json.Unmarshal(data, &errResp); err != nil {
+			errResp.Error = string(data)
+		}
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: response status code: %s, error: %s", ErrNotFound, res.Status, errResp.Error)
+		case http.StatusServiceUnavailable:
+			return fmt.Errorf("%w: response status code: %s, error: %s", ErrUnavailable, res.Status, errResp.Error)
+		case http.StatusConflict:
+			return errSessionInProgress
+		default:
+			return fmt.Errorf("response status code: %s, error: %s", res.Status, errResp.Error)
+		}
+	}
+
+	if resBody != nil {
+		if err := json.Unmarshal(data, resBody); err != nil {
+			return fmt.Errorf("response json decode failure: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package activation
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+const bearerPrefix = "Bearer "
+
+var (
+	errSessionInProgress = errors.New("post setup session in progress")
+	errUnauthorized      = errors.New("invalid or missing token")
+	errNotAllowed        = errors.New("endpoint is not enabled on the post service")
+)
+
+// PostServiceConfig is a configuration of PostService.
+type PostServiceConfig struct {
+	// Token is a shared secret that clients must present as a bearer token. It is required.
+	Token string
+	// AllowSetup enables endpoints that create or delete the post data: benchmark, session and reset.
+	AllowSetup bool
+	// AllowProving enables generation of proofs.
+	AllowProving bool
+}
+
+// postStatusResponse is a status of the post setup served by PostService.
+type postStatusResponse struct {
+	NodeID           []byte         `json:"node_id"`
+	State            PostSetupState `json:"state"`
+	NumLabelsWritten uint64         `json:"num_labels_written"`
+	LastOpts         *PostSetupOpts `json:"last_opts,omitempty"`
+	// Session is true while a data creation session started by the client is running.
+	Session bool `json:"session"`
+	// Error is the error of the last finished session.
+	Error string `json:"error,omitempty"`
+}
+
+type postSessionRequest struct {
+	Opts          PostSetupOpts `json:"opts"`
+	CommitmentAtx []byte        `json:"commitment_atx"`
+}
+
+type postBenchmarkRequest struct {
+	Provider PostSetupComputeProvider `json:"provider"`
+}
+
+type postBenchmarkResponse struct {
+	Score int `json:"score"`
+}
+
+type postProofRequest struct {
+	Challenge []byte `json:"challenge"`
+}
+
+type postProofResponse struct {
+	Post     *types.Post         `json:"post"`
+	Metadata *types.PostMetadata `json:"metadata"`
+}
+
+type postNonceResponse struct {
+	Nonce types.VRFPostIndex `json:"nonce"`
+}
+
+type postErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// PostService serves the post data of a single identity over http, so that the data
+// can be stored on a different machine than the node. The node uses HTTPPostClient to
+// create the data and generate proofs.
+//
+// Every request must be authorized with the token from PostServiceConfig. Endpoints that
+// modify the data or use a lot of resources are disabled unless enabled in the config.
+type PostService struct {
+	id       types.NodeID
+	dataDir  string
+	provider postSetupProvider
+	cfg      PostServiceConfig
+	logger   log.Log
+	mux      *http.ServeMux
+
+	mu         sync.Mutex
+	cancel     context.CancelFunc
+	done       chan struct{}
+	sessionErr error
+}
+
+// NewPostService creates a new instance of PostService.
+// Post data is created in dataDir regardless of the data dir requested by the node.
+func NewPostService(id types.NodeID, dataDir string, provider postSetupProvider, cfg PostServiceConfig, logger log.Log) (*PostService, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("post service token is required")
+	}
+	s := &PostService{
+		id:       id,
+		dataDir:  dataDir,
+		provider: provider,
+		cfg:      cfg,
+		logger:   logger,
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/v1/status", s.method(http.MethodGet, s.status))
+	s.mux.HandleFunc("/v1/config", s.method(http.MethodGet, s.config))
+	s.mux.HandleFunc("/v1/providers", s.method(http.MethodGet, s.providers))
+	s.mux.HandleFunc("/v1/benchmark", s.allowed(cfg.AllowSetup, s.method(http.MethodPost, s.benchmark)))
+	s.mux.HandleFunc("/v1/session", s.allowed(cfg.AllowSetup, s.session))
+	s.mux.HandleFunc("/v1/reset", s.allowed(cfg.AllowSetup, s.method(http.MethodPost, s.reset)))
+	s.mux.HandleFunc("/v1/proof", s.allowed(cfg.AllowProving, s.method(http.MethodPost, s.proof)))
+	s.mux.HandleFunc("/v1/nonce", s.method(http.MethodGet, s.nonce))
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *PostService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errUnauthorized)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *PostService) authorized(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return false
+	}
+	token := strings.TrimPrefix(header, bearerPrefix)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Token)) == 1
+}
+
+func (s *PostService) allowed(enabled bool, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !enabled {
+			writeError(w, http.StatusForbidden, errNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// Close stops the running data creation session, if any.
+func (s *PostService) Close() {
+	s.stopSession()
+}
+
+func (s *PostService) method(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *PostService) status(w http.ResponseWriter, _ *http.Request) {
+	status := s.provider.Status()
+	resp := postStatusResponse{
+		NodeID:           s.id.Bytes(),
+		State:            status.State,
+		NumLabelsWritten: status.NumLabelsWritten,
+		LastOpts:         status.LastOpts,
+	}
+	s.mu.Lock()
+	resp.Session = s.done != nil
+	if s.sessionErr != nil {
+		resp.Error = s.sessionErr.Error()
+	}
+	s.mu.Unlock()
+	writeJSON(w, &resp)
+}
+
+func (s *PostService) config(w http.ResponseWriter, _ *http.Request) {
+	cfg := s.provider.Config()
+	writeJSON(w, &cfg)
+}
+
+func (s *PostService) providers(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.provider.ComputeProviders())
+}
+
+func (s *PostService) benchmark(w http.ResponseWriter, r *http.Request) {
+	var req postBenchmarkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	score, err := s.provider.Benchmark(req.Provider)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, &postBenchmarkResponse{Score: score})
+}
+
+func (s *PostService) session(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req postSessionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := s.startSession(req.Opts, types.ATXID(types.BytesToHash(req.CommitmentAtx))); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeJSON(w, &struct{}{})
+	case http.MethodDelete:
+		s.stopSession()
+		writeJSON(w, &struct{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+func (s *PostService) startSession(opts PostSetupOpts, commitmentAtx types.ATXID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done != nil {
+		return errSessionInProgress
+	}
+	opts.DataDir = s.dataDir
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	s.sessionErr = nil
+	s.logger.With().Info("starting post setup session requested by the node",
+		s.id,
+		log.Uint32("num_units", opts.NumUnits),
+		log.Stringer("commitment_atx", commitmentAtx),
+	)
+	go func() {
+		defer close(done)
+		err := s.provider.StartSession(ctx, opts, commitmentAtx)
+		cancel()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.sessionErr = err
+		s.cancel = nil
+		s.done = nil
+	}()
+	return nil
+}
+
+func (s *PostService) stopSession() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if done == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (s *PostService) reset(w http.ResponseWriter, _ *http.Request) {
+	if err := s.provider.Reset(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, &struct{}{})
+}
+
+func (s *PostService) proof(w http.ResponseWriter, r *http.Request) {
+	var req postProofRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	post, meta, err := s.provider.GenerateProof(req.Challenge)
+	if err != nil {
+		s.logger.With().Warning("failed to generate proof", log.Err(err))
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	writeJSON(w, &postProofResponse{Post: post, Metadata: meta})
+}
+
+func (s *PostService) nonce(w http.ResponseWriter, _ *http.Request) {
+	nonce, err := s.provider.VRFNonce()
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	writeJSON(w, &postNonceResponse{Nonce: *nonce})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(&postErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,215 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+const testPostServiceToken = "secret"
+
+func newTestPostService(t *testing.T, id types.NodeID, opts ...func(*PostServiceConfig)) (*MockpostSetupProvider, *HTTPPostClient) {
+	t.Helper()
+	provider := NewMockpostSetupProvider(gomock.NewController(t))
+	cfg := PostServiceConfig{Token: testPostServiceToken, AllowSetup: true, AllowProving: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	svc, err := NewPostService(id, "/remote", provider, cfg, logtest.New(t))
+	require.NoError(t, err)
+	srv := httptest.NewServer(svc)
+	t.Cleanup(func() {
+		svc.Close()
+		srv.Close()
+	})
+	client := NewHTTPPostClient(strings.TrimPrefix(srv.URL, "http://"), id, PostClientConfig{
+		Token:        testPostServiceToken,
+		ProofTimeout: time.Second,
+	}, logtest.New(t))
+	client.pollInterval = 10 * time.Millisecond
+	return provider, client
+}
+
+func TestHTTPPostClient_Proving(t *testing.T) {
+	id := types.NodeID{1}
+	provider, client := newTestPostService(t, id)
+
+	opts := DefaultPostSetupOpts()
+	opts.NumUnits = 3
+	provider.EXPECT().Status().Return(&PostSetupStatus{
+		State:            PostSetupStateComplete,
+		NumLabelsWritten: 100,
+		LastOpts:         &opts,
+	})
+	status := client.Status()
+	require.Equal(t, PostSetupStateComplete, status.State)
+	require.EqualValues(t, 100, status.NumLabelsWritten)
+	require.Equal(t, opts, *status.LastOpts)
+
+	cfg := DefaultPostConfig()
+	provider.EXPECT().Config().Return(cfg)
+	require.Equal(t, cfg, client.Config())
+	require.Equal(t, cfg, client.Config(), "config is cached")
+
+	challenge := []byte("challenge")
+	post := &types.Post{Nonce: 7, Indices: []byte{1, 2, 3}}
+	meta := &types.PostMetadata{Challenge: challenge, BitsPerLabel: 8, LabelsPerUnit: 1024, K1: 2, K2: 3}
+	provider.EXPECT().GenerateProof(challenge).Return(post, meta, nil)
+	gotPost, gotMeta, err := client.GenerateProof(challenge)
+	require.NoError(t, err)
+	require.Equal(t, post, gotPost)
+	require.Equal(t, meta, gotMeta)
+
+	provider.EXPECT().GenerateProof(challenge).Return(nil, nil, errNotComplete)
+	_, _, err = client.GenerateProof(challenge)
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	nonce := types.VRFPostIndex(11)
+	provider.EXPECT().VRFNonce().Return(&nonce, nil)
+	gotNonce, err := client.VRFNonce()
+	require.NoError(t, err)
+	require.Equal(t, nonce, *gotNonce)
+
+	provider.EXPECT().Reset().Return(nil)
+	require.NoError(t, client.Reset())
+}
+
+func TestHTTPPostClient_Session(t *testing.T) {
+	id := types.NodeID{1}
+	commitmentAtx := types.ATXID{2}
+	opts := DefaultPostSetupOpts()
+	opts.DataDir = "/local"
+
+	t.Run("completed", func(t *testing.T) {
+		provider, client := newTestPostService(t, id)
+		var complete atomic.Bool
+		provider.EXPECT().Status().DoAndReturn(func() *PostSetupStatus {
+			if complete.Load() {
+				return &PostSetupStatus{State: PostSetupStateComplete}
+			}
+			return &PostSetupStatus{State: PostSetupStateNotStarted}
+		}).AnyTimes()
+		provider.EXPECT().StartSession(gomock.Any(), gomock.Any(), commitmentAtx).DoAndReturn(
+			func(_ context.Context, got PostSetupOpts, _ types.ATXID) error {
+				require.Equal(t, "/remote", got.DataDir)
+				complete.Store(true)
+				return nil
+			})
+		require.NoError(t, client.StartSession(context.Background(), opts, commitmentAtx))
+		require.Equal(t, opts, *client.LastOpts())
+	})
+	t.Run("failed", func(t *testing.T) {
+		provider, client := newTestPostService(t, id)
+		provider.EXPECT().Status().Return(&PostSetupStatus{State: PostSetupStateError}).AnyTimes()
+		provider.EXPECT().StartSession(gomock.Any(), gomock.Any(), commitmentAtx).Return(errors.New("disk is full"))
+		err := client.StartSession(context.Background(), opts, commitmentAtx)
+		require.ErrorContains(t, err, "disk is full")
+	})
+	t.Run("canceled", func(t *testing.T) {
+		provider, client := newTestPostService(t, id)
+		provider.EXPECT().Status().Return(&PostSetupStatus{State: PostSetupStateInProgress}).AnyTimes()
+		stopped := make(chan struct{})
+		provider.EXPECT().StartSession(gomock.Any(), gomock.Any(), commitmentAtx).DoAndReturn(
+			func(ctx context.Context, _ PostSetupOpts, _ types.ATXID) error {
+				<-ctx.Done()
+				close(stopped)
+				return ctx.Err()
+			})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, client.StartSession(ctx, opts, commitmentAtx), context.DeadlineExceeded)
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			require.FailNow(t, "remote session wasn't stopped")
+		}
+	})
+	t.Run("in progress", func(t *testing.T) {
+		provider, client := newTestPostService(t, id)
+		provider.EXPECT().Status().Return(&PostSetupStatus{State: PostSetupStateInProgress}).AnyTimes()
+		provider.EXPECT().StartSession(gomock.Any(), gomock.Any(), commitmentAtx).DoAndReturn(
+			func(ctx context.Context, _ PostSetupOpts, _ types.ATXID) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go client.StartSession(ctx, opts, commitmentAtx)
+		require.Eventually(t, func() bool {
+			status, err := client.status(context.Background())
+			return err == nil && status.Session
+		}, time.Second, 10*time.Millisecond)
+		require.ErrorIs(t, client.StartSession(context.Background(), opts, commitmentAtx), errSessionInProgress)
+	})
+}
+
+func TestHTTPPostClient_OtherIdentity(t *testing.T) {
+	provider, remote := newTestPostService(t, types.NodeID{1})
+	provider.EXPECT().Status().Return(&PostSetupStatus{State: PostSetupStateComplete}).AnyTimes()
+
+	client := NewHTTPPostClient("", types.NodeID{2}, remote.cfg, logtest.New(t))
+	client.baseURL = remote.baseURL
+	require.Equal(t, PostSetupStateError, client.Status().State)
+	require.ErrorContains(t, client.StartSession(context.Background(), DefaultPostSetupOpts(), types.ATXID{}), "instead of")
+}
+
+func TestPostService_RequiresToken(t *testing.T) {
+	provider := NewMockpostSetupProvider(gomock.NewController(t))
+	_, err := NewPostService(types.NodeID{1}, "/remote", provider, PostServiceConfig{}, logtest.New(t))
+	require.Error(t, err)
+}
+
+func TestHTTPPostClient_Unauthorized(t *testing.T) {
+	id := types.NodeID{1}
+	provider, remote := newTestPostService(t, id)
+	provider.EXPECT().Status().Return(&PostSetupStatus{State: PostSetupStateComplete}).AnyTimes()
+	require.Equal(t, PostSetupStateComplete, remote.Status().State)
+
+	for _, token := range []string{"", "other"} {
+		client := NewHTTPPostClient("", id, PostClientConfig{Token: token, ProofTimeout: time.Second}, logtest.New(t))
+		client.baseURL = remote.baseURL
+		require.Equal(t, PostSetupStateError, client.Status().State)
+		_, err := client.VRFNonce()
+		require.ErrorContains(t, err, "401")
+	}
+}
+
+func TestHTTPPostClient_NotAllowed(t *testing.T) {
+	id := types.NodeID{1}
+	// provider methods must not be called, mock fails the test otherwise
+	_, client := newTestPostService(t, id, func(cfg *PostServiceConfig) {
+		cfg.AllowSetup = false
+		cfg.AllowProving = false
+	})
+	_, _, err := client.GenerateProof([]byte("challenge"))
+	require.ErrorContains(t, err, "403")
+	require.ErrorContains(t, client.Reset(), "403")
+	_, err = client.Benchmark(PostSetupComputeProvider{})
+	require.ErrorContains(t, err, "403")
+	require.ErrorContains(t, client.reqWithTimeout(context.Background(), http.MethodPost, "/session",
+		&postSessionRequest{}, nil), "403")
+}
+
+func TestHTTPPostClient_ProofTimeout(t *testing.T) {
+	provider, client := newTestPostService(t, types.NodeID{1})
+	client.cfg.ProofTimeout = 10 * time.Millisecond
+	release := make(chan struct{})
+	defer close(release)
+	provider.EXPECT().GenerateProof(gomock.Any()).DoAndReturn(func([]byte) (*types.Post, *types.PostMetadata, error) {
+		<-release
+		return nil, nil, errNotComplete
+	})
+	_, _, err := client.GenerateProof([]byte("challenge"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
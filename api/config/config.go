@@ -8,18 +8,20 @@ import (
 )
 
 const (
-	defaultGRPCServerPort          = 9092
-	defaultGRPCServerInterface     = ""
-	defaultStartJSONServer         = false
-	defaultJSONServerPort          = 9093
-	defaultStartDebugService       = false
-	defaultStartGatewayService     = false
-	defaultStartGlobalStateService = false
-	defaultStartMeshService        = false
-	defaultStartNodeService        = false
-	defaultStartSmesherService     = false
-	defaultStartTransactionService = false
-	defaultStartActivationService  = false
+	defaultGRPCServerPort           = 9092
+	defaultGRPCServerInterface      = ""
+	defaultStartJSONServer          = false
+	defaultJSONServerPort           = 9093
+	defaultStartDebugService        = false
+	defaultStartGatewayService      = false
+	defaultStartGlobalStateService  = false
+	defaultStartMeshService         = false
+	defaultStartNodeService         = false
+	defaultStartSmesherService      = false
+	defaultStartTransactionService  = false
+	defaultStartActivationService   = false
+	defaultStartPeerService         = false
+	defaultStartMempoolAdminService = false
 
 	defaultSmesherStreamInterval = 1 * time.Second
 )
@@ -32,14 +34,16 @@ type Config struct {
 	StartJSONServer     bool     `mapstructure:"json-server"`
 	JSONServerPort      int      `mapstructure:"json-port"`
 	// no direct command line flags for these
-	StartDebugService       bool
-	StartGatewayService     bool
-	StartGlobalStateService bool
-	StartMeshService        bool
-	StartNodeService        bool
-	StartSmesherService     bool
-	StartTransactionService bool
-	StartActivationService  bool
+	StartDebugService        bool
+	StartGatewayService      bool
+	StartGlobalStateService  bool
+	StartMeshService         bool
+	StartNodeService         bool
+	StartSmesherService      bool
+	StartTransactionService  bool
+	StartActivationService   bool
+	StartPeerService         bool
+	StartMempoolAdminService bool
 
 	SmesherStreamInterval time.Duration
 }
@@ -52,19 +56,21 @@ func init() {
 func DefaultConfig() Config {
 	return Config{
 		// note: all bool flags default to false so don't set one of these to true here
-		StartGrpcServices:       nil, // note: cannot configure an array as a const
-		GrpcServerPort:          defaultGRPCServerPort,
-		GrpcServerInterface:     defaultGRPCServerInterface,
-		StartJSONServer:         defaultStartJSONServer,
-		JSONServerPort:          defaultJSONServerPort,
-		StartDebugService:       defaultStartDebugService,
-		StartGatewayService:     defaultStartGatewayService,
-		StartGlobalStateService: defaultStartGlobalStateService,
-		StartMeshService:        defaultStartMeshService,
-		StartNodeService:        defaultStartNodeService,
-		StartSmesherService:     defaultStartSmesherService,
-		StartTransactionService: defaultStartTransactionService,
-		StartActivationService:  defaultStartActivationService,
+		StartGrpcServices:        nil, // note: cannot configure an array as a const
+		GrpcServerPort:           defaultGRPCServerPort,
+		GrpcServerInterface:      defaultGRPCServerInterface,
+		StartJSONServer:          defaultStartJSONServer,
+		JSONServerPort:           defaultJSONServerPort,
+		StartDebugService:        defaultStartDebugService,
+		StartGatewayService:      defaultStartGatewayService,
+		StartGlobalStateService:  defaultStartGlobalStateService,
+		StartMeshService:         defaultStartMeshService,
+		StartNodeService:         defaultStartNodeService,
+		StartSmesherService:      defaultStartSmesherService,
+		StartTransactionService:  defaultStartTransactionService,
+		StartActivationService:   defaultStartActivationService,
+		StartPeerService:         defaultStartPeerService,
+		StartMempoolAdminService: defaultStartMempoolAdminService,
 
 		SmesherStreamInterval: defaultSmesherStreamInterval,
 	}
@@ -100,6 +106,10 @@ func (s *Config) ParseServicesList() error {
 			s.StartTransactionService = true
 		case "activation":
 			s.StartActivationService = true
+		case "peer":
+			s.StartPeerService = true
+		case "mempooladmin":
+			s.StartMempoolAdminService = true
 		default:
 			return fmt.Errorf("unrecognized GRPC service requested: %s", svc)
 		}
@@ -116,6 +126,8 @@ func (s *Config) ParseServicesList() error {
 		!s.StartSmesherService &&
 		!s.StartTransactionService &&
 		!s.StartActivationService &&
+		!s.StartPeerService &&
+		!s.StartMempoolAdminService &&
 		// 'true' keeps the above clean
 		true {
 		return errors.New("must enable at least one GRPC service along with JSON gateway service")
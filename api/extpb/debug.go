@@ -0,0 +1,97 @@
+package extpb
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type TortoiseExplainRequest struct {
+	Layer uint32 `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+}
+
+func (m *TortoiseExplainRequest) Reset()         { *m = TortoiseExplainRequest{} }
+func (m *TortoiseExplainRequest) String() string { return proto.CompactTextString(m) }
+func (*TortoiseExplainRequest) ProtoMessage()    {}
+
+type TortoiseExplainResponse struct {
+	Layer           uint32              `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	Verified        bool                `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+	Mode            string              `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	HareTerminated  bool                `protobuf:"varint,4,opt,name=hare_terminated,json=hareTerminated,proto3" json:"hare_terminated,omitempty"`
+	Opinion         []byte              `protobuf:"bytes,5,opt,name=opinion,proto3" json:"opinion,omitempty"`
+	ExpectedWeight  float64             `protobuf:"fixed64,6,opt,name=expected_weight,json=expectedWeight,proto3" json:"expected_weight,omitempty"`
+	LocalThreshold  float64             `protobuf:"fixed64,7,opt,name=local_threshold,json=localThreshold,proto3" json:"local_threshold,omitempty"`
+	GlobalThreshold float64             `protobuf:"fixed64,8,opt,name=global_threshold,json=globalThreshold,proto3" json:"global_threshold,omitempty"`
+	GoodWeight      float64             `protobuf:"fixed64,9,opt,name=good_weight,json=goodWeight,proto3" json:"good_weight,omitempty"`
+	EmptyWeight     float64             `protobuf:"fixed64,10,opt,name=empty_weight,json=emptyWeight,proto3" json:"empty_weight,omitempty"`
+	Blocks          []*BlockExplanation `protobuf:"bytes,11,rep,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (m *TortoiseExplainResponse) Reset()         { *m = TortoiseExplainResponse{} }
+func (m *TortoiseExplainResponse) String() string { return proto.CompactTextString(m) }
+func (*TortoiseExplainResponse) ProtoMessage()    {}
+
+type BlockExplanation struct {
+	Id       []byte  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Height   uint64  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Hare     string  `protobuf:"bytes,3,opt,name=hare,proto3" json:"hare,omitempty"`
+	Validity string  `protobuf:"bytes,4,opt,name=validity,proto3" json:"validity,omitempty"`
+	For      float64 `protobuf:"fixed64,5,opt,name=for,proto3" json:"for,omitempty"`
+	Against  float64 `protobuf:"fixed64,6,opt,name=against,proto3" json:"against,omitempty"`
+	Vote     string  `protobuf:"bytes,7,opt,name=vote,proto3" json:"vote,omitempty"`
+	Reason   string  `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error    string  `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *BlockExplanation) Reset()         { *m = BlockExplanation{} }
+func (m *BlockExplanation) String() string { return proto.CompactTextString(m) }
+func (*BlockExplanation) ProtoMessage()    {}
+
+// DebugServiceServer is the server API for DebugService.
+type DebugServiceServer interface {
+	TortoiseExplain(context.Context, *TortoiseExplainRequest) (*TortoiseExplainResponse, error)
+}
+
+// RegisterDebugServiceServer registers srv on the grpc server.
+func RegisterDebugServiceServer(s *grpc.Server, srv DebugServiceServer) {
+	s.RegisterService(&debugServiceDesc, srv)
+}
+
+// DebugServiceClient is the client API for DebugService.
+type DebugServiceClient interface {
+	TortoiseExplain(ctx context.Context, in *TortoiseExplainRequest, opts ...grpc.CallOption) (*TortoiseExplainResponse, error)
+}
+
+type debugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewDebugServiceClient creates client for DebugService.
+func NewDebugServiceClient(cc grpc.ClientConnInterface) DebugServiceClient {
+	return &debugServiceClient{cc}
+}
+
+func (c *debugServiceClient) TortoiseExplain(ctx context.Context, in *TortoiseExplainRequest, opts ...grpc.CallOption) (*TortoiseExplainResponse, error) {
+	out := new(TortoiseExplainResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.DebugService/TortoiseExplain", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var debugServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.ext.v1.DebugService",
+	HandlerType: (*DebugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TortoiseExplain",
+			Handler: unaryHandler("/spacemesh.ext.v1.DebugService/TortoiseExplain",
+				func(srv any, ctx context.Context, in *TortoiseExplainRequest) (any, error) {
+					return srv.(DebugServiceServer).TortoiseExplain(ctx, in)
+				}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
@@ -0,0 +1,9 @@
+// Package extpb defines node api services and messages that are not published
+// in github.com/spacemeshos/api yet.
+//
+// Go types in this package are the source of truth for the schema, there are no .proto files
+// and nothing is generated. Messages are plain structs with protobuf field tags, so that they
+// are encoded by the default grpc codec, and service descriptors are written the same way as
+// generated ones. Field numbers must not be changed or reused once released. Services are
+// registered on the same grpc server as the rest of the api.
+package extpb
@@ -0,0 +1,89 @@
+package extpb
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type AccountWithProofRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Layer   uint32 `protobuf:"varint,2,opt,name=layer,proto3" json:"layer,omitempty"`
+}
+
+func (m *AccountWithProofRequest) Reset()         { *m = AccountWithProofRequest{} }
+func (m *AccountWithProofRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountWithProofRequest) ProtoMessage()    {}
+
+type AccountWithProofResponse struct {
+	Layer     uint32       `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	StateRoot []byte       `protobuf:"bytes,2,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	Account   *AccountLeaf `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Siblings  [][]byte     `protobuf:"bytes,4,rep,name=siblings,proto3" json:"siblings,omitempty"`
+}
+
+func (m *AccountWithProofResponse) Reset()         { *m = AccountWithProofResponse{} }
+func (m *AccountWithProofResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountWithProofResponse) ProtoMessage()    {}
+
+type AccountLeaf struct {
+	Address     string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Layer       uint32 `protobuf:"varint,2,opt,name=layer,proto3" json:"layer,omitempty"`
+	Initialized bool   `protobuf:"varint,3,opt,name=initialized,proto3" json:"initialized,omitempty"`
+	NextNonce   uint64 `protobuf:"varint,4,opt,name=next_nonce,json=nextNonce,proto3" json:"next_nonce,omitempty"`
+	Balance     uint64 `protobuf:"varint,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	Template    string `protobuf:"bytes,6,opt,name=template,proto3" json:"template,omitempty"`
+	State       []byte `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (m *AccountLeaf) Reset()         { *m = AccountLeaf{} }
+func (m *AccountLeaf) String() string { return proto.CompactTextString(m) }
+func (*AccountLeaf) ProtoMessage()    {}
+
+// GlobalStateServiceServer is the server API for GlobalStateService.
+type GlobalStateServiceServer interface {
+	AccountWithProof(context.Context, *AccountWithProofRequest) (*AccountWithProofResponse, error)
+}
+
+// RegisterGlobalStateServiceServer registers srv on the grpc server.
+func RegisterGlobalStateServiceServer(s *grpc.Server, srv GlobalStateServiceServer) {
+	s.RegisterService(&globalStateServiceDesc, srv)
+}
+
+// GlobalStateServiceClient is the client API for GlobalStateService.
+type GlobalStateServiceClient interface {
+	AccountWithProof(ctx context.Context, in *AccountWithProofRequest, opts ...grpc.CallOption) (*AccountWithProofResponse, error)
+}
+
+type globalStateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewGlobalStateServiceClient creates client for GlobalStateService.
+func NewGlobalStateServiceClient(cc grpc.ClientConnInterface) GlobalStateServiceClient {
+	return &globalStateServiceClient{cc}
+}
+
+func (c *globalStateServiceClient) AccountWithProof(ctx context.Context, in *AccountWithProofRequest, opts ...grpc.CallOption) (*AccountWithProofResponse, error) {
+	out := new(AccountWithProofResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.GlobalStateService/AccountWithProof", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var globalStateServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.ext.v1.GlobalStateService",
+	HandlerType: (*GlobalStateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AccountWithProof",
+			Handler: unaryHandler("/spacemesh.ext.v1.GlobalStateService/AccountWithProof",
+				func(srv any, ctx context.Context, in *AccountWithProofRequest) (any, error) {
+					return srv.(GlobalStateServiceServer).AccountWithProof(ctx, in)
+				}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
@@ -0,0 +1,29 @@
+package extpb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type methodHandler = func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error)
+
+// unaryHandler adapts a typed method to the handler of grpc.MethodDesc, the same way as generated code does.
+func unaryHandler[T any](method string, call func(srv any, ctx context.Context, in *T) (any, error)) methodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(T)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv, ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: method,
+		}
+		return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+			return call(srv, ctx, req.(*T))
+		})
+	}
+}
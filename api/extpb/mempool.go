@@ -0,0 +1,96 @@
+package extpb
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type DropTransactionsRequest struct {
+	Ids [][]byte `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (m *DropTransactionsRequest) Reset()         { *m = DropTransactionsRequest{} }
+func (m *DropTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*DropTransactionsRequest) ProtoMessage()    {}
+
+type DropPrincipalRequest struct {
+	Principal string `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+}
+
+func (m *DropPrincipalRequest) Reset()         { *m = DropPrincipalRequest{} }
+func (m *DropPrincipalRequest) String() string { return proto.CompactTextString(m) }
+func (*DropPrincipalRequest) ProtoMessage()    {}
+
+type DropResponse struct {
+	Dropped [][]byte `protobuf:"bytes,1,rep,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (m *DropResponse) Reset()         { *m = DropResponse{} }
+func (m *DropResponse) String() string { return proto.CompactTextString(m) }
+func (*DropResponse) ProtoMessage()    {}
+
+// MempoolAdminServiceServer is the server API for MempoolAdminService.
+type MempoolAdminServiceServer interface {
+	DropTransactions(context.Context, *DropTransactionsRequest) (*DropResponse, error)
+	DropPrincipal(context.Context, *DropPrincipalRequest) (*DropResponse, error)
+}
+
+// RegisterMempoolAdminServiceServer registers srv on the grpc server.
+func RegisterMempoolAdminServiceServer(s *grpc.Server, srv MempoolAdminServiceServer) {
+	s.RegisterService(&mempoolAdminServiceDesc, srv)
+}
+
+// MempoolAdminServiceClient is the client API for MempoolAdminService.
+type MempoolAdminServiceClient interface {
+	DropTransactions(ctx context.Context, in *DropTransactionsRequest, opts ...grpc.CallOption) (*DropResponse, error)
+	DropPrincipal(ctx context.Context, in *DropPrincipalRequest, opts ...grpc.CallOption) (*DropResponse, error)
+}
+
+type mempoolAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewMempoolAdminServiceClient creates client for MempoolAdminService.
+func NewMempoolAdminServiceClient(cc grpc.ClientConnInterface) MempoolAdminServiceClient {
+	return &mempoolAdminServiceClient{cc}
+}
+
+func (c *mempoolAdminServiceClient) DropTransactions(ctx context.Context, in *DropTransactionsRequest, opts ...grpc.CallOption) (*DropResponse, error) {
+	out := new(DropResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.MempoolAdminService/DropTransactions", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolAdminServiceClient) DropPrincipal(ctx context.Context, in *DropPrincipalRequest, opts ...grpc.CallOption) (*DropResponse, error) {
+	out := new(DropResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.MempoolAdminService/DropPrincipal", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var mempoolAdminServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.ext.v1.MempoolAdminService",
+	HandlerType: (*MempoolAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DropTransactions",
+			Handler: unaryHandler("/spacemesh.ext.v1.MempoolAdminService/DropTransactions",
+				func(srv any, ctx context.Context, in *DropTransactionsRequest) (any, error) {
+					return srv.(MempoolAdminServiceServer).DropTransactions(ctx, in)
+				}),
+		},
+		{
+			MethodName: "DropPrincipal",
+			Handler: unaryHandler("/spacemesh.ext.v1.MempoolAdminService/DropPrincipal",
+				func(srv any, ctx context.Context, in *DropPrincipalRequest) (any, error) {
+					return srv.(MempoolAdminServiceServer).DropPrincipal(ctx, in)
+				}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
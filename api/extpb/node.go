@@ -0,0 +1,132 @@
+package extpb
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type PeersRequest struct{}
+
+func (m *PeersRequest) Reset()         { *m = PeersRequest{} }
+func (m *PeersRequest) String() string { return proto.CompactTextString(m) }
+func (*PeersRequest) ProtoMessage()    {}
+
+type PeersResponse struct {
+	Peers []*PeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (m *PeersResponse) Reset()         { *m = PeersResponse{} }
+func (m *PeersResponse) String() string { return proto.CompactTextString(m) }
+func (*PeersResponse) ProtoMessage()    {}
+
+type PeerInfo struct {
+	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version   string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Protocols []string `protobuf:"bytes,3,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	Topics    []string `protobuf:"bytes,4,rep,name=topics,proto3" json:"topics,omitempty"`
+}
+
+func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
+func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()    {}
+
+type StatusRequest struct{}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+
+type StatusResponse struct {
+	ConnectedPeers uint64          `protobuf:"varint,1,opt,name=connected_peers,json=connectedPeers,proto3" json:"connected_peers,omitempty"`
+	IsSynced       bool            `protobuf:"varint,2,opt,name=is_synced,json=isSynced,proto3" json:"is_synced,omitempty"`
+	SyncedLayer    uint32          `protobuf:"varint,3,opt,name=synced_layer,json=syncedLayer,proto3" json:"synced_layer,omitempty"`
+	TopLayer       uint32          `protobuf:"varint,4,opt,name=top_layer,json=topLayer,proto3" json:"top_layer,omitempty"`
+	VerifiedLayer  uint32          `protobuf:"varint,5,opt,name=verified_layer,json=verifiedLayer,proto3" json:"verified_layer,omitempty"`
+	Tortoise       *TortoiseStatus `protobuf:"bytes,6,opt,name=tortoise,proto3" json:"tortoise,omitempty"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+
+type TortoiseStatus struct {
+	LastLayer      uint32 `protobuf:"varint,1,opt,name=last_layer,json=lastLayer,proto3" json:"last_layer,omitempty"`
+	ProcessedLayer uint32 `protobuf:"varint,2,opt,name=processed_layer,json=processedLayer,proto3" json:"processed_layer,omitempty"`
+	VerifiedLayer  uint32 `protobuf:"varint,3,opt,name=verified_layer,json=verifiedLayer,proto3" json:"verified_layer,omitempty"`
+	Lag            uint32 `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`
+	Mode           string `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	FullSince      uint32 `protobuf:"varint,6,opt,name=full_since,json=fullSince,proto3" json:"full_since,omitempty"`
+	ModeSwitches   uint64 `protobuf:"varint,7,opt,name=mode_switches,json=modeSwitches,proto3" json:"mode_switches,omitempty"`
+	HealedLayers   uint64 `protobuf:"varint,8,opt,name=healed_layers,json=healedLayers,proto3" json:"healed_layers,omitempty"`
+	CoinflipLayers uint64 `protobuf:"varint,9,opt,name=coinflip_layers,json=coinflipLayers,proto3" json:"coinflip_layers,omitempty"`
+}
+
+func (m *TortoiseStatus) Reset()         { *m = TortoiseStatus{} }
+func (m *TortoiseStatus) String() string { return proto.CompactTextString(m) }
+func (*TortoiseStatus) ProtoMessage()    {}
+
+// NodeServiceServer is the server API for NodeService.
+type NodeServiceServer interface {
+	Peers(context.Context, *PeersRequest) (*PeersResponse, error)
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+}
+
+// RegisterNodeServiceServer registers srv on the grpc server.
+func RegisterNodeServiceServer(s *grpc.Server, srv NodeServiceServer) {
+	s.RegisterService(&nodeServiceDesc, srv)
+}
+
+// NodeServiceClient is the client API for NodeService.
+type NodeServiceClient interface {
+	Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type nodeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewNodeServiceClient creates client for NodeService.
+func NewNodeServiceClient(cc grpc.ClientConnInterface) NodeServiceClient {
+	return &nodeServiceClient{cc}
+}
+
+func (c *nodeServiceClient) Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error) {
+	out := new(PeersResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.NodeService/Peers", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.NodeService/Status", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var nodeServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.ext.v1.NodeService",
+	HandlerType: (*NodeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Peers",
+			Handler: unaryHandler("/spacemesh.ext.v1.NodeService/Peers",
+				func(srv any, ctx context.Context, in *PeersRequest) (any, error) {
+					return srv.(NodeServiceServer).Peers(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Status",
+			Handler: unaryHandler("/spacemesh.ext.v1.NodeService/Status",
+				func(srv any, ctx context.Context, in *StatusRequest) (any, error) {
+					return srv.(NodeServiceServer).Status(ctx, in)
+				}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
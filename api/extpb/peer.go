@@ -0,0 +1,336 @@
+package extpb
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type ConnectionsRequest struct{}
+
+func (m *ConnectionsRequest) Reset()         { *m = ConnectionsRequest{} }
+func (m *ConnectionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectionsRequest) ProtoMessage()    {}
+
+type ConnectionsResponse struct {
+	Connections []*Connection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+}
+
+func (m *ConnectionsResponse) Reset()         { *m = ConnectionsResponse{} }
+func (m *ConnectionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ConnectionsResponse) ProtoMessage()    {}
+
+type Connection struct {
+	Id         string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outbound   bool     `protobuf:"varint,2,opt,name=outbound,proto3" json:"outbound,omitempty"`
+	Address    string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	AgeSeconds uint64   `protobuf:"varint,4,opt,name=age_seconds,json=ageSeconds,proto3" json:"age_seconds,omitempty"`
+	Protocols  []string `protobuf:"bytes,5,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	Score      float64  `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (m *Connection) Reset()         { *m = Connection{} }
+func (m *Connection) String() string { return proto.CompactTextString(m) }
+func (*Connection) ProtoMessage()    {}
+
+type AddressBookRequest struct{}
+
+func (m *AddressBookRequest) Reset()         { *m = AddressBookRequest{} }
+func (m *AddressBookRequest) String() string { return proto.CompactTextString(m) }
+func (*AddressBookRequest) ProtoMessage()    {}
+
+type AddressBookResponse struct {
+	New   []*Bucket `protobuf:"bytes,1,rep,name=new,proto3" json:"new,omitempty"`
+	Tried []*Bucket `protobuf:"bytes,2,rep,name=tried,proto3" json:"tried,omitempty"`
+}
+
+func (m *AddressBookResponse) Reset()         { *m = AddressBookResponse{} }
+func (m *AddressBookResponse) String() string { return proto.CompactTextString(m) }
+func (*AddressBookResponse) ProtoMessage()    {}
+
+type Bucket struct {
+	Index     uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (m *Bucket) Reset()         { *m = Bucket{} }
+func (m *Bucket) String() string { return proto.CompactTextString(m) }
+func (*Bucket) ProtoMessage()    {}
+
+type ConnectRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
+func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectRequest) ProtoMessage()    {}
+
+type ConnectResponse struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
+func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
+func (*ConnectResponse) ProtoMessage()    {}
+
+type DisconnectRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
+func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectRequest) ProtoMessage()    {}
+
+type BootnodesRequest struct{}
+
+func (m *BootnodesRequest) Reset()         { *m = BootnodesRequest{} }
+func (m *BootnodesRequest) String() string { return proto.CompactTextString(m) }
+func (*BootnodesRequest) ProtoMessage()    {}
+
+type BootnodesResponse struct {
+	Static []string `protobuf:"bytes,1,rep,name=static,proto3" json:"static,omitempty"`
+	Added  []string `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+}
+
+func (m *BootnodesResponse) Reset()         { *m = BootnodesResponse{} }
+func (m *BootnodesResponse) String() string { return proto.CompactTextString(m) }
+func (*BootnodesResponse) ProtoMessage()    {}
+
+type BootnodeRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *BootnodeRequest) Reset()         { *m = BootnodeRequest{} }
+func (m *BootnodeRequest) String() string { return proto.CompactTextString(m) }
+func (*BootnodeRequest) ProtoMessage()    {}
+
+type BanRequest struct {
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cidr string `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
+}
+
+func (m *BanRequest) Reset()         { *m = BanRequest{} }
+func (m *BanRequest) String() string { return proto.CompactTextString(m) }
+func (*BanRequest) ProtoMessage()    {}
+
+type BansRequest struct{}
+
+func (m *BansRequest) Reset()         { *m = BansRequest{} }
+func (m *BansRequest) String() string { return proto.CompactTextString(m) }
+func (*BansRequest) ProtoMessage()    {}
+
+type BansResponse struct {
+	Peers []string `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	Cidrs []string `protobuf:"bytes,2,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+}
+
+func (m *BansResponse) Reset()         { *m = BansResponse{} }
+func (m *BansResponse) String() string { return proto.CompactTextString(m) }
+func (*BansResponse) ProtoMessage()    {}
+
+type PeerResponse struct{}
+
+func (m *PeerResponse) Reset()         { *m = PeerResponse{} }
+func (m *PeerResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerResponse) ProtoMessage()    {}
+
+// PeerServiceServer is the server API for PeerService.
+type PeerServiceServer interface {
+	Connections(context.Context, *ConnectionsRequest) (*ConnectionsResponse, error)
+	AddressBook(context.Context, *AddressBookRequest) (*AddressBookResponse, error)
+	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
+	Disconnect(context.Context, *DisconnectRequest) (*PeerResponse, error)
+	Bootnodes(context.Context, *BootnodesRequest) (*BootnodesResponse, error)
+	AddBootnode(context.Context, *BootnodeRequest) (*PeerResponse, error)
+	RemoveBootnode(context.Context, *BootnodeRequest) (*PeerResponse, error)
+	Ban(context.Context, *BanRequest) (*PeerResponse, error)
+	Unban(context.Context, *BanRequest) (*PeerResponse, error)
+	Bans(context.Context, *BansRequest) (*BansResponse, error)
+}
+
+// RegisterPeerServiceServer registers srv on the grpc server.
+func RegisterPeerServiceServer(s *grpc.Server, srv PeerServiceServer) {
+	s.RegisterService(&peerServiceDesc, srv)
+}
+
+// PeerServiceClient is the client API for PeerService.
+type PeerServiceClient interface {
+	Connections(ctx context.Context, in *ConnectionsRequest, opts ...grpc.CallOption) (*ConnectionsResponse, error)
+	AddressBook(ctx context.Context, in *AddressBookRequest, opts ...grpc.CallOption) (*AddressBookResponse, error)
+	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
+	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	Bootnodes(ctx context.Context, in *BootnodesRequest, opts ...grpc.CallOption) (*BootnodesResponse, error)
+	AddBootnode(ctx context.Context, in *BootnodeRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	RemoveBootnode(ctx context.Context, in *BootnodeRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	Ban(ctx context.Context, in *BanRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	Unban(ctx context.Context, in *BanRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	Bans(ctx context.Context, in *BansRequest, opts ...grpc.CallOption) (*BansResponse, error)
+}
+
+type peerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewPeerServiceClient creates client for PeerService.
+func NewPeerServiceClient(cc grpc.ClientConnInterface) PeerServiceClient {
+	return &peerServiceClient{cc}
+}
+
+func (c *peerServiceClient) Connections(ctx context.Context, in *ConnectionsRequest, opts ...grpc.CallOption) (*ConnectionsResponse, error) {
+	out := new(ConnectionsResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Connections", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) AddressBook(ctx context.Context, in *AddressBookRequest, opts ...grpc.CallOption) (*AddressBookResponse, error) {
+	out := new(AddressBookResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/AddressBook", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error) {
+	out := new(ConnectResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Connect", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Disconnect", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Bootnodes(ctx context.Context, in *BootnodesRequest, opts ...grpc.CallOption) (*BootnodesResponse, error) {
+	out := new(BootnodesResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Bootnodes", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) AddBootnode(ctx context.Context, in *BootnodeRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/AddBootnode", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) RemoveBootnode(ctx context.Context, in *BootnodeRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/RemoveBootnode", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Ban(ctx context.Context, in *BanRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Ban", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Unban(ctx context.Context, in *BanRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Unban", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) Bans(ctx context.Context, in *BansRequest, opts ...grpc.CallOption) (*BansResponse, error) {
+	out := new(BansResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.PeerService/Bans", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var peerServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.ext.v1.PeerService",
+	HandlerType: (*PeerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connections",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Connections",
+				func(srv any, ctx context.Context, in *ConnectionsRequest) (any, error) {
+					return srv.(PeerServiceServer).Connections(ctx, in)
+				}),
+		},
+		{
+			MethodName: "AddressBook",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/AddressBook",
+				func(srv any, ctx context.Context, in *AddressBookRequest) (any, error) {
+					return srv.(PeerServiceServer).AddressBook(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Connect",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Connect",
+				func(srv any, ctx context.Context, in *ConnectRequest) (any, error) {
+					return srv.(PeerServiceServer).Connect(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Disconnect",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Disconnect",
+				func(srv any, ctx context.Context, in *DisconnectRequest) (any, error) {
+					return srv.(PeerServiceServer).Disconnect(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Bootnodes",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Bootnodes",
+				func(srv any, ctx context.Context, in *BootnodesRequest) (any, error) {
+					return srv.(PeerServiceServer).Bootnodes(ctx, in)
+				}),
+		},
+		{
+			MethodName: "AddBootnode",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/AddBootnode",
+				func(srv any, ctx context.Context, in *BootnodeRequest) (any, error) {
+					return srv.(PeerServiceServer).AddBootnode(ctx, in)
+				}),
+		},
+		{
+			MethodName: "RemoveBootnode",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/RemoveBootnode",
+				func(srv any, ctx context.Context, in *BootnodeRequest) (any, error) {
+					return srv.(PeerServiceServer).RemoveBootnode(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Ban",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Ban",
+				func(srv any, ctx context.Context, in *BanRequest) (any, error) {
+					return srv.(PeerServiceServer).Ban(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Unban",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Unban",
+				func(srv any, ctx context.Context, in *BanRequest) (any, error) {
+					return srv.(PeerServiceServer).Unban(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Bans",
+			Handler: unaryHandler("/spacemesh.ext.v1.PeerService/Bans",
+				func(srv any, ctx context.Context, in *BansRequest) (any, error) {
+					return srv.(PeerServiceServer).Bans(ctx, in)
+				}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
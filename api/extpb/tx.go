@@ -0,0 +1,180 @@
+package extpb
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+type MempoolRequest struct {
+	Principal string `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+}
+
+func (m *MempoolRequest) Reset()         { *m = MempoolRequest{} }
+func (m *MempoolRequest) String() string { return proto.CompactTextString(m) }
+func (*MempoolRequest) ProtoMessage()    {}
+
+type MempoolResponse struct {
+	Accounts []*AccountMempool `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+}
+
+func (m *MempoolResponse) Reset()         { *m = MempoolResponse{} }
+func (m *MempoolResponse) String() string { return proto.CompactTextString(m) }
+func (*MempoolResponse) ProtoMessage()    {}
+
+type AccountMempool struct {
+	Principal        string                `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	ProjectedNonce   uint64                `protobuf:"varint,2,opt,name=projected_nonce,json=projectedNonce,proto3" json:"projected_nonce,omitempty"`
+	ProjectedBalance uint64                `protobuf:"varint,3,opt,name=projected_balance,json=projectedBalance,proto3" json:"projected_balance,omitempty"`
+	Transactions     []*PendingTransaction `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (m *AccountMempool) Reset()         { *m = AccountMempool{} }
+func (m *AccountMempool) String() string { return proto.CompactTextString(m) }
+func (*AccountMempool) ProtoMessage()    {}
+
+type PendingTransaction struct {
+	Id       []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nonce    uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	MaxGas   uint64 `protobuf:"varint,3,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	GasPrice uint64 `protobuf:"varint,4,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	MaxSpend uint64 `protobuf:"varint,5,opt,name=max_spend,json=maxSpend,proto3" json:"max_spend,omitempty"`
+}
+
+func (m *PendingTransaction) Reset()         { *m = PendingTransaction{} }
+func (m *PendingTransaction) String() string { return proto.CompactTextString(m) }
+func (*PendingTransaction) ProtoMessage()    {}
+
+type SimulateRequest struct {
+	Transactions [][]byte `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Layer        uint32   `protobuf:"varint,2,opt,name=layer,proto3" json:"layer,omitempty"`
+}
+
+func (m *SimulateRequest) Reset()         { *m = SimulateRequest{} }
+func (m *SimulateRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulateRequest) ProtoMessage()    {}
+
+type SimulateResponse struct {
+	Layer   uint32                  `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	Results []*SimulatedTransaction `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *SimulateResponse) Reset()         { *m = SimulateResponse{} }
+func (m *SimulateResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulateResponse) ProtoMessage()    {}
+
+type SimulatedTransaction struct {
+	Id               []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status           string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message          string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	GasConsumed      uint64   `protobuf:"varint,4,opt,name=gas_consumed,json=gasConsumed,proto3" json:"gas_consumed,omitempty"`
+	Fee              uint64   `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	TouchedAddresses []string `protobuf:"bytes,6,rep,name=touched_addresses,json=touchedAddresses,proto3" json:"touched_addresses,omitempty"`
+}
+
+func (m *SimulatedTransaction) Reset()         { *m = SimulatedTransaction{} }
+func (m *SimulatedTransaction) String() string { return proto.CompactTextString(m) }
+func (*SimulatedTransaction) ProtoMessage()    {}
+
+type EstimateGasRequest struct {
+	Transaction []byte `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Layer       uint32 `protobuf:"varint,2,opt,name=layer,proto3" json:"layer,omitempty"`
+}
+
+func (m *EstimateGasRequest) Reset()         { *m = EstimateGasRequest{} }
+func (m *EstimateGasRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimateGasRequest) ProtoMessage()    {}
+
+type EstimateGasResponse struct {
+	Layer     uint32 `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	Intrinsic uint64 `protobuf:"varint,2,opt,name=intrinsic,proto3" json:"intrinsic,omitempty"`
+	Fixed     uint64 `protobuf:"varint,3,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	MaxGas    uint64 `protobuf:"varint,4,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	Consumed  uint64 `protobuf:"varint,5,opt,name=consumed,proto3" json:"consumed,omitempty"`
+}
+
+func (m *EstimateGasResponse) Reset()         { *m = EstimateGasResponse{} }
+func (m *EstimateGasResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateGasResponse) ProtoMessage()    {}
+
+// TransactionServiceServer is the server API for TransactionService.
+type TransactionServiceServer interface {
+	Mempool(context.Context, *MempoolRequest) (*MempoolResponse, error)
+	Simulate(context.Context, *SimulateRequest) (*SimulateResponse, error)
+	EstimateGas(context.Context, *EstimateGasRequest) (*EstimateGasResponse, error)
+}
+
+// RegisterTransactionServiceServer registers srv on the grpc server.
+func RegisterTransactionServiceServer(s *grpc.Server, srv TransactionServiceServer) {
+	s.RegisterService(&transactionServiceDesc, srv)
+}
+
+// TransactionServiceClient is the client API for TransactionService.
+type TransactionServiceClient interface {
+	Mempool(ctx context.Context, in *MempoolRequest, opts ...grpc.CallOption) (*MempoolResponse, error)
+	Simulate(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*SimulateResponse, error)
+	EstimateGas(ctx context.Context, in *EstimateGasRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
+}
+
+type transactionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewTransactionServiceClient creates client for TransactionService.
+func NewTransactionServiceClient(cc grpc.ClientConnInterface) TransactionServiceClient {
+	return &transactionServiceClient{cc}
+}
+
+func (c *transactionServiceClient) Mempool(ctx context.Context, in *MempoolRequest, opts ...grpc.CallOption) (*MempoolResponse, error) {
+	out := new(MempoolResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.TransactionService/Mempool", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) Simulate(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*SimulateResponse, error) {
+	out := new(SimulateResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.TransactionService/Simulate", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) EstimateGas(ctx context.Context, in *EstimateGasRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error) {
+	out := new(EstimateGasResponse)
+	if err := c.cc.Invoke(ctx, "/spacemesh.ext.v1.TransactionService/EstimateGas", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var transactionServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.ext.v1.TransactionService",
+	HandlerType: (*TransactionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Mempool",
+			Handler: unaryHandler("/spacemesh.ext.v1.TransactionService/Mempool",
+				func(srv any, ctx context.Context, in *MempoolRequest) (any, error) {
+					return srv.(TransactionServiceServer).Mempool(ctx, in)
+				}),
+		},
+		{
+			MethodName: "Simulate",
+			Handler: unaryHandler("/spacemesh.ext.v1.TransactionService/Simulate",
+				func(srv any, ctx context.Context, in *SimulateRequest) (any, error) {
+					return srv.(TransactionServiceServer).Simulate(ctx, in)
+				}),
+		},
+		{
+			MethodName: "EstimateGas",
+			Handler: unaryHandler("/spacemesh.ext.v1.TransactionService/EstimateGas",
+				func(srv any, ctx context.Context, in *EstimateGasRequest) (any, error) {
+					return srv.(TransactionServiceServer).EstimateGas(ctx, in)
+				}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -12,26 +13,32 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 // DebugService exposes global state data, output from the STF.
 type DebugService struct {
 	conState api.ConservativeState
 	identity api.NetworkIdentity
+	tortoise api.TortoiseAPI
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (d DebugService) RegisterService(server *Server) {
 	pb.RegisterDebugServiceServer(server.GrpcServer, d)
+	extpb.RegisterDebugServiceServer(server.GrpcServer, d)
 }
 
 // NewDebugService creates a new grpc service using config data.
-func NewDebugService(conState api.ConservativeState, host api.NetworkIdentity) *DebugService {
+func NewDebugService(conState api.ConservativeState, host api.NetworkIdentity, trtl api.TortoiseAPI) *DebugService {
 	return &DebugService{
 		conState: conState,
 		identity: host,
+		tortoise: trtl,
 	}
 }
 
@@ -96,6 +103,42 @@ func (d DebugService) ProposalsStream(_ *emptypb.Empty, stream pb.DebugService_P
 	}
 }
 
+// TortoiseExplain returns weights, thresholds and votes that tortoise uses to decide on the layer.
+func (d DebugService) TortoiseExplain(_ context.Context, in *extpb.TortoiseExplainRequest) (*extpb.TortoiseExplainResponse, error) {
+	rst, err := d.tortoise.Explain(types.NewLayerID(in.Layer))
+	if errors.Is(err, tortoise.ErrNotTracked) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &extpb.TortoiseExplainResponse{
+		Layer:           rst.Layer.Uint32(),
+		Verified:        rst.Verified,
+		Mode:            rst.Mode,
+		HareTerminated:  rst.HareTerminated,
+		Opinion:         rst.Opinion.Bytes(),
+		ExpectedWeight:  rst.ExpectedWeight,
+		LocalThreshold:  rst.LocalThreshold,
+		GlobalThreshold: rst.GlobalThreshold,
+		GoodWeight:      rst.GoodWeight,
+		EmptyWeight:     rst.EmptyWeight,
+	}
+	for _, block := range rst.Blocks {
+		res.Blocks = append(res.Blocks, &extpb.BlockExplanation{
+			Id:       block.ID.Bytes(),
+			Height:   block.Height,
+			Hare:     block.Hare,
+			Validity: block.Validity,
+			For:      block.For,
+			Against:  block.Against,
+			Vote:     block.Vote,
+			Reason:   block.Reason,
+			Error:    block.Error,
+		})
+	}
+	return res, nil
+}
+
 func castEventProposal(ev *events.EventProposal) *pb.Proposal {
 	proposal := &pb.Proposal{
 		Id:      ev.Proposal.ID().Bytes(),
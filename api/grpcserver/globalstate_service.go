@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
@@ -10,9 +11,11 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 // GlobalStateService exposes global state data, output from the STF.
@@ -24,6 +27,7 @@ type GlobalStateService struct {
 // RegisterService registers this service with a grpc server instance.
 func (s GlobalStateService) RegisterService(server *Server) {
 	pb.RegisterGlobalStateServiceServer(server.GrpcServer, s)
+	extpb.RegisterGlobalStateServiceServer(server.GrpcServer, s)
 }
 
 // NewGlobalStateService creates a new grpc service using config data.
@@ -99,6 +103,54 @@ func (s GlobalStateService) Account(_ context.Context, in *pb.AccountRequest) (*
 	return &pb.AccountResponse{AccountWrapper: acct}, nil
 }
 
+// AccountWithProof returns account state as of the applied layer with the proof
+// of its inclusion into the state root of that layer.
+func (s GlobalStateService) AccountWithProof(_ context.Context, in *extpb.AccountWithProofRequest) (*extpb.AccountWithProofResponse, error) {
+	addr, err := types.StringToAddress(in.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse address `%s`: %v", in.Address, err)
+	}
+	lid := types.NewLayerID(in.Layer)
+	if in.Layer == 0 {
+		lid = s.mesh.LatestLayerInState()
+	}
+	if pruned := s.mesh.PrunedBelow(); lid.Before(pruned) {
+		return nil, status.Errorf(codes.OutOfRange, "state below layer %s is pruned", pruned)
+	}
+	root, err := s.conState.GetLayerStateRoot(lid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "layer %s is not applied: %v", lid, err)
+	}
+	account, proof, err := s.conState.GetAccountWithProof(addr, lid)
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "account %s is not in the state of layer %s", addr, lid)
+	} else if err != nil {
+		log.With().Error("failed to prove account", addr, lid, log.Err(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	leaf := &extpb.AccountLeaf{
+		Address:     account.Address.String(),
+		Layer:       account.Layer.Uint32(),
+		Initialized: account.Initialized,
+		NextNonce:   account.NextNonce,
+		Balance:     account.Balance,
+		State:       account.State,
+	}
+	if account.TemplateAddress != nil {
+		leaf.Template = account.TemplateAddress.String()
+	}
+	res := &extpb.AccountWithProofResponse{
+		Layer:     lid.Uint32(),
+		StateRoot: root.Bytes(),
+		Account:   leaf,
+		Siblings:  make([][]byte, 0, len(proof.Siblings)),
+	}
+	for _, sibling := range proof.Siblings {
+		res.Siblings = append(res.Siblings, sibling.Bytes())
+	}
+	return res, nil
+}
+
 // AccountDataQuery returns historical account data such as rewards and receipts.
 func (s GlobalStateService) AccountDataQuery(_ context.Context, in *pb.AccountDataQueryRequest) (*pb.AccountDataQueryResponse, error) {
 	log.Info("GRPC GlobalStateService.AccountDataQuery")
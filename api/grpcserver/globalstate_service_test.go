@@ -0,0 +1,117 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// trieConState serves accounts and proofs from the trie with a single root for every layer.
+type trieConState struct {
+	*ConStateAPIMock
+	db       *sql.Database
+	root     types.Hash32
+	accounts map[types.Address]*types.Account
+}
+
+func (t *trieConState) GetLayerStateRoot(types.LayerID) (types.Hash32, error) {
+	return t.root, nil
+}
+
+func (t *trieConState) GetAccountWithProof(address types.Address, _ types.LayerID) (*types.Account, *trie.Proof, error) {
+	_, proof, err := trie.Prove(t.db, t.root, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t.accounts[address], proof, nil
+}
+
+func TestGlobalStateService_AccountWithProof(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	template := types.GenerateAddress([]byte{200})
+	conState := &trieConState{
+		ConStateAPIMock: conStateAPI,
+		db:              sql.InMemory(),
+		accounts:        map[types.Address]*types.Account{},
+	}
+	var all []*types.Account
+	for i := 0; i < 10; i++ {
+		account := &types.Account{
+			Layer:     types.NewLayerID(uint32(i)),
+			Address:   types.GenerateAddress([]byte{byte(i)}),
+			NextNonce: uint64(i),
+			Balance:   uint64(i) * 100,
+		}
+		if i%2 == 0 {
+			account.Initialized = true
+			account.TemplateAddress = &template
+			account.State = []byte{byte(i)}
+		}
+		conState.accounts[account.Address] = account
+		all = append(all, account)
+	}
+	root, err := trie.Update(conState.db, types.Hash32{}, all)
+	require.NoError(t, err)
+	conState.root = root
+
+	svc := NewGlobalStateService(meshAPI, conState)
+	t.Cleanup(launchServer(t, svc))
+
+	conn := dialGrpc(ctx, t, cfg)
+	client := extpb.NewGlobalStateServiceClient(conn)
+
+	for _, expected := range all {
+		res, err := client.AccountWithProof(ctx, &extpb.AccountWithProofRequest{Address: expected.Address.String()})
+		require.NoError(t, err)
+		require.Equal(t, layerVerified.Uint32(), res.Layer)
+		require.Equal(t, root.Bytes(), res.StateRoot)
+
+		// verify as a light client would do
+		account := types.Account{
+			Layer:       types.NewLayerID(res.Account.Layer),
+			Initialized: res.Account.Initialized,
+			NextNonce:   res.Account.NextNonce,
+			Balance:     res.Account.Balance,
+			State:       res.Account.State,
+		}
+		account.Address, err = types.StringToAddress(res.Account.Address)
+		require.NoError(t, err)
+		if len(res.Account.Template) > 0 {
+			address, err := types.StringToAddress(res.Account.Template)
+			require.NoError(t, err)
+			account.TemplateAddress = &address
+		}
+		require.Equal(t, *expected, account)
+		proof := trie.Proof{}
+		for _, sibling := range res.Siblings {
+			proof.Siblings = append(proof.Siblings, types.BytesToHash(sibling))
+		}
+		require.True(t, proof.Verify(types.BytesToHash(res.StateRoot), &account))
+	}
+
+	res, err := client.AccountWithProof(ctx, &extpb.AccountWithProofRequest{
+		Address: all[0].Address.String(),
+		Layer:   layerLatest.Uint32(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, layerLatest.Uint32(), res.Layer)
+
+	_, err = client.AccountWithProof(ctx, &extpb.AccountWithProofRequest{
+		Address: types.GenerateAddress([]byte{100}).String(),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.AccountWithProof(ctx, &extpb.AccountWithProofRequest{Address: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -36,20 +37,25 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/api/config"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
 	"github.com/spacemeshos/go-spacemesh/api/mocks"
 	"github.com/spacemeshos/go-spacemesh/cmd"
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk/wallet"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/handshake"
 	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
 	"github.com/spacemeshos/go-spacemesh/rand"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 	"github.com/spacemeshos/go-spacemesh/txs"
 )
 
@@ -227,12 +233,27 @@ func newNIPostWithChallenge(challenge *types.Hash32, poetRef []byte) *types.NIPo
 	}
 }
 
-type NetworkMock struct{}
+type NetworkMock struct {
+	peers map[p2p.Peer]*handshake.PeerInfo
+}
 
 func (s *NetworkMock) PeerCount() uint64 {
 	return 0
 }
 
+func (s *NetworkMock) GetPeers() []p2p.Peer {
+	peers := make([]p2p.Peer, 0, len(s.peers))
+	for peer := range s.peers {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func (s *NetworkMock) PeerInfo(peer p2p.Peer) (*handshake.PeerInfo, bool) {
+	info := s.peers[peer]
+	return info, info != nil
+}
+
 type MeshAPIMock struct{}
 
 // latest layer received.
@@ -254,6 +275,10 @@ func (m *MeshAPIMock) ProcessedLayer() types.LayerID {
 	return layerVerified
 }
 
+func (m *MeshAPIMock) PrunedBelow() types.LayerID {
+	return types.LayerID{}
+}
+
 func (m *MeshAPIMock) GetRewards(types.Address) (rewards []*types.Reward, err error) {
 	return []*types.Reward{
 		{
@@ -370,6 +395,38 @@ func (t *ConStateAPIMock) GetLayerStateRoot(types.LayerID) (types.Hash32, error)
 	return stateRoot, nil
 }
 
+func (t *ConStateAPIMock) GetAccountWithProof(types.Address, types.LayerID) (*types.Account, *trie.Proof, error) {
+	return nil, nil, sql.ErrNotFound
+}
+
+func (t *ConStateAPIMock) Simulate(lid types.LayerID, txs []types.RawTx) ([]types.TransactionWithResult, error) {
+	rst := make([]types.TransactionWithResult, 0, len(txs))
+	for _, raw := range txs {
+		tx, ok := t.poolByTxId[raw.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: transaction %s can't be parsed", core.ErrMalformed, raw.ID)
+		}
+		rst = append(rst, types.TransactionWithResult{
+			Transaction: *tx,
+			TransactionResult: types.TransactionResult{
+				Layer:     lid.Add(1),
+				Gas:       tx.MaxGas,
+				Fee:       tx.MaxGas * tx.GasPrice,
+				Addresses: []types.Address{tx.Principal},
+			},
+		})
+	}
+	return rst, nil
+}
+
+func (t *ConStateAPIMock) EstimateGas(lid types.LayerID, raw types.RawTx) (*vm.GasEstimate, error) {
+	tx, ok := t.poolByTxId[raw.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction %s can't be parsed", core.ErrMalformed, raw.ID)
+	}
+	return &vm.GasEstimate{Intrinsic: tx.MaxGas / 2, Fixed: tx.MaxGas / 2, Consumed: tx.MaxGas}, nil
+}
+
 func (t *ConStateAPIMock) GetBalance(addr types.Address) (uint64, error) {
 	return t.balances[addr].Uint64(), nil
 }
@@ -378,6 +435,37 @@ func (t *ConStateAPIMock) GetNonce(addr types.Address) (types.Nonce, error) {
 	return t.nonces[addr], nil
 }
 
+func (t *ConStateAPIMock) GetMempool() map[types.Address][]*types.MeshTransaction {
+	mempool := make(map[types.Address][]*types.MeshTransaction)
+	for _, tx := range t.poolByTxId {
+		mempool[tx.Principal] = append(mempool[tx.Principal], &types.MeshTransaction{Transaction: *tx, State: types.MEMPOOL})
+	}
+	return mempool
+}
+
+func (t *ConStateAPIMock) DropTXs(_ context.Context, tids []types.TransactionID) (dropped []types.TransactionID, err error) {
+	for _, tid := range tids {
+		tx, ok := t.poolByTxId[tid]
+		if !ok {
+			continue
+		}
+		delete(t.poolByTxId, tid)
+		delete(t.poolByAddress, tx.Principal)
+		dropped = append(dropped, tid)
+	}
+	return dropped, nil
+}
+
+func (t *ConStateAPIMock) DropPrincipal(ctx context.Context, addr types.Address) ([]types.TransactionID, error) {
+	var tids []types.TransactionID
+	for tid, tx := range t.poolByTxId {
+		if tx.Principal == addr {
+			tids = append(tids, tid)
+		}
+	}
+	return t.DropTXs(ctx, tids)
+}
+
 func NewTx(nonce uint64, recipient types.Address, signer *signing.EdSigner) *types.Transaction {
 	tx := types.Transaction{TxHeader: &types.TxHeader{}}
 	tx.Principal = wallet.Address(signer.PublicKey().Bytes())
@@ -591,7 +679,7 @@ func TestNodeService(t *testing.T) {
 	logtest.SetupGlobal(t)
 	syncer := SyncerMock{}
 	atxapi := &ActivationAPIMock{}
-	grpcService := NewNodeService(&networkMock, meshAPI, &genTime, &syncer, atxapi)
+	grpcService := NewNodeService(&networkMock, meshAPI, &genTime, &syncer, atxapi, nil)
 	shutDown := launchServer(t, grpcService)
 	defer shutDown()
 
@@ -707,6 +795,80 @@ func TestNodeService(t *testing.T) {
 	}
 }
 
+func TestNodeServicePeers(t *testing.T) {
+	logtest.SetupGlobal(t)
+	network := &NetworkMock{peers: map[p2p.Peer]*handshake.PeerInfo{
+		"b": {Version: "v1.0.0", Protocols: []string{"/ax/1"}, Topics: []string{"ax1"}},
+		"a": nil, // handshake with a legacy peer
+	}}
+	svc := NewNodeService(network, meshAPI, &genTime, &SyncerMock{}, &ActivationAPIMock{}, nil)
+	shutDown := launchServer(t, svc)
+	defer shutDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+	c := extpb.NewNodeServiceClient(conn)
+
+	res, err := c.Peers(ctx, &extpb.PeersRequest{})
+	require.NoError(t, err)
+	expected := []*extpb.PeerInfo{
+		{Id: p2p.Peer("a").String()},
+		{
+			Id:        p2p.Peer("b").String(),
+			Version:   "v1.0.0",
+			Protocols: []string{"/ax/1"},
+			Topics:    []string{"ax1"},
+		},
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return expected[i].Id < expected[j].Id
+	})
+	require.Equal(t, expected, res.Peers)
+}
+
+func TestNodeServiceExtStatus(t *testing.T) {
+	logtest.SetupGlobal(t)
+	ctrl := gomock.NewController(t)
+	trtl := mocks.NewMockTortoiseAPI(ctrl)
+	svc := NewNodeService(&networkMock, meshAPI, &genTime, &SyncerMock{}, &ActivationAPIMock{}, trtl)
+	shutDown := launchServer(t, svc)
+	defer shutDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+	c := extpb.NewNodeServiceClient(conn)
+
+	trtl.EXPECT().Progress().Return(tortoise.Progress{
+		Last:         types.NewLayerID(20),
+		Processed:    types.NewLayerID(20),
+		Verified:     types.NewLayerID(12),
+		Lag:          8,
+		Mode:         "full",
+		FullSince:    types.NewLayerID(17),
+		ModeSwitches: 3,
+		Healed:       4,
+		Coinflips:    2,
+	})
+	res, err := c.Status(ctx, &extpb.StatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, layerLatest.Uint32(), res.SyncedLayer)
+	require.Equal(t, layerCurrent.Uint32(), res.TopLayer)
+	require.Equal(t, layerVerified.Uint32(), res.VerifiedLayer)
+	require.Equal(t, &extpb.TortoiseStatus{
+		LastLayer:      20,
+		ProcessedLayer: 20,
+		VerifiedLayer:  12,
+		Lag:            8,
+		Mode:           "full",
+		FullSince:      17,
+		ModeSwitches:   3,
+		HealedLayers:   4,
+		CoinflipLayers: 2,
+	}, res.Tortoise)
+}
+
 func TestGlobalStateService(t *testing.T) {
 	logtest.SetupGlobal(t)
 	svc := NewGlobalStateService(meshAPI, conStateAPI)
@@ -992,7 +1154,7 @@ func TestGlobalStateService(t *testing.T) {
 
 func TestSmesherService(t *testing.T) {
 	logtest.SetupGlobal(t)
-	svc := NewSmesherService(&PostAPIMock{}, &SmeshingAPIMock{}, 10*time.Millisecond)
+	svc := NewSmesherService(&PostAPIMock{}, &SmeshingAPIMock{}, nil, &genTime, 10*time.Millisecond)
 	shutDown := launchServer(t, svc)
 	defer shutDown()
 
@@ -2545,7 +2707,7 @@ func checkGlobalStateDataGlobalState(t *testing.T, dataItem any) {
 func TestMultiService(t *testing.T) {
 	logtest.SetupGlobal(t)
 	cfg.GrpcServerPort = 9192
-	svc1 := NewNodeService(&networkMock, meshAPI, &genTime, &SyncerMock{}, &ActivationAPIMock{})
+	svc1 := NewNodeService(&networkMock, meshAPI, &genTime, &SyncerMock{}, &ActivationAPIMock{}, nil)
 	svc2 := NewMeshService(meshAPI, conStateAPI, &genTime, layersPerEpoch, types.Hash20{}, layerDurationSec, layerAvgSize, txsPerProposal)
 	shutDown := launchServer(t, svc1, svc2)
 	defer shutDown()
@@ -2599,7 +2761,7 @@ func TestJsonApi(t *testing.T) {
 	shutDown()
 
 	// enable services and try again
-	svc1 := NewNodeService(&networkMock, meshAPI, &genTime, &SyncerMock{}, &ActivationAPIMock{})
+	svc1 := NewNodeService(&networkMock, meshAPI, &genTime, &SyncerMock{}, &ActivationAPIMock{}, nil)
 	svc2 := NewMeshService(meshAPI, conStateAPI, &genTime, layersPerEpoch, types.Hash20{}, layerDurationSec, layerAvgSize, txsPerProposal)
 	cfg.StartNodeService = true
 	cfg.StartMeshService = true
@@ -2627,7 +2789,8 @@ func TestDebugService(t *testing.T) {
 	logtest.SetupGlobal(t)
 	ctrl := gomock.NewController(t)
 	identity := mocks.NewMockNetworkIdentity(ctrl)
-	svc := NewDebugService(conStateAPI, identity)
+	trtl := mocks.NewMockTortoiseAPI(ctrl)
+	svc := NewDebugService(conStateAPI, identity, trtl)
 	shutDown := launchServer(t, svc)
 	defer shutDown()
 
@@ -2658,6 +2821,38 @@ func TestDebugService(t *testing.T) {
 		require.NotNil(t, response)
 		require.Equal(t, id.String(), response.Id)
 	})
+	t.Run("TortoiseExplain", func(t *testing.T) {
+		lid := types.NewLayerID(10)
+		explained := &tortoise.LayerExplanation{
+			Layer:           lid,
+			Verified:        true,
+			Mode:            "verifying",
+			GlobalThreshold: 60,
+			GoodWeight:      100,
+			Blocks: []tortoise.BlockExplanation{{
+				ID:     types.BlockID{1},
+				Hare:   "support",
+				For:    100,
+				Vote:   "support",
+				Reason: "hare",
+			}},
+		}
+		trtl.EXPECT().Explain(lid).Return(explained, nil)
+		ext := extpb.NewDebugServiceClient(conn)
+		response, err := ext.TortoiseExplain(context.Background(), &extpb.TortoiseExplainRequest{Layer: lid.Uint32()})
+		require.NoError(t, err)
+		require.Equal(t, lid.Uint32(), response.Layer)
+		require.Equal(t, explained.Mode, response.Mode)
+		require.Equal(t, explained.GoodWeight, response.GoodWeight)
+		require.Len(t, response.Blocks, 1)
+		require.Equal(t, types.BlockID{1}.Bytes(), response.Blocks[0].Id)
+		require.Equal(t, 100.0, response.Blocks[0].For)
+		require.Equal(t, "hare", response.Blocks[0].Reason)
+
+		trtl.EXPECT().Explain(lid.Add(1)).Return(nil, tortoise.ErrNotTracked)
+		_, err = ext.TortoiseExplain(context.Background(), &extpb.TortoiseExplainRequest{Layer: lid.Add(1).Uint32()})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
 	t.Run("ProposalsStream", func(t *testing.T) {
 		events.InitializeReporter()
 		t.Cleanup(events.CloseEventReporter)
@@ -2789,7 +2984,7 @@ func TestEventsReceived(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 	svm := vm.New(sql.InMemory(), vm.WithLogger(logtest.New(t)))
 	conState := txs.NewConservativeState(svm, sql.InMemory(), txs.WithLogger(logtest.New(t).WithName("conState")))
-	conState.AddToCache(context.Background(), globalTx)
+	conState.AddToCache(context.Background(), globalTx, false)
 
 	weight := new(big.Rat).SetFloat64(18.7)
 	require.NoError(t, err)
@@ -0,0 +1,74 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// MempoolAdminService allows the operator to remove pending transactions from the mempool.
+// It is separate from TransactionService, so that public nodes can expose the latter without it.
+type MempoolAdminService struct {
+	conState api.ConservativeState
+}
+
+// RegisterService registers this service with a grpc server instance.
+func (s MempoolAdminService) RegisterService(server *Server) {
+	extpb.RegisterMempoolAdminServiceServer(server.GrpcServer, s)
+}
+
+// NewMempoolAdminService creates a new grpc service using config data.
+func NewMempoolAdminService(conState api.ConservativeState) *MempoolAdminService {
+	return &MempoolAdminService{conState: conState}
+}
+
+// DropTransactions removes pending transactions from the mempool and the database.
+func (s MempoolAdminService) DropTransactions(ctx context.Context, in *extpb.DropTransactionsRequest) (*extpb.DropResponse, error) {
+	log.Info("GRPC MempoolAdminService.DropTransactions")
+
+	if len(in.Ids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "`Ids` must include one or more transaction IDs")
+	}
+	tids := make([]types.TransactionID, 0, len(in.Ids))
+	for _, id := range in.Ids {
+		var tid types.TransactionID
+		copy(tid[:], id)
+		tids = append(tids, tid)
+	}
+	dropped, err := s.conState.DropTXs(ctx, tids)
+	if err != nil {
+		log.Error("failed to drop transactions: %v", err)
+		return nil, status.Error(codes.Internal, "failed to drop transactions")
+	}
+	return dropResponse(dropped), nil
+}
+
+// DropPrincipal removes all pending transactions of the principal from the mempool and the database.
+func (s MempoolAdminService) DropPrincipal(ctx context.Context, in *extpb.DropPrincipalRequest) (*extpb.DropResponse, error) {
+	log.Info("GRPC MempoolAdminService.DropPrincipal")
+
+	addr, err := types.StringToAddress(in.Principal)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse principal `%s`: %v", in.Principal, err)
+	}
+	dropped, err := s.conState.DropPrincipal(ctx, addr)
+	if err != nil {
+		log.Error("failed to drop transactions of %s: %v", addr, err)
+		return nil, status.Error(codes.Internal, "failed to drop transactions")
+	}
+	return dropResponse(dropped), nil
+}
+
+func dropResponse(dropped []types.TransactionID) *extpb.DropResponse {
+	res := &extpb.DropResponse{}
+	for _, tid := range dropped {
+		res.Dropped = append(res.Dropped, tid.Bytes())
+	}
+	return res
+}
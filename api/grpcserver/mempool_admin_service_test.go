@@ -0,0 +1,78 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestMempoolAdminService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conState := &ConStateAPIMock{
+		poolByAddress: make(map[types.Address]types.TransactionID),
+		poolByTxId:    make(map[types.TransactionID]*types.Transaction),
+	}
+	principals := []types.Address{types.GenerateAddress([]byte{0}), types.GenerateAddress([]byte{1})}
+	var pending []*types.Transaction
+	for i := 0; i < 3; i++ {
+		tx := &types.Transaction{
+			RawTx: types.NewRawTx([]byte{byte(i)}),
+			TxHeader: &types.TxHeader{
+				Principal: principals[i%2],
+				Nonce:     uint64(i),
+				MaxGas:    100,
+				GasPrice:  uint64(i + 1),
+			},
+		}
+		conState.poolByTxId[tx.ID] = tx
+		pending = append(pending, tx)
+	}
+
+	t.Cleanup(launchServer(t,
+		NewMempoolAdminService(conState),
+		NewTransactionService(sql.InMemory(), nil, nil, conState, nil),
+	))
+
+	conn := dialGrpc(ctx, t, cfg)
+	client := extpb.NewMempoolAdminServiceClient(conn)
+
+	dropped, err := client.DropTransactions(ctx, &extpb.DropTransactionsRequest{Ids: [][]byte{pending[0].ID.Bytes()}})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{pending[0].ID.Bytes()}, dropped.Dropped)
+
+	dropped, err = client.DropPrincipal(ctx, &extpb.DropPrincipalRequest{Principal: principals[0].String()})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{pending[2].ID.Bytes()}, dropped.Dropped)
+
+	_, err = client.DropTransactions(ctx, &extpb.DropTransactionsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.DropPrincipal(ctx, &extpb.DropPrincipalRequest{Principal: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err := extpb.NewTransactionServiceClient(conn).Mempool(ctx, &extpb.MempoolRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Accounts, 1)
+	require.Equal(t, principals[1].String(), res.Accounts[0].Principal)
+}
+
+func TestMempoolAdminService_NotOnTransactionService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Cleanup(launchServer(t, NewTransactionService(sql.InMemory(), nil, nil, conStateAPI, nil)))
+
+	conn := dialGrpc(ctx, t, cfg)
+	_, err := extpb.NewMempoolAdminServiceClient(conn).DropPrincipal(ctx,
+		&extpb.DropPrincipalRequest{Principal: types.GenerateAddress([]byte{0}).String()})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
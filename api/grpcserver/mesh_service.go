@@ -119,6 +119,9 @@ func (s MeshService) getFilteredActivations(ctx context.Context, startLayer type
 	// We have no way to look up activations by coinbase so we have no choice but to read all of them.
 	// TODO: index activations by layer (and maybe by coinbase)
 	// See https://github.com/spacemeshos/go-spacemesh/issues/2064.
+	if pruned := s.mesh.PrunedBelow(); startLayer.Before(pruned) {
+		return nil, status.Errorf(codes.OutOfRange, "ballots below layer %s are pruned, start from layer %s or later", pruned, pruned)
+	}
 	var atxids []types.ATXID
 	for l := startLayer; !l.After(s.mesh.LatestLayer()); l = l.Add(1) {
 		layer, err := s.mesh.GetLayer(l)
@@ -377,6 +380,10 @@ func (s MeshService) LayersQuery(ctx context.Context, in *pb.LayersQueryRequest)
 		endLayer = types.NewLayerID(in.EndLayer.Number)
 	}
 
+	if pruned := s.mesh.PrunedBelow(); startLayer.Before(pruned) {
+		return nil, status.Errorf(codes.OutOfRange, "ballots below layer %s are pruned, start from layer %s or later", pruned, pruned)
+	}
+
 	// Get the latest layers that passed both consensus engines.
 	lastLayerPassedHare := s.mesh.LatestLayerInState()
 	lastLayerPassedTortoise := s.mesh.ProcessedLayer()
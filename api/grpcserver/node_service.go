@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
 	"github.com/spacemeshos/go-spacemesh/cmd"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
@@ -25,28 +27,32 @@ import (
 // data such as node status, software version, errors, etc. It can also be used to start
 // the sync process, or to shut down the node.
 type NodeService struct {
-	mesh        api.MeshAPI
-	genTime     api.GenesisTimeAPI
-	peerCounter api.PeerCounter
-	syncer      api.Syncer
-	atxAPI      api.ActivationAPI
+	mesh    api.MeshAPI
+	genTime api.GenesisTimeAPI
+	peers   api.PeerInfoProvider
+	syncer  api.Syncer
+	atxAPI  api.ActivationAPI
+	trtl    api.TortoiseAPI
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (s NodeService) RegisterService(server *Server) {
 	pb.RegisterNodeServiceServer(server.GrpcServer, s)
+	extpb.RegisterNodeServiceServer(server.GrpcServer, extNodeService{s})
 }
 
 // NewNodeService creates a new grpc service using config data.
 func NewNodeService(
-	peers api.PeerCounter, msh api.MeshAPI, genTime api.GenesisTimeAPI, syncer api.Syncer, atxapi api.ActivationAPI,
+	peers api.PeerInfoProvider, msh api.MeshAPI, genTime api.GenesisTimeAPI, syncer api.Syncer, atxapi api.ActivationAPI,
+	trtl api.TortoiseAPI,
 ) *NodeService {
 	return &NodeService{
-		mesh:        msh,
-		genTime:     genTime,
-		peerCounter: peers,
-		syncer:      syncer,
-		atxAPI:      atxapi,
+		mesh:    msh,
+		genTime: genTime,
+		peers:   peers,
+		syncer:  syncer,
+		atxAPI:  atxapi,
+		trtl:    trtl,
 	}
 }
 
@@ -83,7 +89,7 @@ func (s NodeService) Status(ctx context.Context, _ *pb.StatusRequest) (*pb.Statu
 	curLayer, latestLayer, verifiedLayer := s.getLayers()
 	return &pb.StatusResponse{
 		Status: &pb.NodeStatus{
-			ConnectedPeers: s.peerCounter.PeerCount(),              // number of connected peers
+			ConnectedPeers: s.peers.PeerCount(),                    // number of connected peers
 			IsSynced:       s.syncer.IsSynced(ctx),                 // whether the node is synced
 			SyncedLayer:    &pb.LayerNumber{Number: latestLayer},   // latest layer we saw from the network
 			TopLayer:       &pb.LayerNumber{Number: curLayer},      // current layer, based on time
@@ -137,6 +143,58 @@ func (s NodeService) UpdatePoetServers(ctx context.Context, req *pb.UpdatePoetSe
 	return nil, status.Errorf(codes.Internal, "failed to update poet server")
 }
 
+// Peers returns connected peers with the version and protocols they advertised in the handshake.
+func (s NodeService) Peers(context.Context, *extpb.PeersRequest) (*extpb.PeersResponse, error) {
+	peers := s.peers.GetPeers()
+	rst := &extpb.PeersResponse{Peers: make([]*extpb.PeerInfo, 0, len(peers))}
+	for _, peer := range peers {
+		pinfo := &extpb.PeerInfo{Id: peer.String()}
+		if info, exists := s.peers.PeerInfo(peer); exists {
+			pinfo.Version = info.Version
+			pinfo.Protocols = info.Protocols
+			pinfo.Topics = info.Topics
+		}
+		rst.Peers = append(rst.Peers, pinfo)
+	}
+	sort.Slice(rst.Peers, func(i, j int) bool {
+		return rst.Peers[i].Id < rst.Peers[j].Id
+	})
+	return rst, nil
+}
+
+// extNodeService serves extensions of the NodeService. Status conflicts with the method of
+// the spacemesh.v1.NodeService, therefore it is implemented by a separate type.
+type extNodeService struct {
+	NodeService
+}
+
+// Status returns the same status as spacemesh.v1.NodeService.Status, extended with the
+// progress of the tortoise.
+func (s extNodeService) Status(ctx context.Context, _ *extpb.StatusRequest) (*extpb.StatusResponse, error) {
+	log.Info("GRPC ext NodeService.Status")
+
+	curLayer, latestLayer, verifiedLayer := s.getLayers()
+	progress := s.trtl.Progress()
+	return &extpb.StatusResponse{
+		ConnectedPeers: s.peers.PeerCount(),
+		IsSynced:       s.syncer.IsSynced(ctx),
+		SyncedLayer:    latestLayer,
+		TopLayer:       curLayer,
+		VerifiedLayer:  verifiedLayer,
+		Tortoise: &extpb.TortoiseStatus{
+			LastLayer:      progress.Last.Uint32(),
+			ProcessedLayer: progress.Processed.Uint32(),
+			VerifiedLayer:  progress.Verified.Uint32(),
+			Lag:            progress.Lag,
+			Mode:           progress.Mode,
+			FullSince:      progress.FullSince.Uint32(),
+			ModeSwitches:   progress.ModeSwitches,
+			HealedLayers:   progress.Healed,
+			CoinflipLayers: progress.Coinflips,
+		},
+	}, nil
+}
+
 // STREAMS
 
 // StatusStream exposes a stream of node status updates.
@@ -168,7 +226,7 @@ func (s NodeService) StatusStream(_ *pb.StatusStreamRequest, stream pb.NodeServi
 
 			resp := &pb.StatusStreamResponse{
 				Status: &pb.NodeStatus{
-					ConnectedPeers: s.peerCounter.PeerCount(),              // number of connected peers
+					ConnectedPeers: s.peers.PeerCount(),                    // number of connected peers
 					IsSynced:       s.syncer.IsSynced(stream.Context()),    // whether the node is synced
 					SyncedLayer:    &pb.LayerNumber{Number: latestLayer},   // latest layer we saw from the network
 					TopLayer:       &pb.LayerNumber{Number: curLayer},      // current layer, based on time
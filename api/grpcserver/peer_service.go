@@ -0,0 +1,199 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/addressbook"
+)
+
+// PeerService exposes connections, address book, bootnodes and bans of the p2p host.
+type PeerService struct {
+	host api.PeerAdmin
+}
+
+// RegisterService registers this service with a grpc server instance.
+func (s PeerService) RegisterService(server *Server) {
+	extpb.RegisterPeerServiceServer(server.GrpcServer, s)
+}
+
+// NewPeerService creates a new grpc service using config data.
+func NewPeerService(host api.PeerAdmin) *PeerService {
+	return &PeerService{host: host}
+}
+
+// Connections returns connected peers.
+func (s PeerService) Connections(context.Context, *extpb.ConnectionsRequest) (*extpb.ConnectionsResponse, error) {
+	log.Info("GRPC PeerService.Connections")
+
+	conns := s.host.Connections()
+	rst := &extpb.ConnectionsResponse{Connections: make([]*extpb.Connection, 0, len(conns))}
+	for _, conn := range conns {
+		info := &extpb.Connection{
+			Id:        conn.ID.String(),
+			Outbound:  conn.Direction == network.DirOutbound,
+			Protocols: conn.Protocols,
+			Score:     conn.Score,
+		}
+		if conn.Address != nil {
+			info.Address = conn.Address.String()
+		}
+		if !conn.Opened.IsZero() {
+			info.AgeSeconds = uint64(time.Since(conn.Opened).Seconds())
+		}
+		rst.Connections = append(rst.Connections, info)
+	}
+	return rst, nil
+}
+
+// AddressBook returns non-empty new and tried buckets of the address book.
+func (s PeerService) AddressBook(context.Context, *extpb.AddressBookRequest) (*extpb.AddressBookResponse, error) {
+	log.Info("GRPC PeerService.AddressBook")
+
+	newBuckets, triedBuckets := s.host.AddressBook()
+	return &extpb.AddressBookResponse{
+		New:   convertBuckets(newBuckets),
+		Tried: convertBuckets(triedBuckets),
+	}, nil
+}
+
+func convertBuckets(buckets [][]*addressbook.AddrInfo) []*extpb.Bucket {
+	var rst []*extpb.Bucket
+	for i, bucket := range buckets {
+		if len(bucket) == 0 {
+			continue
+		}
+		converted := &extpb.Bucket{Index: uint32(i), Addresses: make([]string, 0, len(bucket))}
+		for _, addr := range bucket {
+			converted.Addresses = append(converted.Addresses, addr.String())
+		}
+		rst = append(rst, converted)
+	}
+	return rst
+}
+
+// Connect connects to the peer with the multiaddr, including the peer id.
+func (s PeerService) Connect(ctx context.Context, in *extpb.ConnectRequest) (*extpb.ConnectResponse, error) {
+	log.Info("GRPC PeerService.Connect")
+
+	if in.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "`Address` must be provided")
+	}
+	pid, err := s.host.ConnectAddress(ctx, in.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect: %v", err)
+	}
+	return &extpb.ConnectResponse{Id: pid.String()}, nil
+}
+
+// Disconnect closes all connections with the peer.
+func (s PeerService) Disconnect(_ context.Context, in *extpb.DisconnectRequest) (*extpb.PeerResponse, error) {
+	log.Info("GRPC PeerService.Disconnect")
+
+	pid, err := parsePeer(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.host.Disconnect(pid); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to disconnect: %v", err)
+	}
+	return &extpb.PeerResponse{}, nil
+}
+
+// Bootnodes returns bootnodes from the config and bootnodes that were added at runtime.
+func (s PeerService) Bootnodes(context.Context, *extpb.BootnodesRequest) (*extpb.BootnodesResponse, error) {
+	log.Info("GRPC PeerService.Bootnodes")
+
+	static, added := s.host.Bootnodes()
+	return &extpb.BootnodesResponse{Static: static, Added: added}, nil
+}
+
+// AddBootnode adds a bootnode, it is persisted across restarts.
+func (s PeerService) AddBootnode(_ context.Context, in *extpb.BootnodeRequest) (*extpb.PeerResponse, error) {
+	log.Info("GRPC PeerService.AddBootnode")
+
+	if err := s.host.AddBootnode(in.Address); err != nil {
+		if errors.Is(err, p2p.ErrBootnodeExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "failed to add bootnode: %v", err)
+	}
+	return &extpb.PeerResponse{}, nil
+}
+
+// RemoveBootnode removes a bootnode that was added at runtime.
+func (s PeerService) RemoveBootnode(_ context.Context, in *extpb.BootnodeRequest) (*extpb.PeerResponse, error) {
+	log.Info("GRPC PeerService.RemoveBootnode")
+
+	if err := s.host.RemoveBootnode(in.Address); err != nil {
+		if errors.Is(err, p2p.ErrBootnodeNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "failed to remove bootnode: %v", err)
+	}
+	return &extpb.PeerResponse{}, nil
+}
+
+// Ban bans a peer or an ip range, bans are persisted across restarts.
+func (s PeerService) Ban(_ context.Context, in *extpb.BanRequest) (*extpb.PeerResponse, error) {
+	log.Info("GRPC PeerService.Ban")
+
+	return s.updateBans(in, s.host.BanPeer, s.host.BanIPNet)
+}
+
+// Unban removes the ban from a peer or an ip range.
+func (s PeerService) Unban(_ context.Context, in *extpb.BanRequest) (*extpb.PeerResponse, error) {
+	log.Info("GRPC PeerService.Unban")
+
+	return s.updateBans(in, s.host.UnbanPeer, s.host.UnbanIPNet)
+}
+
+func (s PeerService) updateBans(in *extpb.BanRequest, byPeer func(p2p.Peer) error, byNet func(string) error) (*extpb.PeerResponse, error) {
+	if (in.Id == "") == (in.Cidr == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of `Id` or `Cidr` must be provided")
+	}
+	if in.Id != "" {
+		pid, err := parsePeer(in.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := byPeer(pid); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update bans: %v", err)
+		}
+		return &extpb.PeerResponse{}, nil
+	}
+	if err := byNet(in.Cidr); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to update bans: %v", err)
+	}
+	return &extpb.PeerResponse{}, nil
+}
+
+// Bans returns banned peers and ip ranges.
+func (s PeerService) Bans(context.Context, *extpb.BansRequest) (*extpb.BansResponse, error) {
+	log.Info("GRPC PeerService.Bans")
+
+	peers, nets := s.host.Bans()
+	rst := &extpb.BansResponse{Peers: make([]string, 0, len(peers)), Cidrs: nets}
+	for _, pid := range peers {
+		rst.Peers = append(rst.Peers, pid.String())
+	}
+	return rst, nil
+}
+
+func parsePeer(raw string) (p2p.Peer, error) {
+	pid, err := peer.Decode(raw)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "failed to parse peer id `%s`: %v", raw, err)
+	}
+	return pid, nil
+}
@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+)
+
+func TestPeerService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mesh, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	var hosts []*p2p.Host
+	for _, h := range mesh.Hosts() {
+		pcfg := p2p.DefaultConfig()
+		pcfg.DataDir = t.TempDir()
+		fh, err := p2p.Upgrade(h, types.Hash20{1}, p2p.WithConfig(pcfg), p2p.WithLog(logtest.New(t)))
+		require.NoError(t, err)
+		t.Cleanup(func() { fh.Stop() })
+		hosts = append(hosts, fh)
+	}
+
+	svc := NewPeerService(hosts[0])
+	t.Cleanup(launchServer(t, svc))
+
+	conn := dialGrpc(ctx, t, cfg)
+	client := extpb.NewPeerServiceClient(conn)
+
+	remote := fmt.Sprintf("%s/p2p/%s", hosts[1].Addrs()[0], hosts[1].ID())
+	connected, err := client.Connect(ctx, &extpb.ConnectRequest{Address: remote})
+	require.NoError(t, err)
+	require.Equal(t, hosts[1].ID().String(), connected.Id)
+
+	conns, err := client.Connections(ctx, &extpb.ConnectionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, conns.Connections, 1)
+	require.Equal(t, hosts[1].ID().String(), conns.Connections[0].Id)
+	require.True(t, conns.Connections[0].Outbound)
+	require.Equal(t, hosts[1].Addrs()[0].String(), conns.Connections[0].Address)
+
+	_, err = client.AddBootnode(ctx, &extpb.BootnodeRequest{Address: remote})
+	require.NoError(t, err)
+	_, err = client.AddBootnode(ctx, &extpb.BootnodeRequest{Address: remote})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	bootnodes, err := client.Bootnodes(ctx, &extpb.BootnodesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{remote}, bootnodes.Added)
+
+	book, err := client.AddressBook(ctx, &extpb.AddressBookRequest{})
+	require.NoError(t, err)
+	require.Len(t, book.New, 1)
+	require.Equal(t, []string{remote}, book.New[0].Addresses)
+
+	_, err = client.RemoveBootnode(ctx, &extpb.BootnodeRequest{Address: remote})
+	require.NoError(t, err)
+	_, err = client.RemoveBootnode(ctx, &extpb.BootnodeRequest{Address: remote})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Ban(ctx, &extpb.BanRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Ban(ctx, &extpb.BanRequest{Id: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Ban(ctx, &extpb.BanRequest{Cidr: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Ban(ctx, &extpb.BanRequest{Id: hosts[1].ID().String()})
+	require.NoError(t, err)
+	_, err = client.Ban(ctx, &extpb.BanRequest{Cidr: "10.0.0.0/8"})
+	require.NoError(t, err)
+	bans, err := client.Bans(ctx, &extpb.BansRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{hosts[1].ID().String()}, bans.Peers)
+	require.Equal(t, []string{"10.0.0.0/8"}, bans.Cidrs)
+
+	conns, err = client.Connections(ctx, &extpb.ConnectionsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, conns.Connections)
+
+	_, err = client.Unban(ctx, &extpb.BanRequest{Id: hosts[1].ID().String()})
+	require.NoError(t, err)
+	_, err = client.Unban(ctx, &extpb.BanRequest{Cidr: "10.0.0.0/8"})
+	require.NoError(t, err)
+	bans, err = client.Bans(ctx, &extpb.BansRequest{})
+	require.NoError(t, err)
+	require.Empty(t, bans.Peers)
+	require.Empty(t, bans.Cidrs)
+
+	_, err = client.Connect(ctx, &extpb.ConnectRequest{Address: remote})
+	require.NoError(t, err)
+	_, err = client.Disconnect(ctx, &extpb.DisconnectRequest{Id: hosts[1].ID().String()})
+	require.NoError(t, err)
+	conns, err = client.Connections(ctx, &extpb.ConnectionsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, conns.Connections)
+}
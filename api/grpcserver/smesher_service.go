@@ -2,6 +2,8 @@ package grpcserver
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,18 +12,33 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/code"
 	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/api"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
-// SmesherService exposes endpoints to manage smeshing.
-type SmesherService struct {
+// SmesherIDHeader is the request metadata key that selects the identity of the node
+// the request is served for. The value is the hex encoded id of the smesher.
+// Requests without the header are served for the default identity.
+const SmesherIDHeader = "smesher-id"
+
+// smesherIdentity groups providers that manage smeshing for a single identity.
+type smesherIdentity struct {
 	postSetupProvider api.PostSetupProvider
 	smeshingProvider  api.SmeshingAPI
+	estimator         api.RewardEstimator
+}
+
+// SmesherService exposes endpoints to manage smeshing.
+type SmesherService struct {
+	smesherIdentity
+	identities map[types.NodeID]smesherIdentity
+	genTime    api.GenesisTimeAPI
 
 	streamInterval time.Duration
 }
@@ -32,20 +49,57 @@ func (s SmesherService) RegisterService(server *Server) {
 }
 
 // NewSmesherService creates a new grpc service using config data.
-func NewSmesherService(post api.PostSetupProvider, smeshing api.SmeshingAPI, streamInterval time.Duration) *SmesherService {
-	return &SmesherService{post, smeshing, streamInterval}
+// Providers are used for the default identity of the node.
+func NewSmesherService(post api.PostSetupProvider, smeshing api.SmeshingAPI, estimator api.RewardEstimator, genTime api.GenesisTimeAPI, streamInterval time.Duration) *SmesherService {
+	return &SmesherService{
+		smesherIdentity: smesherIdentity{post, smeshing, estimator},
+		identities:      map[types.NodeID]smesherIdentity{},
+		genTime:         genTime,
+		streamInterval:  streamInterval,
+	}
+}
+
+// AddIdentity adds providers for the identity that can be selected with SmesherIDHeader.
+func (s *SmesherService) AddIdentity(id types.NodeID, post api.PostSetupProvider, smeshing api.SmeshingAPI, estimator api.RewardEstimator) {
+	s.identities[id] = smesherIdentity{post, smeshing, estimator}
+}
+
+// identity returns providers for the identity selected in the request metadata.
+func (s SmesherService) identity(ctx context.Context) (*smesherIdentity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(SmesherIDHeader)
+	if len(values) == 0 {
+		return &s.smesherIdentity, nil
+	}
+	raw, err := hex.DecodeString(values[0])
+	if err != nil || len(raw) != types.NodeIDSize {
+		return nil, status.Errorf(codes.InvalidArgument, "`%s` must be a hex encoded smesher id", SmesherIDHeader)
+	}
+	identity, exists := s.identities[types.BytesToNodeID(raw)]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "smesher %s is not managed by this node", values[0])
+	}
+	return &identity, nil
 }
 
 // IsSmeshing reports whether the node is smeshing.
-func (s SmesherService) IsSmeshing(context.Context, *empty.Empty) (*pb.IsSmeshingResponse, error) {
+func (s SmesherService) IsSmeshing(ctx context.Context, _ *empty.Empty) (*pb.IsSmeshingResponse, error) {
 	log.Info("GRPC SmesherService.IsSmeshing")
 
-	return &pb.IsSmeshingResponse{IsSmeshing: s.smeshingProvider.Smeshing()}, nil
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsSmeshingResponse{IsSmeshing: identity.smeshingProvider.Smeshing()}, nil
 }
 
 // StartSmeshing requests that the node begin smeshing.
 func (s SmesherService) StartSmeshing(ctx context.Context, in *pb.StartSmeshingRequest) (*pb.StartSmeshingResponse, error) {
 	log.Info("GRPC SmesherService.StartSmeshing")
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if in.Coinbase == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "`Coinbase` must be provided")
 	}
@@ -78,7 +132,7 @@ func (s SmesherService) StartSmeshing(ctx context.Context, in *pb.StartSmeshingR
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse in.Coinbase.Address `%s`: %w", in.Coinbase.Address, err)
 	}
-	if err := s.smeshingProvider.StartSmeshing(coinbaseAddr, opts); err != nil {
+	if err := identity.smeshingProvider.StartSmeshing(coinbaseAddr, opts); err != nil {
 		err := fmt.Sprintf("failed to start smeshing: %v", err)
 		log.Error(err)
 		return nil, status.Error(codes.Internal, err)
@@ -92,10 +146,14 @@ func (s SmesherService) StartSmeshing(ctx context.Context, in *pb.StartSmeshingR
 // StopSmeshing requests that the node stop smeshing.
 func (s SmesherService) StopSmeshing(ctx context.Context, in *pb.StopSmeshingRequest) (*pb.StopSmeshingResponse, error) {
 	log.Info("GRPC SmesherService.StopSmeshing")
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	errchan := make(chan error, 1)
 	go func() {
-		errchan <- s.smeshingProvider.StopSmeshing(in.DeleteFiles)
+		errchan <- identity.smeshingProvider.StopSmeshing(in.DeleteFiles)
 	}()
 	select {
 	case <-ctx.Done():
@@ -113,25 +171,37 @@ func (s SmesherService) StopSmeshing(ctx context.Context, in *pb.StopSmeshingReq
 }
 
 // SmesherID returns the smesher ID of this node.
-func (s SmesherService) SmesherID(context.Context, *empty.Empty) (*pb.SmesherIDResponse, error) {
+func (s SmesherService) SmesherID(ctx context.Context, _ *empty.Empty) (*pb.SmesherIDResponse, error) {
 	log.Info("GRPC SmesherService.SmesherID")
 
-	nodeID := s.smeshingProvider.SmesherID()
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeID := identity.smeshingProvider.SmesherID()
 	addr := types.GenerateAddress(nodeID[:])
 	return &pb.SmesherIDResponse{AccountId: &pb.AccountId{Address: addr.String()}}, nil
 }
 
 // Coinbase returns the current coinbase setting of this node.
-func (s SmesherService) Coinbase(context.Context, *empty.Empty) (*pb.CoinbaseResponse, error) {
+func (s SmesherService) Coinbase(ctx context.Context, _ *empty.Empty) (*pb.CoinbaseResponse, error) {
 	log.Info("GRPC SmesherService.Coinbase")
 
-	addr := s.smeshingProvider.Coinbase()
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addr := identity.smeshingProvider.Coinbase()
 	return &pb.CoinbaseResponse{AccountId: &pb.AccountId{Address: addr.String()}}, nil
 }
 
 // SetCoinbase sets the current coinbase setting of this node.
-func (s SmesherService) SetCoinbase(_ context.Context, in *pb.SetCoinbaseRequest) (*pb.SetCoinbaseResponse, error) {
+func (s SmesherService) SetCoinbase(ctx context.Context, in *pb.SetCoinbaseRequest) (*pb.SetCoinbaseResponse, error) {
 	log.Info("GRPC SmesherService.SetCoinbase")
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	if in.Id == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "`Id` must be provided")
@@ -141,7 +211,7 @@ func (s SmesherService) SetCoinbase(_ context.Context, in *pb.SetCoinbaseRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse in.Id.Address `%s`: %w", in.Id.Address, err)
 	}
-	s.smeshingProvider.SetCoinbase(addr)
+	identity.smeshingProvider.SetCoinbase(addr)
 
 	return &pb.SetCoinbaseResponse{
 		Status: &rpcstatus.Status{Code: int32(code.Code_OK)},
@@ -161,16 +231,36 @@ func (s SmesherService) SetMinGas(context.Context, *pb.SetMinGasRequest) (*pb.Se
 }
 
 // EstimatedRewards returns estimated smeshing rewards over the next epoch.
-func (s SmesherService) EstimatedRewards(context.Context, *pb.EstimatedRewardsRequest) (*pb.EstimatedRewardsResponse, error) {
+func (s SmesherService) EstimatedRewards(ctx context.Context, _ *pb.EstimatedRewardsRequest) (*pb.EstimatedRewardsResponse, error) {
 	log.Info("GRPC SmesherService.EstimatedRewards")
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target := s.genTime.GetCurrentLayer().GetEpoch() + 1
+	estimate, err := identity.estimator.EstimateRewards(target)
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, status.Errorf(codes.FailedPrecondition, "node has no activations: %v", err)
+	} else if err != nil {
+		log.With().Error("failed to estimate rewards", target, log.Err(err))
+		return nil, status.Errorf(codes.Internal, "failed to estimate rewards for epoch %d", target)
+	}
+	return &pb.EstimatedRewardsResponse{
+		Amount:   &pb.Amount{Value: estimate.Amount},
+		NumUnits: estimate.NumUnits,
+	}, nil
 }
 
 // PostSetupStatus returns post data status.
-func (s SmesherService) PostSetupStatus(context.Context, *empty.Empty) (*pb.PostSetupStatusResponse, error) {
+func (s SmesherService) PostSetupStatus(ctx context.Context, _ *empty.Empty) (*pb.PostSetupStatusResponse, error) {
 	log.Info("GRPC SmesherService.PostSetupStatus")
 
-	status := s.postSetupProvider.Status()
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := identity.postSetupProvider.Status()
 	return &pb.PostSetupStatusResponse{Status: statusToPbStatus(status)}, nil
 }
 
@@ -178,13 +268,17 @@ func (s SmesherService) PostSetupStatus(context.Context, *empty.Empty) (*pb.Post
 func (s SmesherService) PostSetupStatusStream(_ *empty.Empty, stream pb.SmesherService_PostSetupStatusStreamServer) error {
 	log.Info("GRPC SmesherService.PostSetupStatusStream")
 
+	identity, err := s.identity(stream.Context())
+	if err != nil {
+		return err
+	}
 	timer := time.NewTicker(s.streamInterval)
 	defer timer.Stop()
 
 	for {
 		select {
 		case <-timer.C:
-			status := s.postSetupProvider.Status()
+			status := identity.postSetupProvider.Status()
 			if err := stream.Send(&pb.PostSetupStatusStreamResponse{Status: statusToPbStatus(status)}); err != nil {
 				return fmt.Errorf("send to stream: %w", err)
 			}
@@ -198,7 +292,11 @@ func (s SmesherService) PostSetupStatusStream(_ *empty.Empty, stream pb.SmesherS
 func (s SmesherService) PostSetupComputeProviders(ctx context.Context, in *pb.PostSetupComputeProvidersRequest) (*pb.PostSetupComputeProvidersResponse, error) {
 	log.Info("GRPC SmesherService.PostSetupComputeProviders")
 
-	providers := s.postSetupProvider.ComputeProviders()
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	providers := identity.postSetupProvider.ComputeProviders()
 
 	res := &pb.PostSetupComputeProvidersResponse{}
 	res.Providers = make([]*pb.PostSetupComputeProvider, len(providers))
@@ -206,7 +304,7 @@ func (s SmesherService) PostSetupComputeProviders(ctx context.Context, in *pb.Po
 		var hashesPerSec int
 		if in.Benchmark {
 			var err error
-			hashesPerSec, err = s.postSetupProvider.Benchmark(p)
+			hashesPerSec, err = identity.postSetupProvider.Benchmark(p)
 			if err != nil {
 				log.Error("failed to benchmark provider: %v", err)
 				return nil, status.Error(codes.Internal, "failed to benchmark provider")
@@ -225,10 +323,14 @@ func (s SmesherService) PostSetupComputeProviders(ctx context.Context, in *pb.Po
 }
 
 // PostConfig returns the Post protocol config.
-func (s SmesherService) PostConfig(context.Context, *empty.Empty) (*pb.PostConfigResponse, error) {
+func (s SmesherService) PostConfig(ctx context.Context, _ *empty.Empty) (*pb.PostConfigResponse, error) {
 	log.Info("GRPC SmesherService.PostConfig")
 
-	cfg := s.postSetupProvider.Config()
+	identity, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg := identity.postSetupProvider.Config()
 
 	return &pb.PostConfigResponse{
 		BitsPerLabel:  uint32(cfg.BitsPerLabel),
@@ -7,18 +7,26 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
+	"github.com/spacemeshos/go-spacemesh/api/mocks"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/miner"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 func TestPostConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	postSetupProvider := activation.NewMockpostSetupProvider(ctrl)
 	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
-	svc := grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, time.Second)
+	svc := grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, nil, nil, time.Second)
 
 	postConfig := activation.PostConfig{
 		MinNumUnits:   rand.Uint32(),
@@ -39,3 +47,62 @@ func TestPostConfig(t *testing.T) {
 	require.Equal(t, postConfig.K1, response.K1)
 	require.EqualValues(t, postConfig.K2, response.K2)
 }
+
+type layerClock types.LayerID
+
+func (layerClock) GetGenesisTime() time.Time { return time.Time{} }
+
+func (c layerClock) GetCurrentLayer() types.LayerID { return types.LayerID(c) }
+
+func TestEstimatedRewards(t *testing.T) {
+	types.SetLayersPerEpoch(4)
+	ctrl := gomock.NewController(t)
+	estimator := mocks.NewMockRewardEstimator(ctrl)
+	clock := layerClock(types.NewLayerID(9))
+	svc := grpcserver.NewSmesherService(nil, nil, estimator, clock, time.Second)
+
+	estimator.EXPECT().EstimateRewards(types.EpochID(3)).Return(&miner.RewardEstimate{
+		Epoch:    3,
+		NumUnits: 4,
+		Amount:   1000,
+	}, nil)
+	response, err := svc.EstimatedRewards(context.Background(), &pb.EstimatedRewardsRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, response.Amount.Value)
+	require.EqualValues(t, 4, response.NumUnits)
+
+	estimator.EXPECT().EstimateRewards(types.EpochID(3)).Return(nil, sql.ErrNotFound)
+	_, err = svc.EstimatedRewards(context.Background(), &pb.EstimatedRewardsRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestSmesherIdentitySelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defaultProvider := activation.NewMockSmeshingProvider(ctrl)
+	otherProvider := activation.NewMockSmeshingProvider(ctrl)
+	svc := grpcserver.NewSmesherService(nil, defaultProvider, nil, nil, time.Second)
+	other := types.NodeID{1, 2, 3}
+	svc.AddIdentity(other, nil, otherProvider, nil)
+
+	defaultCoinbase := types.GenerateAddress([]byte("default"))
+	otherCoinbase := types.GenerateAddress([]byte("other"))
+	defaultProvider.EXPECT().Coinbase().Return(defaultCoinbase)
+	otherProvider.EXPECT().Coinbase().Return(otherCoinbase)
+
+	response, err := svc.Coinbase(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, defaultCoinbase.String(), response.AccountId.Address)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.SmesherIDHeader, other.String()))
+	response, err = svc.Coinbase(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, otherCoinbase.String(), response.AccountId.Address)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.SmesherIDHeader, types.NodeID{4}.String()))
+	_, err = svc.Coinbase(ctx, &emptypb.Empty{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.SmesherIDHeader, "not hex"))
+	_, err = svc.Coinbase(ctx, &emptypb.Empty{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"google.golang.org/genproto/googleapis/rpc/code"
@@ -15,8 +16,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -35,6 +38,7 @@ type TransactionService struct {
 // RegisterService registers this service with a grpc server instance.
 func (s TransactionService) RegisterService(server *Server) {
 	pb.RegisterTransactionServiceServer(server.GrpcServer, s)
+	extpb.RegisterTransactionServiceServer(server.GrpcServer, s)
 }
 
 // NewTransactionService creates a new grpc service using config data.
@@ -136,6 +140,119 @@ func (s TransactionService) TransactionsState(_ context.Context, in *pb.Transact
 	return res, nil
 }
 
+// Mempool returns pending transactions that are eligible for proposals, grouped by principal.
+func (s TransactionService) Mempool(_ context.Context, in *extpb.MempoolRequest) (*extpb.MempoolResponse, error) {
+	log.Info("GRPC TransactionService.Mempool")
+
+	var filter *types.Address
+	if in.Principal != "" {
+		addr, err := types.StringToAddress(in.Principal)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse principal `%s`: %v", in.Principal, err)
+		}
+		filter = &addr
+	}
+	res := &extpb.MempoolResponse{}
+	for principal, mtxs := range s.conState.GetMempool() {
+		if filter != nil && *filter != principal {
+			continue
+		}
+		nonce, balance := s.conState.GetProjection(principal)
+		account := &extpb.AccountMempool{
+			Principal:        principal.String(),
+			ProjectedNonce:   nonce,
+			ProjectedBalance: balance,
+		}
+		for _, mtx := range mtxs {
+			account.Transactions = append(account.Transactions, &extpb.PendingTransaction{
+				Id:       mtx.ID.Bytes(),
+				Nonce:    mtx.Nonce,
+				MaxGas:   mtx.MaxGas,
+				GasPrice: mtx.GasPrice,
+				MaxSpend: mtx.MaxSpend,
+			})
+		}
+		res.Accounts = append(res.Accounts, account)
+	}
+	sort.Slice(res.Accounts, func(i, j int) bool {
+		return res.Accounts[i].Principal < res.Accounts[j].Principal
+	})
+	return res, nil
+}
+
+// Simulate executes transactions on top of the state of the applied layer without persisting anything.
+func (s TransactionService) Simulate(_ context.Context, in *extpb.SimulateRequest) (*extpb.SimulateResponse, error) {
+	log.Info("GRPC TransactionService.Simulate")
+
+	if len(in.Transactions) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "`Transactions` must include one or more transactions")
+	}
+	lid := types.NewLayerID(in.Layer)
+	if in.Layer == 0 {
+		lid = s.mesh.LatestLayerInState()
+	}
+	raw := make([]types.RawTx, 0, len(in.Transactions))
+	for _, tx := range in.Transactions {
+		raw = append(raw, types.NewRawTx(tx))
+	}
+	results, err := s.conState.Simulate(lid, raw)
+	switch {
+	case errors.Is(err, core.ErrInternal):
+		log.With().Error("failed to simulate transactions", lid, log.Err(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	case errors.Is(err, sql.ErrNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		// transaction can't be executed, for example nonce is too low or it can't be parsed
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	res := &extpb.SimulateResponse{Layer: lid.Uint32()}
+	for _, rst := range results {
+		simulated := &extpb.SimulatedTransaction{
+			Id:          rst.ID.Bytes(),
+			Status:      rst.Status.String(),
+			Message:     rst.Message,
+			GasConsumed: rst.Gas,
+			Fee:         rst.Fee,
+		}
+		for _, addr := range rst.Addresses {
+			simulated.TouchedAddresses = append(simulated.TouchedAddresses, addr.String())
+		}
+		res.Results = append(res.Results, simulated)
+	}
+	return res, nil
+}
+
+// EstimateGas returns gas that the transaction reserves from the principal balance and gas consumed by its execution.
+func (s TransactionService) EstimateGas(_ context.Context, in *extpb.EstimateGasRequest) (*extpb.EstimateGasResponse, error) {
+	log.Info("GRPC TransactionService.EstimateGas")
+
+	if len(in.Transaction) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "`Transaction` payload empty")
+	}
+	lid := types.NewLayerID(in.Layer)
+	if in.Layer == 0 {
+		lid = s.mesh.LatestLayerInState()
+	}
+	estimate, err := s.conState.EstimateGas(lid, types.NewRawTx(in.Transaction))
+	switch {
+	case errors.Is(err, core.ErrInternal):
+		log.With().Error("failed to estimate gas", lid, log.Err(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	case errors.Is(err, sql.ErrNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &extpb.EstimateGasResponse{
+		Layer:     lid.Uint32(),
+		Intrinsic: estimate.Intrinsic,
+		Fixed:     estimate.Fixed,
+		MaxGas:    estimate.MaxGas(),
+		Consumed:  estimate.Consumed,
+	}, nil
+}
+
 // STREAMS
 
 // TransactionsStateStream exposes a stream of tx data.
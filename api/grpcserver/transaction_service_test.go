@@ -10,7 +10,10 @@ import (
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/spacemeshos/go-spacemesh/api/extpb"
 	"github.com/spacemeshos/go-spacemesh/common/fixture"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -186,3 +189,145 @@ func BenchmarkStreamResults(b *testing.B) {
 		require.Equal(b, maxcount, n)
 	}
 }
+
+func TestTransactionService_Mempool(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conState := &ConStateAPIMock{
+		poolByAddress: make(map[types.Address]types.TransactionID),
+		poolByTxId:    make(map[types.TransactionID]*types.Transaction),
+	}
+	principals := []types.Address{types.GenerateAddress([]byte{0}), types.GenerateAddress([]byte{1})}
+	var pending []*types.Transaction
+	for i := 0; i < 3; i++ {
+		tx := &types.Transaction{
+			RawTx: types.NewRawTx([]byte{byte(i)}),
+			TxHeader: &types.TxHeader{
+				Principal: principals[i%2],
+				Nonce:     uint64(i),
+				MaxGas:    100,
+				GasPrice:  uint64(i + 1),
+			},
+		}
+		conState.poolByTxId[tx.ID] = tx
+		pending = append(pending, tx)
+	}
+
+	svc := NewTransactionService(sql.InMemory(), nil, nil, conState, nil)
+	t.Cleanup(launchServer(t, svc))
+
+	conn := dialGrpc(ctx, t, cfg)
+	client := extpb.NewTransactionServiceClient(conn)
+
+	res, err := client.Mempool(ctx, &extpb.MempoolRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Accounts, 2)
+	require.Equal(t, 3, len(res.Accounts[0].Transactions)+len(res.Accounts[1].Transactions))
+	require.EqualValues(t, accountCounter+1, res.Accounts[0].ProjectedNonce)
+	require.EqualValues(t, accountBalance+1, res.Accounts[0].ProjectedBalance)
+
+	res, err = client.Mempool(ctx, &extpb.MempoolRequest{Principal: principals[1].String()})
+	require.NoError(t, err)
+	require.Len(t, res.Accounts, 1)
+	require.Equal(t, &extpb.PendingTransaction{
+		Id:       pending[1].ID.Bytes(),
+		Nonce:    1,
+		MaxGas:   100,
+		GasPrice: 2,
+	}, res.Accounts[0].Transactions[0])
+
+	_, err = client.Mempool(ctx, &extpb.MempoolRequest{Principal: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+}
+
+func TestTransactionService_Simulate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conState := &ConStateAPIMock{
+		poolByAddress: make(map[types.Address]types.TransactionID),
+		poolByTxId:    make(map[types.TransactionID]*types.Transaction),
+	}
+	principal := types.GenerateAddress([]byte{1})
+	var txs [][]byte
+	for i := 0; i < 2; i++ {
+		tx := &types.Transaction{
+			RawTx: types.NewRawTx([]byte{byte(i)}),
+			TxHeader: &types.TxHeader{
+				Principal: principal,
+				Nonce:     uint64(i),
+				MaxGas:    100,
+				GasPrice:  2,
+			},
+		}
+		conState.poolByTxId[tx.ID] = tx
+		txs = append(txs, tx.Raw)
+	}
+
+	svc := NewTransactionService(sql.InMemory(), nil, meshAPI, conState, nil)
+	t.Cleanup(launchServer(t, svc))
+
+	conn := dialGrpc(ctx, t, cfg)
+	client := extpb.NewTransactionServiceClient(conn)
+
+	res, err := client.Simulate(ctx, &extpb.SimulateRequest{Transactions: txs})
+	require.NoError(t, err)
+	require.Equal(t, layerVerified.Uint32(), res.Layer)
+	require.Len(t, res.Results, 2)
+	for i, rst := range res.Results {
+		require.Equal(t, types.NewRawTx(txs[i]).ID.Bytes(), rst.Id)
+		require.Equal(t, types.TransactionSuccess.String(), rst.Status)
+		require.EqualValues(t, 100, rst.GasConsumed)
+		require.EqualValues(t, 200, rst.Fee)
+		require.Equal(t, []string{principal.String()}, rst.TouchedAddresses)
+	}
+
+	res, err = client.Simulate(ctx, &extpb.SimulateRequest{Transactions: txs[:1], Layer: layerLatest.Uint32()})
+	require.NoError(t, err)
+	require.Equal(t, layerLatest.Uint32(), res.Layer)
+
+	_, err = client.Simulate(ctx, &extpb.SimulateRequest{Transactions: [][]byte{{100}}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.Simulate(ctx, &extpb.SimulateRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTransactionService_EstimateGas(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conState := &ConStateAPIMock{
+		poolByAddress: make(map[types.Address]types.TransactionID),
+		poolByTxId:    make(map[types.TransactionID]*types.Transaction),
+	}
+	tx := &types.Transaction{
+		RawTx:    types.NewRawTx([]byte{1}),
+		TxHeader: &types.TxHeader{MaxGas: 200},
+	}
+	conState.poolByTxId[tx.ID] = tx
+
+	svc := NewTransactionService(sql.InMemory(), nil, meshAPI, conState, nil)
+	t.Cleanup(launchServer(t, svc))
+
+	conn := dialGrpc(ctx, t, cfg)
+	client := extpb.NewTransactionServiceClient(conn)
+
+	res, err := client.EstimateGas(ctx, &extpb.EstimateGasRequest{Transaction: tx.Raw})
+	require.NoError(t, err)
+	require.Equal(t, &extpb.EstimateGasResponse{
+		Layer:     layerVerified.Uint32(),
+		Intrinsic: 100,
+		Fixed:     100,
+		MaxGas:    200,
+		Consumed:  200,
+	}, res)
+
+	_, err = client.EstimateGas(ctx, &extpb.EstimateGasRequest{Transaction: []byte{100}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.EstimateGas(ctx, &extpb.EstimateGasRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/spacemeshos/go-spacemesh/api (interfaces: NetworkIdentity,AtxProvider,PostSetupProvider,ChallengeVerifier)
+// Source: github.com/spacemeshos/go-spacemesh/api (interfaces: NetworkIdentity,AtxProvider,PostSetupProvider,ChallengeVerifier,RewardEstimator,TortoiseAPI)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -12,6 +12,8 @@ import (
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	activation "github.com/spacemeshos/go-spacemesh/activation"
 	types "github.com/spacemeshos/go-spacemesh/common/types"
+	miner "github.com/spacemeshos/go-spacemesh/miner"
+	tortoise "github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 // MockNetworkIdentity is a mock of NetworkIdentity interface.
@@ -206,3 +208,93 @@ func (mr *MockChallengeVerifierMockRecorder) Verify(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockChallengeVerifier)(nil).Verify), arg0, arg1, arg2)
 }
+
+// MockRewardEstimator is a mock of RewardEstimator interface.
+type MockRewardEstimator struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardEstimatorMockRecorder
+}
+
+// MockRewardEstimatorMockRecorder is the mock recorder for MockRewardEstimator.
+type MockRewardEstimatorMockRecorder struct {
+	mock *MockRewardEstimator
+}
+
+// NewMockRewardEstimator creates a new mock instance.
+func NewMockRewardEstimator(ctrl *gomock.Controller) *MockRewardEstimator {
+	mock := &MockRewardEstimator{ctrl: ctrl}
+	mock.recorder = &MockRewardEstimatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewardEstimator) EXPECT() *MockRewardEstimatorMockRecorder {
+	return m.recorder
+}
+
+// EstimateRewards mocks base method.
+func (m *MockRewardEstimator) EstimateRewards(arg0 types.EpochID) (*miner.RewardEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateRewards", arg0)
+	ret0, _ := ret[0].(*miner.RewardEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateRewards indicates an expected call of EstimateRewards.
+func (mr *MockRewardEstimatorMockRecorder) EstimateRewards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateRewards", reflect.TypeOf((*MockRewardEstimator)(nil).EstimateRewards), arg0)
+}
+
+// MockTortoiseAPI is a mock of TortoiseAPI interface.
+type MockTortoiseAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTortoiseAPIMockRecorder
+}
+
+// MockTortoiseAPIMockRecorder is the mock recorder for MockTortoiseAPI.
+type MockTortoiseAPIMockRecorder struct {
+	mock *MockTortoiseAPI
+}
+
+// NewMockTortoiseAPI creates a new mock instance.
+func NewMockTortoiseAPI(ctrl *gomock.Controller) *MockTortoiseAPI {
+	mock := &MockTortoiseAPI{ctrl: ctrl}
+	mock.recorder = &MockTortoiseAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTortoiseAPI) EXPECT() *MockTortoiseAPIMockRecorder {
+	return m.recorder
+}
+
+// Explain mocks base method.
+func (m *MockTortoiseAPI) Explain(arg0 types.LayerID) (*tortoise.LayerExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Explain", arg0)
+	ret0, _ := ret[0].(*tortoise.LayerExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Explain indicates an expected call of Explain.
+func (mr *MockTortoiseAPIMockRecorder) Explain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explain", reflect.TypeOf((*MockTortoiseAPI)(nil).Explain), arg0)
+}
+
+// Progress mocks base method.
+func (m *MockTortoiseAPI) Progress() tortoise.Progress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(tortoise.Progress)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockTortoiseAPIMockRecorder) Progress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockTortoiseAPI)(nil).Progress))
+}
@@ -6,8 +6,14 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/miner"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/addressbook"
+	"github.com/spacemeshos/go-spacemesh/p2p/handshake"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 // Publisher interface for publishing messages.
@@ -16,6 +22,11 @@ type Publisher = pubsub.Publisher
 // SmeshingAPI is an alias to SmeshingProvider.
 type SmeshingAPI = activation.SmeshingProvider
 
+// RewardEstimator is an API to estimate smeshing rewards of the node.
+type RewardEstimator interface {
+	EstimateRewards(types.EpochID) (*miner.RewardEstimate, error)
+}
+
 // GenesisTimeAPI is an API to get genesis time and current layer of the system.
 type GenesisTimeAPI interface {
 	GetGenesisTime() time.Time
@@ -39,12 +50,18 @@ type ConservativeState interface {
 	GetLayerStateRoot(types.LayerID) (types.Hash32, error)
 	GetLayerApplied(types.TransactionID) (types.LayerID, error)
 	GetAllAccounts() ([]*types.Account, error)
+	GetAccountWithProof(types.Address, types.LayerID) (*types.Account, *trie.Proof, error)
+	Simulate(types.LayerID, []types.RawTx) ([]types.TransactionWithResult, error)
+	EstimateGas(types.LayerID, types.RawTx) (*vm.GasEstimate, error)
 	GetBalance(types.Address) (uint64, error)
 	GetNonce(types.Address) (types.Nonce, error)
 	GetProjection(types.Address) (uint64, uint64)
 	GetMeshTransaction(types.TransactionID) (*types.MeshTransaction, error)
 	GetMeshTransactions([]types.TransactionID) ([]*types.MeshTransaction, map[types.TransactionID]struct{})
 	GetTransactionsByAddress(types.LayerID, types.LayerID, types.Address) ([]*types.MeshTransaction, error)
+	GetMempool() map[types.Address][]*types.MeshTransaction
+	DropTXs(context.Context, []types.TransactionID) ([]types.TransactionID, error)
+	DropPrincipal(context.Context, types.Address) ([]types.TransactionID, error)
 }
 
 // MeshAPI is an api for getting mesh status about layers/blocks/rewards.
@@ -55,10 +72,17 @@ type MeshAPI interface {
 	LatestLayer() types.LayerID
 	LatestLayerInState() types.LayerID
 	ProcessedLayer() types.LayerID
+	PrunedBelow() types.LayerID
+}
+
+// TortoiseAPI is an api to inspect decisions of the tortoise.
+type TortoiseAPI interface {
+	Explain(types.LayerID) (*tortoise.LayerExplanation, error)
+	Progress() tortoise.Progress
 }
 
 // NOTE that mockgen doesn't use source-mode to avoid generating mocks for all interfaces in this file.
-//go:generate mockgen -package=mocks -destination=./mocks/mocks.go . NetworkIdentity,AtxProvider,PostSetupProvider,ChallengeVerifier
+//go:generate mockgen -package=mocks -destination=./mocks/mocks.go . NetworkIdentity,AtxProvider,PostSetupProvider,ChallengeVerifier,RewardEstimator,TortoiseAPI
 
 // NetworkIdentity interface.
 type NetworkIdentity interface {
@@ -70,6 +94,29 @@ type PeerCounter interface {
 	PeerCount() uint64
 }
 
+// PeerInfoProvider is an api to get connected peers and info they sent in the handshake.
+type PeerInfoProvider interface {
+	PeerCounter
+	GetPeers() []p2p.Peer
+	PeerInfo(p2p.Peer) (*handshake.PeerInfo, bool)
+}
+
+// PeerAdmin is an api to inspect and manage connections, address book, bootnodes and bans of the p2p host.
+type PeerAdmin interface {
+	Connections() []p2p.ConnectionInfo
+	AddressBook() (newBuckets, triedBuckets [][]*addressbook.AddrInfo)
+	ConnectAddress(context.Context, string) (p2p.Peer, error)
+	Disconnect(p2p.Peer) error
+	Bootnodes() (static, added []string)
+	AddBootnode(string) error
+	RemoveBootnode(string) error
+	Bans() (peers []p2p.Peer, nets []string)
+	BanPeer(p2p.Peer) error
+	UnbanPeer(p2p.Peer) error
+	BanIPNet(string) error
+	UnbanIPNet(string) error
+}
+
 type PostSetupProvider interface {
 	Status() *activation.PostSetupStatus
 	ComputeProviders() []activation.PostSetupComputeProvider
@@ -0,0 +1,203 @@
+// Package backup creates snapshots of the node state database and restores them.
+//
+// Snapshot is a directory with a copy of the database and a manifest
+// that describes the state captured in the copy.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+)
+
+const (
+	// ManifestFile is the name of the manifest in the snapshot directory.
+	ManifestFile = "manifest.json"
+	// DatabaseFile is the name of the database copy in the snapshot directory.
+	DatabaseFile = "state.sql"
+)
+
+var (
+	// ErrGenesisMismatch is returned if snapshot was created for a different network.
+	ErrGenesisMismatch = errors.New("backup: genesis id mismatch")
+	// ErrManifestMismatch is returned if manifest doesn't describe the database in the snapshot.
+	ErrManifestMismatch = errors.New("backup: manifest doesn't match database")
+)
+
+// Manifest describes the state stored in the snapshot.
+type Manifest struct {
+	GenesisID types.Hash20 `json:"genesis_id"`
+	// Layer is the last applied layer.
+	Layer uint32 `json:"layer"`
+	// StateHash is the state hash of the last applied layer.
+	StateHash types.Hash32 `json:"state_hash"`
+	Created   time.Time    `json:"created"`
+}
+
+// Backup writes a snapshot of the database into the dir.
+// Database can be used concurrently while snapshot is created.
+func Backup(ctx context.Context, db *sql.Database, dir string, genesis types.Hash20) (*Manifest, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create %s: %w", dir, err)
+	}
+	dbpath := filepath.Join(dir, DatabaseFile)
+	if _, err := os.Stat(dbpath); err == nil {
+		return nil, fmt.Errorf("snapshot %s: %w", dbpath, os.ErrExist)
+	}
+	if err := db.Backup(ctx, dbpath); err != nil {
+		return nil, err
+	}
+	snapshot, err := open(dbpath)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Close()
+	// manifest is read from the copy as the original database may have progressed
+	manifest, err := describe(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	manifest.GenesisID = genesis
+	manifest.Created = time.Now().UTC()
+	if err := writeManifest(filepath.Join(dir, ManifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Restore replaces database at the dbpath with the snapshot from the dir.
+// Snapshot is rejected if it was created for a different genesis.
+// Node must not be running while database is restored.
+func Restore(ctx context.Context, dir, dbpath string, genesis types.Hash20) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.GenesisID != genesis {
+		return nil, fmt.Errorf("%w: snapshot %x, configured %x", ErrGenesisMismatch, manifest.GenesisID, genesis)
+	}
+	snapshot, err := open(filepath.Join(dir, DatabaseFile))
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Close()
+	actual, err := describe(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if actual.Layer != manifest.Layer || actual.StateHash != manifest.StateHash {
+		return nil, fmt.Errorf("%w: layer %d state %x, manifest layer %d state %x", ErrManifestMismatch,
+			actual.Layer, actual.StateHash, manifest.Layer, manifest.StateHash)
+	}
+	if err := replace(ctx, snapshot, dbpath); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// replace copies the snapshot into a temporary file next to the dbpath and renames it over the dbpath,
+// so that the database is either left intact or fully replaced if restore is interrupted.
+func replace(ctx context.Context, snapshot *sql.Database, dbpath string) error {
+	dir := filepath.Dir(dbpath)
+	tmp, err := os.CreateTemp(dir, filepath.Base(dbpath)+".restore-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file in %s: %w", dir, err)
+	}
+	tmppath := tmp.Name()
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", tmppath, err)
+	}
+	if err := restoreInto(ctx, snapshot, tmppath); err != nil {
+		for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+			_ = os.Remove(tmppath + suffix)
+		}
+		return err
+	}
+	// wal and shm files of the replaced database must not be applied to the restored one.
+	// losing them before rename leaves the replaced database at its last checkpoint, which is consistent.
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbpath + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", dbpath+suffix, err)
+		}
+	}
+	if err := os.Rename(tmppath, dbpath); err != nil {
+		return fmt.Errorf("rename %s to %s: %w", tmppath, dbpath, err)
+	}
+	return syncPath(dir)
+}
+
+func restoreInto(ctx context.Context, snapshot *sql.Database, path string) error {
+	if err := snapshot.Backup(ctx, path); err != nil {
+		return err
+	}
+	return syncPath(path)
+}
+
+func syncPath(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadManifest reads manifest from the snapshot in the dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFile)
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %s: %w", path, err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(buf, &manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+func writeManifest(path string, manifest *Manifest) error {
+	buf, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		return fmt.Errorf("write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+func open(path string) (*sql.Database, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	// snapshot is not migrated, it must be usable by the node that created it
+	db, err := sql.Open("file:"+path, sql.WithMigrations(nil), sql.WithConnections(1))
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func describe(db sql.Executor) (*Manifest, error) {
+	lid, err := layers.GetLastApplied(db)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := layers.GetStateHash(db, lid)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return nil, err
+	}
+	return &Manifest{Layer: lid.Uint32(), StateHash: hash}, nil
+}
@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+)
+
+func applyLayer(tb testing.TB, db sql.Executor, lid types.LayerID) types.Hash32 {
+	tb.Helper()
+	hash := types.Hash32{byte(lid.Uint32())}
+	require.NoError(tb, layers.SetApplied(db, lid, types.BlockID{1}))
+	require.NoError(tb, layers.UpdateStateHash(db, lid, hash))
+	return hash
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	genesis := types.Hash20{1}
+
+	db, err := sql.Open("file:" + filepath.Join(dir, "state.sql"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	applyLayer(t, db, types.NewLayerID(9))
+	hash := applyLayer(t, db, types.NewLayerID(10))
+
+	snapshot := filepath.Join(dir, "snapshot")
+	manifest, err := Backup(ctx, db, snapshot, genesis)
+	require.NoError(t, err)
+	require.Equal(t, genesis, manifest.GenesisID)
+	require.Equal(t, uint32(10), manifest.Layer)
+	require.Equal(t, hash, manifest.StateHash)
+
+	stored, err := ReadManifest(snapshot)
+	require.NoError(t, err)
+	require.Equal(t, manifest.StateHash, stored.StateHash)
+
+	_, err = Backup(ctx, db, snapshot, genesis)
+	require.ErrorIs(t, err, os.ErrExist)
+
+	// database progresses after the snapshot was taken
+	applyLayer(t, db, types.NewLayerID(11))
+
+	target := filepath.Join(dir, "restored.sql")
+	_, err = Restore(ctx, snapshot, target, types.Hash20{2})
+	require.ErrorIs(t, err, ErrGenesisMismatch)
+
+	restored, err := Restore(ctx, snapshot, target, genesis)
+	require.NoError(t, err)
+	require.Equal(t, manifest.Layer, restored.Layer)
+
+	rdb, err := sql.Open("file:" + target)
+	require.NoError(t, err)
+	defer rdb.Close()
+	last, err := layers.GetLastApplied(rdb)
+	require.NoError(t, err)
+	require.Equal(t, types.NewLayerID(10), last)
+}
+
+func TestRestoreManifestMismatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	genesis := types.Hash20{1}
+
+	db := sql.InMemory()
+	applyLayer(t, db, types.NewLayerID(10))
+
+	snapshot := filepath.Join(dir, "snapshot")
+	manifest, err := Backup(ctx, db, snapshot, genesis)
+	require.NoError(t, err)
+
+	manifest.StateHash = types.Hash32{0xff}
+	buf, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(snapshot, ManifestFile), buf, 0o600))
+
+	_, err = Restore(ctx, snapshot, filepath.Join(dir, "state.sql"), genesis)
+	require.ErrorIs(t, err, ErrManifestMismatch)
+}
+
+func TestRestoreReplacesDatabase(t *testing.T) {
+	dir := t.TempDir()
+	genesis := types.Hash20{1}
+	dbpath := filepath.Join(dir, "state.sql")
+
+	db, err := sql.Open("file:" + dbpath)
+	require.NoError(t, err)
+	applyLayer(t, db, types.NewLayerID(10))
+	snapshot := filepath.Join(dir, "snapshot")
+	_, err = Backup(context.Background(), db, snapshot, genesis)
+	require.NoError(t, err)
+	applyLayer(t, db, types.NewLayerID(11))
+	require.NoError(t, db.Close())
+
+	lastApplied := func() types.LayerID {
+		db, err := sql.Open("file:" + dbpath)
+		require.NoError(t, err)
+		defer db.Close()
+		last, err := layers.GetLastApplied(db)
+		require.NoError(t, err)
+		return last
+	}
+	noTemporaryFiles := func() {
+		matches, err := filepath.Glob(dbpath + ".restore-*")
+		require.NoError(t, err)
+		require.Empty(t, matches)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Restore(ctx, snapshot, dbpath, genesis)
+	require.Error(t, err)
+	require.Equal(t, types.NewLayerID(11), lastApplied(), "database is intact if restore failed")
+	noTemporaryFiles()
+
+	_, err = Restore(context.Background(), snapshot, dbpath, genesis)
+	require.NoError(t, err)
+	require.Equal(t, types.NewLayerID(10), lastApplied())
+	noTemporaryFiles()
+}
@@ -89,8 +89,7 @@ type Certifier struct {
 
 	db         *sql.Database
 	oracle     hare.Rolacle
-	nodeID     types.NodeID
-	signer     *signing.EdSigner
+	identities []certifierIdentity
 	publisher  pubsub.Publisher
 	layerClock layerClock
 	beacon     system.BeaconGetter
@@ -100,6 +99,13 @@ type Certifier struct {
 	certifyMsgs map[types.LayerID]map[types.BlockID]*certInfo
 }
 
+// certifierIdentity is an identity that certifies hare outputs if eligible.
+type certifierIdentity struct {
+	nodeID types.NodeID
+	signer *signing.EdSigner
+	oracle hare.Rolacle
+}
+
 // NewCertifier creates new block certifier.
 func NewCertifier(
 	db *sql.Database, o hare.Rolacle, n types.NodeID, s *signing.EdSigner, p pubsub.Publisher, lc layerClock, b system.BeaconGetter, tortoise system.Tortoise,
@@ -111,8 +117,7 @@ func NewCertifier(
 		ctx:         context.Background(),
 		db:          db,
 		oracle:      o,
-		nodeID:      n,
-		signer:      s,
+		identities:  []certifierIdentity{{nodeID: n, signer: s, oracle: o}},
 		publisher:   p,
 		layerClock:  lc,
 		beacon:      b,
@@ -126,6 +131,12 @@ func NewCertifier(
 	return c
 }
 
+// AddIdentity adds an identity that certifies hare outputs along with the node identity.
+// Oracle must compute eligibility proofs with the vrf key of the identity.
+func (c *Certifier) AddIdentity(s *signing.EdSigner, o hare.Rolacle) {
+	c.identities = append(c.identities, certifierIdentity{nodeID: s.NodeID(), signer: s, oracle: o})
+}
+
 // Start starts the background goroutine for periodic pruning.
 func (c *Certifier) Start() {
 	c.once.Do(func() {
@@ -205,19 +216,29 @@ func (c *Certifier) RegisterForCert(ctx context.Context, lid types.LayerID, bid
 }
 
 // CertifyIfEligible signs the hare output, along with its role proof as a certifier, and gossip the CertifyMessage
-// if the node is eligible to be a certifier.
+// for every identity of the node that is eligible to be a certifier.
 func (c *Certifier) CertifyIfEligible(ctx context.Context, logger log.Log, lid types.LayerID, bid types.BlockID) error {
 	if _, err := c.beacon.GetBeacon(lid.GetEpoch()); err != nil {
 		return errBeaconNotAvailable
 	}
-	// check if the node is eligible to certify the hare output
-	proof, err := c.oracle.Proof(ctx, lid, eligibility.CertifyRound)
+	var rst error
+	for _, id := range c.identities {
+		if err := c.certify(ctx, logger.WithFields(id.nodeID), id, lid, bid); err != nil && rst == nil {
+			rst = err
+		}
+	}
+	return rst
+}
+
+func (c *Certifier) certify(ctx context.Context, logger log.Log, id certifierIdentity, lid types.LayerID, bid types.BlockID) error {
+	// check if the identity is eligible to certify the hare output
+	proof, err := id.oracle.Proof(ctx, lid, eligibility.CertifyRound)
 	if err != nil {
 		logger.With().Error("failed to get eligibility proof to certify", log.Err(err))
 		return err
 	}
 
-	eligibilityCount, err := c.oracle.CalcEligibility(ctx, lid, eligibility.CertifyRound, c.cfg.CommitteeSize, c.nodeID, proof)
+	eligibilityCount, err := id.oracle.CalcEligibility(ctx, lid, eligibility.CertifyRound, c.cfg.CommitteeSize, id.nodeID, proof)
 	if err != nil {
 		logger.With().Error("failed to check eligibility to certify", log.Err(err))
 		return err
@@ -234,7 +255,7 @@ func (c *Certifier) CertifyIfEligible(ctx context.Context, logger log.Log, lid t
 			Proof:          proof,
 		},
 	}
-	msg.Signature = c.signer.Sign(msg.Bytes())
+	msg.Signature = id.signer.Sign(msg.Bytes())
 	data, err := codec.Encode(&msg)
 	if err != nil {
 		logger.With().Panic("failed to serialize certify message", log.Err(err))
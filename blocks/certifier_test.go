@@ -587,7 +587,7 @@ func Test_CertifyIfEligible(t *testing.T) {
 	require.NoError(t, err)
 
 	tc.mOracle.EXPECT().Proof(gomock.Any(), b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
-	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nodeID, proof).Return(defaultCnt, nil)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, proof).Return(defaultCnt, nil)
 	tc.mPub.EXPECT().Publish(gomock.Any(), pubsub.BlockCertify, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, got []byte) error {
 			var msg types.CertifyMessage
@@ -595,7 +595,7 @@ func Test_CertifyIfEligible(t *testing.T) {
 
 			nodeId, err := extractor.ExtractNodeID(msg.Bytes(), msg.Signature)
 			require.NoError(t, err)
-			require.Equal(t, tc.nodeID, nodeId)
+			require.Equal(t, tc.nid, nodeId)
 			require.Equal(t, b.LayerIndex, msg.LayerID)
 			require.Equal(t, b.ID(), msg.BlockID)
 			require.Equal(t, proof, msg.Proof)
@@ -605,13 +605,54 @@ func Test_CertifyIfEligible(t *testing.T) {
 	require.NoError(t, tc.CertifyIfEligible(context.TODO(), tc.logger, b.LayerIndex, b.ID()))
 }
 
+func Test_CertifyIfEligible_MultipleIdentities(t *testing.T) {
+	tc := newTestCertifier(t)
+	b := generateBlock(t, tc.db)
+	tc.mb.EXPECT().GetBeacon(b.LayerIndex.GetEpoch()).Return(types.RandomBeacon(), nil)
+
+	other, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	otherOracle := hmocks.NewMockRolacle(gomock.NewController(t))
+	tc.AddIdentity(other, otherOracle)
+	third, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	thirdOracle := hmocks.NewMockRolacle(gomock.NewController(t))
+	tc.AddIdentity(third, thirdOracle)
+
+	extractor, err := signing.NewPubKeyExtractor()
+	require.NoError(t, err)
+
+	proof := []byte("not a fraud")
+	otherProof := []byte("not a fraud either")
+	tc.mOracle.EXPECT().Proof(gomock.Any(), b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, proof).Return(defaultCnt, nil)
+	otherOracle.EXPECT().Proof(gomock.Any(), b.LayerIndex, eligibility.CertifyRound).Return(otherProof, nil)
+	otherOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, other.NodeID(), otherProof).Return(defaultCnt, nil)
+	thirdOracle.EXPECT().Proof(gomock.Any(), b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
+	thirdOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, third.NodeID(), proof).Return(uint16(0), nil)
+
+	proofs := map[types.NodeID][]byte{}
+	tc.mPub.EXPECT().Publish(gomock.Any(), pubsub.BlockCertify, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, got []byte) error {
+			var msg types.CertifyMessage
+			require.NoError(t, codec.Decode(got, &msg))
+
+			nodeId, err := extractor.ExtractNodeID(msg.Bytes(), msg.Signature)
+			require.NoError(t, err)
+			proofs[nodeId] = msg.Proof
+			return nil
+		}).Times(2)
+	require.NoError(t, tc.CertifyIfEligible(context.TODO(), tc.logger, b.LayerIndex, b.ID()))
+	require.Equal(t, map[types.NodeID][]byte{tc.nid: proof, other.NodeID(): otherProof}, proofs)
+}
+
 func Test_CertifyIfEligible_NotEligible(t *testing.T) {
 	tc := newTestCertifier(t)
 	b := generateBlock(t, tc.db)
 	tc.mb.EXPECT().GetBeacon(b.LayerIndex.GetEpoch()).Return(types.RandomBeacon(), nil)
 	proof := []byte("not a fraud")
 	tc.mOracle.EXPECT().Proof(gomock.Any(), b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
-	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nodeID, proof).Return(uint16(0), nil)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, proof).Return(uint16(0), nil)
 	require.NoError(t, tc.CertifyIfEligible(context.TODO(), tc.logger, b.LayerIndex, b.ID()))
 }
 
@@ -622,7 +663,7 @@ func Test_CertifyIfEligible_EligibilityErr(t *testing.T) {
 	errUnknown := errors.New("unknown")
 	proof := []byte("not a fraud")
 	tc.mOracle.EXPECT().Proof(gomock.Any(), b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
-	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nodeID, proof).Return(uint16(0), errUnknown)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, proof).Return(uint16(0), errUnknown)
 	require.ErrorIs(t, tc.CertifyIfEligible(context.TODO(), tc.logger, b.LayerIndex, b.ID()), errUnknown)
 }
 
@@ -2,22 +2,22 @@ package blocks
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
 var (
-	errMalformedData  = errors.New("malformed data")
-	errInvalidRewards = errors.New("invalid rewards")
-	errDuplicateTX    = errors.New("duplicate TxID in proposal")
+	errMalformedData  = fmt.Errorf("%w: malformed data", pubsub.ErrValidationReject)
+	errInvalidRewards = fmt.Errorf("%w: invalid rewards", pubsub.ErrValidationReject)
+	errDuplicateTX    = fmt.Errorf("%w: duplicate TxID in proposal", pubsub.ErrValidationReject)
 )
 
 // Handler processes Block fetched from peers during sync.
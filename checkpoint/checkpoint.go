@@ -0,0 +1,372 @@
+// Package checkpoint exports and imports the state of the node at a layer,
+// so that a new node can start syncing from that layer instead of genesis.
+//
+// Checkpoint contains accounts as they were after the layer was applied,
+// activations that are needed for the current and the next epochs,
+// and beacons for those epochs. Ballots and the applied block of the layer
+// are included, together with the opinion of the tortoise, so that ballots
+// from later layers can be decoded without ballots from earlier layers.
+package checkpoint
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/tortoise/opinionhash"
+)
+
+// Version of the checkpoint format.
+const Version = 1
+
+var (
+	// ErrGenesisMismatch is returned if checkpoint was created for a different network.
+	ErrGenesisMismatch = errors.New("checkpoint: genesis id mismatch")
+	// ErrStateMismatch is returned if accounts in the checkpoint don't match trusted state root.
+	ErrStateMismatch = errors.New("checkpoint: accounts don't match state root")
+	// ErrNotEmpty is returned if checkpoint is imported into the database that already has state.
+	ErrNotEmpty = errors.New("checkpoint: database is not empty")
+)
+
+// Export writes checkpoint for the applied layer.
+func Export(db sql.Executor, lid types.LayerID, genesis types.Hash20, w io.Writer) (*Header, error) {
+	header := &Header{
+		Version:   Version,
+		GenesisID: genesis,
+		Layer:     lid,
+	}
+	var err error
+	if header.Block, err = layers.GetApplied(db, lid); err != nil {
+		return nil, fmt.Errorf("layer %s is not applied: %w", lid, err)
+	}
+	if header.StateRoot, err = layers.GetStateHash(db, lid); err != nil {
+		return nil, err
+	}
+	if header.Hash, err = layers.GetHash(db, lid); err != nil {
+		return nil, err
+	}
+	if header.AggregatedHash, err = layers.GetAggregatedHash(db, lid); err != nil {
+		return nil, err
+	}
+	if header.Opinion, err = opinion(db, lid.Sub(1)); err != nil {
+		return nil, err
+	}
+	epoch := lid.GetEpoch()
+	for _, target := range []types.EpochID{epoch, epoch + 1} {
+		beacon, err := beacons.Get(db, target)
+		if errors.Is(err, sql.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		header.Beacons = append(header.Beacons, EpochBeacon{Epoch: target, Beacon: beacon})
+	}
+	all, err := accounts.Snapshot(db, lid)
+	if err != nil {
+		return nil, err
+	}
+	header.Accounts = uint64(len(all))
+
+	// activations published in the previous epoch are active in the current epoch,
+	// and activations published in the current epoch are active in the next one.
+	var ids []types.ATXID
+	for _, publish := range []types.EpochID{epoch - 1, epoch} {
+		if publish > epoch {
+			continue // underflow
+		}
+		epochIDs, err := atxs.GetIDsByEpoch(db, publish)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, epochIDs...)
+	}
+	blts, err := exportBallots(db, lid)
+	if err != nil {
+		return nil, err
+	}
+	header.Ballots = uint64(len(blts))
+	// activations referenced by ballots are published in the same epochs,
+	// unless the ballot is malicious. such activations are exported too.
+	exported := map[types.ATXID]struct{}{}
+	for _, id := range ids {
+		exported[id] = struct{}{}
+	}
+	for _, ballot := range blts {
+		refs := []types.ATXID{ballot.AtxID}
+		if ballot.EpochData != nil {
+			refs = append(refs, ballot.EpochData.ActiveSet...)
+		}
+		for _, id := range refs {
+			if _, exists := exported[id]; !exists {
+				exported[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	header.ATXs = uint64(len(ids))
+	var block *types.Block
+	if header.Block != types.EmptyBlockID {
+		if block, err = blocks.Get(db, header.Block); err != nil {
+			return nil, fmt.Errorf("applied block %s: %w", header.Block, err)
+		}
+	}
+
+	buf := bufio.NewWriter(w)
+	if _, err := codec.EncodeTo(buf, header); err != nil {
+		return nil, fmt.Errorf("encode header: %w", err)
+	}
+	for _, account := range all {
+		if _, err := codec.EncodeTo(buf, account); err != nil {
+			return nil, fmt.Errorf("encode account %s: %w", account.Address, err)
+		}
+	}
+	for _, id := range ids {
+		atx, err := exportATX(db, id)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := codec.EncodeTo(buf, atx); err != nil {
+			return nil, fmt.Errorf("encode atx %s: %w", id, err)
+		}
+	}
+	for _, ballot := range blts {
+		if _, err := codec.EncodeTo(buf, ballot); err != nil {
+			return nil, fmt.Errorf("encode ballot %s: %w", ballot.ID(), err)
+		}
+	}
+	if block != nil {
+		if _, err := codec.EncodeTo(buf, block); err != nil {
+			return nil, fmt.Errorf("encode block %s: %w", block.ID(), err)
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return nil, fmt.Errorf("write checkpoint: %w", err)
+	}
+	return header, nil
+}
+
+// opinion computes the opinion of the tortoise about layers up to and including lid.
+// Layers are expected to be verified, so that the opinion is defined by the validity of blocks.
+func opinion(db sql.Executor, lid types.LayerID) (types.Hash32, error) {
+	var (
+		rst     types.Hash32
+		genesis = types.GetEffectiveGenesis()
+	)
+	for current := genesis; !current.After(lid); current = current.Add(1) {
+		hasher := opinionhash.New()
+		if current != genesis {
+			hasher.WritePrevious(rst)
+		}
+		all, err := blocks.Layer(db, current)
+		if err != nil {
+			return types.Hash32{}, err
+		}
+		var valid []*types.Block
+		for _, block := range all {
+			isValid, err := blocks.IsValid(db, block.ID())
+			if err != nil && !errors.Is(err, sql.ErrNotFound) {
+				return types.Hash32{}, err
+			}
+			if isValid {
+				valid = append(valid, block)
+			}
+		}
+		sort.Slice(valid, func(i, j int) bool {
+			if valid[i].TickHeight != valid[j].TickHeight {
+				return valid[i].TickHeight < valid[j].TickHeight
+			}
+			return valid[i].ID().Compare(valid[j].ID())
+		})
+		for _, block := range valid {
+			hasher.WriteSupport(block.ID(), block.TickHeight)
+		}
+		hasher.Sum(rst[:0])
+	}
+	return rst, nil
+}
+
+// exportBallots returns ballots from the layer and reference ballots from earlier layers in the epoch.
+// Ballots in later layers use them as base and reference ballots.
+func exportBallots(db sql.Executor, lid types.LayerID) ([]*types.Ballot, error) {
+	var rst []*types.Ballot
+	for current := lid.GetEpoch().FirstLayer(); !current.After(lid); current = current.Add(1) {
+		blts, err := ballots.Layer(db, current)
+		if err != nil {
+			return nil, err
+		}
+		for _, ballot := range blts {
+			if current == lid || ballot.EpochData != nil {
+				rst = append(rst, ballot)
+			}
+		}
+	}
+	return rst, nil
+}
+
+func exportATX(db sql.Executor, id types.ATXID) (*ATX, error) {
+	atx, err := atxs.Get(db, id)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := atxs.GetTimestamp(db, id)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := atxs.GetBlob(db, id.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &ATX{
+		ATX:            blob,
+		BaseTickHeight: atx.BaseTickHeight(),
+		TickCount:      atx.TickCount(),
+		Timestamp:      uint64(timestamp.UnixNano()),
+	}, nil
+}
+
+// Import checkpoint into the empty database. Accounts are verified against
+// the trusted state root before anything is written, the root recorded in the checkpoint
+// must be the same.
+//
+// After import the last applied and processed layer is the layer of the checkpoint,
+// and the layer and the opinion of the checkpoint are recorded in the kvstore.
+func Import(ctx context.Context, db *sql.Database, genesis types.Hash20, root types.Hash32, r io.Reader) (*Header, error) {
+	buf := bufio.NewReader(r)
+	var header Header
+	if _, err := codec.DecodeFrom(buf, &header); err != nil {
+		return nil, fmt.Errorf("decode header: %w", err)
+	}
+	if header.Version != Version {
+		return nil, fmt.Errorf("checkpoint: unsupported version %d", header.Version)
+	}
+	if header.GenesisID != genesis {
+		return nil, fmt.Errorf("%w: checkpoint %x, configured %x", ErrGenesisMismatch, header.GenesisID, genesis)
+	}
+	if header.StateRoot != root {
+		return nil, fmt.Errorf("%w: checkpoint %x, trusted %x", ErrStateMismatch, header.StateRoot, root)
+	}
+	all := make([]*types.Account, 0, header.Accounts)
+	for i := uint64(0); i < header.Accounts; i++ {
+		var account types.Account
+		if _, err := codec.DecodeFrom(buf, &account); err != nil {
+			return nil, fmt.Errorf("decode account %d: %w", i, err)
+		}
+		all = append(all, &account)
+	}
+	activations := make([]*types.VerifiedActivationTx, 0, header.ATXs)
+	timestamps := make([]time.Time, 0, header.ATXs)
+	for i := uint64(0); i < header.ATXs; i++ {
+		var encoded ATX
+		if _, err := codec.DecodeFrom(buf, &encoded); err != nil {
+			return nil, fmt.Errorf("decode atx %d: %w", i, err)
+		}
+		var atx types.ActivationTx
+		if err := codec.Decode(encoded.ATX, &atx); err != nil {
+			return nil, fmt.Errorf("decode atx %d: %w", i, err)
+		}
+		verified, err := atx.Verify(encoded.BaseTickHeight, encoded.TickCount)
+		if err != nil {
+			return nil, fmt.Errorf("verify atx %d: %w", i, err)
+		}
+		activations = append(activations, verified)
+		timestamps = append(timestamps, time.Unix(0, int64(encoded.Timestamp)))
+	}
+	blts := make([]*types.Ballot, 0, header.Ballots)
+	for i := uint64(0); i < header.Ballots; i++ {
+		var ballot types.Ballot
+		if _, err := codec.DecodeFrom(buf, &ballot); err != nil {
+			return nil, fmt.Errorf("decode ballot %d: %w", i, err)
+		}
+		if err := ballot.Initialize(); err != nil {
+			return nil, fmt.Errorf("initialize ballot %d: %w", i, err)
+		}
+		blts = append(blts, &ballot)
+	}
+	var block *types.Block
+	if header.Block != types.EmptyBlockID {
+		block = &types.Block{}
+		if _, err := codec.DecodeFrom(buf, block); err != nil {
+			return nil, fmt.Errorf("decode block: %w", err)
+		}
+		block.Initialize()
+		if block.ID() != header.Block {
+			return nil, fmt.Errorf("checkpoint: applied block %s doesn't match block %s", header.Block, block.ID())
+		}
+	}
+
+	if err := db.WithTx(ctx, func(tx *sql.Tx) error {
+		applied, err := layers.GetLastApplied(tx)
+		if err != nil {
+			return err
+		}
+		if applied != (types.LayerID{}) {
+			return fmt.Errorf("%w: applied layer %s", ErrNotEmpty, applied)
+		}
+		computed, err := trie.Update(tx, types.Hash32{}, all)
+		if err != nil {
+			return err
+		}
+		if computed != root {
+			return fmt.Errorf("%w: computed %x, trusted %x", ErrStateMismatch, computed, root)
+		}
+		for _, account := range all {
+			if err := accounts.Update(tx, account); err != nil {
+				return err
+			}
+		}
+		for i, atx := range activations {
+			if err := atxs.Add(tx, atx, timestamps[i]); err != nil {
+				return err
+			}
+		}
+		for _, beacon := range header.Beacons {
+			if err := beacons.Add(tx, beacon.Epoch, beacon.Beacon); err != nil {
+				return err
+			}
+		}
+		for _, ballot := range blts {
+			if err := ballots.Add(tx, ballot); err != nil {
+				return err
+			}
+		}
+		if block != nil {
+			if err := blocks.Add(tx, block); err != nil {
+				return err
+			}
+			if err := blocks.SetValid(tx, block.ID()); err != nil {
+				return err
+			}
+		}
+		if err := layers.SetApplied(tx, header.Layer, header.Block); err != nil {
+			return err
+		}
+		if err := layers.SetProcessed(tx, header.Layer); err != nil {
+			return err
+		}
+		if err := layers.UpdateStateHash(tx, header.Layer, header.StateRoot); err != nil {
+			return err
+		}
+		if err := layers.SetHashes(tx, header.Layer, header.Hash, header.AggregatedHash); err != nil {
+			return err
+		}
+		return kvstore.SetImportedCheckpoint(tx, header.Layer, header.Opinion)
+	}); err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
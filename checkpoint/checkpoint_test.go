@@ -0,0 +1,227 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+	res := m.Run()
+	os.Exit(res)
+}
+
+func addATX(tb testing.TB, db sql.Executor, lid types.LayerID) *types.VerifiedActivationTx {
+	tb.Helper()
+	sig, err := signing.NewEdSigner()
+	require.NoError(tb, err)
+	atx := &types.ActivationTx{
+		InnerActivationTx: types.InnerActivationTx{
+			NIPostChallenge: types.NIPostChallenge{
+				PubLayerID: lid,
+				PrevATXID:  types.RandomATXID(),
+			},
+			NumUnits: 2,
+		},
+	}
+	atx.Sig = sig.Sign(atx.SignedBytes())
+	verified, err := atx.Verify(10, 20)
+	require.NoError(tb, err)
+	require.NoError(tb, atxs.Add(db, verified, time.Now()))
+	return verified
+}
+
+func addBallot(tb testing.TB, db sql.Executor, lid types.LayerID, atx types.ATXID, ref *types.Ballot) *types.Ballot {
+	tb.Helper()
+	sig, err := signing.NewEdSigner()
+	require.NoError(tb, err)
+	ballot := &types.Ballot{
+		InnerBallot: types.InnerBallot{
+			AtxID:       atx,
+			LayerIndex:  lid,
+			OpinionHash: types.RandomHash(),
+		},
+	}
+	if ref == nil {
+		ballot.EpochData = &types.EpochData{ActiveSet: []types.ATXID{atx}, Beacon: types.Beacon{1}}
+	} else {
+		ballot.RefBallot = ref.ID()
+	}
+	ballot.Signature = sig.Sign(ballot.SignedBytes())
+	require.NoError(tb, ballot.Initialize())
+	require.NoError(tb, ballots.Add(db, ballot))
+	return ballot
+}
+
+type state struct {
+	lid      types.LayerID
+	accounts []*types.Account
+	atxs     []types.ATXID
+	beacon   types.Beacon
+	ballots  []*types.Ballot
+	block    *types.Block
+}
+
+func genState(tb testing.TB, db sql.Executor) *state {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(1001))
+	st := &state{lid: types.NewLayerID(10)}
+	var root types.Hash32
+	for lid := types.NewLayerID(1); !lid.After(st.lid.Add(1)); lid = lid.Add(1) {
+		var changed []*types.Account
+		for i := 0; i < 5; i++ {
+			account := &types.Account{Layer: lid, Balance: rng.Uint64(), NextNonce: uint64(lid.Uint32())}
+			rng.Read(account.Address[:])
+			require.NoError(tb, accounts.Update(db, account))
+			changed = append(changed, account)
+		}
+		var err error
+		root, err = trie.Update(db, root, changed)
+		require.NoError(tb, err)
+		block := &types.Block{InnerBlock: types.InnerBlock{LayerIndex: lid, TickHeight: uint64(lid.Uint32())}}
+		block.Initialize()
+		require.NoError(tb, blocks.Add(db, block))
+		require.NoError(tb, blocks.SetValid(db, block.ID()))
+		require.NoError(tb, layers.SetApplied(db, lid, block.ID()))
+		if lid == st.lid {
+			st.block = block
+		}
+		require.NoError(tb, layers.SetProcessed(db, lid))
+		require.NoError(tb, layers.UpdateStateHash(db, lid, root))
+		require.NoError(tb, layers.SetHashes(db, lid, types.Hash32{1}, types.Hash32{2}))
+		if !lid.After(st.lid) {
+			st.accounts = append(st.accounts, changed...)
+		}
+	}
+	// published in the epoch 0 is not needed, in epochs 1 and 2 are needed.
+	addATX(tb, db, types.NewLayerID(1))
+	for _, lid := range []types.LayerID{types.NewLayerID(5), types.NewLayerID(9), types.NewLayerID(10)} {
+		st.atxs = append(st.atxs, addATX(tb, db, lid).ID())
+	}
+	// reference ballot from the earlier layer in the epoch and ballots from the checkpoint layer are needed,
+	// other ballots from earlier layers are not.
+	ref := addBallot(tb, db, types.NewLayerID(8), st.atxs[0], nil)
+	addBallot(tb, db, types.NewLayerID(9), st.atxs[0], ref)
+	st.ballots = append(st.ballots,
+		ref,
+		addBallot(tb, db, st.lid, st.atxs[0], ref),
+		addBallot(tb, db, st.lid, st.atxs[1], nil),
+	)
+	st.beacon = types.Beacon{1, 2, 3, 4}
+	require.NoError(tb, beacons.Add(db, st.lid.GetEpoch(), st.beacon))
+	return st
+}
+
+func TestExportImport(t *testing.T) {
+	genesis := types.Hash20{1}
+	db := sql.InMemory()
+	st := genState(t, db)
+
+	var buf bytes.Buffer
+	exported, err := Export(db, st.lid, genesis, &buf)
+	require.NoError(t, err)
+	require.EqualValues(t, len(st.accounts), exported.Accounts)
+	require.EqualValues(t, len(st.atxs), exported.ATXs)
+	require.EqualValues(t, len(st.ballots), exported.Ballots)
+	require.NotEqual(t, types.Hash32{}, exported.Opinion)
+
+	imported := sql.InMemory()
+	header, err := Import(context.Background(), imported, genesis, exported.StateRoot, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, exported, header)
+
+	applied, err := layers.GetLastApplied(imported)
+	require.NoError(t, err)
+	require.Equal(t, st.lid, applied)
+	processed, err := layers.GetProcessed(imported)
+	require.NoError(t, err)
+	require.Equal(t, st.lid, processed)
+	root, err := layers.GetStateHash(imported, st.lid)
+	require.NoError(t, err)
+	require.Equal(t, exported.StateRoot, root)
+	aggregated, err := layers.GetAggregatedHash(imported, st.lid)
+	require.NoError(t, err)
+	require.Equal(t, types.Hash32{2}, aggregated)
+	lid, opinion, err := kvstore.GetImportedCheckpoint(imported)
+	require.NoError(t, err)
+	require.Equal(t, st.lid, lid)
+	require.Equal(t, exported.Opinion, opinion)
+
+	all, err := accounts.All(imported)
+	require.NoError(t, err)
+	require.ElementsMatch(t, st.accounts, all)
+	for _, id := range st.atxs {
+		expected, err := atxs.Get(db, id)
+		require.NoError(t, err)
+		atx, err := atxs.Get(imported, id)
+		require.NoError(t, err)
+		require.Equal(t, expected, atx)
+	}
+	beacon, err := beacons.Get(imported, st.lid.GetEpoch())
+	require.NoError(t, err)
+	require.Equal(t, st.beacon, beacon)
+	for _, expected := range st.ballots {
+		ballot, err := ballots.Get(imported, expected.ID())
+		require.NoError(t, err)
+		require.Equal(t, expected, ballot)
+	}
+	block, err := blocks.Get(imported, st.block.ID())
+	require.NoError(t, err)
+	require.Equal(t, st.block, block)
+	valid, err := blocks.IsValid(imported, st.block.ID())
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	_, err = Import(context.Background(), imported, genesis, exported.StateRoot, bytes.NewReader(buf.Bytes()))
+	require.ErrorIs(t, err, ErrNotEmpty)
+}
+
+func TestImportRejected(t *testing.T) {
+	genesis := types.Hash20{1}
+	db := sql.InMemory()
+	st := genState(t, db)
+
+	var buf bytes.Buffer
+	exported, err := Export(db, st.lid, genesis, &buf)
+	require.NoError(t, err)
+
+	t.Run("genesis", func(t *testing.T) {
+		_, err := Import(context.Background(), sql.InMemory(), types.Hash20{2}, exported.StateRoot, bytes.NewReader(buf.Bytes()))
+		require.ErrorIs(t, err, ErrGenesisMismatch)
+	})
+	t.Run("untrusted state root", func(t *testing.T) {
+		_, err := Import(context.Background(), sql.InMemory(), genesis, types.Hash32{0xff}, bytes.NewReader(buf.Bytes()))
+		require.ErrorIs(t, err, ErrStateMismatch)
+	})
+	t.Run("accounts", func(t *testing.T) {
+		require.NoError(t, layers.UpdateStateHash(db, st.lid, types.Hash32{0xff}))
+		var tampered bytes.Buffer
+		_, err := Export(db, st.lid, genesis, &tampered)
+		require.NoError(t, err)
+
+		imported := sql.InMemory()
+		_, err = Import(context.Background(), imported, genesis, types.Hash32{0xff}, &tampered)
+		require.ErrorIs(t, err, ErrStateMismatch)
+		applied, err := layers.GetLastApplied(imported)
+		require.NoError(t, err)
+		require.Equal(t, types.LayerID{}, applied)
+	})
+}
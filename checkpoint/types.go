@@ -0,0 +1,48 @@
+package checkpoint
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+//go:generate scalegen -types Header,EpochBeacon,ATX
+
+// Header of the checkpoint. Header is followed by Accounts accounts, ATXs activations,
+// Ballots ballots and the applied Block unless it is empty, each encoded separately.
+type Header struct {
+	Version   uint32
+	GenesisID types.Hash20
+	// Layer is the last applied layer in the checkpoint.
+	Layer types.LayerID
+	// Block is the block applied in the Layer.
+	Block types.BlockID
+	// StateRoot is the root of the state trie after the Layer was applied.
+	StateRoot types.Hash32
+	// Hash and AggregatedHash of the Layer, required to continue building the mesh
+	// from the checkpoint.
+	Hash           types.Hash32
+	AggregatedHash types.Hash32
+	// Opinion of the tortoise about layers before the Layer. Ballots in the Layer
+	// vote with this opinion, and the tortoise continues from it.
+	Opinion  types.Hash32
+	Beacons  []EpochBeacon
+	Accounts uint64
+	ATXs     uint64
+	// Ballots in the Layer and reference ballots of the epoch that were published before it.
+	Ballots uint64
+}
+
+// EpochBeacon is a beacon for the epoch.
+type EpochBeacon struct {
+	Epoch  types.EpochID
+	Beacon types.Beacon
+}
+
+// ATX is an encoded activation together with data that is computed by the node
+// when activation is validated.
+type ATX struct {
+	ATX            []byte
+	BaseTickHeight uint64
+	TickCount      uint64
+	// Timestamp when activation was received, in unix nanoseconds.
+	Timestamp uint64
+}
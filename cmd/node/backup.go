@@ -0,0 +1,98 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/gofrs/flock"
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/backup"
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// backupCommand creates snapshot of the state database. It is safe to run it while node is running.
+func backupCommand(node *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup <dir>",
+		Short: "Create snapshot of the state database in the dir, node may be running",
+		Args:  cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			conf, err := loadConfig(node)
+			if err != nil {
+				log.With().Fatal("failed to initialize config", log.Err(err))
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			manifest, err := backupDatabase(ctx, conf, args[0])
+			if err != nil {
+				log.With().Fatal("failed to create snapshot", log.Err(err))
+			}
+			log.With().Info("snapshot created",
+				log.String("dir", args[0]),
+				log.Stringer("genesis_id", manifest.GenesisID),
+				log.Uint32("layer", manifest.Layer),
+				log.Stringer("state_hash", manifest.StateHash),
+			)
+		},
+	}
+}
+
+// restoreCommand replaces the state database with the snapshot. Node must be stopped.
+func restoreCommand(node *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <dir>",
+		Short: "Replace the state database with the snapshot from the dir, node must be stopped",
+		Args:  cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			conf, err := loadConfig(node)
+			if err != nil {
+				log.With().Fatal("failed to initialize config", log.Err(err))
+			}
+			manifest, err := restoreDatabase(context.Background(), conf, args[0])
+			if err != nil {
+				log.With().Fatal("failed to restore snapshot", log.Err(err))
+			}
+			log.With().Info("snapshot restored",
+				log.String("dir", args[0]),
+				log.Uint32("layer", manifest.Layer),
+				log.Stringer("state_hash", manifest.StateHash),
+			)
+		},
+	}
+}
+
+func backupDatabase(ctx context.Context, conf *config.Config, dir string) (*backup.Manifest, error) {
+	path := filepath.Join(conf.DataDir(), dbFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("state database %s: %w", path, err)
+	}
+	// running node is responsible for migrations
+	db, err := sql.Open("file:"+path, sql.WithMigrations(nil), sql.WithConnections(1))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite db %w", err)
+	}
+	defer db.Close()
+	return backup.Backup(ctx, db, dir, conf.Genesis.GenesisID())
+}
+
+func restoreDatabase(ctx context.Context, conf *config.Config, dir string) (*backup.Manifest, error) {
+	if err := os.MkdirAll(conf.DataDir(), 0o700); err != nil {
+		return nil, fmt.Errorf("ensure folders exist: %w", err)
+	}
+	fl := flock.New(filepath.Join(conf.DataDir(), lockFile))
+	locked, err := fl.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("flock %s: %w", fl.Path(), err)
+	} else if !locked {
+		return nil, fmt.Errorf("node must be stopped before restore (locking file %s)", fl.Path())
+	}
+	defer fl.Unlock()
+	return backup.Restore(ctx, dir, filepath.Join(conf.DataDir(), dbFile), conf.Genesis.GenesisID())
+}
@@ -0,0 +1,171 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+)
+
+// exportCheckpointCommand writes checkpoint of the state database. It is safe to run it while node is running.
+func exportCheckpointCommand(node *cobra.Command) *cobra.Command {
+	var layer uint32
+	c := &cobra.Command{
+		Use:   "export-checkpoint <file>",
+		Short: "Export checkpoint of the state at the applied layer to the file, node may be running",
+		Args:  cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			conf, err := loadConfig(node)
+			if err != nil {
+				log.With().Fatal("failed to initialize config", log.Err(err))
+			}
+			types.SetLayersPerEpoch(conf.LayersPerEpoch)
+			header, err := exportCheckpoint(conf.DataDir(), types.NewLayerID(layer), conf.Genesis.GenesisID(), args[0])
+			if err != nil {
+				log.With().Fatal("failed to export checkpoint", log.Err(err))
+			}
+			log.With().Info("checkpoint exported",
+				log.String("file", args[0]),
+				header.Layer,
+				log.Stringer("state_hash", header.StateRoot),
+				log.Uint64("accounts", header.Accounts),
+				log.Uint64("atxs", header.ATXs),
+				log.Uint64("ballots", header.Ballots),
+			)
+		},
+	}
+	c.Flags().Uint32Var(&layer, "layer", 0, "layer of the checkpoint, last applied layer if not set")
+	return c
+}
+
+func exportCheckpoint(dataDir string, lid types.LayerID, genesis types.Hash20, file string) (*checkpoint.Header, error) {
+	path := filepath.Join(dataDir, dbFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("state database %s: %w", path, err)
+	}
+	db, err := sql.Open("file:"+path, sql.WithMigrations(nil), sql.WithConnections(1))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite db %w", err)
+	}
+	defer db.Close()
+	// read transaction keeps the checkpoint consistent while node is writing
+	tx, err := db.Tx(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Release()
+	if lid == (types.LayerID{}) {
+		if lid, err = layers.GetLastApplied(tx); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create checkpoint file: %w", err)
+	}
+	header, err := checkpoint.Export(tx, lid, genesis, f)
+	if err != nil {
+		f.Close()
+		os.Remove(file)
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close checkpoint file: %w", err)
+	}
+	return header, nil
+}
+
+func parseStateRoot(root string) (types.Hash32, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(root, "0x"))
+	if err != nil {
+		return types.Hash32{}, fmt.Errorf("decode checkpoint state root: %w", err)
+	}
+	if len(decoded) != types.Hash32Length {
+		return types.Hash32{}, fmt.Errorf("checkpoint state root must be %d bytes, got %d", types.Hash32Length, len(decoded))
+	}
+	return types.BytesToHash(decoded), nil
+}
+
+// importCheckpoint imports checkpoint into the empty database and seeds the tortoise
+// with the state at the layer of the checkpoint.
+func (app *App) importCheckpoint(ctx context.Context, logger log.Log, cdb *datastore.CachedDB, trtlCfg tortoise.Config) error {
+	if app.Config.CheckpointStateRoot == "" {
+		return errors.New("checkpoint state root is required to import checkpoint")
+	}
+	root, err := parseStateRoot(app.Config.CheckpointStateRoot)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(app.Config.CheckpointFile)
+	if err != nil {
+		return fmt.Errorf("open checkpoint file: %w", err)
+	}
+	defer f.Close()
+	header, err := checkpoint.Import(ctx, app.db, app.Config.Genesis.GenesisID(), root, f)
+	if errors.Is(err, checkpoint.ErrNotEmpty) {
+		logger.With().Info("checkpoint is not imported into non-empty database",
+			log.String("file", app.Config.CheckpointFile),
+			log.Err(err),
+		)
+		return reseedTortoise(logger, cdb, trtlCfg)
+	} else if err != nil {
+		return fmt.Errorf("import checkpoint %s: %w", app.Config.CheckpointFile, err)
+	}
+	if err := tortoise.Seed(cdb, trtlCfg, header.Layer, header.Opinion); err != nil {
+		return fmt.Errorf("seed tortoise from checkpoint %s: %w", app.Config.CheckpointFile, err)
+	}
+	logger.With().Info("checkpoint imported",
+		log.String("file", app.Config.CheckpointFile),
+		header.Layer,
+		log.Stringer("state_hash", header.StateRoot),
+		log.Uint64("accounts", header.Accounts),
+		log.Uint64("atxs", header.ATXs),
+		log.Uint64("ballots", header.Ballots),
+	)
+	return nil
+}
+
+// reseedTortoise seeds the tortoise if the checkpoint was imported, but the node stopped before
+// the tortoise was seeded. Import and seed are not done in one transaction, and the database
+// is not empty on the next start.
+func reseedTortoise(logger log.Log, cdb *datastore.CachedDB, trtlCfg tortoise.Config) error {
+	lid, opinion, err := kvstore.GetImportedCheckpoint(cdb)
+	if err != nil {
+		return err
+	}
+	if lid == (types.LayerID{}) {
+		return nil
+	}
+	processed, err := layers.GetProcessed(cdb)
+	if err != nil {
+		return err
+	}
+	if processed != lid {
+		return nil
+	}
+	var cp tortoise.Checkpoint
+	if err := kvstore.GetTortoiseCheckpoint(cdb, &cp); err == nil {
+		return nil
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return err
+	}
+	if err := tortoise.Seed(cdb, trtlCfg, lid, opinion); err != nil {
+		return fmt.Errorf("seed tortoise from imported checkpoint: %w", err)
+	}
+	logger.With().Info("tortoise seeded from previously imported checkpoint", lid)
+	return nil
+}
@@ -0,0 +1,50 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+)
+
+func TestReseedTortoise(t *testing.T) {
+	types.SetLayersPerEpoch(4)
+	lid := types.GetEffectiveGenesis().Add(10)
+	seeded := func(cdb *datastore.CachedDB) bool {
+		var cp tortoise.Checkpoint
+		err := kvstore.GetTortoiseCheckpoint(cdb, &cp)
+		if err == nil {
+			return true
+		}
+		require.ErrorIs(t, err, sql.ErrNotFound)
+		return false
+	}
+
+	t.Run("not imported", func(t *testing.T) {
+		cdb := datastore.NewCachedDB(sql.InMemory(), logtest.New(t))
+		require.NoError(t, layers.SetProcessed(cdb, lid))
+		require.NoError(t, reseedTortoise(logtest.New(t), cdb, tortoise.DefaultConfig()))
+		require.False(t, seeded(cdb))
+	})
+	t.Run("imported but not seeded", func(t *testing.T) {
+		cdb := datastore.NewCachedDB(sql.InMemory(), logtest.New(t))
+		require.NoError(t, layers.SetProcessed(cdb, lid))
+		require.NoError(t, kvstore.SetImportedCheckpoint(cdb, lid, types.RandomHash()))
+		require.NoError(t, reseedTortoise(logtest.New(t), cdb, tortoise.DefaultConfig()))
+		require.True(t, seeded(cdb))
+	})
+	t.Run("processed after import", func(t *testing.T) {
+		cdb := datastore.NewCachedDB(sql.InMemory(), logtest.New(t))
+		require.NoError(t, layers.SetProcessed(cdb, lid.Add(1)))
+		require.NoError(t, kvstore.SetImportedCheckpoint(cdb, lid, types.RandomHash()))
+		require.NoError(t, reseedTortoise(logtest.New(t), cdb, tortoise.DefaultConfig()))
+		require.False(t, seeded(cdb))
+	})
+}
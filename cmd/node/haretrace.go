@@ -0,0 +1,59 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// mergeHareTracesCommand merges hare traces recorded by several nodes (see hare-trace-dir)
+// into a single timeline of every hare instance.
+func mergeHareTracesCommand() *cobra.Command {
+	var layer int64
+	c := &cobra.Command{
+		Use:   "merge-hare-traces <dir or file>...",
+		Short: "Merge hare traces recorded by several nodes into a single timeline of every hare instance",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			timelines, err := hare.MergeTraces(args...)
+			if err != nil {
+				log.With().Fatal("failed to merge hare traces", log.Err(err))
+			}
+			out := bufio.NewWriter(os.Stdout)
+			defer out.Flush()
+			if err := writeHareTimelines(out, timelines, layer); err != nil {
+				log.With().Fatal("failed to write hare timelines", log.Err(err))
+			}
+		},
+	}
+	c.Flags().Int64Var(&layer, "layer", -1, "print only the timeline of the layer")
+	return c
+}
+
+func writeHareTimelines(w io.Writer, timelines []hare.TraceTimeline, layer int64) error {
+	for _, timeline := range timelines {
+		if layer >= 0 && int64(timeline.Layer.Value) != layer {
+			continue
+		}
+		nodes := make([]string, 0, len(timeline.Nodes))
+		for _, node := range timeline.Nodes {
+			nodes = append(nodes, node.ShortString())
+		}
+		if _, err := fmt.Fprintf(w, "layer %d nodes=%s\n", timeline.Layer.Value, strings.Join(nodes, ",")); err != nil {
+			return err
+		}
+		for i := range timeline.Entries {
+			if _, err := fmt.Fprintf(w, "  %s\n", timeline.Entries[i].String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/api"
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
 	"github.com/spacemeshos/go-spacemesh/beacon"
 	"github.com/spacemeshos/go-spacemesh/blocks"
@@ -42,14 +43,17 @@ import (
 	"github.com/spacemeshos/go-spacemesh/hare/eligibility"
 	"github.com/spacemeshos/go-spacemesh/layerpatrol"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/malfeasance"
 	"github.com/spacemeshos/go-spacemesh/mesh"
 	"github.com/spacemeshos/go-spacemesh/metrics"
 	"github.com/spacemeshos/go-spacemesh/miner"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/proposals"
+	"github.com/spacemeshos/go-spacemesh/prune"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
 	dbmetrics "github.com/spacemeshos/go-spacemesh/sql/metrics"
 	"github.com/spacemeshos/go-spacemesh/syncer"
 	"github.com/spacemeshos/go-spacemesh/system"
@@ -64,6 +68,7 @@ const (
 	edKeyFileName   = "key.bin"
 	genesisFileName = "genesis.json"
 	lockFile        = "LOCK"
+	dbFile          = "state.sql"
 )
 
 // Logger names.
@@ -94,6 +99,10 @@ const (
 	GRPCLogger             = "grpc"
 	ConStateLogger         = "conState"
 	Executor               = "executor"
+	PrunerLogger           = "pruner"
+	MalfeasanceLogger      = "malfeasance"
+	PoetLogger             = "poet"
+	LocalPoetLogger        = "localPoet"
 )
 
 func GetCommand() *cobra.Command {
@@ -118,6 +127,13 @@ func GetCommand() *cobra.Command {
 					events.EventHook())),
 			)
 
+			if conf.DatabaseMigrateDryRun || conf.DatabaseRollbackTo >= 0 {
+				if err := app.MigrateDatabase(); err != nil {
+					log.With().Fatal("failed to migrate database", log.Err(err))
+				}
+				return
+			}
+
 			run := func(ctx context.Context) error {
 				if err = app.Initialize(); err != nil {
 					return err
@@ -164,6 +180,12 @@ func GetCommand() *cobra.Command {
 		},
 	}
 	c.AddCommand(&versionCmd)
+	c.AddCommand(backupCommand(c))
+	c.AddCommand(restoreCommand(c))
+	c.AddCommand(exportCheckpointCommand(c))
+	c.AddCommand(replayTortoiseCommand(c))
+	c.AddCommand(postServiceCommand(c))
+	c.AddCommand(mergeHareTracesCommand())
 
 	return c
 }
@@ -275,6 +297,45 @@ func New(opts ...Option) *App {
 	return app
 }
 
+// smesher is a smeshing identity of the node and the components that act on its behalf.
+type smesher struct {
+	signer    *signing.EdSigner
+	vrfSigner *signing.VRFSigner
+	coinbase  string
+	opts      activation.PostSetupOpts
+	// postService is an address of the remote post service, post data is stored locally if empty.
+	postService      string
+	postServiceToken string
+
+	postSetupMgr    postSetupProvider
+	atxBuilder      *activation.Builder
+	proposalBuilder *miner.ProposalBuilder
+}
+
+// postSetupProvider is implemented by activation.PostSetupManager for the local post data
+// and by activation.HTTPPostClient for the data served by a remote post service.
+type postSetupProvider interface {
+	api.PostSetupProvider
+	StartSession(ctx context.Context, opts activation.PostSetupOpts, commitmentAtx types.ATXID) error
+	Reset() error
+	GenerateProof(challenge []byte) (*types.Post, *types.PostMetadata, error)
+	VRFNonce() (*types.VRFPostIndex, error)
+	LastOpts() *activation.PostSetupOpts
+}
+
+// atxBuilders updates poet servers for all smeshing identities of the node.
+type atxBuilders []*activation.Builder
+
+// UpdatePoETServers implements api.ActivationAPI.
+func (builders atxBuilders) UpdatePoETServers(ctx context.Context, endpoints []string) error {
+	for _, builder := range builders {
+		if err := builder.UpdatePoETServers(ctx, endpoints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // App is the cli app singleton.
 type App struct {
 	*cobra.Command
@@ -287,15 +348,14 @@ type App struct {
 	jsonAPIService   *grpcserver.JSONHTTPServer
 	syncer           *syncer.Syncer
 	proposalListener *proposals.Handler
-	proposalBuilder  *miner.ProposalBuilder
 	mesh             *mesh.Mesh
 	atxDB            datastore.CachedDB
 	clock            TickProvider
 	hare             *hare.Hare
 	blockGen         *blocks.Generator
 	certifier        *blocks.Certifier
-	postSetupMgr     *activation.PostSetupManager
-	atxBuilder       *activation.Builder
+	pruner           *prune.Pruner
+	smeshers         []*smesher
 	atxHandler       *activation.Handler
 	edSgn            *signing.EdSigner
 	keyExtractor     *signing.PubKeyExtractor
@@ -372,6 +432,17 @@ func (app *App) Initialize() (err error) {
 		return errors.New("incompatible tortoise hare params")
 	}
 
+	// tortoise may need to recount ballots within hdist, and hare output within zdist
+	if retention := app.Config.PRUNE.Retention; retention != 0 &&
+		(retention < app.Config.Tortoise.Hdist || retention < app.Config.Tortoise.Zdist) {
+		log.With().Error("incompatible params",
+			log.Uint32("prune_retention", retention),
+			log.Uint32("tortoise_hdist", app.Config.Tortoise.Hdist),
+			log.Uint32("tortoise_zdist", app.Config.Tortoise.Zdist))
+
+		return errors.New("prune retention must not be lower than tortoise hdist and zdist")
+	}
+
 	// override default config in timesync since timesync is using TimeConfigValues
 	timeCfg.TimeConfigValues = app.Config.TIME
 
@@ -456,33 +527,113 @@ func (app *App) SetLogLevel(name, loglevel string) error {
 	return nil
 }
 
+// MigrateDatabase either reports pending migrations of the state database
+// or rolls it back to the configured version, without starting the node.
+func (app *App) MigrateDatabase() error {
+	path := filepath.Join(app.Config.DataDir(), dbFile)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("state database %s: %w", path, err)
+	}
+	sqlDB, err := sql.Open("file:"+path, sql.WithMigrations(nil))
+	if err != nil {
+		return fmt.Errorf("open sqlite db %w", err)
+	}
+	defer sqlDB.Close()
+	migrations, err := sql.EmbeddedMigrations()
+	if err != nil {
+		return err
+	}
+	tx, err := sqlDB.Tx(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Release()
+
+	if app.Config.DatabaseRollbackTo >= 0 {
+		if err := sql.Rollback(tx, migrations, app.Config.DatabaseRollbackTo); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		app.log.With().Info("database rolled back", log.Int("version", app.Config.DatabaseRollbackTo))
+		return nil
+	}
+	// transaction is not committed, dry run leaves database untouched
+	pending, err := sql.Pending(tx, migrations)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		app.log.Info("database is up to date")
+	}
+	for _, m := range pending {
+		app.log.With().Info("pending migration",
+			log.Int("version", m.Order()),
+			log.String("name", m.Name()),
+		)
+	}
+	return nil
+}
+
+// initServices initializes services of the node. The first of smeshers is the node identity,
+// the rest are smeshing alongside it.
 func (app *App) initServices(ctx context.Context,
-	nodeID types.NodeID,
 	dbStorepath string,
-	sgn *signing.EdSigner,
+	smeshers []*smesher,
 	layerSize uint32,
 	poetClients []activation.PoetProvingServiceClient,
-	vrfSigner *signing.VRFSigner,
 	layersPerEpoch uint32, clock TickProvider,
 ) error {
+	sgn := smeshers[0].signer
+	vrfSigner := smeshers[0].vrfSigner
+	nodeID := sgn.NodeID()
 	app.nodeID = nodeID
+	app.edSgn = sgn
 
 	lg := app.log.Named(nodeID.ShortString()).WithFields(nodeID)
 	types.SetLayersPerEpoch(app.Config.LayersPerEpoch)
 
 	app.log = app.addLogger(AppLogger, lg)
 
-	sqlDB, err := sql.Open("file:" + filepath.Join(dbStorepath, "state.sql"))
+	sqlDB, err := sql.Open("file:" + filepath.Join(dbStorepath, dbFile))
 	if err != nil {
 		return fmt.Errorf("open sqlite db %w", err)
 	}
 	app.db = sqlDB
+	// nipost state of the node was stored without identity before multiple smeshers were supported
+	if err := sqlDB.WithTx(ctx, func(tx *sql.Tx) error {
+		return kvstore.MigrateLegacyNIPost(tx, nodeID)
+	}); err != nil {
+		return fmt.Errorf("migrate nipost state: %w", err)
+	}
 	if app.Config.CollectMetrics {
 		app.dbMetrics = dbmetrics.NewDBMetricsCollector(ctx, sqlDB, app.addLogger(StateDbLogger, lg), 5*time.Minute)
 	}
 
 	cdb := datastore.NewCachedDB(sqlDB, app.addLogger(CachedDBLogger, lg))
 	app.atxDB = *cdb
+
+	app.keyExtractor, err = signing.NewPubKeyExtractor(
+		signing.WithExtractorPrefix(app.Config.Genesis.GenesisID().Bytes()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create key extractor: %w", err)
+	}
+
+	types.ExtractNodeIDFromSig = app.keyExtractor.ExtractNodeID
+
+	trtlCfg := app.Config.Tortoise
+	trtlCfg.LayerSize = layerSize
+	trtlCfg.BadBeaconVoteDelayLayers = app.Config.LayersPerEpoch
+	if app.Config.CheckpointFile != "" {
+		// ballots and activations in the checkpoint are signed, therefore it is imported
+		// after the key extractor is configured.
+		if err := app.importCheckpoint(ctx, lg, cdb, trtlCfg); err != nil {
+			return err
+		}
+	}
+
 	poetDb := activation.NewPoetDb(sqlDB, app.addLogger(PoetDbLogger, lg))
 	validator := activation.NewValidator(poetDb, app.Config.POST)
 
@@ -500,6 +651,8 @@ func (app *App) initServices(ctx context.Context,
 		txs.WithCSConfig(txs.CSConfig{
 			BlockGasLimit:     app.Config.BlockGasLimit,
 			NumTXsPerProposal: app.Config.TxsPerProposal,
+			ReplaceBump:       app.Config.MempoolReplaceBump,
+			MaxMempoolSize:    app.Config.MempoolMaxSize,
 		}),
 		txs.WithLogger(app.addLogger(ConStateLogger, lg)))
 
@@ -521,15 +674,20 @@ func (app *App) initServices(ctx context.Context,
 		return errors.New("invalid golden atx id")
 	}
 
-	app.keyExtractor, err = signing.NewPubKeyExtractor(
-		signing.WithExtractorPrefix(app.Config.Genesis.GenesisID().Bytes()),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create key extractor: %w", err)
+	if app.Config.POET.Local {
+		verifier := activation.NewChallengeVerifier(cdb, app.keyExtractor, app.Config.POST, goldenATXID, layersPerEpoch)
+		poetClients = append(poetClients, activation.NewLocalPoet(
+			types.PoetServiceID(types.CalcHash32([]byte("local poet")).Bytes()),
+			verifier,
+			sqlDB,
+			app.Config.POET,
+			clock.LayerToTime(types.NewLayerID(0)),
+			time.Duration(layersPerEpoch)*time.Duration(app.Config.LayerDurationSec)*time.Second,
+			activation.WithLocalPoetLeaves(app.Config.POET.LocalLeaves),
+			activation.WithLocalPoetLogger(app.addLogger(LocalPoetLogger, lg)),
+		))
 	}
 
-	types.ExtractNodeIDFromSig = app.keyExtractor.ExtractNodeID
-
 	vrfVerifier, err := signing.NewVRFVerifier(signing.WithNonceFromDB(cdb))
 	if err != nil {
 		return fmt.Errorf("failed to create vrf verifier: %w", err)
@@ -540,9 +698,6 @@ func (app *App) initServices(ctx context.Context,
 		beacon.WithConfig(app.Config.Beacon),
 		beacon.WithLogger(app.addLogger(BeaconLogger, lg)))
 
-	trtlCfg := app.Config.Tortoise
-	trtlCfg.LayerSize = layerSize
-	trtlCfg.BadBeaconVoteDelayLayers = app.Config.LayersPerEpoch
 	trtl := tortoise.New(cdb, beaconProtocol,
 		tortoise.WithContext(ctx),
 		tortoise.WithLogger(app.addLogger(TrtlLogger, lg)),
@@ -556,7 +711,9 @@ func (app *App) initServices(ctx context.Context,
 	}
 
 	fetcherWrapped := &layerFetcher{}
-	atxHandler := activation.NewHandler(cdb, fetcherWrapped, layersPerEpoch, app.Config.TickSize, goldenATXID, validator, trtl, app.addLogger(ATXHandlerLogger, lg))
+	malfeasanceHandler := malfeasance.NewHandler(cdb, app.host.ID(),
+		malfeasance.WithLogger(app.addLogger(MalfeasanceLogger, lg)))
+	atxHandler := activation.NewHandler(cdb, fetcherWrapped, app.host, layersPerEpoch, app.Config.TickSize, goldenATXID, validator, trtl, app.addLogger(ATXHandlerLogger, lg))
 
 	// we can't have an epoch offset which is greater/equal than the number of layers in an epoch
 
@@ -565,7 +722,7 @@ func (app *App) initServices(ctx context.Context,
 			app.Config.HareEligibility.EpochOffset, app.Config.BaseConfig.LayersPerEpoch)
 	}
 
-	proposalListener := proposals.NewHandler(cdb, fetcherWrapped, beaconProtocol, msh, trtl,
+	proposalListener := proposals.NewHandler(cdb, app.host, fetcherWrapped, beaconProtocol, msh, trtl,
 		proposals.WithLogger(app.addLogger(ProposalListenerLogger, lg)),
 		proposals.WithConfig(proposals.Config{
 			LayerSize:      layerSize,
@@ -580,7 +737,8 @@ func (app *App) initServices(ctx context.Context,
 
 	txHandler := txs.NewTxHandler(app.conState, app.addLogger(TxHandlerLogger, lg))
 
-	hOracle := eligibility.New(beaconProtocol, cdb, vrfVerifier, vrfSigner, app.Config.LayersPerEpoch, app.Config.HareEligibility, app.addLogger(HareOracleLogger, lg))
+	hareOracleLogger := app.addLogger(HareOracleLogger, lg)
+	hOracle := eligibility.New(beaconProtocol, cdb, vrfVerifier, vrfSigner, app.Config.LayersPerEpoch, app.Config.HareEligibility, hareOracleLogger)
 	// TODO: genesisMinerWeight is set to app.Config.SpaceToCommit, because PoET ticks are currently hardcoded to 1
 
 	app.certifier = blocks.NewCertifier(sqlDB, hOracle, nodeID, sgn, app.host, clock, beaconProtocol, trtl,
@@ -593,6 +751,11 @@ func (app *App) initServices(ctx context.Context,
 		}),
 		blocks.WithCertifierLogger(app.addLogger(BlockCertLogger, lg)))
 
+	app.pruner = prune.New(sqlDB, clock, trtl,
+		prune.WithContext(ctx),
+		prune.WithConfig(app.Config.PRUNE),
+		prune.WithLogger(app.addLogger(PrunerLogger, lg)))
+
 	fetcher := fetch.NewFetch(cdb, msh, beaconProtocol, app.host,
 		fetch.WithContext(ctx),
 		fetch.WithConfig(app.Config.FETCH),
@@ -603,6 +766,7 @@ func (app *App) initServices(ctx context.Context,
 		fetch.WithProposalHandler(proposalListener),
 		fetch.WithTXHandler(txHandler),
 		fetch.WithPoetHandler(poetDb),
+		fetch.WithMalfeasanceHandler(malfeasanceHandler),
 	)
 	fetcherWrapped.Fetcher = fetcher
 
@@ -649,54 +813,79 @@ func (app *App) initServices(ctx context.Context,
 		clock,
 		app.addLogger(HareLogger, lg))
 
-	proposalBuilder := miner.NewProposalBuilder(
-		ctx,
-		clock.Subscribe(),
-		sgn,
-		vrfSigner,
-		cdb,
-		app.host,
-		trtl,
-		beaconProtocol,
-		newSyncer,
-		app.conState,
-		miner.WithMinerID(nodeID),
-		miner.WithLayerSize(layerSize),
-		miner.WithLayerPerEpoch(layersPerEpoch),
-		miner.WithHdist(app.Config.Tortoise.Hdist),
-		miner.WithLogger(app.addLogger(ProposalBuilderLogger, lg)))
-
-	postSetupMgr, err := activation.NewPostSetupManager(nodeID, app.Config.POST, app.addLogger(PostLogger, lg), cdb, goldenATXID)
-	if err != nil {
-		app.log.Panic("failed to create post setup manager: %v", err)
-	}
-
-	nipostBuilder := activation.NewNIPostBuilder(nodeID, postSetupMgr, poetClients, poetDb, sqlDB, app.addLogger(NipostBuilderLogger, lg), sgn)
+	var (
+		proposalBuilderLogger = app.addLogger(ProposalBuilderLogger, lg)
+		postLogger            = app.addLogger(PostLogger, lg)
+		nipostBuilderLogger   = app.addLogger(NipostBuilderLogger, lg)
+		atxBuilderLogger      = app.addLogger("atxBuilder", lg)
+	)
+	for i, s := range smeshers {
+		id := s.signer.NodeID()
+		field := log.String("smesher", id.ShortString())
+		if i > 0 {
+			// the node identity uses the oracle that validates eligibilities of other smeshers
+			oracle := eligibility.New(beaconProtocol, cdb, vrfVerifier, s.vrfSigner, app.Config.LayersPerEpoch, app.Config.HareEligibility,
+				hareOracleLogger.WithFields(field))
+			app.hare.AddIdentity(s.signer, id, oracle)
+			app.certifier.AddIdentity(s.signer, oracle)
+		}
 
-	var coinbaseAddr types.Address
-	if app.Config.SMESHING.Start {
-		coinbaseAddr, err = types.StringToAddress(app.Config.SMESHING.CoinbaseAccount)
-		if err != nil {
-			app.log.Panic("failed to parse CoinbaseAccount address `%s`: %v", app.Config.SMESHING.CoinbaseAccount, err)
+		s.proposalBuilder = miner.NewProposalBuilder(
+			ctx,
+			clock.Subscribe(),
+			s.signer,
+			s.vrfSigner,
+			cdb,
+			app.host,
+			trtl,
+			beaconProtocol,
+			newSyncer,
+			app.conState,
+			miner.WithMinerID(id),
+			miner.WithLayerSize(layerSize),
+			miner.WithLayerPerEpoch(layersPerEpoch),
+			miner.WithHdist(app.Config.Tortoise.Hdist),
+			miner.WithLogger(proposalBuilderLogger.WithFields(field)))
+
+		if s.postService != "" {
+			s.postSetupMgr = activation.NewHTTPPostClient(s.postService, id, activation.PostClientConfig{
+				Token:        s.postServiceToken,
+				ProofTimeout: app.Config.SMESHING.PostServiceProofTimeout,
+			}, postLogger.WithFields(field))
+		} else {
+			s.postSetupMgr, err = activation.NewPostSetupManager(id, app.Config.POST, postLogger.WithFields(field), cdb, goldenATXID)
+			if err != nil {
+				app.log.Panic("failed to create post setup manager: %v", err)
+			}
 		}
-		if coinbaseAddr.IsEmpty() {
-			app.log.Panic("invalid coinbase account")
+
+		nipostBuilder := activation.NewNIPostBuilder(id, s.postSetupMgr, poetClients, poetDb, sqlDB, nipostBuilderLogger.WithFields(field), s.signer)
+
+		var coinbaseAddr types.Address
+		if app.Config.SMESHING.Start {
+			coinbaseAddr, err = types.StringToAddress(s.coinbase)
+			if err != nil {
+				app.log.Panic("failed to parse CoinbaseAccount address `%s`: %v", s.coinbase, err)
+			}
+			if coinbaseAddr.IsEmpty() {
+				app.log.Panic("invalid coinbase account")
+			}
 		}
-	}
 
-	builderConfig := activation.Config{
-		CoinbaseAccount: coinbaseAddr,
-		GoldenATXID:     goldenATXID,
-		LayersPerEpoch:  layersPerEpoch,
+		builderConfig := activation.Config{
+			CoinbaseAccount: coinbaseAddr,
+			GoldenATXID:     goldenATXID,
+			LayersPerEpoch:  layersPerEpoch,
+		}
+		s.atxBuilder = activation.NewBuilder(builderConfig, id, s.signer, cdb, atxHandler, app.host, nipostBuilder,
+			s.postSetupMgr, clock, newSyncer, atxBuilderLogger.WithFields(field),
+			activation.WithContext(ctx),
+			activation.WithPoetConfig(activation.PoetConfig{
+				PhaseShift:  app.Config.POET.PhaseShift,
+				CycleGap:    app.Config.POET.CycleGap,
+				GracePeriod: app.Config.POET.GracePeriod,
+			}))
 	}
-	atxBuilder := activation.NewBuilder(builderConfig, nodeID, sgn, cdb, atxHandler, app.host, nipostBuilder,
-		postSetupMgr, clock, newSyncer, app.addLogger("atxBuilder", lg),
-		activation.WithContext(ctx),
-		activation.WithPoetConfig(activation.PoetConfig{
-			PhaseShift:  app.Config.POET.PhaseShift,
-			CycleGap:    app.Config.POET.CycleGap,
-			GracePeriod: app.Config.POET.GracePeriod,
-		}))
 
 	syncHandler := func(_ context.Context, _ p2p.Peer, _ []byte) pubsub.ValidationResult {
 		if newSyncer.ListenToGossip() {
@@ -713,26 +902,24 @@ func (app *App) initServices(ctx context.Context,
 	app.host.Register(pubsub.BeaconFollowingVotesProtocol,
 		pubsub.ChainGossipHandler(syncHandler, beaconProtocol.HandleFollowingVotes))
 	app.host.Register(pubsub.ProposalProtocol, pubsub.ChainGossipHandler(syncHandler, proposalListener.HandleProposal))
-	app.host.Register(pubsub.AtxProtocol, pubsub.ChainGossipHandler(
-		func(_ context.Context, _ p2p.Peer, _ []byte) pubsub.ValidationResult {
-			if newSyncer.ListenToATXGossip() {
-				return pubsub.ValidationAccept
-			}
-			return pubsub.ValidationIgnore
-		},
-		atxHandler.HandleGossipAtx))
+	atxSyncHandler := func(_ context.Context, _ p2p.Peer, _ []byte) pubsub.ValidationResult {
+		if newSyncer.ListenToATXGossip() {
+			return pubsub.ValidationAccept
+		}
+		return pubsub.ValidationIgnore
+	}
+	app.host.Register(pubsub.AtxProtocol, pubsub.ChainGossipHandler(atxSyncHandler, atxHandler.HandleGossipAtx))
 	app.host.Register(pubsub.TxProtocol, pubsub.ChainGossipHandler(syncHandler, txHandler.HandleGossipTransaction))
 	app.host.Register(pubsub.HareProtocol, pubsub.ChainGossipHandler(syncHandler, app.hare.GetHareMsgHandler()))
 	app.host.Register(pubsub.BlockCertify, pubsub.ChainGossipHandler(syncHandler, app.certifier.HandleCertifyMessage))
+	app.host.Register(pubsub.MalfeasanceProtocol, pubsub.ChainGossipHandler(atxSyncHandler, malfeasanceHandler.HandleMalfeasanceProof))
 
-	app.proposalBuilder = proposalBuilder
+	app.smeshers = smeshers
 	app.proposalListener = proposalListener
 	app.mesh = msh
 	app.syncer = newSyncer
 	app.clock = clock
 	app.svm = state
-	app.atxBuilder = atxBuilder
-	app.postSetupMgr = postSetupMgr
 	app.atxHandler = atxHandler
 	app.fetcher = fetcher
 	app.beaconProtocol = beaconProtocol
@@ -756,20 +943,25 @@ func (app *App) startServices(ctx context.Context) error {
 
 	app.blockGen.Start()
 	app.certifier.Start()
+	app.pruner.Start()
 	if err := app.hare.Start(ctx); err != nil {
 		return fmt.Errorf("cannot start hare: %w", err)
 	}
-	if err := app.proposalBuilder.Start(ctx); err != nil {
-		return fmt.Errorf("cannot start block producer: %w", err)
+	for _, s := range app.smeshers {
+		if err := s.proposalBuilder.Start(ctx); err != nil {
+			return fmt.Errorf("cannot start block producer: %w", err)
+		}
 	}
 
 	if app.Config.SMESHING.Start {
-		coinbaseAddr, err := types.StringToAddress(app.Config.SMESHING.CoinbaseAccount)
-		if err != nil {
-			app.log.Panic("failed to parse CoinbaseAccount address on start `%s`: %v", app.Config.SMESHING.CoinbaseAccount, err)
-		}
-		if err := app.atxBuilder.StartSmeshing(coinbaseAddr, app.Config.SMESHING.Opts); err != nil {
-			log.Panic("failed to start smeshing: %v", err)
+		for _, s := range app.smeshers {
+			coinbaseAddr, err := types.StringToAddress(s.coinbase)
+			if err != nil {
+				app.log.Panic("failed to parse CoinbaseAccount address on start `%s`: %v", s.coinbase, err)
+			}
+			if err := s.atxBuilder.StartSmeshing(coinbaseAddr, s.opts); err != nil {
+				log.Panic("failed to start smeshing: %v", err)
+			}
 		}
 	} else {
 		log.Info("smeshing not started, waiting to be triggered via smesher api")
@@ -813,7 +1005,7 @@ func (app *App) startAPIServices(ctx context.Context) {
 
 	// Register the requested services one by one
 	if apiConf.StartDebugService {
-		registerService(grpcserver.NewDebugService(app.conState, app.host))
+		registerService(grpcserver.NewDebugService(app.conState, app.host, app.tortoise))
 	}
 	if apiConf.StartGatewayService {
 		verifier := activation.NewChallengeVerifier(&app.atxDB, app.keyExtractor, app.Config.POST, types.ATXID(app.Config.Genesis.GenesisID().ToHash32()), app.Config.LayersPerEpoch)
@@ -826,11 +1018,20 @@ func (app *App) startAPIServices(ctx context.Context) {
 		registerService(grpcserver.NewMeshService(app.mesh, app.conState, app.clock, app.Config.LayersPerEpoch, app.Config.Genesis.GenesisID(), layerDuration, app.Config.LayerAvgSize, app.Config.TxsPerProposal))
 	}
 	if apiConf.StartNodeService {
-		nodeService := grpcserver.NewNodeService(app.host, app.mesh, app.clock, app.syncer, app.atxBuilder)
+		builders := make(atxBuilders, 0, len(app.smeshers))
+		for _, s := range app.smeshers {
+			builders = append(builders, s.atxBuilder)
+		}
+		nodeService := grpcserver.NewNodeService(app.host, app.mesh, app.clock, app.syncer, builders, app.tortoise)
 		registerService(nodeService)
 	}
 	if apiConf.StartSmesherService {
-		registerService(grpcserver.NewSmesherService(app.postSetupMgr, app.atxBuilder, apiConf.SmesherStreamInterval))
+		primary := app.smeshers[0]
+		svc := grpcserver.NewSmesherService(primary.postSetupMgr, primary.atxBuilder, primary.proposalBuilder, app.clock, apiConf.SmesherStreamInterval)
+		for _, s := range app.smeshers {
+			svc.AddIdentity(s.signer.NodeID(), s.postSetupMgr, s.atxBuilder, s.proposalBuilder)
+		}
+		registerService(svc)
 	}
 	if apiConf.StartTransactionService {
 		registerService(grpcserver.NewTransactionService(app.db, app.host, app.mesh, app.conState, app.syncer))
@@ -838,6 +1039,12 @@ func (app *App) startAPIServices(ctx context.Context) {
 	if apiConf.StartActivationService {
 		registerService(grpcserver.NewActivationService(&app.atxDB))
 	}
+	if apiConf.StartPeerService {
+		registerService(grpcserver.NewPeerService(app.host))
+	}
+	if apiConf.StartMempoolAdminService {
+		registerService(grpcserver.NewMempoolAdminService(app.conState))
+	}
 
 	// Now that the services are registered, start the server.
 	if app.grpcAPIService != nil {
@@ -869,9 +1076,11 @@ func (app *App) stopServices(ctx context.Context) {
 		_ = app.grpcAPIService.Close()
 	}
 
-	if app.proposalBuilder != nil {
-		app.log.Info("closing proposal builder")
-		app.proposalBuilder.Close()
+	for _, s := range app.smeshers {
+		if s.proposalBuilder != nil {
+			app.log.Info("closing proposal builder")
+			s.proposalBuilder.Close()
+		}
 	}
 
 	if app.clock != nil {
@@ -884,9 +1093,11 @@ func (app *App) stopServices(ctx context.Context) {
 		app.beaconProtocol.Close()
 	}
 
-	if app.atxBuilder != nil {
-		app.log.Info("closing atx builder")
-		_ = app.atxBuilder.StopSmeshing(false)
+	for _, s := range app.smeshers {
+		if s.atxBuilder != nil {
+			app.log.Info("closing atx builder")
+			_ = s.atxBuilder.StopSmeshing(false)
+		}
 	}
 
 	if app.hare != nil {
@@ -904,6 +1115,11 @@ func (app *App) stopServices(ctx context.Context) {
 		app.certifier.Stop()
 	}
 
+	if app.pruner != nil {
+		app.log.Info("stopping pruner")
+		app.pruner.Stop()
+	}
+
 	if app.fetcher != nil {
 		app.log.Info("closing layerFetch")
 		app.fetcher.Stop()
@@ -942,7 +1158,57 @@ func (app *App) stopServices(ctx context.Context) {
 
 // LoadOrCreateEdSigner either loads a previously created ed identity for the node or creates a new one if not exists.
 func (app *App) LoadOrCreateEdSigner() (*signing.EdSigner, error) {
-	filename := filepath.Join(app.Config.SMESHING.Opts.DataDir, edKeyFileName)
+	return app.loadOrCreateEdSigner(app.Config.SMESHING.Opts.DataDir)
+}
+
+// loadSmeshers loads or creates keys for all smeshing identities of the node.
+// The first one is the node identity, stored in the smeshing data dir.
+func (app *App) loadSmeshers() ([]*smesher, error) {
+	identities := append([]config.SmeshingIdentity{{
+		CoinbaseAccount:      app.Config.SMESHING.CoinbaseAccount,
+		DataDir:              app.Config.SMESHING.Opts.DataDir,
+		PostService:          app.Config.SMESHING.PostService,
+		PostServiceTokenFile: app.Config.SMESHING.PostServiceTokenFile,
+	}}, app.Config.SMESHING.Identities...)
+
+	smeshers := make([]*smesher, 0, len(identities))
+	dirs := make(map[string]struct{}, len(identities))
+	for _, identity := range identities {
+		dir := filepath.Clean(identity.DataDir)
+		if _, exists := dirs[dir]; exists {
+			return nil, fmt.Errorf("smeshing data dir %s is used by more than one identity", identity.DataDir)
+		}
+		dirs[dir] = struct{}{}
+
+		signer, err := app.loadOrCreateEdSigner(identity.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		vrfSigner, err := signer.VRFSigner(signing.WithNonceFromDB(&app.atxDB))
+		if err != nil {
+			return nil, fmt.Errorf("could not create vrf signer: %w", err)
+		}
+		var token string
+		if identity.PostService != "" {
+			token, err = readPostServiceToken(identity.PostServiceTokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("post service %s: %w", identity.PostService, err)
+			}
+		}
+		smeshers = append(smeshers, &smesher{
+			signer:           signer,
+			vrfSigner:        vrfSigner,
+			coinbase:         identity.CoinbaseAccount,
+			opts:             app.Config.SMESHING.IdentityOpts(identity),
+			postService:      identity.PostService,
+			postServiceToken: token,
+		})
+	}
+	return smeshers, nil
+}
+
+func (app *App) loadOrCreateEdSigner(dir string) (*signing.EdSigner, error) {
+	filename := filepath.Join(dir, edKeyFileName)
 	log.Info("Looking for identity file at `%v`", filename)
 
 	data, err := os.ReadFile(filename)
@@ -1047,26 +1313,22 @@ func (app *App) Start(ctx context.Context) error {
 
 	/* Create or load miner identity */
 
-	app.edSgn, err = app.LoadOrCreateEdSigner()
+	smeshers, err := app.loadSmeshers()
 	if err != nil {
 		return fmt.Errorf("could not retrieve identity: %w", err)
 	}
 
+	nodeID := smeshers[0].signer.NodeID()
+
+	lg := logger.Named(nodeID.ShortString()).WithFields(nodeID)
+
+	poetLogger := app.addLogger(PoetLogger, lg)
 	poetClients := make([]activation.PoetProvingServiceClient, 0, len(app.Config.PoETServers))
 	for _, address := range app.Config.PoETServers {
-		poetClients = append(poetClients, activation.NewHTTPPoetClient(address))
-	}
-
-	edPubkey := app.edSgn.PublicKey()
-	vrfSigner, err := app.edSgn.VRFSigner(signing.WithNonceFromDB(&app.atxDB))
-	if err != nil {
-		return fmt.Errorf("could not create vrf signer: %w", err)
+		poetClients = append(poetClients, activation.NewPoetClient(address,
+			activation.NewHTTPPoetClient(address), app.Config.POET.PolicyFor(address), poetLogger))
 	}
 
-	nodeID := types.BytesToNodeID(edPubkey.Bytes())
-
-	lg := logger.Named(nodeID.ShortString()).WithFields(nodeID)
-
 	/* Initialize all protocol services */
 
 	dbStorepath := app.Config.DataDir()
@@ -1086,18 +1348,18 @@ func (app *App) Start(ctx context.Context) error {
 	cfg.LogLevel = app.getLevel(P2PLogger)
 	app.host, err = p2p.New(ctx, p2plog, cfg, app.Config.Genesis.GenesisID(),
 		p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
+		p2p.WithNodeVersion(cmd.Version),
+		p2p.WithProtocols(fetch.Protocols...),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to initialize p2p host: %w", err)
 	}
 
 	if err = app.initServices(ctx,
-		nodeID,
 		dbStorepath,
-		app.edSgn,
+		smeshers,
 		uint32(app.Config.LayerAvgSize),
 		poetClients,
-		vrfSigner,
 		app.Config.LayersPerEpoch,
 		clock); err != nil {
 		return fmt.Errorf("cannot start services: %w", err)
@@ -93,6 +93,54 @@ func TestSpacemeshApp_getEdIdentity(t *testing.T) {
 	r.NotEqual(signer1.PublicKey(), signer3.PublicKey())
 }
 
+func TestSpacemeshApp_loadSmeshers(t *testing.T) {
+	r := require.New(t)
+
+	app := New(WithLog(logtest.New(t)))
+	app.log = logtest.New(t)
+	app.Config.SMESHING.CoinbaseAccount = types.GenerateAddress([]byte("primary")).String()
+	app.Config.SMESHING.Opts.DataDir = t.TempDir()
+	app.Config.SMESHING.Opts.NumUnits = 2
+	other := config.SmeshingIdentity{
+		CoinbaseAccount: types.GenerateAddress([]byte("other")).String(),
+		DataDir:         t.TempDir(),
+		NumUnits:        4,
+		PostService:     "10.0.0.1:9094",
+	}
+	app.Config.SMESHING.Identities = []config.SmeshingIdentity{other}
+
+	// token is required for the post service
+	_, err := app.loadSmeshers()
+	r.ErrorContains(err, "token")
+	other.PostServiceTokenFile = filepath.Join(t.TempDir(), "token")
+	r.NoError(os.WriteFile(other.PostServiceTokenFile, []byte("secret\n"), 0o600))
+	app.Config.SMESHING.Identities = []config.SmeshingIdentity{other}
+
+	smeshers, err := app.loadSmeshers()
+	r.NoError(err)
+	r.Len(smeshers, 2)
+	r.NotEqual(smeshers[0].signer.NodeID(), smeshers[1].signer.NodeID())
+	r.Equal(app.Config.SMESHING.CoinbaseAccount, smeshers[0].coinbase)
+	r.Equal(app.Config.SMESHING.Opts, smeshers[0].opts)
+	r.Equal(other.CoinbaseAccount, smeshers[1].coinbase)
+	r.Equal(other.DataDir, smeshers[1].opts.DataDir)
+	r.EqualValues(4, smeshers[1].opts.NumUnits)
+	r.Empty(smeshers[0].postService)
+	r.Equal(other.PostService, smeshers[1].postService)
+	r.Equal("secret", smeshers[1].postServiceToken)
+
+	// keys are loaded from the data dirs of the identities
+	reloaded, err := app.loadSmeshers()
+	r.NoError(err)
+	for i := range smeshers {
+		r.Equal(smeshers[i].signer.NodeID(), reloaded[i].signer.NodeID())
+	}
+
+	app.Config.SMESHING.Identities = append(app.Config.SMESHING.Identities, other)
+	_, err = app.loadSmeshers()
+	r.Error(err)
+}
+
 func newLogger(buf *bytes.Buffer) log.Log {
 	lvl := zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	syncer := zapcore.AddSync(buf)
@@ -1024,7 +1072,6 @@ func initSingleInstance(lg log.Log, cfg config.Config, i int, genesisTime string
 	smApp.Config.SMESHING.Opts.DataDir, _ = os.MkdirTemp("", "sm-app-test-post-datadir")
 
 	smApp.host = host
-	smApp.edSgn = edSgn
 
 	vrfSigner, err := edSgn.VRFSigner(
 		signing.WithNonceForNode(1, edSgn.NodeID()),
@@ -1032,9 +1079,15 @@ func initSingleInstance(lg log.Log, cfg config.Config, i int, genesisTime string
 	if err != nil {
 		return nil, err
 	}
-
-	err = smApp.initServices(context.Background(), edSgn.NodeID(), storePath, edSgn,
-		uint32(smApp.Config.LayerAvgSize), []activation.PoetProvingServiceClient{poetClient}, vrfSigner, smApp.Config.LayersPerEpoch, clock)
+	smeshers := []*smesher{{
+		signer:    edSgn,
+		vrfSigner: vrfSigner,
+		coinbase:  smApp.Config.SMESHING.CoinbaseAccount,
+		opts:      smApp.Config.SMESHING.Opts,
+	}}
+
+	err = smApp.initServices(context.Background(), storePath, smeshers,
+		uint32(smApp.Config.LayerAvgSize), []activation.PoetProvingServiceClient{poetClient}, smApp.Config.LayersPerEpoch, clock)
 	if err != nil {
 		return nil, err
 	}
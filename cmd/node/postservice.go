@@ -0,0 +1,134 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// postServiceCommand serves post data stored in the smeshing data dir to a node running on another machine.
+func postServiceCommand(node *cobra.Command) *cobra.Command {
+	var (
+		listen, nodeID, tokenFile string
+		svcCfg                    activation.PostServiceConfig
+	)
+	c := &cobra.Command{
+		Use:   "post-service",
+		Short: "Serve post data of the identity stored in the smeshing data dir to a remote node (see smeshing-post-service)",
+		Args:  cobra.NoArgs,
+		Run: func(c *cobra.Command, args []string) {
+			conf, err := loadConfig(node)
+			if err != nil {
+				log.With().Fatal("failed to initialize config", log.Err(err))
+			}
+			id, err := parseNodeID(nodeID)
+			if err != nil {
+				log.With().Fatal("invalid node id", log.Err(err))
+			}
+			svcCfg.Token, err = readPostServiceToken(tokenFile)
+			if err != nil {
+				log.With().Fatal("failed to load post service token", log.Err(err))
+			}
+			goldenATXID := types.ATXID(conf.Genesis.GenesisID().ToHash32())
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			if err := runPostService(ctx, listen, id, conf.SMESHING.Opts.DataDir, conf.POST, svcCfg, goldenATXID); err != nil {
+				log.With().Fatal("post service failed", log.Err(err))
+			}
+		},
+	}
+	c.Flags().StringVar(&listen, "listen", "127.0.0.1:9094",
+		"address for the post service to listen on. the service uses plain http, expose it only over a trusted network or a tls proxy")
+	c.Flags().StringVar(&nodeID, "node-id", "", "hex encoded id of the identity that created the post data")
+	c.Flags().StringVar(&tokenFile, "token-file", "",
+		"file with the token that the node must present (required, see smeshing-post-service-token-file)")
+	c.Flags().BoolVar(&svcCfg.AllowSetup, "allow-setup", false,
+		"allow the node to create and delete post data (benchmark, start/stop session and reset)")
+	c.Flags().BoolVar(&svcCfg.AllowProving, "allow-proving", false, "allow the node to generate proofs")
+	return c
+}
+
+// readPostServiceToken reads the shared token of the post service from the file.
+func readPostServiceToken(filename string) (string, error) {
+	if filename == "" {
+		return "", errors.New("token file is not configured")
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", filename)
+	}
+	return token, nil
+}
+
+func parseNodeID(s string) (types.NodeID, error) {
+	buf, err := hex.DecodeString(s)
+	if err != nil {
+		return types.NodeID{}, fmt.Errorf("decode %s: %w", s, err)
+	}
+	if len(buf) != len(types.NodeID{}) {
+		return types.NodeID{}, fmt.Errorf("node id %s has %d bytes instead of %d", s, len(buf), len(types.NodeID{}))
+	}
+	return types.BytesToNodeID(buf), nil
+}
+
+func runPostService(
+	ctx context.Context,
+	listen string,
+	id types.NodeID,
+	dataDir string,
+	cfg activation.PostConfig,
+	svcCfg activation.PostServiceConfig,
+	goldenATXID types.ATXID,
+) error {
+	logger := log.NewDefault("post-service")
+	mgr, err := activation.NewPostSetupManager(id, cfg, logger, nil, goldenATXID)
+	if err != nil {
+		return fmt.Errorf("create post setup manager: %w", err)
+	}
+	svc, err := activation.NewPostService(id, dataDir, mgr, svcCfg, logger)
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	srv := &http.Server{Addr: listen, Handler: svc}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+	logger.With().Info("post service started",
+		id,
+		log.String("listen", listen),
+		log.String("data_dir", dataDir),
+		log.Bool("allow_setup", svcCfg.AllowSetup),
+		log.Bool("allow_proving", svcCfg.AllowProving),
+	)
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+	"github.com/spacemeshos/go-spacemesh/tortoise/replay"
+)
+
+// replayTortoiseCommand replays the mesh from the state database into a fresh tortoise. Database is not modified.
+func replayTortoiseCommand(node *cobra.Command) *cobra.Command {
+	var (
+		last                     uint32
+		hdist, zdist, windowSize uint32
+	)
+	c := &cobra.Command{
+		Use:   "replay-tortoise",
+		Short: "Replay the mesh from the state database into a fresh tortoise and compare validity of blocks, node may be running",
+		Args:  cobra.NoArgs,
+		Run: func(c *cobra.Command, args []string) {
+			conf, err := loadConfig(node)
+			if err != nil {
+				log.With().Fatal("failed to initialize config", log.Err(err))
+			}
+			types.SetLayersPerEpoch(conf.LayersPerEpoch)
+			cfg := conf.Tortoise
+			if c.Flags().Changed("hdist") {
+				cfg.Hdist = hdist
+			}
+			if c.Flags().Changed("zdist") {
+				cfg.Zdist = zdist
+			}
+			if c.Flags().Changed("window-size") {
+				cfg.WindowSize = windowSize
+			}
+			if cfg.Hdist < cfg.Zdist {
+				log.With().Fatal("hdist must be >= zdist", log.Uint32("hdist", cfg.Hdist), log.Uint32("zdist", cfg.Zdist))
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			report, err := replayTortoise(ctx, conf.DataDir(), cfg, types.NewLayerID(last))
+			if err != nil {
+				log.With().Fatal("failed to replay tortoise", log.Err(err))
+			}
+			fields := []log.LoggableField{
+				log.Stringer("last", report.Last),
+				log.Stringer("verified", report.Verified),
+				log.Int("compared", report.Compared),
+				log.Int("unknown", report.Unknown),
+				log.Int("diverged", len(report.Divergences)),
+			}
+			if len(report.Divergences) > 0 {
+				log.With().Fatal("tortoise replay diverged from the stored validity", fields...)
+			}
+			log.With().Info("tortoise replay is consistent with the stored validity", fields...)
+		},
+	}
+	c.Flags().Uint32Var(&last, "last", 0, "last replayed layer, latest layer with ballots if not set")
+	c.Flags().Uint32Var(&hdist, "hdist", 0, "overwrite tortoise hdist")
+	c.Flags().Uint32Var(&zdist, "zdist", 0, "overwrite tortoise zdist")
+	c.Flags().Uint32Var(&windowSize, "window-size", 0, "overwrite tortoise window size")
+	return c
+}
+
+func replayTortoise(ctx context.Context, dataDir string, cfg tortoise.Config, last types.LayerID) (*replay.Report, error) {
+	path := filepath.Join(dataDir, dbFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("state database %s: %w", path, err)
+	}
+	db, err := sql.Open("file:"+path+"?mode=ro", sql.WithMigrations(nil), sql.WithConnections(1))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite db %w", err)
+	}
+	defer db.Close()
+	return replay.Run(ctx, db,
+		replay.WithLogger(log.NewDefault("replay")),
+		replay.WithConfig(cfg),
+		replay.WithLast(last),
+	)
+}
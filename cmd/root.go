@@ -57,6 +57,14 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.ProfilerURL, "send profiler data to certain url, if no url no profiling will be sent, format: http://<IP>:<PORT>")
 	cmd.PersistentFlags().StringVar(&cfg.ProfilerName, "profiler-name",
 		cfg.ProfilerName, "the name to use when sending profiles")
+	cmd.PersistentFlags().StringVar(&cfg.CheckpointFile, "checkpoint-file",
+		cfg.CheckpointFile, "import checkpoint from the file into the empty state database and sync from its layer")
+	cmd.PersistentFlags().StringVar(&cfg.CheckpointStateRoot, "checkpoint-state-root",
+		cfg.CheckpointStateRoot, "trusted state root of the checkpoint layer in hex, required with --checkpoint-file")
+	cmd.PersistentFlags().BoolVar(&cfg.DatabaseMigrateDryRun, "db-migrate-dry-run",
+		cfg.DatabaseMigrateDryRun, "report pending migrations of the state database and exit")
+	cmd.PersistentFlags().IntVar(&cfg.DatabaseRollbackTo, "db-rollback-to",
+		cfg.DatabaseRollbackTo, "roll back migrations of the state database to the specified version and exit, 0 reverts all migrations")
 
 	cmd.PersistentFlags().IntVar(&cfg.SyncRequestTimeout, "sync-request-timeout",
 		cfg.SyncRequestTimeout, "the timeout in ms for direct requests in the sync")
@@ -64,6 +72,10 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.TxsPerProposal, "the number of transactions to select per proposal")
 	cmd.PersistentFlags().Uint64Var(&cfg.BlockGasLimit, "block-gas-limit",
 		cfg.BlockGasLimit, "max gas allowed per block")
+	cmd.PersistentFlags().Uint64Var(&cfg.MempoolReplaceBump, "mempool-replace-bump",
+		cfg.MempoolReplaceBump, "minimal gas price increase, in percents, to replace a pending transaction with the same nonce")
+	cmd.PersistentFlags().IntVar(&cfg.MempoolMaxSize, "mempool-max-size",
+		cfg.MempoolMaxSize, "max number of transactions in the mempool, transactions with the lowest gas price are evicted")
 	cmd.PersistentFlags().IntVar(&cfg.OptFilterThreshold, "optimistic-filtering-threshold",
 		cfg.OptFilterThreshold, "threshold for optimistic filtering in percentage")
 
@@ -119,7 +131,7 @@ func AddCommands(cmd *cobra.Command) {
 	// StartGrpcServices determines which (if any) GRPC API services should be started
 	cmd.PersistentFlags().StringSliceVar(&cfg.API.StartGrpcServices, "grpc",
 		cfg.API.StartGrpcServices, "Comma-separated list of individual grpc services to enable "+
-			"(gateway,globalstate,mesh,node,smesher,transaction)")
+			"(gateway,globalstate,mesh,node,smesher,transaction,peer,mempooladmin)")
 	// GrpcServerPort determines the grpc server local listening port
 	cmd.PersistentFlags().IntVar(&cfg.API.GrpcServerPort, "grpc-port",
 		cfg.API.GrpcServerPort, "GRPC api server port")
@@ -146,6 +158,8 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.HARE.LimitIterations, "The limit of the number of iteration per consensus process")
 	cmd.PersistentFlags().IntVar(&cfg.HARE.LimitConcurrent, "hare-limit-concurrent",
 		cfg.HARE.LimitConcurrent, "The number of consensus processes running concurrently")
+	cmd.PersistentFlags().StringVar(&cfg.HARE.TraceDir, "hare-trace-dir",
+		cfg.HARE.TraceDir, "Directory to record messages and tracker transitions of every consensus process, disabled if empty")
 
 	/**======================== Hare Eligibility Oracle Flags ========================== **/
 
@@ -182,6 +196,8 @@ func AddCommands(cmd *cobra.Command) {
 	/**======================== Tortoise Flags ========================== **/
 	cmd.PersistentFlags().Uint32Var(&cfg.Tortoise.Hdist, "tortoise-hdist",
 		cfg.Tortoise.Hdist, "hdist")
+	cmd.PersistentFlags().Uint32Var(&cfg.Tortoise.CheckpointInterval, "tortoise-checkpoint-interval",
+		cfg.Tortoise.CheckpointInterval, "number of processed layers between checkpoints of the tortoise state (0 disables checkpoints)")
 
 	// TODO(moshababo): add usage desc
 
@@ -216,6 +232,12 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.SMESHING.Opts.ComputeProviderID, "")
 	cmd.PersistentFlags().BoolVar(&cfg.SMESHING.Opts.Throttle, "smeshing-opts-throttle",
 		cfg.SMESHING.Opts.Throttle, "")
+	cmd.PersistentFlags().StringVar(&cfg.SMESHING.PostService, "smeshing-post-service",
+		cfg.SMESHING.PostService, "address of the post service that holds post data of the node identity")
+	cmd.PersistentFlags().StringVar(&cfg.SMESHING.PostServiceTokenFile, "smeshing-post-service-token-file",
+		cfg.SMESHING.PostServiceTokenFile, "file with the token of the post service, required if smeshing-post-service is set")
+	cmd.PersistentFlags().DurationVar(&cfg.SMESHING.PostServiceProofTimeout, "smeshing-post-service-proof-timeout",
+		cfg.SMESHING.PostServiceProofTimeout, "time limit for the post service to generate a proof")
 
 	/**======================== Consensus Flags ========================== **/
 
@@ -230,6 +252,15 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.POET.CycleGap, "cycle gap of poet server")
 	cmd.PersistentFlags().DurationVar(&cfg.POET.GracePeriod, "grace-period",
 		cfg.POET.GracePeriod, "propagation time for ATXs in the network")
+	cmd.PersistentFlags().BoolVar(&cfg.POET.Local, "poet-local",
+		cfg.POET.Local, "run in-process poet, for local networks without poet servers")
+	cmd.PersistentFlags().Uint64Var(&cfg.POET.LocalLeaves, "poet-local-leaves",
+		cfg.POET.LocalLeaves, "number of leaves in proofs of the in-process poet")
+
+	/**======================== Prune Flags ========================== **/
+
+	cmd.PersistentFlags().Uint32Var(&cfg.PRUNE.Retention, "prune-retention",
+		cfg.PRUNE.Retention, "number of layers behind the verified layer to keep ballots, proposals and transaction references for. 0 disables pruning")
 
 	// Bind Flags to config
 	err := viper.BindPFlags(cmd.PersistentFlags())
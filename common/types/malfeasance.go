@@ -0,0 +1,70 @@
+package types
+
+import (
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+//go:generate scalegen -types MalfeasanceProof,AtxProof,BallotProof,HareProof,HareProofMsg
+
+const (
+	// MultipleATXs is an offense of publishing more than one ATX in the same epoch.
+	MultipleATXs = "multiple_atxs"
+	// MultipleBallots is an offense of publishing more than one ballot in the same layer.
+	MultipleBallots = "multiple_ballots"
+	// HareEquivocation is an offense of publishing different hare messages in the same round.
+	HareEquivocation = "hare_equivocation"
+)
+
+// MalfeasanceProof is a self-contained proof that an identity is malicious.
+// It contains two conflicting messages signed by the same identity, exactly one of
+// the proofs must be set.
+type MalfeasanceProof struct {
+	// Layer is a layer when the proof was created.
+	Layer LayerID
+
+	MultipleATXs     *AtxProof
+	MultipleBallots  *BallotProof
+	HareEquivocation *HareProof
+}
+
+// Offense returns the name of the offense proved by the proof.
+func (p *MalfeasanceProof) Offense() string {
+	switch {
+	case p.MultipleATXs != nil:
+		return MultipleATXs
+	case p.MultipleBallots != nil:
+		return MultipleBallots
+	case p.HareEquivocation != nil:
+		return HareEquivocation
+	}
+	return "unknown"
+}
+
+// MarshalLogObject implements logging interface.
+func (p *MalfeasanceProof) MarshalLogObject(encoder log.ObjectEncoder) error {
+	encoder.AddUint32("layer", p.Layer.Uint32())
+	encoder.AddString("offense", p.Offense())
+	return nil
+}
+
+// AtxProof contains two ATXs published by the same identity in the same epoch.
+type AtxProof struct {
+	Messages [2]ActivationTx
+}
+
+// BallotProof contains two ballots published by the same identity in the same layer.
+type BallotProof struct {
+	Messages [2]Ballot
+}
+
+// HareProof contains two different hare messages published by the same identity in the same round.
+type HareProof struct {
+	Messages [2]HareProofMsg
+}
+
+// HareProofMsg is a signed hare message.
+type HareProofMsg struct {
+	// InnerMsg is a serialized hare message, as it was signed.
+	InnerMsg  []byte
+	Signature []byte
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/spacemeshos/ed25519"
+	"github.com/spacemeshos/go-scale"
 
 	"github.com/spacemeshos/go-spacemesh/log"
 )
@@ -50,8 +51,27 @@ func (id NodeID) ShortString() string {
 	return Shorten(id.String(), 5)
 }
 
+// EncodeScale implements scale codec interface.
+func (id *NodeID) EncodeScale(e *scale.Encoder) (int, error) {
+	return scale.EncodeByteArray(e, id[:])
+}
+
+// DecodeScale implements scale codec interface.
+func (id *NodeID) DecodeScale(d *scale.Decoder) (int, error) {
+	return scale.DecodeByteArray(d, id[:])
+}
+
 // Field returns a log field. Implements the LoggableField interface.
 func (id NodeID) Field() log.Field { return log.Stringer("node_id", id) }
 
 // EmptyNodeID is a canonical empty NodeID.
 var EmptyNodeID NodeID
+
+// NodeIDsToHashes turns a list of NodeID into their Hash32 representation.
+func NodeIDsToHashes(ids []NodeID) []Hash32 {
+	hashes := make([]Hash32, 0, len(ids))
+	for _, id := range ids {
+		hashes = append(hashes, Hash32(id))
+	}
+	return hashes
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -19,6 +20,7 @@ import (
 	eligConfig "github.com/spacemeshos/go-spacemesh/hare/eligibility/config"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/prune"
 	timeConfig "github.com/spacemeshos/go-spacemesh/timesync/config"
 	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
@@ -57,6 +59,7 @@ type Config struct {
 	SMESHING        SmeshingConfig        `mapstructure:"smeshing"`
 	LOGGING         LoggerConfig          `mapstructure:"logging"`
 	FETCH           fetch.Config          `mapstructure:"fetch"`
+	PRUNE           prune.Config          `mapstructure:"prune"`
 }
 
 // DataDir returns the absolute path to use for the node's data. This is the tilde-expanded path given in the config
@@ -99,10 +102,28 @@ type BaseConfig struct {
 
 	TxsPerProposal int    `mapstructure:"txs-per-proposal"`
 	BlockGasLimit  uint64 `mapstructure:"block-gas-limit"`
+	// MempoolReplaceBump is the minimal gas price increase, in percents, to replace a pending transaction.
+	MempoolReplaceBump uint64 `mapstructure:"mempool-replace-bump"`
+	// MempoolMaxSize is the maximal number of transactions in the mempool.
+	MempoolMaxSize int `mapstructure:"mempool-max-size"`
 	// if the number of proposals with the same mesh state crosses this threshold (in percentage),
 	// then we optimistically filter out infeasible transactions before constructing the block.
 	OptFilterThreshold int    `mapstructure:"optimistic-filtering-threshold"`
 	TickSize           uint64 `mapstructure:"tick-size"`
+
+	// CheckpointFile is imported into the empty state database on start,
+	// node then syncs from the layer of the checkpoint instead of genesis.
+	CheckpointFile string `mapstructure:"checkpoint-file"`
+	// CheckpointStateRoot is the trusted state root of the checkpoint layer, in hex.
+	// It is obtained out of band, checkpoint is rejected if its accounts don't match it.
+	CheckpointStateRoot string `mapstructure:"checkpoint-state-root"`
+
+	// DatabaseMigrateDryRun reports pending migrations of the state database and exits.
+	DatabaseMigrateDryRun bool `mapstructure:"db-migrate-dry-run"`
+	// DatabaseRollbackTo reverts migrations of the state database above this version and exits.
+	// Used before downgrading the node to the release that doesn't know about newer migrations.
+	// Negative value disables rollback, zero reverts all migrations.
+	DatabaseRollbackTo int `mapstructure:"db-rollback-to"`
 }
 
 // SmeshingConfig defines configuration for the node's smeshing (mining).
@@ -110,6 +131,36 @@ type SmeshingConfig struct {
 	Start           bool                     `mapstructure:"smeshing-start"`
 	CoinbaseAccount string                   `mapstructure:"smeshing-coinbase"`
 	Opts            activation.PostSetupOpts `mapstructure:"smeshing-opts"`
+	// PostService is an address of the post service holding the post data, if the data isn't stored on the node.
+	PostService string `mapstructure:"smeshing-post-service"`
+	// PostServiceTokenFile is a file with the token of the post service.
+	PostServiceTokenFile string `mapstructure:"smeshing-post-service-token-file"`
+	// PostServiceProofTimeout limits the time a post service may spend generating a proof.
+	PostServiceProofTimeout time.Duration `mapstructure:"smeshing-post-service-proof-timeout"`
+	// Identities are smeshing on the node in addition to the identity stored in Opts.DataDir.
+	Identities []SmeshingIdentity `mapstructure:"smeshing-identities"`
+}
+
+// SmeshingIdentity defines configuration for an additional smeshing identity of the node.
+// The key of the identity is stored together with its post data in DataDir.
+// Post setup options that are not set here are the same as in SmeshingConfig.Opts.
+type SmeshingIdentity struct {
+	CoinbaseAccount string `mapstructure:"smeshing-coinbase"`
+	DataDir         string `mapstructure:"smeshing-opts-datadir"`
+	NumUnits        uint32 `mapstructure:"smeshing-opts-numunits"`
+	PostService     string `mapstructure:"smeshing-post-service"`
+	// PostServiceTokenFile is a file with the token of the post service.
+	PostServiceTokenFile string `mapstructure:"smeshing-post-service-token-file"`
+}
+
+// IdentityOpts returns post setup options for the identity.
+func (cfg *SmeshingConfig) IdentityOpts(identity SmeshingIdentity) activation.PostSetupOpts {
+	opts := cfg.Opts
+	opts.DataDir = identity.DataDir
+	if identity.NumUnits != 0 {
+		opts.NumUnits = identity.NumUnits
+	}
+	return opts
 }
 
 // DefaultConfig returns the default configuration for a spacemesh node.
@@ -131,6 +182,7 @@ func DefaultConfig() Config {
 		SMESHING:        DefaultSmeshingConfig(),
 		FETCH:           fetch.DefaultConfig(),
 		LOGGING:         defaultLoggingConfig(),
+		PRUNE:           prune.DefaultConfig(),
 	}
 }
 
@@ -164,8 +216,11 @@ func defaultBaseConfig() BaseConfig {
 		SyncInterval:        10,
 		TxsPerProposal:      100,
 		BlockGasLimit:       math.MaxUint64,
+		MempoolReplaceBump:  10,
+		MempoolMaxSize:      100_000,
 		OptFilterThreshold:  90,
 		TickSize:            100,
+		DatabaseRollbackTo:  -1,
 	}
 }
 
@@ -175,6 +230,8 @@ func DefaultSmeshingConfig() SmeshingConfig {
 		Start:           false,
 		CoinbaseAccount: "",
 		Opts:            activation.DefaultPostSetupOpts(),
+		// proving reads all the post data, it takes from minutes to hours depending on the size of the data
+		PostServiceProofTimeout: 2 * time.Hour,
 	}
 }
 
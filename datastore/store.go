@@ -11,6 +11,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/poets"
 	"github.com/spacemeshos/go-spacemesh/sql/proposals"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
@@ -138,6 +139,8 @@ const (
 	ATXDB      Hint = "ATXDB"
 	TXDB       Hint = "TXDB"
 	POETDB     Hint = "POETDB"
+	// MalfeasanceDB is queried by node id.
+	MalfeasanceDB Hint = "malfeasanceDB"
 )
 
 // NewBlobStore returns a BlobStore.
@@ -183,6 +186,8 @@ func (bs *BlobStore) Get(hint Hint, key []byte) ([]byte, error) {
 		return transactions.GetBlob(bs.DB, key)
 	case POETDB:
 		return poets.Get(bs.DB, key)
+	case MalfeasanceDB:
+		return identities.GetMalfeasanceProof(bs.DB, key)
 	}
 	return nil, fmt.Errorf("blob store not found %s", hint)
 }
@@ -403,6 +403,7 @@ type EventReporter struct {
 	rewardEmitter      event.Emitter
 	resultsEmitter     event.Emitter
 	proposalsEmitter   event.Emitter
+	tortoiseEmitter    event.Emitter
 	stopChan           chan struct{}
 }
 
@@ -454,6 +455,11 @@ func newEventReporter() *EventReporter {
 		log.With().Panic("failed to to create proposal emitter", log.Err(err))
 	}
 
+	tortoiseEmitter, err := bus.Emitter(new(EventTortoise))
+	if err != nil {
+		log.With().Panic("failed to create tortoise emitter", log.Err(err))
+	}
+
 	return &EventReporter{
 		bus:                bus,
 		transactionEmitter: transactionEmitter,
@@ -465,6 +471,7 @@ func newEventReporter() *EventReporter {
 		resultsEmitter:     resultsEmitter,
 		errorEmitter:       errorEmitter,
 		proposalsEmitter:   proposalsEmitter,
+		tortoiseEmitter:    tortoiseEmitter,
 		stopChan:           make(chan struct{}),
 	}
 }
@@ -501,6 +508,9 @@ func CloseEventReporter() {
 		if err := reporter.proposalsEmitter.Close(); err != nil {
 			log.With().Panic("failed to close propoposalsEmitter", log.Err(err))
 		}
+		if err := reporter.tortoiseEmitter.Close(); err != nil {
+			log.With().Panic("failed to close tortoiseEmitter", log.Err(err))
+		}
 
 		close(reporter.stopChan)
 		reporter = nil
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// TortoiseEventKind is a type of the tortoise progress event.
+type TortoiseEventKind int
+
+func (k TortoiseEventKind) String() string {
+	switch k {
+	case TortoiseFull:
+		return "full"
+	case TortoiseVerifying:
+		return "verifying"
+	case TortoiseHealed:
+		return "healed"
+	case TortoiseCoinflip:
+		return "coinflip"
+	default:
+		panic("unknown kind")
+	}
+}
+
+const (
+	// TortoiseFull is emitted when verifying tortoise can't make progress and
+	// tortoise switches to full mode, which is when healing starts.
+	TortoiseFull TortoiseEventKind = iota
+	// TortoiseVerifying is emitted when tortoise switches back to verifying mode.
+	TortoiseVerifying
+	// TortoiseHealed is emitted when the layer is verified in full mode.
+	TortoiseHealed
+	// TortoiseCoinflip is emitted when local votes for the layer are decided by the weak coin.
+	TortoiseCoinflip
+)
+
+// EventTortoise is a progress event of the tortoise.
+type EventTortoise struct {
+	Kind TortoiseEventKind
+	// Layer is a subject of the event. Layer that was processed when mode was switched,
+	// layer that was healed, or layer where votes were decided by the weak coin.
+	Layer     types.LayerID
+	Processed types.LayerID
+	Verified  types.LayerID
+}
+
+// Field returns a log field. Implements the LoggableField interface.
+func (e EventTortoise) Field() log.Field {
+	return log.Inline(log.ObjectMarshallerFunc(func(encoder log.ObjectEncoder) error {
+		encoder.AddString("kind", e.Kind.String())
+		encoder.AddUint32("layer", e.Layer.Uint32())
+		encoder.AddUint32("processed", e.Processed.Uint32())
+		encoder.AddUint32("verified", e.Verified.Uint32())
+		return nil
+	}))
+}
+
+// ReportTortoise reports a tortoise progress event.
+func ReportTortoise(ev EventTortoise) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		if err := reporter.tortoiseEmitter.Emit(ev); err != nil {
+			log.With().Error("failed to emit tortoise event", ev, log.Err(err))
+		}
+	}
+}
@@ -91,6 +91,22 @@ func (hpc *HashPeersCache) GetRandom(hash types.Hash32, hint datastore.Hint, rng
 	return p2p.NoPeer, false
 }
 
+// GetPeers returns all peers registered for a given hash.
+func (hpc *HashPeersCache) GetPeers(hash types.Hash32, hint datastore.Hint) []p2p.Peer {
+	hpc.mu.Lock()
+	defer hpc.mu.Unlock()
+
+	hashPeersMap, exists := hpc.getWithStats(hash, hint)
+	if !exists {
+		return nil
+	}
+	peers := make([]p2p.Peer, 0, len(hashPeersMap))
+	for peer := range hashPeersMap {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
 // RegisterPeerHashes registers provided peer for a list of hashes.
 func (hpc *HashPeersCache) RegisterPeerHashes(peer p2p.Peer, hashes []types.Hash32) {
 	if len(hashes) == 0 {
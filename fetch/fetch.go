@@ -16,22 +16,45 @@ import (
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/p2p/server"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
 const (
-	atxProtocol      = "ax/1"
-	lyrDataProtocol  = "ld/1"
-	lyrOpnsProtocol  = "lp/1"
-	hashProtocol     = "hs/1"
-	meshHashProtocol = "mh/1"
+	// AtxProtocol is the protocol id for epoch info requests.
+	AtxProtocol = "ax/1"
+	// LyrDataProtocol is the protocol id for layer data requests.
+	LyrDataProtocol = "ld/1"
+	// LyrOpnsProtocol is the protocol id for layer opinions requests.
+	LyrOpnsProtocol = "lp/1"
+	// HashProtocol is the protocol id for batched hash requests.
+	HashProtocol = "hs/1"
+	// MeshHashProtocol is the protocol id for mesh hashes requests.
+	MeshHashProtocol = "mh/1"
+	// AtxStreamProtocol is the protocol id for epoch info requests with the response streamed in chunks.
+	AtxStreamProtocol = "axs/1"
+	// LyrDataStreamProtocol is the protocol id for layer data requests with the response streamed in chunks.
+	LyrDataStreamProtocol = "lds/1"
+	// MalProtocol is the protocol id for requests of malicious identities.
+	MalProtocol = "ml/1"
 
 	cacheSize = 1000
 )
 
-// errExceedMaxRetries is returned when MaxRetriesForRequest attempts has been made to fetch data for a hash and failed.
-var errExceedMaxRetries = errors.New("fetch failed after max retries for request")
+// Protocols are all protocols served by fetch.
+var Protocols = []string{
+	AtxProtocol, LyrDataProtocol, LyrOpnsProtocol, HashProtocol, MeshHashProtocol,
+	AtxStreamProtocol, LyrDataStreamProtocol, MalProtocol,
+}
+
+var (
+	// errExceedMaxRetries is returned when MaxRetriesForRequest attempts has been made to fetch data for a hash and failed.
+	errExceedMaxRetries = errors.New("fetch failed after max retries for request")
+
+	// ErrProtocolNotSupported is returned when peer doesn't support the protocol of the request.
+	ErrProtocolNotSupported = errors.New("fetch: protocol is not supported by peer")
+)
 
 // request contains all relevant Data for a single request for a specified hash.
 type request struct {
@@ -51,6 +74,7 @@ type promise struct {
 type batchInfo struct {
 	RequestBatch
 	peer p2p.Peer
+	sent time.Time
 }
 
 // setID calculates the hash of all requests and sets it as this batches ID.
@@ -77,6 +101,10 @@ type Config struct {
 	BatchSize, QueueSize int
 	RequestTimeout       time.Duration // in seconds
 	MaxRetriesForRequest int
+	// PeerBanThreshold is the number of consecutive responses that failed validation
+	// after which the peer is temporarily banned. Zero disables bans.
+	PeerBanThreshold int
+	PeerBanDuration  time.Duration
 }
 
 // DefaultConfig is the default config for the fetch component.
@@ -88,6 +116,8 @@ func DefaultConfig() Config {
 		BatchSize:            20,
 		RequestTimeout:       time.Second * time.Duration(10),
 		MaxRetriesForRequest: 100,
+		PeerBanThreshold:     5,
+		PeerBanDuration:      10 * time.Minute,
 	}
 }
 
@@ -158,6 +188,13 @@ func WithTXHandler(h txHandler) Option {
 	}
 }
 
+// WithMalfeasanceHandler configures the malfeasance proof handler of the fetcher.
+func WithMalfeasanceHandler(h malfeasanceHandler) Option {
+	return func(f *Fetch) {
+		f.malHandler = h
+	}
+}
+
 // WithPoetHandler configures the PoET handler of the fetcher.
 func WithPoetHandler(h poetHandler) Option {
 	return func(f *Fetch) {
@@ -191,6 +228,7 @@ type Fetch struct {
 	blockHandler    blockHandler
 	proposalHandler proposalHandler
 	txHandler       txHandler
+	malHandler      malfeasanceHandler
 
 	// unprocessed contains requests that are not processed
 	unprocessed map[types.Hash32]*request
@@ -202,6 +240,7 @@ type Fetch struct {
 	mu           sync.Mutex
 	onlyOnce     sync.Once
 	hashToPeers  *HashPeersCache
+	scores       *peerScores
 
 	shutdownCtx context.Context
 	cancel      context.CancelFunc
@@ -226,6 +265,7 @@ func NewFetch(cdb *datastore.CachedDB, msh meshProvider, b system.BeaconGetter,
 		opt(f)
 	}
 
+	f.scores = newPeerScores(f.cfg.PeerBanThreshold, f.cfg.PeerBanDuration)
 	f.batchTimeout = time.NewTicker(f.cfg.BatchTimeout)
 	srvOpts := []server.Opt{
 		server.WithTimeout(f.cfg.RequestTimeout),
@@ -233,11 +273,14 @@ func NewFetch(cdb *datastore.CachedDB, msh meshProvider, b system.BeaconGetter,
 	}
 	if len(f.servers) == 0 {
 		h := newHandler(cdb, bs, msh, b, f.logger)
-		f.servers[atxProtocol] = server.New(host, atxProtocol, h.handleEpochInfoReq, srvOpts...)
-		f.servers[lyrDataProtocol] = server.New(host, lyrDataProtocol, h.handleLayerDataReq, srvOpts...)
-		f.servers[lyrOpnsProtocol] = server.New(host, lyrOpnsProtocol, h.handleLayerOpinionsReq, srvOpts...)
-		f.servers[hashProtocol] = server.New(host, hashProtocol, h.handleHashReq, srvOpts...)
-		f.servers[meshHashProtocol] = server.New(host, meshHashProtocol, h.handleMeshHashReq, srvOpts...)
+		f.servers[AtxProtocol] = server.New(host, AtxProtocol, h.handleEpochInfoReq, srvOpts...)
+		f.servers[LyrDataProtocol] = server.New(host, LyrDataProtocol, h.handleLayerDataReq, srvOpts...)
+		f.servers[LyrOpnsProtocol] = server.New(host, LyrOpnsProtocol, h.handleLayerOpinionsReq, srvOpts...)
+		f.servers[HashProtocol] = server.New(host, HashProtocol, h.handleHashReq, srvOpts...)
+		f.servers[MeshHashProtocol] = server.New(host, MeshHashProtocol, h.handleMeshHashReq, srvOpts...)
+		f.servers[AtxStreamProtocol] = server.NewStreaming(host, AtxStreamProtocol, h.streamEpochInfoReq, srvOpts...)
+		f.servers[LyrDataStreamProtocol] = server.NewStreaming(host, LyrDataStreamProtocol, h.streamLayerDataReq, srvOpts...)
+		f.servers[MalProtocol] = server.New(host, MalProtocol, h.handleMaliciousIDsReq, srvOpts...)
 	}
 	return f
 }
@@ -287,6 +330,8 @@ func (f *Fetch) stopped() bool {
 // there can be a priority request that will not be batched.
 func (f *Fetch) loop() {
 	f.logger.Info("starting fetch main loop")
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
 	for {
 		select {
 		case <-f.batchTimeout.C:
@@ -294,6 +339,8 @@ func (f *Fetch) loop() {
 				f.requestHashBatchFromPeers() // Process the batch.
 				return nil
 			})
+		case <-prune.C:
+			f.scores.prune(f.host.GetPeers())
 		case <-f.shutdownCtx.Done():
 			return
 		}
@@ -325,6 +372,7 @@ func (f *Fetch) receiveResponse(data []byte) {
 			log.Stringer("batch_hash", response.ID))
 		return
 	}
+	f.scores.onSuccess(batch.peer, time.Since(batch.sent))
 
 	batchMap := batch.toMap()
 	// iterate all hash Responses
@@ -343,7 +391,7 @@ func (f *Fetch) receiveResponse(data []byte) {
 		rsp := resp
 		f.eg.Go(func() error {
 			// validation fetch data recursively. offload to another goroutine
-			f.hashValidationDone(rsp.Hash, req.validator(req.ctx, rsp.Data))
+			f.hashValidationDone(rsp.Hash, batch.peer, req.validator(req.ctx, rsp.Data))
 			return nil
 		})
 		delete(batchMap, resp.Hash)
@@ -358,9 +406,26 @@ func (f *Fetch) receiveResponse(data []byte) {
 			log.Stringer("peer", batch.peer))
 		f.failAfterRetry(r.Hash)
 	}
+	if len(batchMap) > 0 {
+		f.scores.onFailure(batch.peer)
+	}
 }
 
-func (f *Fetch) hashValidationDone(hash types.Hash32, err error) {
+func (f *Fetch) hashValidationDone(hash types.Hash32, peer p2p.Peer, err error) {
+	// peer is not responsible for failures to fetch dependencies of the data or to store it
+	switch {
+	case err == nil:
+		f.scores.onValidation(peer, true)
+	case errors.Is(err, pubsub.ErrValidationReject):
+		if f.scores.onValidation(peer, false) {
+			f.logger.With().Warning("peer banned after serving invalid data",
+				log.Stringer("peer", peer),
+				log.Stringer("hash", hash),
+				log.Err(err),
+				log.Duration("duration", f.cfg.PeerBanDuration))
+		}
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -450,10 +515,14 @@ func (f *Fetch) send(requests []RequestMessage) {
 func (f *Fetch) organizeRequests(requests []RequestMessage) map[p2p.Peer][][]RequestMessage {
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	peer2requests := make(map[p2p.Peer][]RequestMessage)
-	peers := f.host.GetPeers()
+	peers := f.filterPeers(f.host.GetPeers(), HashProtocol)
 
 	for _, req := range requests {
-		p, exists := f.hashToPeers.GetRandom(req.Hash, req.Hint, rng)
+		// prefer peers that advertised the hash, and fallback to any peer that is not banned
+		p, exists := f.scores.selectPeer(f.filterPeers(f.hashToPeers.GetPeers(req.Hash, req.Hint), HashProtocol), rng)
+		if !exists {
+			p, exists = f.scores.selectPeer(peers, rng)
+		}
 		if !exists {
 			p = randomPeer(peers)
 		}
@@ -489,6 +558,7 @@ func (f *Fetch) organizeRequests(requests []RequestMessage) map[p2p.Peer][][]Req
 // sendBatch dispatches batched request messages to provided peer.
 func (f *Fetch) sendBatch(p p2p.Peer, batch *batchInfo) error {
 	f.mu.Lock()
+	batch.sent = time.Now()
 	f.batched[batch.ID] = batch
 	f.mu.Unlock()
 
@@ -520,7 +590,7 @@ func (f *Fetch) sendBatch(p p2p.Peer, batch *batchInfo) error {
 			log.Int("num_requests", len(batch.Requests)),
 			log.Stringer("peer", p))
 
-		err = f.servers[hashProtocol].Request(f.shutdownCtx, p, bytes, f.receiveResponse, errorFunc)
+		err = f.servers[HashProtocol].Request(f.shutdownCtx, p, bytes, f.receiveResponse, errorFunc)
 		if err == nil {
 			break
 		}
@@ -550,6 +620,7 @@ func (f *Fetch) handleHashError(batchHash types.Hash32, err error) {
 		f.logger.With().Error("batch not found", log.Stringer("batch_hash", batchHash))
 		return
 	}
+	f.scores.onFailure(batch.peer)
 	for _, br := range batch.Requests {
 		req, ok := f.ongoing[br.Hash]
 		if !ok {
@@ -624,6 +695,27 @@ func (f *Fetch) AddPeersFromHash(fromHash types.Hash32, toHashes []types.Hash32)
 	f.hashToPeers.AddPeersFromHash(fromHash, toHashes)
 }
 
+// GetPeers returns connected peers that are not temporarily banned.
+// If all peers are banned, all connected peers are returned.
 func (f *Fetch) GetPeers() []p2p.Peer {
-	return f.host.GetPeers()
+	peers := f.host.GetPeers()
+	if allowed := f.scores.filter(peers); len(allowed) > 0 {
+		return allowed
+	}
+	return peers
+}
+
+// GetPeersWithProtocol is the same as GetPeers, but returns only peers that support the protocol.
+func (f *Fetch) GetPeersWithProtocol(protocol string) []p2p.Peer {
+	return f.filterPeers(f.GetPeers(), protocol)
+}
+
+func (f *Fetch) filterPeers(peers []p2p.Peer, protocol string) []p2p.Peer {
+	rst := make([]p2p.Peer, 0, len(peers))
+	for _, peer := range peers {
+		if f.host.SupportsProtocol(peer, protocol) {
+			rst = append(rst, peer)
+		}
+	}
+	return rst
 }
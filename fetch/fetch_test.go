@@ -3,6 +3,7 @@ package fetch
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/fetch/mocks"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -27,6 +29,10 @@ type testFetch struct {
 	mOpnS   *mocks.Mockrequester
 	mHashS  *mocks.Mockrequester
 	mMHashS *mocks.Mockrequester
+	mMalS   *mocks.Mockrequester
+	// streaming servers
+	mAtxStreamS *mocks.Mockrequester
+	mLyrStreamS *mocks.Mockrequester
 
 	mMesh      *mocks.MockmeshProvider
 	mAtxH      *mocks.MockatxHandler
@@ -36,23 +42,35 @@ type testFetch struct {
 	method     int
 	mTxH       *mocks.MocktxHandler
 	mPoetH     *mocks.MockpoetHandler
+	mMalH      *mocks.MockmalfeasanceHandler
+	// unsupported peers don't support any of the fetch protocols.
+	unsupported map[p2p.Peer]struct{}
+	// legacy peers don't support streaming protocols.
+	legacy map[p2p.Peer]struct{}
 }
 
 func createFetch(tb testing.TB) *testFetch {
 	ctrl := gomock.NewController(tb)
 	tf := &testFetch{
-		mh:         mocks.NewMockhost(ctrl),
-		mAtxS:      mocks.NewMockrequester(ctrl),
-		mLyrS:      mocks.NewMockrequester(ctrl),
-		mOpnS:      mocks.NewMockrequester(ctrl),
-		mHashS:     mocks.NewMockrequester(ctrl),
-		mMHashS:    mocks.NewMockrequester(ctrl),
-		mAtxH:      mocks.NewMockatxHandler(ctrl),
-		mBallotH:   mocks.NewMockballotHandler(ctrl),
-		mBlocksH:   mocks.NewMockblockHandler(ctrl),
-		mProposalH: mocks.NewMockproposalHandler(ctrl),
-		mTxH:       mocks.NewMocktxHandler(ctrl),
-		mPoetH:     mocks.NewMockpoetHandler(ctrl),
+		mh:          mocks.NewMockhost(ctrl),
+		mAtxS:       mocks.NewMockrequester(ctrl),
+		mLyrS:       mocks.NewMockrequester(ctrl),
+		mOpnS:       mocks.NewMockrequester(ctrl),
+		mHashS:      mocks.NewMockrequester(ctrl),
+		mMHashS:     mocks.NewMockrequester(ctrl),
+		mMalS:       mocks.NewMockrequester(ctrl),
+		mAtxStreamS: mocks.NewMockrequester(ctrl),
+		mLyrStreamS: mocks.NewMockrequester(ctrl),
+		mAtxH:       mocks.NewMockatxHandler(ctrl),
+		mBallotH:    mocks.NewMockballotHandler(ctrl),
+		mBlocksH:    mocks.NewMockblockHandler(ctrl),
+		mProposalH:  mocks.NewMockproposalHandler(ctrl),
+		mTxH:        mocks.NewMocktxHandler(ctrl),
+		mPoetH:      mocks.NewMockpoetHandler(ctrl),
+		mMalH:       mocks.NewMockmalfeasanceHandler(ctrl),
+
+		unsupported: map[p2p.Peer]struct{}{},
+		legacy:      map[p2p.Peer]struct{}{},
 	}
 	cfg := Config{
 		time.Millisecond * time.Duration(2000), // make sure we never hit the batch timeout
@@ -61,6 +79,8 @@ func createFetch(tb testing.TB) *testFetch {
 		1000,
 		time.Second * time.Duration(3),
 		3,
+		3,
+		time.Minute,
 	}
 	lg := logtest.New(tb)
 	tf.Fetch = NewFetch(datastore.NewCachedDB(sql.InMemory(), lg), tf.mMesh, nil, nil,
@@ -73,14 +93,26 @@ func createFetch(tb testing.TB) *testFetch {
 		WithProposalHandler(tf.mProposalH),
 		WithTXHandler(tf.mTxH),
 		WithPoetHandler(tf.mPoetH),
+		WithMalfeasanceHandler(tf.mMalH),
 		withServers(map[string]requester{
-			atxProtocol:      tf.mAtxS,
-			lyrDataProtocol:  tf.mLyrS,
-			lyrOpnsProtocol:  tf.mOpnS,
-			hashProtocol:     tf.mHashS,
-			meshHashProtocol: tf.mMHashS,
+			AtxProtocol:           tf.mAtxS,
+			LyrDataProtocol:       tf.mLyrS,
+			LyrOpnsProtocol:       tf.mOpnS,
+			HashProtocol:          tf.mHashS,
+			MeshHashProtocol:      tf.mMHashS,
+			AtxStreamProtocol:     tf.mAtxStreamS,
+			LyrDataStreamProtocol: tf.mLyrStreamS,
+			MalProtocol:           tf.mMalS,
 		}),
 		withHost(tf.mh))
+	tf.mh.EXPECT().SupportsProtocol(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(peer p2p.Peer, protocol string) bool {
+			if _, exists := tf.unsupported[peer]; exists {
+				return false
+			}
+			_, exists := tf.legacy[peer]
+			return !exists || (protocol != AtxStreamProtocol && protocol != LyrDataStreamProtocol)
+		}).AnyTimes()
 
 	return tf
 }
@@ -275,3 +307,63 @@ func TestFetch_GetRandomPeer(t *testing.T) {
 	}
 	assert.False(t, allTheSame)
 }
+
+func TestFetch_BanPeerServingInvalidData(t *testing.T) {
+	f := createFetch(t)
+	good, bad := p2p.Peer("good"), p2p.Peer("bad")
+	hash := types.RandomHash()
+	f.RegisterPeerHashes(bad, []types.Hash32{hash})
+	f.RegisterPeerHashes(good, []types.Hash32{hash})
+
+	for i := 0; i < 2*f.cfg.PeerBanThreshold; i++ {
+		// data may fail validation because dependencies couldn't be fetched or stored
+		f.hashValidationDone(hash, good, errors.New("failed to fetch atx"))
+	}
+	for i := 0; i < f.cfg.PeerBanThreshold; i++ {
+		f.hashValidationDone(hash, bad, fmt.Errorf("%w: invalid", pubsub.ErrValidationReject))
+	}
+	f.mh.EXPECT().GetPeers().Return([]p2p.Peer{good, bad}).AnyTimes()
+	require.Equal(t, []p2p.Peer{good}, f.GetPeers())
+	for i := 0; i < 10; i++ {
+		requests := f.organizeRequests([]RequestMessage{{Hash: hash, Hint: datastore.BallotDB}})
+		require.Len(t, requests, 1)
+		require.Contains(t, requests, good)
+	}
+}
+
+func TestFetch_UnsupportedProtocol(t *testing.T) {
+	f := createFetch(t)
+	supported, unsupported := p2p.Peer("new"), p2p.Peer("old")
+	f.unsupported[unsupported] = struct{}{}
+	f.legacy[supported] = struct{}{}
+	peers := []p2p.Peer{supported, unsupported}
+	f.mh.EXPECT().GetPeers().Return(peers).AnyTimes()
+
+	require.Equal(t, []p2p.Peer{supported}, f.GetPeersWithProtocol(LyrDataProtocol))
+
+	f.mLyrS.EXPECT().Request(gomock.Any(), supported, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ p2p.Peer, _ []byte, okCB func([]byte), _ func(error)) error {
+			okCB([]byte("data"))
+			return nil
+		})
+	var oks, errs []p2p.Peer
+	require.NoError(t, f.GetLayerData(context.TODO(), peers, types.NewLayerID(10),
+		func(_ []byte, peer p2p.Peer) { oks = append(oks, peer) },
+		func(err error, peer p2p.Peer) {
+			if err != nil {
+				require.ErrorIs(t, err, ErrProtocolNotSupported)
+				errs = append(errs, peer)
+			}
+		}))
+	require.Equal(t, []p2p.Peer{supported}, oks)
+	require.Equal(t, []p2p.Peer{unsupported}, errs)
+
+	err := f.PeerEpochInfo(context.TODO(), unsupported, types.EpochID(1), func(*EpochData) error { return nil })
+	require.ErrorIs(t, err, ErrProtocolNotSupported)
+
+	hash := types.RandomHash()
+	f.RegisterPeerHashes(unsupported, []types.Hash32{hash})
+	requests := f.organizeRequests([]RequestMessage{{Hash: hash, Hint: datastore.BallotDB}})
+	require.Len(t, requests, 1)
+	require.Contains(t, requests, supported)
+}
@@ -3,6 +3,7 @@ package fetch
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -14,34 +15,40 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
+// idsPerChunk is the maximal number of ids in a chunk of the streamed response.
+const idsPerChunk = 1 << 12
+
 type handler struct {
-	logger log.Log
-	cdb    *datastore.CachedDB
-	bs     *datastore.BlobStore
-	msh    meshProvider
-	beacon system.BeaconGetter
+	logger    log.Log
+	chunkSize int
+	cdb       *datastore.CachedDB
+	bs        *datastore.BlobStore
+	msh       meshProvider
+	beacon    system.BeaconGetter
 }
 
 func newHandler(cdb *datastore.CachedDB, bs *datastore.BlobStore, m meshProvider, b system.BeaconGetter, lg log.Log) *handler {
 	return &handler{
-		logger: lg,
-		cdb:    cdb,
-		bs:     bs,
-		msh:    m,
-		beacon: b,
+		logger:    lg,
+		cdb:       cdb,
+		bs:        bs,
+		msh:       m,
+		beacon:    b,
+		chunkSize: idsPerChunk,
 	}
 }
 
 // handleEpochInfoReq returns the ATXs published in the specified epoch.
 func (h *handler) handleEpochInfoReq(ctx context.Context, msg []byte) ([]byte, error) {
 	epoch := types.EpochID(util.BytesToUint32(msg))
-	atxids, err := atxs.GetIDsByEpoch(h.cdb, epoch)
+	atxids, err := h.epochAtxs(ctx, epoch)
 	if err != nil {
-		h.logger.WithContext(ctx).With().Warning("failed to get epoch atx IDs", epoch, log.Err(err))
 		return nil, err
 	}
 	ed := EpochData{
@@ -57,13 +64,113 @@ func (h *handler) handleEpochInfoReq(ctx context.Context, msg []byte) ([]byte, e
 	return bts, nil
 }
 
+// streamEpochInfoReq streams the ATXs published in the specified epoch,
+// every chunk is EpochData with at most chunkSize ATXs.
+func (h *handler) streamEpochInfoReq(ctx context.Context, msg []byte, send func([]byte) error) error {
+	epoch := types.EpochID(util.BytesToUint32(msg))
+	atxids, err := h.epochAtxs(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(atxids); i += h.chunkSize {
+		end := i + h.chunkSize
+		if end > len(atxids) {
+			end = len(atxids)
+		}
+		bts, err := codec.Encode(&EpochData{AtxIDs: atxids[i:end]})
+		if err != nil {
+			h.logger.WithContext(ctx).With().Fatal("failed to serialize epoch atx", epoch, log.Err(err))
+		}
+		if err := send(bts); err != nil {
+			return err
+		}
+	}
+	h.logger.WithContext(ctx).With().Debug("streamed epoch info",
+		epoch,
+		log.Int("atx_count", len(atxids)))
+	return nil
+}
+
+func (h *handler) epochAtxs(ctx context.Context, epoch types.EpochID) ([]types.ATXID, error) {
+	atxids, err := atxs.GetIDsByEpoch(h.cdb, epoch)
+	if err != nil {
+		h.logger.WithContext(ctx).With().Warning("failed to get epoch atx IDs", epoch, log.Err(err))
+		return nil, err
+	}
+	return atxids, nil
+}
+
+// handleMaliciousIDsReq returns the identities that have a malfeasance proof.
+func (h *handler) handleMaliciousIDsReq(ctx context.Context, _ []byte) ([]byte, error) {
+	nodes, err := identities.GetMalicious(h.cdb)
+	if err != nil {
+		h.logger.WithContext(ctx).With().Warning("failed to get malicious ids", log.Err(err))
+		return nil, err
+	}
+	h.logger.WithContext(ctx).With().Debug("responded to malicious ids request", log.Int("num_malicious", len(nodes)))
+	data, err := codec.Encode(&MaliciousIDs{NodeIDs: nodes})
+	if err != nil {
+		h.logger.WithContext(ctx).With().Fatal("failed to serialize malicious ids", log.Err(err))
+	}
+	return data, nil
+}
+
 // handleLayerDataReq returns all data in a layer, described in LayerData.
 func (h *handler) handleLayerDataReq(ctx context.Context, req []byte) ([]byte, error) {
+	ld, err := h.layerData(ctx, types.BytesToLayerID(req))
+	if err != nil {
+		return nil, err
+	}
+	out, err := codec.Encode(ld)
+	if err != nil {
+		h.logger.WithContext(ctx).With().Fatal("failed to serialize layer data response", log.Err(err))
+	}
+	return out, nil
+}
+
+// streamLayerDataReq streams all data in a layer, every chunk is LayerData
+// with at most chunkSize ballots and blocks.
+func (h *handler) streamLayerDataReq(ctx context.Context, req []byte, send func([]byte) error) error {
+	ld, err := h.layerData(ctx, types.BytesToLayerID(req))
+	if err != nil {
+		return err
+	}
+	for len(ld.Ballots) > 0 || len(ld.Blocks) > 0 {
+		var chunk LayerData
+		n := h.chunkSize
+		if n > len(ld.Ballots) {
+			n = len(ld.Ballots)
+		}
+		chunk.Ballots, ld.Ballots = ld.Ballots[:n], ld.Ballots[n:]
+		n = h.chunkSize - n
+		if n > len(ld.Blocks) {
+			n = len(ld.Blocks)
+		}
+		chunk.Blocks, ld.Blocks = ld.Blocks[:n], ld.Blocks[n:]
+		out, err := codec.Encode(&chunk)
+		if err != nil {
+			h.logger.WithContext(ctx).With().Fatal("failed to serialize layer data response", log.Err(err))
+		}
+		if err := send(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) layerData(ctx context.Context, lyrID types.LayerID) (*LayerData, error) {
 	var (
-		lyrID = types.BytesToLayerID(req)
-		ld    LayerData
-		err   error
+		ld  LayerData
+		err error
 	)
+	pruned, err := kvstore.GetPrunedBelow(h.cdb)
+	if err != nil {
+		h.logger.WithContext(ctx).With().Warning("failed to get pruned layer", lyrID, log.Err(err))
+		return nil, err
+	}
+	if lyrID.Before(pruned) {
+		return nil, fmt.Errorf("%w: layer %s is below %s", sql.ErrPruned, lyrID, pruned)
+	}
 	ld.Ballots, err = ballots.IDsInLayer(h.cdb, lyrID)
 	if err != nil && !errors.Is(err, sql.ErrNotFound) {
 		h.logger.WithContext(ctx).With().Warning("failed to get layer ballots", lyrID, log.Err(err))
@@ -74,12 +181,7 @@ func (h *handler) handleLayerDataReq(ctx context.Context, req []byte) ([]byte, e
 		h.logger.WithContext(ctx).With().Warning("failed to get layer blocks", lyrID, log.Err(err))
 		return nil, err
 	}
-
-	out, err := codec.Encode(&ld)
-	if err != nil {
-		h.logger.WithContext(ctx).With().Fatal("failed to serialize layer data response", log.Err(err))
-	}
-	return out, nil
+	return &ld, nil
 }
 
 // handleLayerOpinionsReq returns the opinions on data in the specified layer, described in LayerOpinion.
@@ -2,6 +2,7 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -19,6 +20,8 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
 )
@@ -112,6 +115,49 @@ func TestHandleLayerDataReq(t *testing.T) {
 	}
 }
 
+func TestHandleLayerDataReq_Pruned(t *testing.T) {
+	th := createTestHandler(t)
+	lid := types.NewLayerID(111)
+	createLayer(t, th.cdb, lid)
+	require.NoError(t, kvstore.SetPrunedBelow(th.cdb, lid.Add(1)))
+
+	_, err := th.handleLayerDataReq(context.TODO(), lid.Bytes())
+	require.ErrorIs(t, err, sql.ErrPruned)
+
+	_, err = th.handleLayerDataReq(context.TODO(), lid.Add(1).Bytes())
+	require.NoError(t, err)
+}
+
+func TestHandleHashReq_Pruned(t *testing.T) {
+	th := createTestHandler(t)
+	lid := types.NewLayerID(111)
+	blts, _ := createLayer(t, th.cdb, lid)
+	pruned := types.RandomBallot().ID()
+	require.NoError(t, kvstore.SetPrunedBelow(th.cdb, lid))
+
+	request := func(ids ...types.BallotID) ([]byte, error) {
+		batch := RequestBatch{ID: types.RandomHash()}
+		for _, id := range ids {
+			batch.Requests = append(batch.Requests, RequestMessage{Hint: datastore.BallotDB, Hash: id.AsHash32()})
+		}
+		data, err := codec.Encode(&batch)
+		require.NoError(t, err)
+		return th.handleHashReq(context.TODO(), data)
+	}
+	// layer of the missing ballot is unknown, it is omitted as any other missing item
+	out, err := request(pruned)
+	require.NoError(t, err)
+	var got ResponseBatch
+	require.NoError(t, codec.Decode(out, &got))
+	require.Empty(t, got.Responses)
+
+	out, err = request(pruned, blts[0])
+	require.NoError(t, err)
+	require.NoError(t, codec.Decode(out, &got))
+	require.Len(t, got.Responses, 1)
+	require.Equal(t, blts[0].AsHash32(), got.Responses[0].Hash)
+}
+
 func TestHandleLayerOpinionsReq(t *testing.T) {
 	tt := []struct {
 		name                       string
@@ -289,3 +335,83 @@ func TestHandleEpochInfoReq(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleMaliciousIDsReq(t *testing.T) {
+	th := createTestHandler(t)
+	var expected []types.NodeID
+	for i := 0; i < 3; i++ {
+		id := types.RandomNodeID()
+		require.NoError(t, identities.SetMalfeasanceProof(th.cdb, id.Bytes(), []byte("proof")))
+		expected = append(expected, id)
+	}
+	// identities without a proof are not served
+	require.NoError(t, identities.SetMalicious(th.cdb, types.RandomNodeID().Bytes()))
+
+	out, err := th.handleMaliciousIDsReq(context.TODO(), []byte{})
+	require.NoError(t, err)
+	var got MaliciousIDs
+	require.NoError(t, codec.Decode(out, &got))
+	require.ElementsMatch(t, expected, got.NodeIDs)
+}
+
+func TestStreamLayerDataReq(t *testing.T) {
+	lid := types.NewLayerID(111)
+	th := createTestHandler(t)
+	th.chunkSize = 3
+	blts, blks := createLayer(t, th.cdb, lid)
+
+	var chunks []LayerData
+	send := func(data []byte) error {
+		var chunk LayerData
+		require.NoError(t, codec.Decode(data, &chunk))
+		require.LessOrEqual(t, len(chunk.Ballots)+len(chunk.Blocks), th.chunkSize)
+		chunks = append(chunks, chunk)
+		return nil
+	}
+	require.NoError(t, th.streamLayerDataReq(context.TODO(), lid.Bytes(), send))
+	require.Len(t, chunks, 4)
+	var got LayerData
+	for _, chunk := range chunks {
+		got.Ballots = append(got.Ballots, chunk.Ballots...)
+		got.Blocks = append(got.Blocks, chunk.Blocks...)
+	}
+	require.ElementsMatch(t, blts, got.Ballots)
+	require.ElementsMatch(t, blks, got.Blocks)
+
+	chunks = nil
+	require.NoError(t, th.streamLayerDataReq(context.TODO(), lid.Add(1).Bytes(), send))
+	require.Empty(t, chunks)
+
+	require.NoError(t, kvstore.SetPrunedBelow(th.cdb, lid.Add(1)))
+	require.ErrorIs(t, th.streamLayerDataReq(context.TODO(), lid.Bytes(), send), sql.ErrPruned)
+}
+
+func TestStreamEpochInfoReq(t *testing.T) {
+	th := createTestHandler(t)
+	th.chunkSize = 4
+	epoch := types.EpochID(11)
+	var expected []types.ATXID
+	for i := 0; i < 10; i++ {
+		vatx := newAtx(t, epoch)
+		require.NoError(t, atxs.Add(th.cdb, vatx, time.Now()))
+		expected = append(expected, vatx.ID())
+	}
+
+	var got []types.ATXID
+	var chunks int
+	require.NoError(t, th.streamEpochInfoReq(context.TODO(), epoch.ToBytes(), func(data []byte) error {
+		var ed EpochData
+		require.NoError(t, codec.Decode(data, &ed))
+		require.LessOrEqual(t, len(ed.AtxIDs), th.chunkSize)
+		got = append(got, ed.AtxIDs...)
+		chunks++
+		return nil
+	}))
+	require.Equal(t, 3, chunks)
+	require.ElementsMatch(t, expected, got)
+
+	errSend := errors.New("send")
+	require.ErrorIs(t, th.streamEpochInfoReq(context.TODO(), epoch.ToBytes(), func([]byte) error {
+		return errSend
+	}), errSend)
+}
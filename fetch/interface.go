@@ -11,6 +11,7 @@ import (
 
 type requester interface {
 	Request(context.Context, p2p.Peer, []byte, func([]byte), func(error)) error
+	StreamRequest(context.Context, p2p.Peer, []byte, func([]byte) error, func(error)) error
 }
 
 type atxHandler interface {
@@ -38,11 +39,16 @@ type poetHandler interface {
 	ValidateAndStoreMsg(context.Context, []byte) error
 }
 
+type malfeasanceHandler interface {
+	HandleSyncedMalfeasanceProof(context.Context, []byte) error
+}
+
 type meshProvider interface {
 	LastVerified() types.LayerID
 }
 
 type host interface {
 	GetPeers() []p2p.Peer
+	SupportsProtocol(p2p.Peer, string) bool
 	Close() error
 }
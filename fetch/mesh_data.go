@@ -62,6 +62,16 @@ func (f *Fetch) getHashes(ctx context.Context, hashes []types.Hash32, hint datas
 	return eg.Wait().ErrorOrNil()
 }
 
+// GetMalfeasanceProofs gets malfeasance proofs for the specified NodeIDs and validates them.
+func (f *Fetch) GetMalfeasanceProofs(ctx context.Context, ids []types.NodeID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	f.logger.WithContext(ctx).With().Debug("requesting malfeasance proofs from peer", log.Int("num_proofs", len(ids)))
+	hashes := types.NodeIDsToHashes(ids)
+	return f.getHashes(ctx, hashes, datastore.MalfeasanceDB, f.malHandler.HandleSyncedMalfeasanceProof)
+}
+
 // GetBallots gets data for the specified BallotIDs and validates them.
 func (f *Fetch) GetBallots(ctx context.Context, ids []types.BallotID) error {
 	if len(ids) == 0 {
@@ -146,17 +156,48 @@ func (f *Fetch) GetPoetProof(ctx context.Context, id types.Hash32) error {
 	}
 }
 
-// GetLayerData get layer data from peers.
-func (f *Fetch) GetLayerData(ctx context.Context, peers []p2p.Peer, lid types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
-	return poll(ctx, f.servers[lyrDataProtocol], peers, lid.Bytes(), okCB, errCB)
+// GetLayerData get layer data from peers. Layer data is streamed from peers that support streaming,
+// and chunkCB receives every chunk as soon as it is received. doneCB is called once for every peer,
+// with nil error if the whole response was received.
+func (f *Fetch) GetLayerData(ctx context.Context, peers []p2p.Peer, lid types.LayerID, chunkCB func([]byte, p2p.Peer), doneCB func(error, p2p.Peer)) error {
+	legacy := make([]p2p.Peer, 0, len(peers))
+	for _, p := range peers {
+		if !f.host.SupportsProtocol(p, LyrDataStreamProtocol) {
+			legacy = append(legacy, p)
+			continue
+		}
+		peer := p
+		chunkFunc := func(data []byte) error {
+			var chunk LayerData
+			if err := codec.Decode(data, &chunk); err != nil {
+				return err
+			}
+			chunkCB(data, peer)
+			return nil
+		}
+		doneFunc := func(err error) {
+			doneCB(err, peer)
+		}
+		if err := f.servers[LyrDataStreamProtocol].StreamRequest(ctx, peer, lid.Bytes(), chunkFunc, doneFunc); err != nil {
+			doneCB(err, peer)
+		}
+	}
+	okFunc := func(data []byte, peer p2p.Peer) {
+		chunkCB(data, peer)
+		doneCB(nil, peer)
+	}
+	return f.poll(ctx, LyrDataProtocol, legacy, lid.Bytes(), okFunc, doneCB)
 }
 
 // GetLayerOpinions get opinions on data in the specified layer from peers.
 func (f *Fetch) GetLayerOpinions(ctx context.Context, peers []p2p.Peer, lid types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
-	return poll(ctx, f.servers[lyrOpnsProtocol], peers, lid.Bytes(), okCB, errCB)
+	return f.poll(ctx, LyrOpnsProtocol, peers, lid.Bytes(), okCB, errCB)
 }
 
-func poll(ctx context.Context, srv requester, peers []p2p.Peer, req []byte, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
+// poll sends request to every peer. Peers that don't support the protocol are not requested,
+// and errCB is called for them with ErrProtocolNotSupported.
+func (f *Fetch) poll(ctx context.Context, protocol string, peers []p2p.Peer, req []byte, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
+	srv := f.servers[protocol]
 	for _, p := range peers {
 		peer := p
 		okFunc := func(data []byte) {
@@ -165,6 +206,10 @@ func poll(ctx context.Context, srv requester, peers []p2p.Peer, req []byte, okCB
 		errFunc := func(err error) {
 			errCB(err, peer)
 		}
+		if !f.host.SupportsProtocol(peer, protocol) {
+			errFunc(fmt.Errorf("%w: %s", ErrProtocolNotSupported, protocol))
+			continue
+		}
 		if err := srv.Request(ctx, peer, req, okFunc, errFunc); err != nil {
 			errFunc(err)
 		}
@@ -173,36 +218,81 @@ func poll(ctx context.Context, srv requester, peers []p2p.Peer, req []byte, okCB
 }
 
 // PeerEpochInfo get the epoch info published in the given epoch from the specified peer.
-func (f *Fetch) PeerEpochInfo(ctx context.Context, peer p2p.Peer, epoch types.EpochID) (*EpochData, error) {
+// Epoch info is streamed if peer supports streaming, and chunkCB receives every chunk
+// as soon as it is received. Otherwise chunkCB receives the whole epoch info.
+func (f *Fetch) PeerEpochInfo(ctx context.Context, peer p2p.Peer, epoch types.EpochID, chunkCB func(*EpochData) error) error {
 	f.logger.WithContext(ctx).With().Debug("requesting epoch info from peer",
 		log.Stringer("peer", peer),
 		log.Stringer("epoch", epoch))
 
-	var (
-		done = make(chan error, 1)
-		ed   EpochData
-	)
-	okCB := func(data []byte) {
-		defer close(done)
-		done <- codec.Decode(data, &ed)
+	received := func(data []byte) error {
+		var ed EpochData
+		if err := codec.Decode(data, &ed); err != nil {
+			return err
+		}
+		f.RegisterPeerHashes(peer, types.ATXIDsToHashes(ed.AtxIDs))
+		return chunkCB(&ed)
 	}
-	errCB := func(perr error) {
-		defer close(done)
-		done <- perr
+	switch {
+	case f.host.SupportsProtocol(peer, AtxStreamProtocol):
+		return f.streamRequest(ctx, AtxStreamProtocol, peer, epoch.ToBytes(), received)
+	case f.host.SupportsProtocol(peer, AtxProtocol):
+		return f.request(ctx, AtxProtocol, peer, epoch.ToBytes(), received)
+	}
+	return fmt.Errorf("%w: %s", ErrProtocolNotSupported, AtxProtocol)
+}
+
+// PeerMalfeasanceIDs gets the identities with malfeasance proofs from the specified peer.
+func (f *Fetch) PeerMalfeasanceIDs(ctx context.Context, peer p2p.Peer) ([]types.NodeID, error) {
+	f.logger.WithContext(ctx).With().Debug("requesting malicious ids from peer", log.Stringer("peer", peer))
+	if !f.host.SupportsProtocol(peer, MalProtocol) {
+		return nil, fmt.Errorf("%w: %s", ErrProtocolNotSupported, MalProtocol)
 	}
-	if err := f.servers[atxProtocol].Request(ctx, peer, epoch.ToBytes(), okCB, errCB); err != nil {
+	var ids MaliciousIDs
+	if err := f.request(ctx, MalProtocol, peer, []byte{}, func(data []byte) error {
+		return codec.Decode(data, &ids)
+	}); err != nil {
 		return nil, err
 	}
+	f.RegisterPeerHashes(peer, types.NodeIDsToHashes(ids.NodeIDs))
+	return ids.NodeIDs, nil
+}
+
+// request sends request to the peer and waits for the response to be processed by okCB.
+func (f *Fetch) request(ctx context.Context, protocol string, peer p2p.Peer, req []byte, okCB func([]byte) error) error {
+	done := make(chan error, 1)
+	okFunc := func(data []byte) {
+		done <- okCB(data)
+	}
+	errFunc := func(err error) {
+		done <- err
+	}
+	if err := f.servers[protocol].Request(ctx, peer, req, okFunc, errFunc); err != nil {
+		return err
+	}
+	return f.wait(ctx, done)
+}
+
+// streamRequest sends request to the peer and waits until every chunk of the response
+// is processed by chunkCB.
+func (f *Fetch) streamRequest(ctx context.Context, protocol string, peer p2p.Peer, req []byte, chunkCB func([]byte) error) error {
+	done := make(chan error, 1)
+	doneFunc := func(err error) {
+		done <- err
+	}
+	if err := f.servers[protocol].StreamRequest(ctx, peer, req, chunkCB, doneFunc); err != nil {
+		return err
+	}
+	return f.wait(ctx, done)
+}
+
+func (f *Fetch) wait(ctx context.Context, done <-chan error) error {
 	select {
 	case err := <-done:
-		if err != nil {
-			return nil, err
-		}
-		f.RegisterPeerHashes(peer, types.ATXIDsToHashes(ed.AtxIDs))
-		return &ed, nil
+		return err
 	case <-ctx.Done():
 		f.logger.WithContext(ctx).With().Debug("context done")
-		return nil, ctx.Err()
+		return ctx.Err()
 	}
 }
 
@@ -229,6 +319,9 @@ func (f *Fetch) PeerMeshHashes(ctx context.Context, peer p2p.Peer, req *MeshHash
 	f.logger.WithContext(ctx).With().Debug("requesting mesh hashes from peer",
 		log.Stringer("peer", peer),
 		log.Object("req", req))
+	if !f.host.SupportsProtocol(peer, MeshHashProtocol) {
+		return nil, fmt.Errorf("%w: %s", ErrProtocolNotSupported, MeshHashProtocol)
+	}
 
 	var (
 		done    = make(chan error, 1)
@@ -254,7 +347,7 @@ func (f *Fetch) PeerMeshHashes(ctx context.Context, peer p2p.Peer, req *MeshHash
 		defer close(done)
 		done <- perr
 	}
-	if err = f.servers[meshHashProtocol].Request(ctx, peer, reqData, okCB, errCB); err != nil {
+	if err = f.servers[MeshHashProtocol].Request(ctx, peer, reqData, okCB, errCB); err != nil {
 		return nil, err
 	}
 	select {
@@ -375,6 +375,20 @@ func TestGetATXs(t *testing.T) {
 	require.NoError(t, eg.Wait())
 }
 
+func TestGetMalfeasanceProofs(t *testing.T) {
+	nodeIDs := []types.NodeID{types.RandomNodeID(), types.RandomNodeID()}
+	f := createFetch(t)
+	f.mMalH.EXPECT().HandleSyncedMalfeasanceProof(gomock.Any(), gomock.Any()).Return(nil).Times(len(nodeIDs))
+
+	stop := make(chan struct{}, 1)
+	var eg errgroup.Group
+	startTestLoop(t, f.Fetch, &eg, stop)
+
+	require.NoError(t, f.GetMalfeasanceProofs(context.TODO(), nodeIDs))
+	close(stop)
+	require.NoError(t, eg.Wait())
+}
+
 func TestGetPoetProof(t *testing.T) {
 	f := createFetch(t)
 	h := types.RandomHash()
@@ -413,16 +427,20 @@ func TestFetch_GetLayerData(t *testing.T) {
 
 			require.Equal(t, len(peers), len(tc.errs))
 			f := createFetch(t)
+			for _, p := range peers {
+				f.legacy[p] = struct{}{}
+			}
 			oks := make(chan struct{}, len(peers))
 			errs := make(chan struct{}, len(peers))
 			var wg sync.WaitGroup
 			wg.Add(len(peers))
 			okFunc := func(data []byte, peer p2p.Peer) {
 				oks <- struct{}{}
-				wg.Done()
 			}
 			errFunc := func(err error, peer p2p.Peer) {
-				errs <- struct{}{}
+				if err != nil {
+					errs <- struct{}{}
+				}
 				wg.Done()
 			}
 			var expOk, expErr int
@@ -545,6 +563,7 @@ func Test_PeerEpochInfo(t *testing.T) {
 			t.Parallel()
 
 			f := createFetch(t)
+			f.legacy[peer] = struct{}{}
 			var expected *EpochData
 			f.mAtxS.EXPECT().Request(gomock.Any(), peer, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
 				func(_ context.Context, _ p2p.Peer, req []byte, okCB func([]byte), errCB func(error)) error {
@@ -557,11 +576,176 @@ func Test_PeerEpochInfo(t *testing.T) {
 					}
 					return nil
 				})
-			got, err := f.PeerEpochInfo(context.TODO(), peer, types.EpochID(111))
+			var got []*EpochData
+			err := f.PeerEpochInfo(context.TODO(), peer, types.EpochID(111), func(ed *EpochData) error {
+				got = append(got, ed)
+				return nil
+			})
+			require.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				require.Equal(t, []*EpochData{expected}, got)
+			}
+		})
+	}
+}
+
+func Test_PeerMalfeasanceIDs(t *testing.T) {
+	peer := p2p.Peer("p0")
+	errUnknown := errors.New("unknown")
+	tt := []struct {
+		name        string
+		unsupported bool
+		err         error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "fail",
+			err:  errUnknown,
+		},
+		{
+			name:        "not supported",
+			unsupported: true,
+			err:         ErrProtocolNotSupported,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := createFetch(t)
+			if tc.unsupported {
+				f.unsupported[peer] = struct{}{}
+			}
+			expected := []types.NodeID{types.RandomNodeID(), types.RandomNodeID()}
+			if !tc.unsupported {
+				f.mMalS.EXPECT().Request(gomock.Any(), peer, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ p2p.Peer, _ []byte, okCB func([]byte), errCB func(error)) error {
+						if tc.err == nil {
+							data, err := codec.Encode(&MaliciousIDs{NodeIDs: expected})
+							require.NoError(t, err)
+							okCB(data)
+						} else {
+							errCB(tc.err)
+						}
+						return nil
+					})
+			}
+			got, err := f.PeerMalfeasanceIDs(context.TODO(), peer)
 			require.ErrorIs(t, err, tc.err)
 			if tc.err == nil {
 				require.Equal(t, expected, got)
+				for _, id := range expected {
+					require.Equal(t, []p2p.Peer{peer}, f.hashToPeers.GetPeers(types.Hash32(id), datastore.MalfeasanceDB))
+				}
+			}
+		})
+	}
+}
+
+func TestFetch_GetLayerDataStreamed(t *testing.T) {
+	peers := []p2p.Peer{"p0", "p1", "p2"}
+	errUnknown := errors.New("unknown")
+	f := createFetch(t)
+	f.legacy[peers[2]] = struct{}{}
+
+	expected := LayerData{
+		Ballots: []types.BallotID{{1}, {2}, {3}},
+		Blocks:  []types.BlockID{{4}},
+	}
+	chunks := []LayerData{
+		{Ballots: expected.Ballots[:2]},
+		{Ballots: expected.Ballots[2:], Blocks: expected.Blocks},
+	}
+	f.mLyrStreamS.EXPECT().StreamRequest(gomock.Any(), peers[0], gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ p2p.Peer, _ []byte, chunkCB func([]byte) error, doneCB func(error)) error {
+			for _, chunk := range chunks {
+				data, err := codec.Encode(&chunk)
+				require.NoError(t, err)
+				require.NoError(t, chunkCB(data))
 			}
+			doneCB(nil)
+			return nil
+		})
+	f.mLyrStreamS.EXPECT().StreamRequest(gomock.Any(), peers[1], gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ p2p.Peer, _ []byte, chunkCB func([]byte) error, doneCB func(error)) error {
+			require.Error(t, chunkCB([]byte("invalid")))
+			doneCB(errUnknown)
+			return nil
+		})
+	// peers that don't support streaming are requested with the legacy protocol
+	f.mLyrS.EXPECT().Request(gomock.Any(), peers[2], gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ p2p.Peer, _ []byte, okCB func([]byte), _ func(error)) error {
+			data, err := codec.Encode(&expected)
+			require.NoError(t, err)
+			okCB(data)
+			return nil
+		})
+	received := map[p2p.Peer][]LayerData{}
+	done := map[p2p.Peer]error{}
+	require.NoError(t, f.GetLayerData(context.TODO(), peers, types.NewLayerID(111),
+		func(data []byte, peer p2p.Peer) {
+			var ld LayerData
+			require.NoError(t, codec.Decode(data, &ld))
+			received[peer] = append(received[peer], ld)
+		},
+		func(err error, peer p2p.Peer) {
+			done[peer] = err
+		}))
+	// chunks are delivered as they are received, and invalid chunks are not delivered
+	require.Equal(t, map[p2p.Peer][]LayerData{peers[0]: chunks, peers[2]: {expected}}, received)
+	require.Equal(t, map[p2p.Peer]error{peers[0]: nil, peers[1]: errUnknown, peers[2]: nil}, done)
+}
+
+func Test_PeerEpochInfoStreamed(t *testing.T) {
+	peer := p2p.Peer("p0")
+	errUnknown := errors.New("unknown")
+	tt := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "fail",
+			err:  errUnknown,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := createFetch(t)
+			expected := &EpochData{AtxIDs: types.RandomActiveSet(11)}
+			f.mAtxStreamS.EXPECT().StreamRequest(gomock.Any(), peer, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ p2p.Peer, req []byte, chunkCB func([]byte) error, doneCB func(error)) error {
+					require.Equal(t, types.EpochID(111).ToBytes(), req)
+					for i := 0; i < len(expected.AtxIDs); i += 5 {
+						end := i + 5
+						if end > len(expected.AtxIDs) {
+							end = len(expected.AtxIDs)
+						}
+						data, err := codec.Encode(&EpochData{AtxIDs: expected.AtxIDs[i:end]})
+						require.NoError(t, err)
+						require.NoError(t, chunkCB(data))
+					}
+					doneCB(tc.err)
+					return nil
+				})
+			var got []types.ATXID
+			err := f.PeerEpochInfo(context.TODO(), peer, types.EpochID(111), func(ed *EpochData) error {
+				require.LessOrEqual(t, len(ed.AtxIDs), 5)
+				got = append(got, ed.AtxIDs...)
+				return nil
+			})
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, expected.AtxIDs, got)
 		})
 	}
 }
@@ -22,6 +22,21 @@ var (
 		subsystem,
 		"Total hash-to-peer cache lookups",
 		[]string{"hint"})
+
+	peerOutcomes = metrics.NewCounter(
+		"peer_outcomes",
+		subsystem,
+		"Outcomes of the requests to peers",
+		[]string{"outcome"})
+	peerSuccess = peerOutcomes.WithLabelValues("success")
+	peerFailure = peerOutcomes.WithLabelValues("failure")
+	peerInvalid = peerOutcomes.WithLabelValues("invalid")
+
+	peerBans = metrics.NewCounter(
+		"peer_bans",
+		subsystem,
+		"Number of peers temporarily banned for serving invalid data",
+		[]string{}).WithLabelValues()
 )
 
 // logCacheHit logs cache hit.
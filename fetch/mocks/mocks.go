@@ -50,6 +50,20 @@ func (mr *MockrequesterMockRecorder) Request(arg0, arg1, arg2, arg3, arg4 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*Mockrequester)(nil).Request), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StreamRequest mocks base method.
+func (m *Mockrequester) StreamRequest(arg0 context.Context, arg1 p2p.Peer, arg2 []byte, arg3 func([]byte) error, arg4 func(error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamRequest", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamRequest indicates an expected call of StreamRequest.
+func (mr *MockrequesterMockRecorder) StreamRequest(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamRequest", reflect.TypeOf((*Mockrequester)(nil).StreamRequest), arg0, arg1, arg2, arg3, arg4)
+}
+
 // MockatxHandler is a mock of atxHandler interface.
 type MockatxHandler struct {
 	ctrl     *gomock.Controller
@@ -286,6 +300,43 @@ func (mr *MockpoetHandlerMockRecorder) ValidateAndStoreMsg(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAndStoreMsg", reflect.TypeOf((*MockpoetHandler)(nil).ValidateAndStoreMsg), arg0, arg1)
 }
 
+// MockmalfeasanceHandler is a mock of malfeasanceHandler interface.
+type MockmalfeasanceHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockmalfeasanceHandlerMockRecorder
+}
+
+// MockmalfeasanceHandlerMockRecorder is the mock recorder for MockmalfeasanceHandler.
+type MockmalfeasanceHandlerMockRecorder struct {
+	mock *MockmalfeasanceHandler
+}
+
+// NewMockmalfeasanceHandler creates a new mock instance.
+func NewMockmalfeasanceHandler(ctrl *gomock.Controller) *MockmalfeasanceHandler {
+	mock := &MockmalfeasanceHandler{ctrl: ctrl}
+	mock.recorder = &MockmalfeasanceHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmalfeasanceHandler) EXPECT() *MockmalfeasanceHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleSyncedMalfeasanceProof mocks base method.
+func (m *MockmalfeasanceHandler) HandleSyncedMalfeasanceProof(arg0 context.Context, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSyncedMalfeasanceProof", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSyncedMalfeasanceProof indicates an expected call of HandleSyncedMalfeasanceProof.
+func (mr *MockmalfeasanceHandlerMockRecorder) HandleSyncedMalfeasanceProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSyncedMalfeasanceProof", reflect.TypeOf((*MockmalfeasanceHandler)(nil).HandleSyncedMalfeasanceProof), arg0, arg1)
+}
+
 // MockmeshProvider is a mock of meshProvider interface.
 type MockmeshProvider struct {
 	ctrl     *gomock.Controller
@@ -373,3 +424,17 @@ func (mr *MockhostMockRecorder) GetPeers() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*Mockhost)(nil).GetPeers))
 }
+
+// SupportsProtocol mocks base method.
+func (m *Mockhost) SupportsProtocol(arg0 p2p.Peer, arg1 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsProtocol", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsProtocol indicates an expected call of SupportsProtocol.
+func (mr *MockhostMockRecorder) SupportsProtocol(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsProtocol", reflect.TypeOf((*Mockhost)(nil).SupportsProtocol), arg0, arg1)
+}
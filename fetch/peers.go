@@ -0,0 +1,181 @@
+package fetch
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/p2p"
+)
+
+const (
+	// scoreDecay is a weight of the latest outcome in the peer success rate and latency.
+	scoreDecay = 0.2
+	// latencyReference is a latency at which peer weight is halved.
+	latencyReference = 100 * time.Millisecond
+	// minWeight ensures that peers with poor score are still occasionally selected,
+	// so that their score can recover.
+	minWeight = 0.01
+	// pruneInterval is how often stats of disconnected peers are removed.
+	pruneInterval = time.Minute
+)
+
+type peerStats struct {
+	// success is an exponentially weighted rate of successful requests.
+	success float64
+	// latency is an exponentially weighted latency of successful requests.
+	latency time.Duration
+	// invalid is a number of consecutive responses that failed validation.
+	invalid     int
+	bannedUntil time.Time
+}
+
+func (s *peerStats) weight() float64 {
+	w := s.success / (1 + float64(s.latency)/float64(latencyReference))
+	if w < minWeight {
+		return minWeight
+	}
+	return w
+}
+
+// peerScores tracks outcomes of the requests to peers and selects peers
+// with probability proportional to their success rate, discounted by latency.
+// Peers that repeatedly serve data that fails validation are temporarily banned.
+type peerScores struct {
+	banThreshold int
+	banDuration  time.Duration
+	now          func() time.Time
+
+	mu    sync.Mutex
+	peers map[p2p.Peer]*peerStats
+}
+
+func newPeerScores(banThreshold int, banDuration time.Duration) *peerScores {
+	return &peerScores{
+		banThreshold: banThreshold,
+		banDuration:  banDuration,
+		now:          time.Now,
+		peers:        map[p2p.Peer]*peerStats{},
+	}
+}
+
+// get returns stats for a peer (non-thread-safe).
+func (ps *peerScores) get(peer p2p.Peer) *peerStats {
+	stats, exists := ps.peers[peer]
+	if !exists {
+		stats = &peerStats{success: 0.5}
+		ps.peers[peer] = stats
+	}
+	return stats
+}
+
+// onSuccess records a response received from the peer after latency.
+func (ps *peerScores) onSuccess(peer p2p.Peer, latency time.Duration) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	peerSuccess.Inc()
+	stats := ps.get(peer)
+	stats.success = (1-scoreDecay)*stats.success + scoreDecay
+	if stats.latency == 0 {
+		stats.latency = latency
+	} else {
+		stats.latency = time.Duration((1-scoreDecay)*float64(stats.latency) + scoreDecay*float64(latency))
+	}
+}
+
+// onFailure records a request to the peer that failed or timed out.
+func (ps *peerScores) onFailure(peer p2p.Peer) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	peerFailure.Inc()
+	stats := ps.get(peer)
+	stats.success = (1 - scoreDecay) * stats.success
+}
+
+// onValidation records the result of the validation of the data served by the peer.
+// Returns true if the peer was banned as a result.
+func (ps *peerScores) onValidation(peer p2p.Peer, valid bool) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	stats := ps.get(peer)
+	if valid {
+		stats.invalid = 0
+		return false
+	}
+	peerInvalid.Inc()
+	stats.success = (1 - scoreDecay) * stats.success
+	stats.invalid++
+	if ps.banThreshold == 0 || stats.invalid < ps.banThreshold {
+		return false
+	}
+	stats.invalid = 0
+	stats.bannedUntil = ps.now().Add(ps.banDuration)
+	peerBans.Inc()
+	return true
+}
+
+// prune removes stats of the peers that are not connected, unless they are banned.
+// Bans are kept until they expire, so that peer can't reset the ban by reconnecting.
+func (ps *peerScores) prune(connected []p2p.Peer) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	keep := make(map[p2p.Peer]struct{}, len(connected))
+	for _, peer := range connected {
+		keep[peer] = struct{}{}
+	}
+	for peer := range ps.peers {
+		if _, exists := keep[peer]; !exists && !ps.isBanned(peer) {
+			delete(ps.peers, peer)
+		}
+	}
+}
+
+func (ps *peerScores) isBanned(peer p2p.Peer) bool {
+	stats, exists := ps.peers[peer]
+	return exists && ps.now().Before(stats.bannedUntil)
+}
+
+// filter returns peers that are not banned.
+func (ps *peerScores) filter(peers []p2p.Peer) []p2p.Peer {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	rst := make([]p2p.Peer, 0, len(peers))
+	for _, peer := range peers {
+		if !ps.isBanned(peer) {
+			rst = append(rst, peer)
+		}
+	}
+	return rst
+}
+
+// selectPeer selects a peer that is not banned, weighted by peer score.
+// Returns false if there are no such peers.
+func (ps *peerScores) selectPeer(peers []p2p.Peer, rng *rand.Rand) (p2p.Peer, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	var (
+		total      float64
+		candidates = make([]p2p.Peer, 0, len(peers))
+		weights    = make([]float64, 0, len(peers))
+	)
+	for _, peer := range peers {
+		if ps.isBanned(peer) {
+			continue
+		}
+		w := ps.get(peer).weight()
+		total += w
+		candidates = append(candidates, peer)
+		weights = append(weights, w)
+	}
+	if len(candidates) == 0 {
+		return p2p.NoPeer, false
+	}
+	r := rng.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return candidates[i], true
+		}
+		r -= w
+	}
+	return candidates[len(candidates)-1], true
+}
@@ -0,0 +1,102 @@
+package fetch
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/p2p"
+)
+
+func selectionCounts(ps *peerScores, peers []p2p.Peer, n int) map[p2p.Peer]int {
+	rng := rand.New(rand.NewSource(1001))
+	counts := map[p2p.Peer]int{}
+	for i := 0; i < n; i++ {
+		peer, exists := ps.selectPeer(peers, rng)
+		if exists {
+			counts[peer]++
+		}
+	}
+	return counts
+}
+
+func TestPeerScores_Select(t *testing.T) {
+	peers := []p2p.Peer{"fast", "slow", "failing"}
+	ps := newPeerScores(3, time.Minute)
+	for i := 0; i < 10; i++ {
+		ps.onSuccess(peers[0], 10*time.Millisecond)
+		ps.onSuccess(peers[1], 2*time.Second)
+		ps.onFailure(peers[2])
+	}
+	counts := selectionCounts(ps, peers, 1000)
+	require.Greater(t, counts[peers[0]], 850)
+	// poorly scored peers are still selected occasionally to let them recover
+	require.NotZero(t, counts[peers[1]])
+	require.NotZero(t, counts[peers[2]])
+
+	t.Run("unknown", func(t *testing.T) {
+		peer, exists := ps.selectPeer([]p2p.Peer{"unknown"}, rand.New(rand.NewSource(1)))
+		require.True(t, exists)
+		require.Equal(t, p2p.Peer("unknown"), peer)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, exists := ps.selectPeer(nil, rand.New(rand.NewSource(1)))
+		require.False(t, exists)
+	})
+}
+
+func TestPeerScores_Ban(t *testing.T) {
+	peers := []p2p.Peer{"good", "bad"}
+	ps := newPeerScores(3, time.Minute)
+	now := time.Now()
+	ps.now = func() time.Time { return now }
+
+	require.False(t, ps.onValidation(peers[1], false))
+	require.False(t, ps.onValidation(peers[1], false))
+	// valid response resets consecutive failures
+	require.False(t, ps.onValidation(peers[1], true))
+	require.False(t, ps.onValidation(peers[1], false))
+	require.False(t, ps.onValidation(peers[1], false))
+	require.Equal(t, peers, ps.filter(peers))
+	require.True(t, ps.onValidation(peers[1], false))
+
+	require.Equal(t, peers[:1], ps.filter(peers))
+	counts := selectionCounts(ps, peers, 100)
+	require.Equal(t, 100, counts[peers[0]])
+	_, exists := ps.selectPeer(peers[1:], rand.New(rand.NewSource(1)))
+	require.False(t, exists)
+
+	now = now.Add(time.Minute)
+	require.Equal(t, peers, ps.filter(peers))
+	_, exists = ps.selectPeer(peers[1:], rand.New(rand.NewSource(1)))
+	require.True(t, exists)
+
+	t.Run("disabled", func(t *testing.T) {
+		ps := newPeerScores(0, time.Minute)
+		for i := 0; i < 10; i++ {
+			require.False(t, ps.onValidation(peers[1], false))
+		}
+		require.Equal(t, peers, ps.filter(peers))
+	})
+}
+
+func TestPeerScores_Prune(t *testing.T) {
+	ps := newPeerScores(1, time.Minute)
+	now := time.Now()
+	ps.now = func() time.Time { return now }
+
+	ps.onSuccess("connected", time.Millisecond)
+	ps.onSuccess("disconnected", time.Millisecond)
+	require.True(t, ps.onValidation("banned", false))
+
+	ps.prune([]p2p.Peer{"connected"})
+	require.Len(t, ps.peers, 2)
+	require.Contains(t, ps.peers, p2p.Peer("connected"))
+	require.Equal(t, []p2p.Peer{}, ps.filter([]p2p.Peer{"banned"}), "ban survives disconnect")
+
+	now = now.Add(time.Minute)
+	ps.prune(nil)
+	require.Empty(t, ps.peers)
+}
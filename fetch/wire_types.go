@@ -56,6 +56,11 @@ type EpochData struct {
 	AtxIDs []types.ATXID
 }
 
+// MaliciousIDs is the response with identities that have a malfeasance proof.
+type MaliciousIDs struct {
+	NodeIDs []types.NodeID
+}
+
 // LayerData is the data response for a given layer ID.
 type LayerData struct {
 	Ballots []types.BallotID
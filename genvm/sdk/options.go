@@ -16,6 +16,10 @@ func Defaults() *Options {
 }
 
 // Options to modify common transaction fields.
+//
+// There is no option for max gas, as it is not encoded in the transaction.
+// It is computed by the vm from the gas of the template method and the size of the transaction,
+// and can be queried with TransactionService.EstimateGas in api/extpb before the transaction is signed.
 type Options struct {
 	GasPrice  uint64
 	GenesisID types.Hash20
@@ -0,0 +1,297 @@
+// Package trie implements authenticated state for accounts.
+//
+// State is a compacted binary merkle trie keyed by the bits of the hashed account address.
+// Address is hashed so that keys are evenly distributed, otherwise reserved prefix
+// of the address would make every path longer than necessary.
+//
+// - empty subtree is represented by the zero hash.
+// - subtree with a single account is represented by the leaf of that account,
+// therefore leaves are stored at the shortest prefix that distinguishes them from other keys.
+// - every other subtree is represented by the internal node with hashes of both children.
+//
+// The shape of the trie depends only on the set of keys, so the root is the same
+// regardless of the order in which accounts were updated.
+//
+// Nodes are addressed by their hash and never modified, hence any root that
+// was committed can be used to load accounts and generate proofs.
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spacemeshos/go-scale"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/statetrie"
+)
+
+const (
+	leafPrefix     byte = 0
+	internalPrefix byte = 1
+
+	leafSize     = 1 + 2*types.Hash32Length
+	internalSize = 1 + 2*types.Hash32Length
+
+	// maxDepth is the number of bits in the key.
+	maxDepth = 8 * types.Hash32Length
+)
+
+// ErrCorrupted is returned if trie node can't be decoded.
+var ErrCorrupted = errors.New("trie: corrupted node")
+
+// Proof of the account inclusion into the state.
+type Proof struct {
+	// Siblings are hashes of the subtrees adjacent to the path from the root
+	// to the account leaf, ordered from the root.
+	Siblings []types.Hash32
+}
+
+// AccountHash computes hash of the account state that is committed into the trie.
+func AccountHash(account *types.Account) types.Hash32 {
+	hasher := hash.New()
+	encoder := scale.NewEncoder(hasher)
+	account.EncodeScale(encoder)
+	var rst types.Hash32
+	hasher.Sum(rst[:0])
+	return rst
+}
+
+func accountKey(address types.Address) types.Hash32 {
+	return hash.Sum(address[:])
+}
+
+func leafNode(key, value types.Hash32) []byte {
+	buf := make([]byte, 0, leafSize)
+	buf = append(buf, leafPrefix)
+	buf = append(buf, key[:]...)
+	return append(buf, value[:]...)
+}
+
+func internalNode(left, right types.Hash32) []byte {
+	buf := make([]byte, 0, internalSize)
+	buf = append(buf, internalPrefix)
+	buf = append(buf, left[:]...)
+	return append(buf, right[:]...)
+}
+
+func bit(key types.Hash32, depth int) byte {
+	return (key[depth/8] >> (7 - depth%8)) & 1
+}
+
+type node struct {
+	leaf        bool
+	key, value  types.Hash32
+	left, right types.Hash32
+}
+
+func decode(hash types.Hash32, buf []byte) (*node, error) {
+	switch {
+	case len(buf) == leafSize && buf[0] == leafPrefix:
+		n := &node{leaf: true}
+		copy(n.key[:], buf[1:])
+		copy(n.value[:], buf[1+types.Hash32Length:])
+		return n, nil
+	case len(buf) == internalSize && buf[0] == internalPrefix:
+		n := &node{}
+		copy(n.left[:], buf[1:])
+		copy(n.right[:], buf[1+types.Hash32Length:])
+		return n, nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrCorrupted, hash)
+}
+
+func load(db sql.Executor, hash types.Hash32) (*node, error) {
+	buf, err := statetrie.Get(db, hash)
+	if err != nil {
+		return nil, err
+	}
+	return decode(hash, buf)
+}
+
+func store(db sql.Executor, buf []byte) (types.Hash32, error) {
+	hash := types.Hash32(hash.Sum(buf))
+	if err := statetrie.Add(db, hash, buf); err != nil {
+		return types.Hash32{}, err
+	}
+	return hash, nil
+}
+
+type leaf struct {
+	key, value types.Hash32
+}
+
+// Has returns true if the root is stored in the database.
+// Empty root is always available.
+func Has(db sql.Executor, root types.Hash32) (bool, error) {
+	if root == (types.Hash32{}) {
+		return true, nil
+	}
+	return statetrie.Has(db, root)
+}
+
+// Update accounts in the trie with the given root and return the new root.
+// New nodes are persisted in the database.
+func Update(db sql.Executor, root types.Hash32, accounts []*types.Account) (types.Hash32, error) {
+	leaves := make([]leaf, 0, len(accounts))
+	for _, account := range accounts {
+		leaves = append(leaves, leaf{key: accountKey(account.Address), value: AccountHash(account)})
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].key[:], leaves[j].key[:]) == -1
+	})
+	for i := 1; i < len(leaves); i++ {
+		if leaves[i].key == leaves[i-1].key {
+			return types.Hash32{}, fmt.Errorf("trie: duplicate account %v", leaves[i].key)
+		}
+	}
+	return update(db, root, 0, leaves)
+}
+
+// update inserts sorted leaves into the subtree at the given depth.
+func update(db sql.Executor, root types.Hash32, depth int, leaves []leaf) (types.Hash32, error) {
+	if len(leaves) == 0 {
+		return root, nil
+	}
+	if root == (types.Hash32{}) {
+		return build(db, depth, leaves)
+	}
+	n, err := load(db, root)
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	if n.leaf {
+		// existing leaf is pushed down together with updated leaves
+		// unless it is replaced by one of them.
+		i := sort.Search(len(leaves), func(i int) bool {
+			return bytes.Compare(leaves[i].key[:], n.key[:]) >= 0
+		})
+		if i == len(leaves) || leaves[i].key != n.key {
+			merged := make([]leaf, 0, len(leaves)+1)
+			merged = append(merged, leaves[:i]...)
+			merged = append(merged, leaf{key: n.key, value: n.value})
+			leaves = append(merged, leaves[i:]...)
+		}
+		return build(db, depth, leaves)
+	}
+	split := splitAt(depth, leaves)
+	left, err := update(db, n.left, depth+1, leaves[:split])
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	right, err := update(db, n.right, depth+1, leaves[split:])
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	return store(db, internalNode(left, right))
+}
+
+// build creates a subtree from sorted leaves.
+func build(db sql.Executor, depth int, leaves []leaf) (types.Hash32, error) {
+	switch len(leaves) {
+	case 0:
+		return types.Hash32{}, nil
+	case 1:
+		return store(db, leafNode(leaves[0].key, leaves[0].value))
+	}
+	if depth == maxDepth {
+		return types.Hash32{}, fmt.Errorf("trie: duplicate account %v", leaves[0].key)
+	}
+	split := splitAt(depth, leaves)
+	left, err := build(db, depth+1, leaves[:split])
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	right, err := build(db, depth+1, leaves[split:])
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	return store(db, internalNode(left, right))
+}
+
+// splitAt returns index of the first leaf with bit set at depth.
+func splitAt(depth int, leaves []leaf) int {
+	return sort.Search(len(leaves), func(i int) bool {
+		return bit(leaves[i].key, depth) == 1
+	})
+}
+
+// Prove generates proof for the account with the address in the trie with the given root.
+// Returns hash of the account state and the proof. sql.ErrNotFound is returned
+// if account is not in the trie.
+func Prove(db sql.Executor, root types.Hash32, address types.Address) (types.Hash32, *Proof, error) {
+	var (
+		key     = accountKey(address)
+		proof   = &Proof{}
+		current = root
+	)
+	for depth := 0; depth <= maxDepth; depth++ {
+		if current == (types.Hash32{}) {
+			break
+		}
+		n, err := load(db, current)
+		if err != nil {
+			return types.Hash32{}, nil, err
+		}
+		if n.leaf {
+			if n.key != key {
+				break
+			}
+			return n.value, proof, nil
+		}
+		if bit(key, depth) == 0 {
+			proof.Siblings = append(proof.Siblings, n.right)
+			current = n.left
+		} else {
+			proof.Siblings = append(proof.Siblings, n.left)
+			current = n.right
+		}
+	}
+	return types.Hash32{}, nil, fmt.Errorf("%w: account %v not in state %v", sql.ErrNotFound, address, root)
+}
+
+// Mark adds hashes of all nodes in the tries with the given roots to the reachable set.
+// Nodes that are already in the set are not visited again, together with their subtrees.
+func Mark(db sql.Executor, reachable map[types.Hash32]struct{}, roots ...types.Hash32) error {
+	stack := append([]types.Hash32{}, roots...)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == (types.Hash32{}) {
+			continue
+		}
+		if _, exists := reachable[current]; exists {
+			continue
+		}
+		n, err := load(db, current)
+		if err != nil {
+			return err
+		}
+		reachable[current] = struct{}{}
+		if !n.leaf {
+			stack = append(stack, n.left, n.right)
+		}
+	}
+	return nil
+}
+
+// Verify that the account is included into the state with the given root.
+func (p *Proof) Verify(root types.Hash32, account *types.Account) bool {
+	if len(p.Siblings) > maxDepth {
+		return false
+	}
+	key := accountKey(account.Address)
+	current := types.Hash32(hash.Sum(leafNode(key, AccountHash(account))))
+	for depth := len(p.Siblings) - 1; depth >= 0; depth-- {
+		if bit(key, depth) == 0 {
+			current = hash.Sum(internalNode(current, p.Siblings[depth]))
+		} else {
+			current = hash.Sum(internalNode(p.Siblings[depth], current))
+		}
+	}
+	return current == root
+}
@@ -0,0 +1,148 @@
+package trie
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func genAccounts(rng *rand.Rand, n int) []*types.Account {
+	rst := make([]*types.Account, n)
+	for i := range rst {
+		rst[i] = &types.Account{Balance: rng.Uint64(), NextNonce: rng.Uint64()}
+		rng.Read(rst[i].Address[:])
+	}
+	return rst
+}
+
+func TestEmpty(t *testing.T) {
+	db := sql.InMemory()
+	root, err := Update(db, types.Hash32{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.Hash32{}, root)
+
+	exists, err := Has(db, root)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	_, _, err = Prove(db, root, types.Address{1})
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestRootIndependentFromOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1001))
+	accounts := genAccounts(rng, 100)
+
+	db := sql.InMemory()
+	expected, err := Update(db, types.Hash32{}, accounts)
+	require.NoError(t, err)
+
+	root := types.Hash32{}
+	perm := rng.Perm(len(accounts))
+	for i := 0; i < len(perm); i += 7 {
+		var batch []*types.Account
+		for j := i; j < i+7 && j < len(perm); j++ {
+			batch = append(batch, accounts[perm[j]])
+		}
+		root, err = Update(db, root, batch)
+		require.NoError(t, err)
+	}
+	require.Equal(t, expected, root)
+}
+
+func TestUpdateChangesRoot(t *testing.T) {
+	rng := rand.New(rand.NewSource(1001))
+	accounts := genAccounts(rng, 10)
+
+	db := sql.InMemory()
+	before, err := Update(db, types.Hash32{}, accounts)
+	require.NoError(t, err)
+
+	updated := *accounts[3]
+	updated.Balance++
+	after, err := Update(db, before, []*types.Account{&updated})
+	require.NoError(t, err)
+	require.NotEqual(t, before, after)
+
+	// nodes are never modified, so previous root is still valid
+	for _, root := range []types.Hash32{before, after} {
+		exists, err := Has(db, root)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+	_, proof, err := Prove(db, before, updated.Address)
+	require.NoError(t, err)
+	require.True(t, proof.Verify(before, accounts[3]))
+	require.False(t, proof.Verify(before, &updated))
+
+	_, proof, err = Prove(db, after, updated.Address)
+	require.NoError(t, err)
+	require.True(t, proof.Verify(after, &updated))
+	require.False(t, proof.Verify(after, accounts[3]))
+}
+
+func TestDuplicateAccounts(t *testing.T) {
+	accounts := genAccounts(rand.New(rand.NewSource(1001)), 2)
+	_, err := Update(sql.InMemory(), types.Hash32{}, append(accounts, accounts[0]))
+	require.Error(t, err)
+}
+
+func TestProve(t *testing.T) {
+	rng := rand.New(rand.NewSource(1001))
+	accounts := genAccounts(rng, 1000)
+
+	db := sql.InMemory()
+	root, err := Update(db, types.Hash32{}, accounts)
+	require.NoError(t, err)
+
+	for _, account := range accounts {
+		value, proof, err := Prove(db, root, account.Address)
+		require.NoError(t, err)
+		require.Equal(t, AccountHash(account), value)
+		require.True(t, proof.Verify(root, account))
+		// 1000 keys are expected to be distinguished within ~log2(1000) bits
+		require.Less(t, len(proof.Siblings), 40)
+
+		tampered := *account
+		tampered.Balance++
+		require.False(t, proof.Verify(root, &tampered))
+		require.False(t, proof.Verify(types.Hash32{1}, account))
+	}
+
+	missing := genAccounts(rng, 1)[0]
+	_, _, err = Prove(db, root, missing.Address)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestMark(t *testing.T) {
+	rng := rand.New(rand.NewSource(1001))
+	accounts := genAccounts(rng, 50)
+
+	db := sql.InMemory()
+	old, err := Update(db, types.Hash32{}, accounts)
+	require.NoError(t, err)
+	accounts[0].Balance++
+	root, err := Update(db, old, accounts[:1])
+	require.NoError(t, err)
+
+	reachable := map[types.Hash32]struct{}{}
+	require.NoError(t, Mark(db, reachable, root))
+	require.Contains(t, reachable, root)
+	require.NotContains(t, reachable, old)
+	for _, account := range accounts {
+		value, proof, err := Prove(db, root, account.Address)
+		require.NoError(t, err)
+		require.True(t, proof.Verify(root, account))
+		require.Contains(t, reachable, types.Hash32(hash.Sum(leafNode(accountKey(account.Address), value))))
+	}
+
+	marked := len(reachable)
+	require.NoError(t, Mark(db, reachable, root, old, types.Hash32{}))
+	require.Contains(t, reachable, old)
+	require.Greater(t, len(reachable), marked)
+}
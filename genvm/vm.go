@@ -17,7 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vault"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vesting"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/wallet"
-	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
@@ -122,6 +122,61 @@ func (v *VM) GetAllAccounts() ([]*types.Account, error) {
 	return accounts.All(v.db)
 }
 
+// GetAccountWithProof returns account state as of the layer together with the proof
+// of its inclusion into the state root of that layer.
+func (v *VM) GetAccountWithProof(address types.Address, lid types.LayerID) (*types.Account, *trie.Proof, error) {
+	root, err := layers.GetStateHash(v.db, lid)
+	if err != nil {
+		return nil, nil, err
+	}
+	account, err := accounts.Get(v.db, address, lid)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, proof, err := trie.Prove(v.db, root, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	if value != trie.AccountHash(&account) {
+		return nil, nil, fmt.Errorf("%w: account %s doesn't match state root %s in layer %s",
+			core.ErrInternal, address, root, lid)
+	}
+	return &account, proof, nil
+}
+
+// loadStateRoot returns state root that is a parent for the next applied layer.
+func (v *VM) loadStateRoot(tx sql.Executor) (types.Hash32, error) {
+	root, err := layers.GetLatestStateHash(tx)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return types.Hash32{}, err
+	}
+	if err == nil {
+		exists, err := trie.Has(tx, root)
+		if err != nil {
+			return types.Hash32{}, err
+		}
+		if exists {
+			return root, nil
+		}
+	}
+	// state root is not computed yet for genesis accounts, or it was computed
+	// before accounts were stored in the trie. in both cases state is rebuilt
+	// from all accounts in the database.
+	all, err := accounts.All(tx)
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	root, err = trie.Update(tx, types.Hash32{}, all)
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	v.logger.With().Info("rebuilt state trie from accounts",
+		log.Int("accounts", len(all)),
+		log.Stringer("state_hash", root),
+	)
+	return root, nil
+}
+
 func (v *VM) revert(lid types.LayerID) error {
 	tx, err := v.db.Tx(context.Background())
 	if err != nil {
@@ -137,6 +192,10 @@ func (v *VM) revert(lid types.LayerID) error {
 	if err != nil {
 		return err
 	}
+	err = layers.UnsetStateHashFrom(tx, lid.Add(1))
+	if err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
@@ -217,40 +276,43 @@ func (v *VM) Apply(lctx ApplyContext, txs []types.Transaction, blockRewards []ty
 	t4 := time.Now()
 	blockDurationRewards.Observe(float64(time.Since(t3)))
 
-	hasher := hash.New()
-	encoder := scale.NewEncoder(hasher)
-	total := 0
-
 	tx, err := v.db.TxImmediate(context.Background())
 	if err != nil {
 		return nil, nil, err
 	}
 	defer tx.Release()
 
+	root, err := v.loadStateRoot(tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+	}
+
 	for _, reward := range rewardsResult {
 		if err := rewards.Add(tx, &reward); err != nil {
 			return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 		}
 	}
 
+	var changed []*core.Account
 	ss.IterateChanged(func(account *core.Account) bool {
-		total++
 		account.Layer = lctx.Layer
 		v.logger.With().Debug("update account state", log.Inline(account))
 		err = accounts.Update(tx, account)
 		if err != nil {
 			return false
 		}
-		account.EncodeScale(encoder)
+		changed = append(changed, account)
 		return true
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 	}
-	writesPerBlock.Observe(float64(total))
+	writesPerBlock.Observe(float64(len(changed)))
 
-	var hash types.Hash32
-	hasher.Sum(hash[:0])
+	hash, err := trie.Update(tx, root, changed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+	}
 	if err := layers.UpdateStateHash(tx, lctx.Layer, hash); err != nil {
 		return nil, nil, err
 	}
@@ -292,127 +354,222 @@ func (v *VM) execute(lctx ApplyContext, ss *core.StagedCache, txs []types.Transa
 		tx := txs[i]
 
 		rd.Reset(tx.GetRaw().Raw)
-		req := &Request{
-			vm:      v,
-			cache:   ss,
-			lid:     lctx.Layer,
-			raw:     txs[i].GetRaw(),
-			decoder: decoder,
+		header, rst, err := v.executeTx(logger, lctx, ss, decoder, &tx, limit)
+		if header != nil && errors.Is(err, core.ErrInternal) {
+			return nil, nil, 0, err
 		}
-
-		header, err := req.Parse()
 		if err != nil {
-			logger.With().Warning("ineffective transaction. failed to parse",
+			logger.With().Warning("ineffective transaction",
 				tx.GetRaw().ID,
 				log.Err(err),
 			)
 			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
+			if errors.Is(err, core.ErrInvalidNonce) {
+				ineffective[len(ineffective)-1].TxHeader = header
+			}
 			invalidTxCount.Inc()
 			continue
 		}
-		ctx := req.ctx
-		args := req.args
+		fees += rst.Fee
+		limit -= rst.Gas
 
-		if header.GasPrice == 0 {
-			logger.With().Warning("ineffective transaction. zero gas price",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
-		if intrinsic := core.ComputeIntrinsicGasCost(ctx.ParseOutput.BaseGas, tx.GetRaw().Raw, v.cfg.StorageCostFactor); ctx.PrincipalAccount.Balance < intrinsic {
-			logger.With().Warning("ineffective transaction. intrinstic gas not covered",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-				log.Uint64("intrinsic gas", intrinsic),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
-		if limit < ctx.Header.MaxGas {
-			logger.With().Warning("ineffective transaction. out of block gas",
-				log.Uint64("block gas limit", v.cfg.GasLimit),
-				log.Uint64("current limit", limit),
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
+		executed = append(executed, *rst)
+		transactionDuration.Observe(float64(time.Since(t1)))
+	}
+	return executed, ineffective, fees, nil
+}
 
-		// NOTE this part is executed only for transactions that weren't verified
-		// when saved into database by txs module
-		if !tx.Verified() && !req.Verify() {
-			logger.With().Warning("ineffective transaction. failed verify",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
+// executeTx executes a single transaction and stages changes in the cache.
+// If transaction was parsed and error wraps core.ErrInternal it is not recoverable,
+// any other error means that transaction is ineffective and can't be executed.
+func (v *VM) executeTx(
+	logger log.Log,
+	lctx ApplyContext,
+	ss *core.StagedCache,
+	decoder *scale.Decoder,
+	tx *types.Transaction,
+	limit uint64,
+) (*core.Header, *types.TransactionWithResult, error) {
+	req := &Request{
+		vm:      v,
+		cache:   ss,
+		lid:     lctx.Layer,
+		raw:     tx.GetRaw(),
+		decoder: decoder,
+	}
 
-		if ctx.PrincipalAccount.NextNonce > ctx.Header.Nonce {
-			logger.With().Warning("ineffective transaction. nonce too low",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw(), TxHeader: header})
-			invalidTxCount.Inc()
-			continue
-		}
+	header, err := req.Parse()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	ctx := req.ctx
+	args := req.args
 
-		t2 := time.Now()
-		logger.With().Debug("applying transaction",
+	if header.GasPrice == 0 {
+		return header, nil, fmt.Errorf("%w: zero gas price", core.ErrMalformed)
+	}
+	if intrinsic := core.ComputeIntrinsicGasCost(ctx.ParseOutput.BaseGas, tx.GetRaw().Raw, v.cfg.StorageCostFactor); ctx.PrincipalAccount.Balance < intrinsic {
+		return header, nil, fmt.Errorf("%w: intrinsic gas %d not covered by balance %d",
+			core.ErrNoBalance, intrinsic, ctx.PrincipalAccount.Balance)
+	}
+	if limit < ctx.Header.MaxGas {
+		return header, nil, fmt.Errorf("%w: out of block gas. limit %d, current limit %d, max gas %d",
+			core.ErrMaxGas, v.cfg.GasLimit, limit, ctx.Header.MaxGas)
+	}
+
+	// NOTE this part is executed only for transactions that weren't verified
+	// when saved into database by txs module
+	if !tx.Verified() && !req.Verify() {
+		return header, nil, fmt.Errorf("%w: failed verify", core.ErrMalformed)
+	}
+
+	if ctx.PrincipalAccount.NextNonce > ctx.Header.Nonce {
+		return header, nil, fmt.Errorf("%w: nonce too low. expected %d, got %d",
+			core.ErrInvalidNonce, ctx.PrincipalAccount.NextNonce, ctx.Header.Nonce)
+	}
+
+	logger.With().Debug("applying transaction",
+		log.Object("header", header),
+		log.Object("account", &ctx.PrincipalAccount),
+	)
+
+	rst := &types.TransactionWithResult{}
+	rst.Layer = lctx.Layer
+
+	t2 := time.Now()
+	err = ctx.Consume(ctx.Header.MaxGas)
+	if err == nil {
+		err = ctx.PrincipalHandler.Exec(ctx, ctx.Header.Method, args)
+	}
+	if err != nil {
+		logger.With().Debug("transaction failed",
 			log.Object("header", header),
 			log.Object("account", &ctx.PrincipalAccount),
+			log.Err(err),
 		)
+		if errors.Is(err, core.ErrInternal) {
+			return header, nil, err
+		}
+	}
+	transactionDurationExecute.Observe(float64(time.Since(t2)))
 
-		rst := types.TransactionWithResult{}
-		rst.Layer = lctx.Layer
+	rst.RawTx = tx.GetRaw()
+	rst.TxHeader = &ctx.Header
+	rst.Status = types.TransactionSuccess
+	if err != nil {
+		rst.Status = types.TransactionFailure
+		rst.Message = err.Error()
+	}
+	rst.Gas = ctx.Consumed()
+	rst.Fee = ctx.Fee()
+	rst.Addresses = ctx.Updated()
 
-		err = ctx.Consume(ctx.Header.MaxGas)
-		if err == nil {
-			err = ctx.PrincipalHandler.Exec(ctx, ctx.Header.Method, args)
-		}
-		if err != nil {
-			logger.With().Debug("transaction failed",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-				log.Err(err),
-			)
-			if errors.Is(err, core.ErrInternal) {
-				return nil, nil, 0, err
-			}
-		}
-		transactionDurationExecute.Observe(float64(time.Since(t2)))
+	if err := ctx.Apply(ss); err != nil {
+		return header, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+	}
+	return header, rst, nil
+}
 
-		rst.RawTx = txs[i].GetRaw()
-		rst.TxHeader = &ctx.Header
-		rst.Status = types.TransactionSuccess
-		if err != nil {
-			rst.Status = types.TransactionFailure
-			rst.Message = err.Error()
-		}
-		rst.Gas = ctx.Consumed()
-		rst.Fee = ctx.Fee()
-		rst.Addresses = ctx.Updated()
+// Simulate executes transactions on top of the state of the applied layer `lid`,
+// as if they were included into the next layer. Transactions are executed
+// in order, so that each one observes changes made by the previous ones.
+// Nothing is persisted.
+//
+// Results are the same as would be returned by Apply. If any of the transactions
+// is ineffective the error explaining the reason is returned.
+func (v *VM) Simulate(lid types.LayerID, txs []types.RawTx) ([]types.TransactionWithResult, error) {
+	tx, err := v.snapshot(lid)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Release()
 
-		err = ctx.Apply(ss)
+	var (
+		rd      bytes.Reader
+		decoder = scale.NewDecoder(&rd)
+		lctx    = ApplyContext{Layer: lid.Add(1)}
+		ss      = core.NewStagedCache(core.LayerLoader{Executor: tx, Layer: lid})
+		limit   = v.cfg.GasLimit
+		results = make([]types.TransactionWithResult, 0, len(txs))
+	)
+	for i := range txs {
+		rd.Reset(txs[i].Raw)
+		_, rst, err := v.executeTx(v.logger, lctx, ss, decoder, &types.Transaction{RawTx: txs[i]}, limit)
 		if err != nil {
-			return nil, nil, 0, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+			return nil, fmt.Errorf("transaction %s: %w", txs[i].ID, err)
 		}
-		fees += ctx.Fee()
-		limit -= ctx.Consumed()
+		limit -= rst.Gas
+		results = append(results, *rst)
+	}
+	return results, nil
+}
 
-		executed = append(executed, rst)
-		transactionDuration.Observe(float64(time.Since(t1)))
+// GasEstimate for the transaction.
+type GasEstimate struct {
+	// Intrinsic gas is charged for parsing, verifying and storing transaction.
+	Intrinsic uint64
+	// Fixed gas is charged by the template method.
+	Fixed uint64
+	// Consumed gas by the simulated execution.
+	Consumed uint64
+}
+
+// MaxGas is the gas that is reserved from principal balance before execution.
+func (e *GasEstimate) MaxGas() uint64 {
+	return e.Intrinsic + e.Fixed
+}
+
+// EstimateGas for the transaction executed on top of the state of the applied layer `lid`.
+// Signature is not verified, therefore transaction can be estimated before it is signed,
+// but storage cost accounts only for the bytes that are present in the raw transaction.
+func (v *VM) EstimateGas(lid types.LayerID, raw types.RawTx) (*GasEstimate, error) {
+	tx, err := v.snapshot(lid)
+	if err != nil {
+		return nil, err
 	}
-	return executed, ineffective, fees, nil
+	defer tx.Release()
+
+	var (
+		rd      = bytes.NewReader(raw.Raw)
+		decoder = scale.NewDecoder(rd)
+		lctx    = ApplyContext{Layer: lid.Add(1)}
+		ss      = core.NewStagedCache(core.LayerLoader{Executor: tx, Layer: lid})
+	)
+	req := &Request{
+		vm:      v,
+		cache:   ss,
+		lid:     lctx.Layer,
+		raw:     raw,
+		decoder: decoder,
+	}
+	header, err := req.Parse()
+	if err != nil {
+		return nil, err
+	}
+	estimate := &GasEstimate{
+		Intrinsic: core.ComputeIntrinsicGasCost(req.ctx.ParseOutput.BaseGas, raw.Raw, v.cfg.StorageCostFactor),
+		Fixed:     req.ctx.ParseOutput.FixedGas,
+	}
+
+	rd.Reset(raw.Raw)
+	// transaction with header is considered verified
+	_, rst, err := v.executeTx(v.logger, lctx, ss, decoder, &types.Transaction{RawTx: raw, TxHeader: header}, v.cfg.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Consumed = rst.Gas
+	return estimate, nil
+}
+
+// snapshot returns database transaction for reading consistent state as of the applied layer.
+// Returned transaction must be released and never committed.
+func (v *VM) snapshot(lid types.LayerID) (*sql.Tx, error) {
+	if lid.After(types.GetEffectiveGenesis()) {
+		if _, err := layers.GetStateHash(v.db, lid); err != nil {
+			return nil, fmt.Errorf("layer %s is not applied: %w", lid, err)
+		}
+	}
+	return v.db.Tx(context.Background())
 }
 
 // Request used to implement 2-step validation flow.
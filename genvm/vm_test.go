@@ -25,7 +25,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vault"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vesting"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/wallet"
-	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -2101,15 +2101,10 @@ func TestStateHashFromUpdatedAccounts(t *testing.T) {
 	require.NoError(tt, err)
 	require.Empty(tt, skipped)
 
-	expected := types.Hash32{}
-	hasher := hash.New()
-	encoder := scale.NewEncoder(hasher)
-	for _, pos := range []int{0, 1, 2, 4} {
-		account, err := accounts.Get(tt.db, tt.accounts[pos].getAddress(), lid)
-		require.NoError(t, err)
-		account.EncodeScale(encoder)
-	}
-	hasher.Sum(expected[:0])
+	all, err := accounts.All(tt.db)
+	require.NoError(t, err)
+	expected, err := trie.Update(sql.InMemory(), types.Hash32{}, all)
+	require.NoError(t, err)
 
 	statehash, err := layers.GetStateHash(tt.db, lid)
 	require.NoError(t, err)
@@ -2118,6 +2113,163 @@ func TestStateHashFromUpdatedAccounts(t *testing.T) {
 	root, err = tt.GetStateRoot()
 	require.NoError(t, err)
 	require.Equal(t, expected, root)
+
+	// empty layer doesn't change state
+	_, _, err = tt.Apply(testContext(lid.Add(1)), nil, nil)
+	require.NoError(t, err)
+	root, err = tt.GetStateRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
+
+func TestAccountWithProof(t *testing.T) {
+	tt := newTester(t).addSingleSig(10).applyGenesis()
+
+	lid := types.GetEffectiveGenesis()
+	skipped, _, err := tt.Apply(testContext(lid), notVerified(
+		tt.selfSpawn(0),
+		tt.spend(0, 2, 100),
+	), nil)
+	require.NoError(tt, err)
+	require.Empty(tt, skipped)
+	skipped, _, err = tt.Apply(testContext(lid.Add(1)), notVerified(
+		tt.spend(0, 2, 100),
+	), nil)
+	require.NoError(tt, err)
+	require.Empty(tt, skipped)
+
+	for _, layer := range []types.LayerID{lid, lid.Add(1)} {
+		root, err := tt.GetLayerStateRoot(layer)
+		require.NoError(t, err)
+		for i := range tt.accounts {
+			account, proof, err := tt.GetAccountWithProof(tt.accounts[i].getAddress(), layer)
+			require.NoError(t, err)
+			require.True(t, proof.Verify(root, account))
+		}
+	}
+	before, _, err := tt.GetAccountWithProof(tt.accounts[2].getAddress(), lid)
+	require.NoError(t, err)
+	after, _, err := tt.GetAccountWithProof(tt.accounts[2].getAddress(), lid.Add(1))
+	require.NoError(t, err)
+	require.Equal(t, before.Balance+100, after.Balance)
+
+	_, _, err = tt.GetAccountWithProof(types.Address{1}, lid)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	_, _, err = tt.GetAccountWithProof(tt.accounts[2].getAddress(), lid.Add(2))
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestStateHashAfterRevert(t *testing.T) {
+	tt := newTester(t).addSingleSig(10).applyGenesis()
+
+	lid := types.GetEffectiveGenesis()
+	_, _, err := tt.Apply(testContext(lid), notVerified(tt.selfSpawn(0)), nil)
+	require.NoError(t, err)
+	expected, err := tt.GetStateRoot()
+	require.NoError(t, err)
+
+	spend := notVerified(tt.spend(0, 2, 100))
+	_, _, err = tt.Apply(testContext(lid.Add(1)), spend, nil)
+	require.NoError(t, err)
+	updated, err := tt.GetStateRoot()
+	require.NoError(t, err)
+	require.NotEqual(t, expected, updated)
+
+	require.NoError(t, tt.Revert(lid))
+	root, err := tt.GetStateRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	_, _, err = tt.Apply(testContext(lid.Add(1)), spend, nil)
+	require.NoError(t, err)
+	reapplied, err := tt.GetStateRoot()
+	require.NoError(t, err)
+	require.Equal(t, updated, reapplied)
+}
+
+func TestSimulate(t *testing.T) {
+	tt := newTester(t).addSingleSig(3).applyGenesis()
+	genesis := types.GetEffectiveGenesis()
+
+	spawn := tt.selfSpawn(0)
+	spend := tt.spend(0, 1, 100)
+	simulated, err := tt.Simulate(genesis, []types.RawTx{spawn, spend})
+	require.NoError(t, err)
+	require.Len(t, simulated, 2)
+
+	// nothing is persisted
+	account, err := accounts.Latest(tt.db, tt.accounts[0].getAddress())
+	require.NoError(t, err)
+	require.Zero(t, account.NextNonce)
+	_, err = tt.GetStateRoot()
+	require.NoError(t, err)
+
+	lid := genesis.Add(1)
+	skipped, results, err := tt.Apply(testContext(lid), notVerified(spawn, spend), nil)
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+	require.Equal(t, results, simulated)
+
+	t.Run("historical state", func(t *testing.T) {
+		// spawn is ineffective on top of the applied layer
+		_, err := tt.Simulate(lid, []types.RawTx{spawn})
+		require.ErrorIs(t, err, core.ErrInvalidNonce)
+
+		// but is effective on top of the genesis
+		rst, err := tt.Simulate(genesis, []types.RawTx{spawn})
+		require.NoError(t, err)
+		require.Equal(t, results[0], rst[0])
+	})
+	t.Run("failed", func(t *testing.T) {
+		rst, err := tt.Simulate(lid, []types.RawTx{tt.spend(0, 1, 1_000_000_000_000)})
+		require.NoError(t, err)
+		require.Equal(t, types.TransactionFailure, rst[0].Status)
+		require.Equal(t, core.ErrNoBalance.Error(), rst[0].Message)
+		require.NotZero(t, rst[0].Gas)
+		require.NotZero(t, rst[0].Fee)
+	})
+	t.Run("not applied", func(t *testing.T) {
+		_, err := tt.Simulate(lid.Add(1), []types.RawTx{tt.spend(0, 1, 100)})
+		require.ErrorIs(t, err, sql.ErrNotFound)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := tt.Simulate(lid, []types.RawTx{types.NewRawTx([]byte{1, 2, 3})})
+		require.ErrorIs(t, err, core.ErrMalformed)
+	})
+}
+
+func TestEstimateGas(t *testing.T) {
+	tt := newTester(t).addSingleSig(2).applyGenesis()
+	genesis := types.GetEffectiveGenesis()
+
+	spawn := tt.selfSpawn(0)
+	estimate, err := tt.EstimateGas(genesis, spawn)
+	require.NoError(t, err)
+	require.Equal(t, core.ComputeIntrinsicGasCost(wallet.BaseGas, spawn.Raw, tt.cfg.StorageCostFactor), estimate.Intrinsic)
+	require.EqualValues(t, wallet.FixedGasSpawn, estimate.Fixed)
+	require.Equal(t, estimate.MaxGas(), estimate.Consumed)
+
+	lid := genesis.Add(1)
+	_, results, err := tt.Apply(testContext(lid), notVerified(spawn), nil)
+	require.NoError(t, err)
+	require.Equal(t, results[0].Gas, estimate.Consumed)
+	require.Equal(t, results[0].MaxGas, estimate.MaxGas())
+
+	t.Run("unsigned", func(t *testing.T) {
+		spend := tt.spend(0, 1, 100)
+		signed, err := tt.EstimateGas(lid, spend)
+		require.NoError(t, err)
+
+		unsigned := types.NewRawTx(spend.Raw[:len(spend.Raw)-ed25519.SignatureSize])
+		estimate, err := tt.EstimateGas(lid, unsigned)
+		require.NoError(t, err)
+		require.EqualValues(t, wallet.FixedGasSpend, estimate.Fixed)
+		require.Equal(t, signed.Intrinsic-uint64(ed25519.SignatureSize)*tt.cfg.StorageCostFactor, estimate.Intrinsic)
+	})
+	t.Run("not spawned", func(t *testing.T) {
+		_, err := tt.EstimateGas(lid, tt.spend(1, 0, 100))
+		require.ErrorIs(t, err, core.ErrNotSpawned)
+	})
 }
 
 func BenchmarkWallet(b *testing.B) {
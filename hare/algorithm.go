@@ -68,6 +68,7 @@ func (cpo procReport) Completed() bool {
 }
 
 func (proc *consensusProcess) report(completed bool) {
+	proc.trace.terminated(completed)
 	proc.terminationReport <- procReport{proc.layer, proc.value, proc.preRoundTracker.coinflip, completed}
 }
 
@@ -159,9 +160,7 @@ type consensusProcess struct {
 	eg                errgroup.Group
 	mu                sync.RWMutex
 	layer             types.LayerID
-	oracle            Rolacle // the roles oracle provider
-	signing           Signer
-	nid               types.NodeID
+	participants      []participant
 	publisher         pubsub.Publisher
 	isStarted         bool
 	inbox             chan *Msg
@@ -175,23 +174,30 @@ type consensusProcess struct {
 	cfg               config.Config
 	pending           map[string]*Msg // buffer for early messages that are pending process
 	mTracker          *msgsTracker    // tracks valid messages
-	eligibilityCount  uint16
 	clock             RoundClock
+	trace             *layerTrace // records messages and tracker transitions, nil if disabled
+}
+
+// participant is an identity that sends messages in the consensus process.
+type participant struct {
+	signing          Signer
+	nid              types.NodeID
+	oracle           Rolacle // the roles oracle provider, computes eligibility proofs for the identity
+	eligibilityCount uint16
 }
 
 // newConsensusProcess creates a new consensus process instance.
-func newConsensusProcess(ctx context.Context, cfg config.Config, layer types.LayerID, s *Set, oracle Rolacle, stateQuerier stateQuerier,
-	layersPerEpoch uint16, signing Signer, nid types.NodeID, p2p pubsub.Publisher,
+// Every participant checks its eligibility and sends messages independently in every round.
+func newConsensusProcess(ctx context.Context, cfg config.Config, layer types.LayerID, s *Set, participants []participant, stateQuerier stateQuerier,
+	layersPerEpoch uint16, p2p pubsub.Publisher,
 	terminationReport chan TerminationOutput,
-	ev roleValidator, clock RoundClock, logger log.Log,
+	ev roleValidator, clock RoundClock, trace *layerTrace, logger log.Log,
 ) *consensusProcess {
 	msgsTracker := newMsgsTracker()
 	proc := &consensusProcess{
 		State:             State{preRound, preRound, s.Clone(), nil},
 		layer:             layer,
-		oracle:            oracle,
-		signing:           signing,
-		nid:               nid,
+		participants:      append([]participant(nil), participants...),
 		publisher:         p2p,
 		preRoundTracker:   newPreRoundTracker(cfg.F+1, cfg.N, logger),
 		cfg:               cfg,
@@ -200,9 +206,14 @@ func newConsensusProcess(ctx context.Context, cfg config.Config, layer types.Lay
 		Log:               logger,
 		mTracker:          msgsTracker,
 		clock:             clock,
+		trace:             trace,
+	}
+	if trace != nil {
+		trace.round = proc.getRound
 	}
+	proc.preRoundTracker.trace = trace
 	proc.ctx, proc.cancel = context.WithCancel(ctx)
-	proc.validator = newSyntaxContextValidator(signing, cfg.F+1, proc.statusValidator(), stateQuerier, layersPerEpoch, ev, msgsTracker, logger)
+	proc.validator = newSyntaxContextValidator(participants[0].signing, cfg.F+1, proc.statusValidator(), stateQuerier, layersPerEpoch, ev, msgsTracker, logger)
 
 	return proc
 }
@@ -253,6 +264,7 @@ func (proc *consensusProcess) SetInbox(inbox chan *Msg) {
 
 func (proc *consensusProcess) terminate() {
 	proc.cancel()
+	proc.trace.close()
 	proc.eg.Wait()
 }
 
@@ -267,6 +279,7 @@ func (proc *consensusProcess) terminating() bool {
 
 // runs the main loop of the protocol.
 func (proc *consensusProcess) eventLoop() {
+	defer proc.trace.close()
 	ctx := proc.ctx
 	logger := proc.WithContext(ctx).WithFields(proc.layer)
 	logger.With().Info("consensus process started",
@@ -275,19 +288,23 @@ func (proc *consensusProcess) eventLoop() {
 
 	// check participation and send message
 	proc.eg.Go(func() error {
-		// check participation
-		if proc.shouldParticipate(ctx) {
+		for i := range proc.participants {
+			p := &proc.participants[i]
+			// check participation
+			if !proc.shouldParticipate(ctx, p) {
+				logger.With().Debug("should not participate",
+					log.Uint32("current_round", proc.getRound()),
+					p.nid)
+				continue
+			}
 			// set pre-round InnerMsg and send
-			builder, err := proc.initDefaultBuilder(proc.value)
+			builder, err := proc.initDefaultBuilder(p, proc.value)
 			if err != nil {
 				logger.With().Error("failed to init msg builder", log.Err(err))
-				return nil
+				continue
 			}
-			m := builder.SetType(pre).Sign(proc.signing).Build()
+			m := builder.SetType(pre).Sign(p.signing).Build()
 			proc.sendMessage(ctx, m)
-		} else {
-			logger.With().Debug("should not participate",
-				log.Uint32("current_round", proc.getRound()))
 		}
 		return nil
 	})
@@ -416,21 +433,25 @@ func (proc *consensusProcess) handleMessage(ctx context.Context, m *Msg) {
 			// validate syntax for early messages
 			if !proc.validator.SyntacticallyValidateMessage(ctx, m) {
 				logger.Warning("early message failed syntactic validation, discarding")
+				proc.trace.received(m, TraceInvalidSyntax)
 				return
 			}
 
+			proc.trace.received(m, TraceEarly)
 			proc.onEarlyMessage(ctx, m)
 			return
 		}
 
 		// not an early message but also contextually invalid
 		logger.With().Warning("late message failed contextual validation, discarding", log.Err(err))
+		proc.trace.received(m, TraceInvalidContext)
 		return
 	}
 
 	// validate syntax for contextually valid messages
 	if !proc.validator.SyntacticallyValidateMessage(ctx, m) {
 		logger.Warning("message failed syntactic validation, discarding")
+		proc.trace.received(m, TraceInvalidSyntax)
 		return
 	}
 
@@ -440,6 +461,7 @@ func (proc *consensusProcess) handleMessage(ctx context.Context, m *Msg) {
 	}
 
 	// valid, continue to process msg by type
+	proc.trace.received(m, TraceValid)
 	proc.processMsg(ctx, m)
 }
 
@@ -491,9 +513,11 @@ func (proc *consensusProcess) sendMessage(ctx context.Context, msg *Msg) bool {
 
 	if err := proc.publisher.Publish(ctx, pubsub.HareProtocol, msg.Bytes()); err != nil {
 		logger.With().Error("failed to broadcast round message", log.Err(err))
+		proc.trace.sent(msg, TracePublishFailed)
 		return false
 	}
 
+	proc.trace.sent(msg, TracePublished)
 	logger.Debug("should participate: message sent")
 	return true
 }
@@ -537,19 +561,23 @@ func (proc *consensusProcess) advanceToNextRound(ctx context.Context) {
 func (proc *consensusProcess) beginStatusRound(ctx context.Context) {
 	proc.statusesTracker = newStatusTracker(proc.cfg.F+1, proc.cfg.N)
 	proc.statusesTracker.Log = proc.Log
+	proc.statusesTracker.trace = proc.trace
 
-	// check participation
-	if !proc.shouldParticipate(ctx) {
-		return
-	}
+	for i := range proc.participants {
+		p := &proc.participants[i]
+		// check participation
+		if !proc.shouldParticipate(ctx, p) {
+			continue
+		}
 
-	b, err := proc.initDefaultBuilder(proc.value)
-	if err != nil {
-		proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
-		return
+		b, err := proc.initDefaultBuilder(p, proc.value)
+		if err != nil {
+			proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
+			continue
+		}
+		statusMsg := b.SetType(status).Sign(p.signing).Build()
+		proc.sendMessage(ctx, statusMsg)
 	}
-	statusMsg := b.SetType(status).Sign(proc.signing).Build()
-	proc.sendMessage(ctx, statusMsg)
 }
 
 func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
@@ -558,19 +586,26 @@ func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
 	// done with building proposal, reset statuses tracking
 	defer func() { proc.statusesTracker = nil }()
 
-	if proc.statusesTracker.IsSVPReady() && proc.shouldParticipate(ctx) {
-		builder, err := proc.initDefaultBuilder(proc.statusesTracker.ProposalSet(defaultSetSize))
+	if !proc.statusesTracker.IsSVPReady() {
+		return
+	}
+	for i := range proc.participants {
+		p := &proc.participants[i]
+		if !proc.shouldParticipate(ctx, p) {
+			continue
+		}
+		builder, err := proc.initDefaultBuilder(p, proc.statusesTracker.ProposalSet(defaultSetSize))
 		if err != nil {
 			proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
-			return
+			continue
 		}
 		svp := proc.statusesTracker.BuildSVP()
-		if svp != nil {
-			proposalMsg := builder.SetType(proposal).SetSVP(svp).Sign(proc.signing).Build()
-			proc.sendMessage(ctx, proposalMsg)
-		} else {
+		if svp == nil {
 			proc.WithContext(ctx).With().Error("failed to build SVP", proc.layer)
+			return
 		}
+		proposalMsg := builder.SetType(proposal).SetSVP(svp).Sign(p.signing).Build()
+		proc.sendMessage(ctx, proposalMsg)
 	}
 }
 
@@ -578,30 +613,36 @@ func (proc *consensusProcess) beginCommitRound(ctx context.Context) {
 	proposedSet := proc.proposalTracker.ProposedSet()
 
 	// proposedSet may be nil, in such case the tracker will ignore Messages
-	proc.commitTracker = newCommitTracker(proc.cfg.F+1, proc.cfg.N, proposedSet) // track commits for proposed set
+	commitTracker := newCommitTracker(proc.cfg.F+1, proc.cfg.N, proposedSet) // track commits for proposed set
+	commitTracker.trace = proc.trace
+	proc.commitTracker = commitTracker
 
 	if proposedSet == nil {
 		return
 	}
 
-	// check participation
-	if !proc.shouldParticipate(ctx) {
-		return
-	}
+	for i := range proc.participants {
+		p := &proc.participants[i]
+		// check participation
+		if !proc.shouldParticipate(ctx, p) {
+			continue
+		}
 
-	builder, err := proc.initDefaultBuilder(proposedSet)
-	if err != nil {
-		proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
-		return
+		builder, err := proc.initDefaultBuilder(p, proposedSet)
+		if err != nil {
+			proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
+			continue
+		}
+		builder = builder.SetType(commit).Sign(p.signing)
+		commitMsg := builder.Build()
+		proc.sendMessage(ctx, commitMsg)
 	}
-	builder = builder.SetType(commit).Sign(proc.signing)
-	commitMsg := builder.Build()
-	proc.sendMessage(ctx, commitMsg)
 }
 
 func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
 	logger := proc.WithContext(ctx).WithFields(proc.layer)
 	proc.notifyTracker = newNotifyTracker(proc.cfg.N)
+	proc.notifyTracker.trace = proc.trace
 
 	// release proposal & commit trackers
 	defer func() {
@@ -637,22 +678,25 @@ func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
 	proc.value = s
 	proc.certificate = cert
 
-	// check participation
-	if !proc.shouldParticipate(ctx) {
-		return
-	}
+	for i := range proc.participants {
+		p := &proc.participants[i]
+		// check participation
+		if !proc.shouldParticipate(ctx, p) {
+			continue
+		}
 
-	// build & send notify message
-	builder, err := proc.initDefaultBuilder(proc.value)
-	if err != nil {
-		logger.With().Error("failed to init msg builder", proc.layer, log.Err(err))
-		return
-	}
+		// build & send notify message
+		builder, err := proc.initDefaultBuilder(p, proc.value)
+		if err != nil {
+			logger.With().Error("failed to init msg builder", proc.layer, log.Err(err))
+			continue
+		}
 
-	builder = builder.SetType(notify).SetCertificate(proc.certificate).Sign(proc.signing)
-	notifyMsg := builder.Build()
-	logger.With().Debug("sending notify message", notifyMsg)
-	proc.sendMessage(ctx, notifyMsg)
+		builder = builder.SetType(notify).SetCertificate(proc.certificate).Sign(p.signing)
+		notifyMsg := builder.Build()
+		logger.With().Debug("sending notify message", notifyMsg)
+		proc.sendMessage(ctx, notifyMsg)
+	}
 }
 
 // passes all pending messages to the inbox of the process so they will be handled.
@@ -692,18 +736,18 @@ func (proc *consensusProcess) onRoundBegin(ctx context.Context) {
 	})
 }
 
-// init a new message builder with the current state (s, k, ki) for this instance.
-func (proc *consensusProcess) initDefaultBuilder(s *Set) (*messageBuilder, error) {
-	builder := newMessageBuilder().SetLayer(proc.layer)
+// init a new message builder with the current state (s, k, ki) for this instance and the participant.
+func (proc *consensusProcess) initDefaultBuilder(p *participant, s *Set) (*messageBuilder, error) {
+	builder := newMessageBuilder().SetLayer(proc.layer).SetPubKey(p.signing.PublicKey())
 	builder = builder.SetRoundCounter(proc.getRound()).SetCommittedRound(proc.committedRound).SetValues(s)
-	proof, err := proc.oracle.Proof(context.TODO(), proc.layer, proc.getRound())
+	proof, err := p.oracle.Proof(context.TODO(), proc.layer, proc.getRound())
 	if err != nil {
 		return nil, fmt.Errorf("init default builder: %w", err)
 	}
 	builder.SetRoleProof(proof)
 
 	proc.mu.RLock()
-	builder.SetEligibilityCount(proc.eligibilityCount)
+	builder.SetEligibilityCount(p.eligibilityCount)
 	proc.mu.RUnlock()
 
 	return builder, nil
@@ -721,15 +765,25 @@ func (proc *consensusProcess) processStatusMsg(ctx context.Context, msg *Msg) {
 func (proc *consensusProcess) processProposalMsg(ctx context.Context, msg *Msg) {
 	currRnd := proc.currentRound()
 
+	var proof *types.MalfeasanceProof
 	if currRnd == proposalRound { // regular proposal
-		proc.proposalTracker.OnProposal(ctx, msg)
+		proof = proc.proposalTracker.OnProposal(ctx, msg)
 	} else if currRnd == commitRound { // late proposal
-		proc.proposalTracker.OnLateProposal(ctx, msg)
+		proof = proc.proposalTracker.OnLateProposal(ctx, msg)
 	} else {
 		proc.WithContext(ctx).With().Warning("received proposal message for processing in an invalid context",
 			log.Uint32("current_round", proc.getRound()),
 			log.Uint32("msg_round", msg.InnerMsg.Round))
 	}
+	if proof != nil {
+		encoded, err := codec.Encode(proof)
+		if err != nil {
+			proc.With().Fatal("failed to encode malfeasance proof", log.Err(err))
+		}
+		if err := proc.publisher.Publish(ctx, pubsub.MalfeasanceProtocol, encoded); err != nil {
+			proc.WithContext(ctx).With().Error("failed to broadcast malfeasance proof", log.Err(err))
+		}
+	}
 }
 
 func (proc *consensusProcess) processCommitMsg(ctx context.Context, msg *Msg) {
@@ -827,16 +881,17 @@ func (proc *consensusProcess) endOfStatusRound() {
 		log.String("analyze_duration", time.Since(before).String()))
 }
 
-// checks if we should participate in the current round
-// returns true if we should participate, false otherwise.
-func (proc *consensusProcess) shouldParticipate(ctx context.Context) bool {
+// checks if the participant should participate in the current round
+// returns true if it should participate, false otherwise.
+func (proc *consensusProcess) shouldParticipate(ctx context.Context, p *participant) bool {
 	logger := proc.WithContext(ctx).WithFields(
 		log.Uint32("current_round", proc.getRound()),
-		proc.layer)
+		proc.layer,
+		p.nid)
 
 	// query if identity is active
-	nid := types.BytesToNodeID(proc.signing.PublicKey().Bytes())
-	res, err := proc.oracle.IsIdentityActiveOnConsensusView(ctx, nid, proc.layer)
+	nid := types.BytesToNodeID(p.signing.PublicKey().Bytes())
+	res, err := p.oracle.IsIdentityActiveOnConsensusView(ctx, nid, proc.layer)
 	if err != nil {
 		logger.With().Error("failed to check own identity for activeness", log.Err(err))
 		return false
@@ -847,14 +902,14 @@ func (proc *consensusProcess) shouldParticipate(ctx context.Context) bool {
 		return false
 	}
 
-	currentRole := proc.currentRole(ctx)
+	currentRole := proc.currentRole(ctx, p)
 	if currentRole == passive {
 		logger.Debug("should not participate: passive")
 		return false
 	}
 
 	proc.mu.RLock()
-	eligibilityCount := proc.eligibilityCount
+	eligibilityCount := p.eligibilityCount
 	proc.mu.RUnlock()
 
 	// should participate
@@ -865,10 +920,10 @@ func (proc *consensusProcess) shouldParticipate(ctx context.Context) bool {
 	return true
 }
 
-// Returns the role matching the current round if eligible for this round, false otherwise.
-func (proc *consensusProcess) currentRole(ctx context.Context) role {
-	logger := proc.WithContext(ctx).WithFields(proc.layer)
-	proof, err := proc.oracle.Proof(ctx, proc.layer, proc.getRound())
+// Returns the role of the participant matching the current round if eligible for this round, false otherwise.
+func (proc *consensusProcess) currentRole(ctx context.Context, p *participant) role {
+	logger := proc.WithContext(ctx).WithFields(proc.layer, p.nid)
+	proof, err := p.oracle.Proof(ctx, proc.layer, proc.getRound())
 	if err != nil {
 		logger.With().Error("failed to get eligibility proof from oracle", log.Err(err))
 		return passive
@@ -876,15 +931,15 @@ func (proc *consensusProcess) currentRole(ctx context.Context) role {
 
 	k := proc.getRound()
 
-	eligibilityCount, err := proc.oracle.CalcEligibility(ctx, proc.layer,
-		k, expectedCommitteeSize(k, proc.cfg.N, proc.cfg.ExpectedLeaders), proc.nid, proof)
+	eligibilityCount, err := p.oracle.CalcEligibility(ctx, proc.layer,
+		k, expectedCommitteeSize(k, proc.cfg.N, proc.cfg.ExpectedLeaders), p.nid, proof)
 	if err != nil {
 		logger.With().Error("failed to check eligibility", log.Err(err))
 		return passive
 	}
 
 	proc.mu.Lock()
-	proc.eligibilityCount = eligibilityCount
+	p.eligibilityCount = eligibilityCount
 	proc.mu.Unlock()
 
 	if eligibilityCount > 0 { // eligible
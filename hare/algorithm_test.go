@@ -95,12 +95,14 @@ type mockProposalTracker struct {
 	countProposedSet    int
 }
 
-func (mpt *mockProposalTracker) OnProposal(context.Context, *Msg) {
+func (mpt *mockProposalTracker) OnProposal(context.Context, *Msg) *types.MalfeasanceProof {
 	mpt.countOnProposal++
+	return nil
 }
 
-func (mpt *mockProposalTracker) OnLateProposal(context.Context, *Msg) {
+func (mpt *mockProposalTracker) OnLateProposal(context.Context, *Msg) *types.MalfeasanceProof {
 	mpt.countOnLateProposal++
+	return nil
 }
 
 func (mpt *mockProposalTracker) IsConflicting() bool {
@@ -226,8 +228,8 @@ func TestConsensusProcess_eventLoop(t *testing.T) {
 	mo := mocks.NewMockRolacle(ctrl)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(2)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).Times(1)
-	proc.oracle = mo
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(1), nil).Times(1)
+	proc.participants[0].oracle = mo
 
 	proc.inbox, _ = broker.Register(context.Background(), proc.ID())
 	proc.value = NewSetFromValues(value1, value2)
@@ -256,7 +258,7 @@ func TestConsensusProcess_handleMessage(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.publisher = net
 	mo := mocks.NewMockRolacle(ctrl)
-	proc.oracle = mo
+	proc.participants[0].oracle = mo
 	mValidator := &mockMessageValidator{}
 	proc.validator = mValidator
 	proc.inbox, _ = broker.Register(context.Background(), proc.ID())
@@ -323,9 +325,9 @@ func generateConsensusProcessWithConfig(tb testing.TB, cfg config.Config) *conse
 	ctrl := gomock.NewController(tb)
 	sq := mocks.NewMockstateQuerier(ctrl)
 	sq.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
-	return newConsensusProcess(context.Background(), cfg, instanceID1, s, oracle, sq,
-		4, edSigner, types.BytesToNodeID(edPubkey.Bytes()),
-		noopPubSub(tb), output, truer{}, newRoundClockFromCfg(logger, cfg),
+	return newConsensusProcess(context.Background(), cfg, instanceID1, s,
+		[]participant{{signing: edSigner, nid: nid, oracle: oracle}}, sq, 4,
+		noopPubSub(tb), output, truer{}, newRoundClockFromCfg(logger, cfg), nil,
 		logtest.New(tb).WithName(edPubkey.String()))
 }
 
@@ -354,11 +356,10 @@ func TestConsensusProcess_InitDefaultBuilder(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	s := NewEmptySet(defaultSetSize)
 	s.Add(value1)
-	builder, err := proc.initDefaultBuilder(s)
+	builder, err := proc.initDefaultBuilder(&proc.participants[0], s)
 	assert.Nil(t, err)
 	assert.True(t, NewSet(builder.inner.Values).Equals(s))
-	verifier := builder.msg.PubKey
-	assert.Nil(t, verifier)
+	assert.Equal(t, proc.participants[0].signing.PublicKey(), builder.msg.PubKey)
 	assert.Equal(t, builder.inner.Round, proc.getRound())
 	assert.Equal(t, builder.inner.CommittedRound, proc.committedRound)
 	assert.Equal(t, builder.inner.Layer, proc.layer)
@@ -369,12 +370,12 @@ func TestConsensusProcess_isEligible_NotEligible(t *testing.T) {
 
 	proc := generateConsensusProcess(t)
 	mo := mocks.NewMockRolacle(ctrl)
-	proc.oracle = mo
+	proc.participants[0].oracle = mo
 
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(1)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(0), nil).Times(1)
-	assert.False(t, proc.shouldParticipate(context.Background()))
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(0), nil).Times(1)
+	assert.False(t, proc.shouldParticipate(context.Background(), &proc.participants[0]))
 }
 
 func TestConsensusProcess_isEligible_Eligible(t *testing.T) {
@@ -382,12 +383,12 @@ func TestConsensusProcess_isEligible_Eligible(t *testing.T) {
 
 	proc := generateConsensusProcess(t)
 	mo := mocks.NewMockRolacle(ctrl)
-	proc.oracle = mo
+	proc.participants[0].oracle = mo
 
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(1)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).Times(1)
-	assert.True(t, proc.shouldParticipate(context.Background()))
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(1), nil).Times(1)
+	assert.True(t, proc.shouldParticipate(context.Background(), &proc.participants[0]))
 }
 
 func TestConsensusProcess_isEligible_ActiveSetFailed(t *testing.T) {
@@ -395,10 +396,10 @@ func TestConsensusProcess_isEligible_ActiveSetFailed(t *testing.T) {
 
 	proc := generateConsensusProcess(t)
 	mo := mocks.NewMockRolacle(ctrl)
-	proc.oracle = mo
+	proc.participants[0].oracle = mo
 
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(false, errors.New("some err")).Times(1)
-	assert.False(t, proc.shouldParticipate(context.Background()))
+	assert.False(t, proc.shouldParticipate(context.Background(), &proc.participants[0]))
 }
 
 func TestConsensusProcess_isEligible_NotActive(t *testing.T) {
@@ -406,10 +407,10 @@ func TestConsensusProcess_isEligible_NotActive(t *testing.T) {
 
 	proc := generateConsensusProcess(t)
 	mo := mocks.NewMockRolacle(ctrl)
-	proc.oracle = mo
+	proc.participants[0].oracle = mo
 
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(false, nil).Times(1)
-	assert.False(t, proc.shouldParticipate(context.Background()))
+	assert.False(t, proc.shouldParticipate(context.Background(), &proc.participants[0]))
 }
 
 func TestConsensusProcess_sendMessage(t *testing.T) {
@@ -602,8 +603,8 @@ func TestConsensusProcess_beginStatusRound(t *testing.T) {
 	mo := mocks.NewMockRolacle(ctrl)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(2)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).Times(1)
-	proc.oracle = mo
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(1), nil).Times(1)
+	proc.participants[0].oracle = mo
 
 	s := NewDefaultEmptySet()
 	signer, err := signing.NewEdSigner()
@@ -617,6 +618,41 @@ func TestConsensusProcess_beginStatusRound(t *testing.T) {
 	assert.NotEqual(t, preStatusTracker, proc.statusesTracker)
 }
 
+func TestConsensusProcess_beginStatusRoundMultipleParticipants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	proc := generateConsensusProcess(t)
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	other, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	mo := mocks.NewMockRolacle(ctrl)
+	mother := mocks.NewMockRolacle(ctrl)
+	proc.participants = []participant{
+		{signing: signer, nid: signer.NodeID(), oracle: mo},
+		{signing: other, nid: other.NodeID(), oracle: mother},
+	}
+	proc.advanceToNextRound(context.Background())
+	network := &mockP2p{}
+	proc.publisher = network
+
+	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), signer.NodeID(), proc.layer).Return(true, nil).Times(2)
+	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(4)
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), signer.NodeID(), gomock.Any()).Return(uint16(1), nil).Times(2)
+	mother.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), other.NodeID(), proc.layer).Return(true, nil).Times(2)
+	mother.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(3)
+	mother.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), other.NodeID(), gomock.Any()).Return(uint16(2), nil)
+	mother.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), other.NodeID(), gomock.Any()).Return(uint16(0), nil)
+
+	proc.beginStatusRound(context.Background())
+	assert.Equal(t, 2, network.getCount())
+	assert.EqualValues(t, 1, proc.participants[0].eligibilityCount)
+	assert.EqualValues(t, 2, proc.participants[1].eligibilityCount)
+
+	proc.beginStatusRound(context.Background())
+	assert.Equal(t, 3, network.getCount())
+}
+
 func TestConsensusProcess_beginProposalRound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -629,8 +665,8 @@ func TestConsensusProcess_beginProposalRound(t *testing.T) {
 	mo := mocks.NewMockRolacle(ctrl)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(2)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).Times(1)
-	proc.oracle = mo
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(1), nil).Times(1)
+	proc.participants[0].oracle = mo
 
 	statusTracker := newStatusTracker(1, 1)
 	s := NewSetFromValues(value1)
@@ -655,7 +691,7 @@ func TestConsensusProcess_beginCommitRound(t *testing.T) {
 	proc.publisher = network
 
 	mo := mocks.NewMockRolacle(ctrl)
-	proc.oracle = mo
+	proc.participants[0].oracle = mo
 
 	mpt := &mockProposalTracker{}
 	proc.proposalTracker = mpt
@@ -664,7 +700,7 @@ func TestConsensusProcess_beginCommitRound(t *testing.T) {
 	preCommitTracker := proc.commitTracker
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(1)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(0), nil).Times(1)
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(0), nil).Times(1)
 	proc.beginCommitRound(context.Background())
 	assert.NotEqual(t, preCommitTracker, proc.commitTracker)
 
@@ -673,7 +709,7 @@ func TestConsensusProcess_beginCommitRound(t *testing.T) {
 	proc.SetInbox(make(chan *Msg, 1))
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.layer).Return(true, nil).Times(1)
 	mo.EXPECT().Proof(gomock.Any(), proc.layer, proc.getRound()).Return(nil, nil).Times(2)
-	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).Times(1)
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.layer, proc.getRound(), gomock.Any(), proc.participants[0].nid, gomock.Any()).Return(uint16(1), nil).Times(1)
 	proc.beginCommitRound(context.Background())
 	assert.Equal(t, 1, network.getCount())
 }
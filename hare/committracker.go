@@ -14,6 +14,7 @@ type commitTracker struct {
 	proposedSet      *Set            // follows the set who has max number of commits
 	threshold        int             // the number of required commits
 	eligibilityCount int
+	trace            *layerTrace
 }
 
 func newCommitTracker(threshold, expectedSize int, proposedSet *Set) *commitTracker {
@@ -29,15 +30,18 @@ func newCommitTracker(threshold, expectedSize int, proposedSet *Set) *commitTrac
 // OnCommit tracks the given commit message.
 func (ct *commitTracker) OnCommit(msg *Msg) {
 	if ct.proposedSet == nil { // no valid proposed set
+		ct.trace.transition(TraceCommitTracker, TraceIgnored, msg, 0)
 		return
 	}
 
 	if ct.HasEnoughCommits() {
+		ct.trace.transition(TraceCommitTracker, TraceIgnored, msg, uint32(ct.eligibilityCount))
 		return
 	}
 
 	pub := msg.PubKey
 	if ct.seenSenders[pub.String()] {
+		ct.trace.transition(TraceCommitTracker, TraceDuplicate, msg, uint32(ct.eligibilityCount))
 		return
 	}
 
@@ -45,12 +49,17 @@ func (ct *commitTracker) OnCommit(msg *Msg) {
 
 	s := NewSet(msg.InnerMsg.Values)
 	if !ct.proposedSet.Equals(s) { // ignore commit on different set
+		ct.trace.transition(TraceCommitTracker, TraceIgnored, msg, uint32(ct.eligibilityCount))
 		return
 	}
 
 	// add msg
 	ct.commits = append(ct.commits, msg.Message)
 	ct.eligibilityCount += int(msg.InnerMsg.EligibilityCount)
+	ct.trace.transition(TraceCommitTracker, TraceTracked, msg, uint32(ct.eligibilityCount))
+	if ct.HasEnoughCommits() {
+		ct.trace.transition(TraceCommitTracker, TraceThreshold, msg, uint32(ct.eligibilityCount))
+	}
 }
 
 // HasEnoughCommits returns true if the tracker can build a certificate, false otherwise.
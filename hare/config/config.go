@@ -9,6 +9,9 @@ type Config struct {
 	ExpectedLeaders int `mapstructure:"hare-exp-leaders"`        // the expected number of leaders
 	LimitIterations int `mapstructure:"hare-limit-iterations"`   // limit on number of iterations
 	LimitConcurrent int `mapstructure:"hare-limit-concurrent"`   // limit number of concurrent CPs
+	// TraceDir is a directory where messages and tracker transitions of every consensus process are recorded.
+	// Recording is disabled if empty.
+	TraceDir string `mapstructure:"hare-trace-dir"`
 }
 
 // DefaultConfig returns the default configuration for the hare.
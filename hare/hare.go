@@ -24,7 +24,7 @@ import (
 // LayerBuffer is the number of layer results we keep at a given time.
 const LayerBuffer = 20
 
-type consensusFactory func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, terminationReport chan TerminationOutput) Consensus
+type consensusFactory func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, participants []participant, p2p pubsub.Publisher, clock RoundClock, terminationReport chan TerminationOutput) Consensus
 
 // Consensus represents an item that acts like a consensus process.
 type Consensus interface {
@@ -70,7 +70,7 @@ type Hare struct {
 	publisher     pubsub.Publisher
 	layerClock    LayerClock
 	broker        *Broker
-	sign          Signer
+	participants  []participant
 	blockGenCh    chan LayerOutput
 	beacons       system.BeaconGetter
 	rolacle       Rolacle
@@ -89,6 +89,7 @@ type Hare struct {
 	outputs    map[types.LayerID][]types.ProposalID
 
 	factory consensusFactory
+	trace   *traceRecorder
 
 	nid types.NodeID
 
@@ -136,7 +137,7 @@ func New(
 
 	ev := newEligibilityValidator(rolacle, layersPerEpoch, conf.N, conf.ExpectedLeaders, logger)
 	h.broker = newBroker(ev, stateQ, syncState, layersPerEpoch, conf.LimitConcurrent, logger)
-	h.sign = sign
+	h.participants = []participant{{signing: sign, nid: nid, oracle: rolacle}}
 	h.blockGenCh = ch
 
 	h.beacons = beacons
@@ -148,8 +149,11 @@ func New(
 	h.bufferSize = LayerBuffer // XXX: must be at least the size of `hdist`
 	h.outputChan = make(chan TerminationOutput, h.bufferSize)
 	h.outputs = make(map[types.LayerID][]types.ProposalID, h.bufferSize) // we keep results about LayerBuffer past layers
-	h.factory = func(ctx context.Context, conf config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, terminationReport chan TerminationOutput) Consensus {
-		return newConsensusProcess(ctx, conf, instanceId, s, oracle, stateQ, layersPerEpoch, signing, nid, p2p, terminationReport, ev, clock, logger)
+	if conf.TraceDir != "" {
+		h.trace = newTraceRecorder(conf.TraceDir, nid, logger)
+	}
+	h.factory = func(ctx context.Context, conf config.Config, instanceId types.LayerID, s *Set, participants []participant, p2p pubsub.Publisher, clock RoundClock, terminationReport chan TerminationOutput) Consensus {
+		return newConsensusProcess(ctx, conf, instanceId, s, participants, stateQ, layersPerEpoch, p2p, terminationReport, ev, clock, h.trace.layer(instanceId), logger)
 	}
 
 	h.nid = nid
@@ -157,6 +161,13 @@ func New(
 	return h
 }
 
+// AddIdentity adds an identity that participates in consensus processes along with the node identity.
+// Oracle must compute eligibility proofs with the vrf key of the identity.
+// It must be called before Start.
+func (h *Hare) AddIdentity(sign Signer, nid types.NodeID, oracle Rolacle) {
+	h.participants = append(h.participants, participant{signing: sign, nid: nid, oracle: oracle})
+}
+
 // GetHareMsgHandler returns the gossip handler for hare protocol message.
 func (h *Hare) GetHareMsgHandler() pubsub.GossipHandler {
 	return h.broker.HandleMessage
@@ -327,7 +338,7 @@ func (h *Hare) onTick(ctx context.Context, id types.LayerID) (bool, error) {
 		logger.With().Error("could not register consensus process on broker", log.Err(err))
 		return false, fmt.Errorf("broker register: %w", err)
 	}
-	cp := h.factory(h.ctx, h.config, instID, set, h.rolacle, h.sign, h.publisher, clock, h.outputChan)
+	cp := h.factory(h.ctx, h.config, instID, set, h.participants, h.publisher, clock, h.outputChan)
 	cp.SetInbox(c)
 	if err = cp.Start(); err != nil {
 		logger.With().Error("could not start consensus process", log.Err(err))
@@ -72,7 +72,7 @@ func (mcp *mockConsensusProcess) SetInbox(chan *Msg) {
 
 var _ Consensus = (*mockConsensusProcess)(nil)
 
-func newMockConsensusProcess(_ config.Config, instanceID types.LayerID, s *Set, _ Rolacle, _ Signer, _ pubsub.Publisher, outputChan chan TerminationOutput, started chan struct{}) *mockConsensusProcess {
+func newMockConsensusProcess(_ config.Config, instanceID types.LayerID, s *Set, _ []participant, _ pubsub.Publisher, outputChan chan TerminationOutput, started chan struct{}) *mockConsensusProcess {
 	mcp := new(mockConsensusProcess)
 	mcp.started = started
 	mcp.id = instanceID
@@ -226,8 +226,8 @@ func TestHare_onTick(t *testing.T) {
 	createdChan := make(chan struct{}, 1)
 	startedChan := make(chan struct{}, 1)
 	var nmcp *mockConsensusProcess
-	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
-		nmcp = newMockConsensusProcess(cfg, instanceId, s, oracle, signing, p2p, outputChan, startedChan)
+	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, participants []participant, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
+		nmcp = newMockConsensusProcess(cfg, instanceId, s, participants, p2p, outputChan, startedChan)
 		close(createdChan)
 		return nmcp
 	}
@@ -296,8 +296,8 @@ func TestHare_onTick_BeaconFromRefBallot(t *testing.T) {
 	createdChan := make(chan struct{}, 1)
 	startedChan := make(chan struct{}, 1)
 	var nmcp *mockConsensusProcess
-	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
-		nmcp = newMockConsensusProcess(cfg, instanceId, s, oracle, signing, p2p, outputChan, startedChan)
+	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, participants []participant, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
+		nmcp = newMockConsensusProcess(cfg, instanceId, s, participants, p2p, outputChan, startedChan)
 		close(createdChan)
 		return nmcp
 	}
@@ -356,8 +356,8 @@ func TestHare_onTick_SomeBadBallots(t *testing.T) {
 	createdChan := make(chan struct{}, 1)
 	startedChan := make(chan struct{}, 1)
 	var nmcp *mockConsensusProcess
-	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
-		nmcp = newMockConsensusProcess(cfg, instanceId, s, oracle, signing, p2p, outputChan, startedChan)
+	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, participants []participant, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
+		nmcp = newMockConsensusProcess(cfg, instanceId, s, participants, p2p, outputChan, startedChan)
 		close(createdChan)
 		return nmcp
 	}
@@ -413,8 +413,8 @@ func TestHare_onTick_NoGoodBallots(t *testing.T) {
 	createdChan := make(chan struct{}, 1)
 	startedChan := make(chan struct{}, 1)
 	var nmcp *mockConsensusProcess
-	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
-		nmcp = newMockConsensusProcess(cfg, instanceId, s, oracle, signing, p2p, outputChan, startedChan)
+	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, participants []participant, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
+		nmcp = newMockConsensusProcess(cfg, instanceId, s, participants, p2p, outputChan, startedChan)
 		close(createdChan)
 		return nmcp
 	}
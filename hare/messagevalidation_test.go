@@ -320,17 +320,17 @@ func TestMessageValidator_ValidateMessage(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.advanceToNextRound(context.Background())
 	v := proc.validator
-	b, err := proc.initDefaultBuilder(proc.value)
+	b, err := proc.initDefaultBuilder(&proc.participants[0], proc.value)
 	assert.Nil(t, err)
-	preround := b.SetType(pre).Sign(proc.signing).Build()
-	preround.PubKey = proc.signing.PublicKey()
+	preround := b.SetType(pre).Sign(proc.participants[0].signing).Build()
+	preround.PubKey = proc.participants[0].signing.PublicKey()
 	assert.True(t, v.SyntacticallyValidateMessage(context.Background(), preround))
 	e := v.ContextuallyValidateMessage(context.Background(), preround, 0)
 	assert.Nil(t, e)
-	b, err = proc.initDefaultBuilder(proc.value)
+	b, err = proc.initDefaultBuilder(&proc.participants[0], proc.value)
 	assert.Nil(t, err)
-	status := b.SetType(status).Sign(proc.signing).Build()
-	status.PubKey = proc.signing.PublicKey()
+	status := b.SetType(status).Sign(proc.participants[0].signing).Build()
+	status.PubKey = proc.participants[0].signing.PublicKey()
 	e = v.ContextuallyValidateMessage(context.Background(), status, 0)
 	assert.Nil(t, e)
 	assert.True(t, v.SyntacticallyValidateMessage(context.Background(), status))
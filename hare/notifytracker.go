@@ -13,6 +13,7 @@ type notifyTracker struct {
 	notifies     map[string]struct{}       // tracks PubKey->Notification
 	tracker      *RefCountTracker          // tracks ref count to each seen set
 	certificates map[types.Hash32]struct{} // tracks Set->certificate
+	trace        *layerTrace
 }
 
 func newNotifyTracker(expectedSize int) *notifyTracker {
@@ -30,6 +31,7 @@ func (nt *notifyTracker) OnNotify(msg *Msg) bool {
 	pub := msg.PubKey
 	eligibilityCount := uint32(msg.InnerMsg.EligibilityCount)
 	if _, exist := nt.notifies[pub.String()]; exist { // already seenSenders
+		nt.trace.transition(TraceNotifyTracker, TraceDuplicate, msg, 0)
 		return true // ignored
 	}
 
@@ -40,6 +42,7 @@ func (nt *notifyTracker) OnNotify(msg *Msg) bool {
 	s := NewSet(msg.InnerMsg.Values)
 	nt.onCertificate(msg.InnerMsg.Cert.AggMsgs.Messages[0].InnerMsg.Round, s)
 	nt.tracker.Track(s.ID(), eligibilityCount)
+	nt.trace.transition(TraceNotifyTracker, TraceTracked, msg, nt.tracker.CountStatus(s.ID()))
 
 	return false
 }
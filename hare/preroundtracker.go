@@ -21,6 +21,7 @@ type preRoundTracker struct {
 	bestVRF   uint32           // the lowest VRF value seen in the round
 	coinflip  bool             // the value of the weak coin (based on bestVRF)
 	logger    log.Log
+	trace     *layerTrace
 }
 
 func newPreRoundTracker(threshold, expectedSize int, logger log.Log) *preRoundTracker {
@@ -56,22 +57,26 @@ func (pre *preRoundTracker) OnPreRound(ctx context.Context, msg *Msg) {
 			log.String("sender_id", pub.ShortString()),
 			log.String("vrf_value", fmt.Sprintf("%x", shaUint32)),
 			log.Bool("weak_coin", pre.coinflip))
+		pre.trace.transition(TracePreRoundTracker, TraceBestVRF, msg, shaUint32)
 	}
 
 	eligibilityCount := uint32(msg.InnerMsg.EligibilityCount)
 	sToTrack := NewSet(msg.InnerMsg.Values) // assume track all Values
 	alreadyTracked := NewDefaultEmptySet()  // assume nothing tracked so far
+	state := TraceTracked
 
 	if set, exist := pre.preRound[pub.String()]; exist { // not first pre-round msg from this sender
 		logger.With().Debug("duplicate preround msg sender", log.String("sender_id", pub.ShortString()))
 		alreadyTracked = set              // update already tracked Values
 		sToTrack.Subtract(alreadyTracked) // subtract the already tracked Values
+		state = TraceDuplicate
 	}
 
 	// record Values
 	for _, v := range sToTrack.elements() {
 		pre.tracker.Track(v, eligibilityCount)
 	}
+	pre.trace.transition(TracePreRoundTracker, state, msg, uint32(sToTrack.Size()))
 
 	// update the union to include new Values
 	pre.preRound[pub.String()] = alreadyTracked.Union(sToTrack)
@@ -109,4 +114,5 @@ func (pre *preRoundTracker) FilterSet(set *Set) {
 			set.Remove(bid)
 		}
 	}
+	pre.trace.transition(TracePreRoundTracker, TraceFiltered, nil, uint32(set.Size()))
 }
@@ -4,12 +4,13 @@ import (
 	"bytes"
 	"context"
 
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
 type proposalTrackerProvider interface {
-	OnProposal(context.Context, *Msg)
-	OnLateProposal(context.Context, *Msg)
+	OnProposal(context.Context, *Msg) *types.MalfeasanceProof
+	OnLateProposal(context.Context, *Msg) *types.MalfeasanceProof
 	IsConflicting() bool
 	ProposedSet() *Set
 }
@@ -32,10 +33,11 @@ func newProposalTracker(log log.Log) *proposalTracker {
 
 // OnProposal tracks the provided proposal message.
 // It assumes the proposal message is syntactically valid and that it was received on the proposal round.
-func (pt *proposalTracker) OnProposal(ctx context.Context, msg *Msg) {
+// Returns a malfeasance proof if the sender of the message equivocated.
+func (pt *proposalTracker) OnProposal(ctx context.Context, msg *Msg) *types.MalfeasanceProof {
 	if pt.proposal == nil { // first leader
 		pt.proposal = msg // just update
-		return
+		return nil
 	}
 
 	// if same sender then we should check for equivocation
@@ -48,27 +50,31 @@ func (pt *proposalTracker) OnProposal(ctx context.Context, msg *Msg) {
 				log.String("current_set", g.String()),
 				log.String("conflicting_set", s.String()))
 			pt.isConflicting = true
+			return equivocationProof(pt.proposal, msg)
 		}
 
-		return // process done
+		return nil // process done
 	}
 
 	// ignore msgs with higher ranked role proof
 	if bytes.Compare(msg.InnerMsg.RoleProof, pt.proposal.InnerMsg.RoleProof) > 0 {
-		return
+		return nil
 	}
 
 	pt.proposal = msg        // update lower leader msg
 	pt.isConflicting = false // assume no conflict
+	return nil
 }
 
 // OnLateProposal tracks the given proposal message.
 // It assumes the proposal message is syntactically valid and that it was not received on the proposal round (late).
-func (pt *proposalTracker) OnLateProposal(ctx context.Context, msg *Msg) {
+// Returns a malfeasance proof if the sender of the message equivocated.
+func (pt *proposalTracker) OnLateProposal(ctx context.Context, msg *Msg) *types.MalfeasanceProof {
 	if pt.proposal == nil {
-		return
+		return nil
 	}
 
+	var proof *types.MalfeasanceProof
 	// if same sender then we should check for equivocation
 	if pt.proposal.PubKey.Equals(msg.PubKey) {
 		s := NewSet(msg.InnerMsg.Values)
@@ -79,6 +85,7 @@ func (pt *proposalTracker) OnLateProposal(ctx context.Context, msg *Msg) {
 				log.String("current_set", g.String()),
 				log.String("conflicting_set", s.String()))
 			pt.isConflicting = true
+			proof = equivocationProof(pt.proposal, msg)
 		}
 	}
 
@@ -89,6 +96,19 @@ func (pt *proposalTracker) OnLateProposal(ctx context.Context, msg *Msg) {
 			log.String("id_malicious", msg.PubKey.String()))
 		pt.isConflicting = true
 	}
+	return proof
+}
+
+func equivocationProof(first, second *Msg) *types.MalfeasanceProof {
+	return &types.MalfeasanceProof{
+		Layer: second.InnerMsg.Layer,
+		HareEquivocation: &types.HareProof{
+			Messages: [2]types.HareProofMsg{
+				{InnerMsg: first.InnerMsg.Bytes(), Signature: first.Signature},
+				{InnerMsg: second.InnerMsg.Bytes(), Signature: second.Signature},
+			},
+		},
+	}
 }
 
 // IsConflicting returns true if there was a conflict, false otherwise.
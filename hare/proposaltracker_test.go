@@ -35,8 +35,15 @@ func TestProposalTracker_OnProposalConflict(t *testing.T) {
 	assert.False(t, tracker.IsConflicting())
 	g := NewSetFromValues(value3)
 	m2 := BuildProposalMsg(signer, g)
-	tracker.OnProposal(context.Background(), m2)
+	proof := tracker.OnProposal(context.Background(), m2)
 	assert.True(t, tracker.IsConflicting())
+	require.NotNil(t, proof)
+	require.NotNil(t, proof.HareEquivocation)
+	require.Equal(t, instanceID1, proof.Layer)
+	require.Equal(t, m1.InnerMsg.Bytes(), proof.HareEquivocation.Messages[0].InnerMsg)
+	require.Equal(t, m1.Signature, proof.HareEquivocation.Messages[0].Signature)
+	require.Equal(t, m2.InnerMsg.Bytes(), proof.HareEquivocation.Messages[1].InnerMsg)
+	require.Equal(t, m2.Signature, proof.HareEquivocation.Messages[1].Signature)
 }
 
 func TestProposalTracker_IsConflicting(t *testing.T) {
@@ -48,7 +55,7 @@ func TestProposalTracker_IsConflicting(t *testing.T) {
 		signer, err := signing.NewEdSigner()
 		require.NoError(t, err)
 
-		tracker.OnProposal(context.Background(), BuildProposalMsg(signer, s))
+		require.Nil(t, tracker.OnProposal(context.Background(), BuildProposalMsg(signer, s)))
 		assert.False(t, tracker.IsConflicting())
 	}
 }
@@ -63,8 +70,10 @@ func TestProposalTracker_OnLateProposal(t *testing.T) {
 	assert.False(t, tracker.IsConflicting())
 	g := NewSetFromValues(value3)
 	m2 := BuildProposalMsg(signer, g)
-	tracker.OnLateProposal(context.Background(), m2)
+	proof := tracker.OnLateProposal(context.Background(), m2)
 	assert.True(t, tracker.IsConflicting())
+	require.NotNil(t, proof)
+	require.NotNil(t, proof.HareEquivocation)
 }
 
 func TestProposalTracker_ProposedSet(t *testing.T) {
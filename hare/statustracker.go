@@ -15,6 +15,7 @@ type statusTracker struct {
 	maxSet            *Set            // tracks the max raw set in the tracked status Messages
 	count             uint16          // the count of valid status messages
 	analyzed          bool            // indicates if the Messages have already been analyzed
+	trace             *layerTrace
 	log.Log
 }
 
@@ -36,10 +37,12 @@ func (st *statusTracker) RecordStatus(ctx context.Context, msg *Msg) {
 	if exist { // already handled this sender's status msg
 		st.WithContext(ctx).With().Warning("duplicate status message detected",
 			log.FieldNamed("sender_id", pub))
+		st.trace.transition(TraceStatusTracker, TraceDuplicate, msg, uint32(len(st.statuses)))
 		return
 	}
 
 	st.statuses[pub.String()] = msg
+	st.trace.transition(TraceStatusTracker, TraceTracked, msg, uint32(len(st.statuses)))
 }
 
 // AnalyzeStatuses analyzes the recorded status messages by the validation function.
@@ -58,6 +61,10 @@ func (st *statusTracker) AnalyzeStatuses(isValid func(m *Msg) bool) {
 	}
 
 	st.analyzed = true
+	st.trace.transition(TraceStatusTracker, TraceAnalyzed, nil, uint32(st.count))
+	if st.IsSVPReady() {
+		st.trace.transition(TraceStatusTracker, TraceThreshold, nil, uint32(st.count))
+	}
 }
 
 // IsSVPReady returns true if theere are enough statuses to build an SVP, false otherwise.
@@ -0,0 +1,513 @@
+package hare
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+//go:generate scalegen -types TraceHeader,TraceRecord
+
+const (
+	traceVersion = 1
+	// TraceExtension is the extension of the files with hare traces.
+	TraceExtension = ".htrace"
+)
+
+// TraceKind is a kind of the trace record.
+type TraceKind byte
+
+const (
+	// TraceReceived is a message received by the consensus process.
+	TraceReceived TraceKind = iota + 1
+	// TraceSent is a message sent by the consensus process.
+	TraceSent
+	// TraceTransition is a state transition of the tracker.
+	TraceTransition
+	// TraceTerminated is a termination of the consensus process.
+	TraceTerminated
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceReceived:
+		return "received"
+	case TraceSent:
+		return "sent"
+	case TraceTransition:
+		return "transition"
+	case TraceTerminated:
+		return "terminated"
+	default:
+		return "unknown"
+	}
+}
+
+// TraceOutcome is an outcome of the validation of a received message or of publishing a sent message.
+type TraceOutcome byte
+
+const (
+	// TraceValid message passed validation and was processed.
+	TraceValid TraceOutcome = iota + 1
+	// TraceEarly message is kept until the round it belongs to.
+	TraceEarly
+	// TraceInvalidContext message was discarded as it is late or otherwise contextually invalid.
+	TraceInvalidContext
+	// TraceInvalidSyntax message was discarded as it is syntactically invalid.
+	TraceInvalidSyntax
+	// TracePublished message was published.
+	TracePublished
+	// TracePublishFailed message failed to be published.
+	TracePublishFailed
+	// TraceCompleted consensus process reached consensus.
+	TraceCompleted
+	// TraceNotCompleted consensus process terminated without reaching consensus.
+	TraceNotCompleted
+)
+
+func (o TraceOutcome) String() string {
+	switch o {
+	case TraceValid:
+		return "valid"
+	case TraceEarly:
+		return "early"
+	case TraceInvalidContext:
+		return "invalid_context"
+	case TraceInvalidSyntax:
+		return "invalid_syntax"
+	case TracePublished:
+		return "published"
+	case TracePublishFailed:
+		return "publish_failed"
+	case TraceCompleted:
+		return "completed"
+	case TraceNotCompleted:
+		return "not_completed"
+	default:
+		return "unknown"
+	}
+}
+
+// TraceTracker identifies the tracker of the transition.
+type TraceTracker byte
+
+const (
+	// TracePreRoundTracker is preRoundTracker.
+	TracePreRoundTracker TraceTracker = iota + 1
+	// TraceStatusTracker is statusTracker.
+	TraceStatusTracker
+	// TraceCommitTracker is commitTracker.
+	TraceCommitTracker
+	// TraceNotifyTracker is notifyTracker.
+	TraceNotifyTracker
+)
+
+func (t TraceTracker) String() string {
+	switch t {
+	case TracePreRoundTracker:
+		return "preround"
+	case TraceStatusTracker:
+		return "status"
+	case TraceCommitTracker:
+		return "commit"
+	case TraceNotifyTracker:
+		return "notify"
+	default:
+		return "unknown"
+	}
+}
+
+// TraceState is a state transition of the tracker.
+// Meaning of the TraceRecord.Count depends on the transition.
+type TraceState byte
+
+const (
+	// TraceTracked message from the sender was tracked.
+	// Count is the number of values tracked from the preround message, the number of recorded statuses,
+	// or the total eligibility count of the commits or notifications for the set.
+	TraceTracked TraceState = iota + 1
+	// TraceDuplicate message from the same sender was already tracked.
+	TraceDuplicate
+	// TraceIgnored message from the sender was not tracked, for example commit for another set.
+	TraceIgnored
+	// TraceBestVRF message from the sender has the lowest vrf seen so far. Count is the vrf value.
+	TraceBestVRF
+	// TraceFiltered set was filtered from unprovable values. Count is the size of the filtered set.
+	TraceFiltered
+	// TraceAnalyzed statuses were validated. Count is the total eligibility count of valid statuses.
+	TraceAnalyzed
+	// TraceThreshold tracker reached the threshold, for example enough statuses to build svp
+	// or enough commits to build a certificate. Count is the total eligibility count.
+	TraceThreshold
+)
+
+func (s TraceState) String() string {
+	switch s {
+	case TraceTracked:
+		return "tracked"
+	case TraceDuplicate:
+		return "duplicate"
+	case TraceIgnored:
+		return "ignored"
+	case TraceBestVRF:
+		return "best_vrf"
+	case TraceFiltered:
+		return "filtered"
+	case TraceAnalyzed:
+		return "analyzed"
+	case TraceThreshold:
+		return "threshold"
+	default:
+		return "unknown"
+	}
+}
+
+// TraceHeader is written once at the start of the trace of the consensus process.
+type TraceHeader struct {
+	Version uint32
+	// Node is the id of the node that recorded the trace.
+	Node  types.NodeID
+	Layer types.LayerID
+}
+
+// TraceRecord is a single event in the consensus process.
+type TraceRecord struct {
+	// Time is unix time in nanoseconds.
+	Time uint64
+	// Round is the round counter of the consensus process when the record was made.
+	Round uint32
+	Kind  TraceKind
+	// Sender of the message, or the sender of the message that caused the transition.
+	Sender types.NodeID
+
+	// MsgType, MsgRound and EligibilityCount are set for messages.
+	MsgType          MessageType
+	MsgRound         uint32
+	EligibilityCount uint16
+	Outcome          TraceOutcome
+
+	// Tracker and State are set for transitions.
+	Tracker TraceTracker
+	State   TraceState
+	Count   uint32
+}
+
+// String returns the record as a single line.
+func (r *TraceRecord) String() string {
+	return fmt.Sprintf("%s %s", formatTraceTime(r.Time), r.details())
+}
+
+func (r *TraceRecord) details() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "round=%d %s", r.Round, r.Kind)
+	switch r.Kind {
+	case TraceReceived, TraceSent:
+		fmt.Fprintf(&b, " type=%s msg_round=%d sender=%s eligibility=%d outcome=%s",
+			r.MsgType, r.MsgRound, r.Sender.ShortString(), r.EligibilityCount, r.Outcome)
+	case TraceTransition:
+		fmt.Fprintf(&b, " tracker=%s state=%s", r.Tracker, r.State)
+		if r.Sender != (types.NodeID{}) {
+			fmt.Fprintf(&b, " sender=%s", r.Sender.ShortString())
+		}
+		fmt.Fprintf(&b, " count=%d", r.Count)
+	case TraceTerminated:
+		fmt.Fprintf(&b, " outcome=%s", r.Outcome)
+	}
+	return b.String()
+}
+
+func formatTraceTime(nanos uint64) string {
+	return time.Unix(0, int64(nanos)).UTC().Format("15:04:05.000000")
+}
+
+// traceRecorder records consensus processes of the node into a directory, a file per process.
+type traceRecorder struct {
+	dir    string
+	node   types.NodeID
+	now    func() time.Time
+	logger log.Log
+}
+
+func newTraceRecorder(dir string, node types.NodeID, logger log.Log) *traceRecorder {
+	return &traceRecorder{dir: dir, node: node, now: time.Now, logger: logger}
+}
+
+// layer returns a trace for the consensus process of the layer.
+// Returns nil if recorder is nil, nil trace discards all records.
+func (r *traceRecorder) layer(lid types.LayerID) *layerTrace {
+	if r == nil {
+		return nil
+	}
+	return &layerTrace{
+		path:   filepath.Join(r.dir, fmt.Sprintf("%d-%s%s", lid.Value, r.node.ShortString(), TraceExtension)),
+		header: TraceHeader{Version: traceVersion, Node: r.node, Layer: lid},
+		now:    r.now,
+		logger: r.logger.WithFields(lid),
+	}
+}
+
+// layerTrace records events of a single consensus process.
+// The file is created on the first record. All methods are safe to call on nil trace.
+type layerTrace struct {
+	path   string
+	header TraceHeader
+	now    func() time.Time
+	logger log.Log
+	// round returns the current round counter of the consensus process.
+	round func() uint32
+
+	mu     sync.Mutex
+	file   *os.File
+	w      *bufio.Writer
+	closed bool
+}
+
+func (t *layerTrace) received(m *Msg, outcome TraceOutcome) {
+	if t == nil {
+		return
+	}
+	t.record(TraceRecord{
+		Kind:             TraceReceived,
+		Sender:           senderID(m),
+		MsgType:          m.InnerMsg.Type,
+		MsgRound:         m.InnerMsg.Round,
+		EligibilityCount: m.InnerMsg.EligibilityCount,
+		Outcome:          outcome,
+	})
+}
+
+func (t *layerTrace) sent(m *Msg, outcome TraceOutcome) {
+	if t == nil {
+		return
+	}
+	t.record(TraceRecord{
+		Kind:             TraceSent,
+		Sender:           senderID(m),
+		MsgType:          m.InnerMsg.Type,
+		MsgRound:         m.InnerMsg.Round,
+		EligibilityCount: m.InnerMsg.EligibilityCount,
+		Outcome:          outcome,
+	})
+}
+
+// transition records a state transition of the tracker. Message may be nil if transition
+// wasn't caused by a message.
+func (t *layerTrace) transition(tracker TraceTracker, state TraceState, m *Msg, count uint32) {
+	if t == nil {
+		return
+	}
+	t.record(TraceRecord{
+		Kind:    TraceTransition,
+		Sender:  senderID(m),
+		Tracker: tracker,
+		State:   state,
+		Count:   count,
+	})
+}
+
+func (t *layerTrace) terminated(completed bool) {
+	if t == nil {
+		return
+	}
+	outcome := TraceNotCompleted
+	if completed {
+		outcome = TraceCompleted
+	}
+	t.record(TraceRecord{Kind: TraceTerminated, Outcome: outcome})
+}
+
+func (t *layerTrace) record(rec TraceRecord) {
+	rec.Time = uint64(t.now().UnixNano())
+	if t.round != nil {
+		rec.Round = t.round()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	if t.w == nil {
+		if err := t.open(); err != nil {
+			t.logger.With().Error("failed to open hare trace, recording disabled", log.Err(err))
+			t.closed = true
+			return
+		}
+	}
+	if _, err := codec.EncodeTo(t.w, &rec); err != nil {
+		t.logger.With().Error("failed to write hare trace, recording disabled", log.Err(err))
+		t.closeFile()
+	}
+}
+
+func (t *layerTrace) open() error {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o700); err != nil {
+		return fmt.Errorf("create trace dir: %w", err)
+	}
+	f, err := os.Create(t.path)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", t.path, err)
+	}
+	t.file = f
+	t.w = bufio.NewWriter(f)
+	if _, err := codec.EncodeTo(t.w, &t.header); err != nil {
+		t.closeFile()
+		return fmt.Errorf("write header: %w", err)
+	}
+	return nil
+}
+
+// close flushes recorded events to disk. Events recorded after close are discarded.
+func (t *layerTrace) close() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closeFile()
+}
+
+func (t *layerTrace) closeFile() {
+	t.closed = true
+	if t.file == nil {
+		return
+	}
+	if err := t.w.Flush(); err != nil {
+		t.logger.With().Warning("failed to flush hare trace", log.Err(err))
+	}
+	if err := t.file.Close(); err != nil {
+		t.logger.With().Warning("failed to close hare trace", log.Err(err))
+	}
+	t.file = nil
+}
+
+func senderID(m *Msg) types.NodeID {
+	if m == nil || m.PubKey == nil {
+		return types.NodeID{}
+	}
+	return types.BytesToNodeID(m.PubKey.Bytes())
+}
+
+// ReadTrace reads the trace of a single consensus process.
+// Trace that was cut short, for example when the node crashed, is read up to the last complete record.
+func ReadTrace(r io.Reader) (*TraceHeader, []TraceRecord, error) {
+	br := bufio.NewReader(r)
+	var header TraceHeader
+	if _, err := codec.DecodeFrom(br, &header); err != nil {
+		return nil, nil, fmt.Errorf("read header: %w", err)
+	}
+	if header.Version != traceVersion {
+		return nil, nil, fmt.Errorf("unsupported trace version %d", header.Version)
+	}
+	var records []TraceRecord
+	for {
+		if _, err := br.Peek(1); errors.Is(err, io.EOF) {
+			return &header, records, nil
+		}
+		var rec TraceRecord
+		if _, err := codec.DecodeFrom(br, &rec); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return &header, records, nil
+			}
+			return nil, nil, fmt.Errorf("read record %d: %w", len(records), err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// TraceEntry is a record in the timeline along with the node that recorded it.
+type TraceEntry struct {
+	Node types.NodeID
+	TraceRecord
+}
+
+// String returns the entry as a single line.
+func (e *TraceEntry) String() string {
+	return fmt.Sprintf("%s node=%s %s", formatTraceTime(e.Time), e.Node.ShortString(), e.details())
+}
+
+// TraceTimeline is a merged timeline of the hare instance from multiple nodes.
+type TraceTimeline struct {
+	Layer   types.LayerID
+	Nodes   []types.NodeID
+	Entries []TraceEntry
+}
+
+// MergeTraces reads traces from the paths and merges them into a timeline per layer.
+// Path is either a trace file or a directory with trace files.
+// Timelines are ordered by layer, and entries within timeline by time.
+func MergeTraces(paths ...string) ([]TraceTimeline, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*"+TraceExtension))
+		if err != nil {
+			return nil, fmt.Errorf("list traces in %s: %w", path, err)
+		}
+		files = append(files, matches...)
+	}
+
+	timelines := map[types.LayerID]*TraceTimeline{}
+	for _, file := range files {
+		header, records, err := readTraceFile(file)
+		if err != nil {
+			return nil, err
+		}
+		timeline, exists := timelines[header.Layer]
+		if !exists {
+			timeline = &TraceTimeline{Layer: header.Layer}
+			timelines[header.Layer] = timeline
+		}
+		timeline.Nodes = append(timeline.Nodes, header.Node)
+		for _, rec := range records {
+			timeline.Entries = append(timeline.Entries, TraceEntry{Node: header.Node, TraceRecord: rec})
+		}
+	}
+
+	rst := make([]TraceTimeline, 0, len(timelines))
+	for _, timeline := range timelines {
+		sort.Slice(timeline.Nodes, func(i, j int) bool {
+			return bytes.Compare(timeline.Nodes[i].Bytes(), timeline.Nodes[j].Bytes()) < 0
+		})
+		sort.SliceStable(timeline.Entries, func(i, j int) bool {
+			return timeline.Entries[i].Time < timeline.Entries[j].Time
+		})
+		rst = append(rst, *timeline)
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		return rst[i].Layer.Before(rst[j].Layer)
+	})
+	return rst, nil
+}
+
+func readTraceFile(path string) (*TraceHeader, []TraceRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	header, records, err := ReadTrace(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("trace %s: %w", path, err)
+	}
+	return header, records, nil
+}
@@ -0,0 +1,231 @@
+package hare
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+func newTestTraceRecorder(tb testing.TB, dir string, node types.NodeID, start time.Time) *traceRecorder {
+	tb.Helper()
+	r := newTraceRecorder(dir, node, logtest.New(tb))
+	now := start
+	r.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+	return r
+}
+
+func readTestTrace(tb testing.TB, dir string) (*TraceHeader, []TraceRecord) {
+	tb.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+TraceExtension))
+	require.NoError(tb, err)
+	require.Len(tb, files, 1)
+	header, records, err := readTraceFile(files[0])
+	require.NoError(tb, err)
+	return header, records
+}
+
+func TestLayerTrace(t *testing.T) {
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	node := types.NodeID{1}
+	sender := types.BytesToNodeID(signer.PublicKey().Bytes())
+	dir := t.TempDir()
+
+	trace := newTestTraceRecorder(t, dir, node, time.Now()).layer(instanceID1)
+	round := uint32(preRound)
+	trace.round = func() uint32 { return round }
+
+	trace.close()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+TraceExtension))
+	require.NoError(t, err)
+	require.Empty(t, files, "file is not created without records")
+	trace.closed = false
+
+	pre := BuildPreRoundMsg(signer, NewSetFromValues(value1), nil)
+	trace.received(pre, TraceValid)
+	round = commitRound
+	cm := BuildCommitMsg(signer, NewSetFromValues(value1))
+	trace.sent(cm, TracePublished)
+	trace.transition(TraceCommitTracker, TraceThreshold, cm, 3)
+	trace.terminated(true)
+	trace.close()
+	trace.received(pre, TraceValid)
+
+	header, records := readTestTrace(t, dir)
+	require.Equal(t, TraceHeader{Version: traceVersion, Node: node, Layer: instanceID1}, *header)
+	require.Len(t, records, 4, "records after close are discarded")
+	for i := 1; i < len(records); i++ {
+		require.Greater(t, records[i].Time, records[i-1].Time)
+	}
+
+	require.Equal(t, TraceReceived, records[0].Kind)
+	require.Equal(t, uint32(preRound), records[0].Round)
+	require.Equal(t, sender, records[0].Sender)
+	require.Equal(t, pre.InnerMsg.Type, records[0].MsgType)
+	require.Equal(t, pre.InnerMsg.Round, records[0].MsgRound)
+	require.Equal(t, pre.InnerMsg.EligibilityCount, records[0].EligibilityCount)
+	require.Equal(t, TraceValid, records[0].Outcome)
+
+	require.Equal(t, TraceSent, records[1].Kind)
+	require.Equal(t, uint32(commitRound), records[1].Round)
+	require.Equal(t, commit, records[1].MsgType)
+	require.Equal(t, TracePublished, records[1].Outcome)
+
+	require.Equal(t, TraceTransition, records[2].Kind)
+	require.Equal(t, TraceCommitTracker, records[2].Tracker)
+	require.Equal(t, TraceThreshold, records[2].State)
+	require.Equal(t, sender, records[2].Sender)
+	require.EqualValues(t, 3, records[2].Count)
+
+	require.Equal(t, TraceTerminated, records[3].Kind)
+	require.Equal(t, TraceCompleted, records[3].Outcome)
+
+	var nilTrace *layerTrace
+	nilTrace.received(pre, TraceValid)
+	nilTrace.close()
+	var nilRecorder *traceRecorder
+	require.Nil(t, nilRecorder.layer(instanceID1))
+}
+
+func TestReadTrace_Truncated(t *testing.T) {
+	dir := t.TempDir()
+	trace := newTestTraceRecorder(t, dir, types.NodeID{1}, time.Now()).layer(instanceID1)
+	for i := 0; i < 3; i++ {
+		trace.transition(TracePreRoundTracker, TraceFiltered, nil, uint32(i))
+	}
+	trace.close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+TraceExtension))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(files[0], info.Size()-1))
+
+	_, records := readTestTrace(t, dir)
+	require.Len(t, records, 2)
+}
+
+func TestTrackersTransitions(t *testing.T) {
+	signer1, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	signer2, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	signer3, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	s := NewSetFromValues(value1, value2)
+	dir := t.TempDir()
+	trace := newTestTraceRecorder(t, dir, types.NodeID{1}, time.Now()).layer(instanceID1)
+
+	pre := newPreRoundTracker(2, lowDefaultSize, logtest.New(t))
+	pre.trace = trace
+	pre.OnPreRound(context.Background(), BuildPreRoundMsg(signer1, s, []byte{1}))
+	pre.OnPreRound(context.Background(), BuildPreRoundMsg(signer1, s, []byte{1}))
+	pre.FilterSet(s.Clone())
+
+	status := newStatusTracker(2, lowDefaultSize)
+	status.Log = logtest.New(t)
+	status.trace = trace
+	status.RecordStatus(context.Background(), BuildStatusMsg(signer1, s))
+	status.RecordStatus(context.Background(), BuildStatusMsg(signer2, s))
+	status.AnalyzeStatuses(validate)
+
+	ct := newCommitTracker(2, lowDefaultSize, s)
+	ct.trace = trace
+	ct.OnCommit(BuildCommitMsg(signer1, s))
+	ct.OnCommit(BuildCommitMsg(signer1, s))
+	ct.OnCommit(BuildCommitMsg(signer2, NewSetFromValues(value3)))
+	ct.OnCommit(BuildCommitMsg(signer3, s))
+
+	nt := newNotifyTracker(lowDefaultSize)
+	nt.trace = trace
+	nt.OnNotify(BuildNotifyMsg(signer1, s))
+	nt.OnNotify(BuildNotifyMsg(signer1, s))
+	trace.close()
+
+	type transition struct {
+		tracker TraceTracker
+		state   TraceState
+		count   uint32
+	}
+	_, records := readTestTrace(t, dir)
+	var transitions []transition
+	for _, rec := range records {
+		require.Equal(t, TraceTransition, rec.Kind)
+		if rec.State == TraceBestVRF {
+			continue
+		}
+		transitions = append(transitions, transition{rec.Tracker, rec.State, rec.Count})
+	}
+	require.Equal(t, []transition{
+		{TracePreRoundTracker, TraceTracked, 2},
+		{TracePreRoundTracker, TraceDuplicate, 0},
+		{TracePreRoundTracker, TraceFiltered, 0},
+		{TraceStatusTracker, TraceTracked, 1},
+		{TraceStatusTracker, TraceTracked, 2},
+		{TraceStatusTracker, TraceAnalyzed, 2},
+		{TraceStatusTracker, TraceThreshold, 2},
+		{TraceCommitTracker, TraceTracked, 1},
+		{TraceCommitTracker, TraceDuplicate, 1},
+		{TraceCommitTracker, TraceIgnored, 1},
+		{TraceCommitTracker, TraceTracked, 2},
+		{TraceCommitTracker, TraceThreshold, 2},
+		{TraceNotifyTracker, TraceTracked, 1},
+		{TraceNotifyTracker, TraceDuplicate, 0},
+	}, transitions)
+}
+
+func TestMergeTraces(t *testing.T) {
+	start := time.Now()
+	nodes := []types.NodeID{{2}, {1}}
+	dirs := []string{t.TempDir(), t.TempDir()}
+	lids := []types.LayerID{instanceID2, instanceID1}
+	for i, node := range nodes {
+		// second node records every layer half a millisecond after the first one
+		r := newTestTraceRecorder(t, dirs[i], node, start.Add(time.Duration(i)*500*time.Microsecond))
+		for _, lid := range lids {
+			trace := r.layer(lid)
+			for j := 0; j < 3; j++ {
+				trace.transition(TracePreRoundTracker, TraceTracked, nil, uint32(j))
+			}
+			trace.close()
+		}
+	}
+
+	timelines, err := MergeTraces(dirs...)
+	require.NoError(t, err)
+	require.Len(t, timelines, 2)
+	require.Equal(t, instanceID1, timelines[0].Layer)
+	require.Equal(t, instanceID2, timelines[1].Layer)
+	for _, timeline := range timelines {
+		require.Equal(t, []types.NodeID{{1}, {2}}, timeline.Nodes)
+		require.Len(t, timeline.Entries, 6)
+		for i, entry := range timeline.Entries {
+			require.Equal(t, nodes[i%2], entry.Node, i)
+			if i > 0 {
+				require.GreaterOrEqual(t, entry.Time, timeline.Entries[i-1].Time)
+			}
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dirs[0], "*"+TraceExtension))
+	require.NoError(t, err)
+	timelines, err = MergeTraces(files[0], dirs[1])
+	require.NoError(t, err)
+	require.Len(t, timelines, 2)
+	require.Equal(t, 3, len(timelines[0].Nodes)+len(timelines[1].Nodes))
+
+	_, err = MergeTraces(filepath.Join(dirs[0], "missing"))
+	require.Error(t, err)
+}
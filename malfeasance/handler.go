@@ -0,0 +1,114 @@
+package malfeasance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+)
+
+var (
+	errMalformedData = fmt.Errorf("%w: malformed data", pubsub.ErrValidationReject)
+	errKnownProof    = errors.New("known proof")
+)
+
+// Handler validates malfeasance proofs received from gossip or sync, and stores them
+// for malicious identities.
+type Handler struct {
+	logger log.Log
+	cdb    *datastore.CachedDB
+	self   p2p.Peer
+}
+
+// Opt for configuring Handler.
+type Opt func(*Handler)
+
+// WithLogger defines logger for Handler.
+func WithLogger(logger log.Log) Opt {
+	return func(h *Handler) {
+		h.logger = logger
+	}
+}
+
+// NewHandler creates new Handler. Self is used to accept proofs published by this node,
+// they may be stored before they are published.
+func NewHandler(cdb *datastore.CachedDB, self p2p.Peer, opts ...Opt) *Handler {
+	h := &Handler{
+		logger: log.NewNop(),
+		cdb:    cdb,
+		self:   self,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// HandleMalfeasanceProof handles malfeasance proofs from gossip.
+func (h *Handler) HandleMalfeasanceProof(ctx context.Context, peer p2p.Peer, data []byte) pubsub.ValidationResult {
+	err := h.handleProof(ctx, data)
+	switch {
+	case err == nil:
+		return pubsub.ValidationAccept
+	case errors.Is(err, errKnownProof):
+		if peer == h.self {
+			return pubsub.ValidationAccept
+		}
+		return pubsub.ValidationIgnore
+	case errors.Is(err, errMalformedData), errors.Is(err, errInvalidProof):
+		h.logger.WithContext(ctx).With().Warning("received invalid malfeasance proof",
+			log.Stringer("peer", peer),
+			log.Err(err),
+		)
+		return pubsub.ValidationReject
+	default:
+		h.logger.WithContext(ctx).With().Warning("failed to process malfeasance proof", log.Err(err))
+		return pubsub.ValidationIgnore
+	}
+}
+
+// HandleSyncedMalfeasanceProof handles malfeasance proofs from sync.
+func (h *Handler) HandleSyncedMalfeasanceProof(ctx context.Context, data []byte) error {
+	err := h.handleProof(ctx, data)
+	if errors.Is(err, errKnownProof) {
+		return nil
+	}
+	return err
+}
+
+func (h *Handler) handleProof(ctx context.Context, data []byte) error {
+	var proof types.MalfeasanceProof
+	if err := codec.Decode(data, &proof); err != nil {
+		return fmt.Errorf("%w: %v", errMalformedData, err)
+	}
+	nodeID, err := Validate(&proof)
+	if err != nil {
+		invalidProofs.Inc()
+		return err
+	}
+	logger := h.logger.WithContext(ctx).WithFields(nodeID, log.Inline(&proof))
+	if _, err := identities.GetMalfeasanceProof(h.cdb, nodeID.Bytes()); err == nil {
+		logger.Debug("known malfeasance proof")
+		return fmt.Errorf("%w: %s", errKnownProof, nodeID)
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return err
+	}
+	encoded, err := codec.Encode(&proof)
+	if err != nil {
+		logger.With().Fatal("failed to encode malfeasance proof", log.Err(err))
+	}
+	if err := identities.SetMalfeasanceProof(h.cdb, nodeID.Bytes(), encoded); err != nil {
+		return err
+	}
+	reportProof(&proof)
+	logger.Warning("new malfeasance proof")
+	return nil
+}
@@ -0,0 +1,105 @@
+package malfeasance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+)
+
+const self = p2p.Peer("self")
+
+func createTestHandler(t *testing.T) *Handler {
+	return NewHandler(datastore.NewCachedDB(sql.InMemory(), logtest.New(t)), self, WithLogger(logtest.New(t)))
+}
+
+func encodeProof(tb testing.TB, proof *types.MalfeasanceProof) []byte {
+	tb.Helper()
+	data, err := codec.Encode(proof)
+	require.NoError(tb, err)
+	return data
+}
+
+func TestHandler_HandleMalfeasanceProof(t *testing.T) {
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	lid := types.NewLayerID(10)
+	proof := ballotProof(
+		signedBallot(t, signer, lid, types.ATXID{1}),
+		signedBallot(t, signer, lid, types.ATXID{2}),
+	)
+	data := encodeProof(t, proof)
+
+	h := createTestHandler(t)
+	require.Equal(t, pubsub.ValidationAccept, h.HandleMalfeasanceProof(context.Background(), "peer", data))
+	stored, err := identities.GetMalfeasanceProof(h.cdb, signer.NodeID().Bytes())
+	require.NoError(t, err)
+	require.Equal(t, data, stored)
+	malicious, err := identities.IsMalicious(h.cdb, signer.NodeID().Bytes())
+	require.NoError(t, err)
+	require.True(t, malicious)
+
+	// known proofs are not propagated again, unless published by this node
+	require.Equal(t, pubsub.ValidationIgnore, h.HandleMalfeasanceProof(context.Background(), "peer", data))
+	require.Equal(t, pubsub.ValidationAccept, h.HandleMalfeasanceProof(context.Background(), self, data))
+
+	// the first proof for the identity is kept
+	other := hareProof(lid,
+		signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+		signedHareMsg(t, signer, lid, 2, types.ProposalID{2}),
+	)
+	require.Equal(t, pubsub.ValidationIgnore, h.HandleMalfeasanceProof(context.Background(), "peer", encodeProof(t, other)))
+	stored, err = identities.GetMalfeasanceProof(h.cdb, signer.NodeID().Bytes())
+	require.NoError(t, err)
+	require.Equal(t, data, stored)
+}
+
+func TestHandler_HandleMalfeasanceProofInvalid(t *testing.T) {
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	lid := types.NewLayerID(10)
+	same := signedBallot(t, signer, lid, types.ATXID{1})
+
+	h := createTestHandler(t)
+	data := encodeProof(t, ballotProof(same, same))
+	require.Equal(t, pubsub.ValidationReject, h.HandleMalfeasanceProof(context.Background(), "peer", data[:len(data)/2]))
+	require.Equal(t, pubsub.ValidationReject, h.HandleMalfeasanceProof(context.Background(), "peer", data))
+	_, err = identities.GetMalfeasanceProof(h.cdb, signer.NodeID().Bytes())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestHandler_HandleSyncedMalfeasanceProof(t *testing.T) {
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	lid := types.NewLayerID(10)
+	coinbase1 := types.GenerateAddress([]byte("aaaa"))
+	coinbase2 := types.GenerateAddress([]byte("bbbb"))
+	data := encodeProof(t, atxProof(
+		signedAtx(t, signer, lid, coinbase1),
+		signedAtx(t, signer, lid, coinbase2),
+	))
+
+	h := createTestHandler(t)
+	require.NoError(t, h.HandleSyncedMalfeasanceProof(context.Background(), data))
+	require.NoError(t, h.HandleSyncedMalfeasanceProof(context.Background(), data))
+	ids, err := identities.GetMalicious(h.cdb)
+	require.NoError(t, err)
+	require.Equal(t, []types.NodeID{signer.NodeID()}, ids)
+
+	invalid := encodeProof(t, atxProof(
+		signedAtx(t, signer, lid, coinbase1),
+		signedAtx(t, signer, lid, coinbase1),
+	))
+	require.ErrorIs(t, h.HandleSyncedMalfeasanceProof(context.Background(), invalid), errInvalidProof)
+	require.ErrorIs(t, h.HandleSyncedMalfeasanceProof(context.Background(), invalid[:len(invalid)/2]), errMalformedData)
+}
@@ -0,0 +1,38 @@
+package malfeasance
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const namespace = "malfeasance"
+
+var (
+	proofsCount = metrics.NewCounter(
+		"proofs",
+		namespace,
+		"Number of stored malfeasance proofs by offense",
+		[]string{"offense"},
+	)
+	multipleATXs     = proofsCount.WithLabelValues(types.MultipleATXs)
+	multipleBallots  = proofsCount.WithLabelValues(types.MultipleBallots)
+	hareEquivocation = proofsCount.WithLabelValues(types.HareEquivocation)
+
+	invalidProofs = metrics.NewCounter(
+		"invalid_proofs",
+		namespace,
+		"Number of received malfeasance proofs that failed validation",
+		[]string{},
+	).WithLabelValues()
+)
+
+func reportProof(proof *types.MalfeasanceProof) {
+	switch {
+	case proof.MultipleATXs != nil:
+		multipleATXs.Inc()
+	case proof.MultipleBallots != nil:
+		multipleBallots.Inc()
+	case proof.HareEquivocation != nil:
+		hareEquivocation.Inc()
+	}
+}
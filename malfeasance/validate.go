@@ -0,0 +1,116 @@
+package malfeasance
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+)
+
+var errInvalidProof = fmt.Errorf("%w: invalid proof", pubsub.ErrValidationReject)
+
+// Validate checks that both messages in the proof are signed by the same identity and
+// that they conflict with each other. Returns the malicious identity.
+func Validate(proof *types.MalfeasanceProof) (types.NodeID, error) {
+	set := 0
+	for _, exists := range []bool{proof.MultipleATXs != nil, proof.MultipleBallots != nil, proof.HareEquivocation != nil} {
+		if exists {
+			set++
+		}
+	}
+	if set != 1 {
+		return types.NodeID{}, fmt.Errorf("%w: expected exactly one offense, got %d", errInvalidProof, set)
+	}
+	switch {
+	case proof.MultipleATXs != nil:
+		return validateMultipleATXs(proof.MultipleATXs)
+	case proof.MultipleBallots != nil:
+		return validateMultipleBallots(proof.MultipleBallots)
+	default:
+		return validateHareEquivocation(proof.HareEquivocation)
+	}
+}
+
+func validateMultipleATXs(proof *types.AtxProof) (types.NodeID, error) {
+	var (
+		ids   [2]types.ATXID
+		nodes [2]types.NodeID
+	)
+	for i := range proof.Messages {
+		atx := proof.Messages[i]
+		if err := atx.CalcAndSetID(); err != nil {
+			return types.NodeID{}, fmt.Errorf("%w: atx %d: %v", errInvalidProof, i, err)
+		}
+		if err := atx.CalcAndSetNodeID(); err != nil {
+			return types.NodeID{}, fmt.Errorf("%w: atx %d: %v", errInvalidProof, i, err)
+		}
+		ids[i] = atx.ID()
+		nodes[i] = atx.NodeID()
+	}
+	first, second := &proof.Messages[0], &proof.Messages[1]
+	if nodes[0] != nodes[1] {
+		return types.NodeID{}, fmt.Errorf("%w: atxs are signed by different identities", errInvalidProof)
+	}
+	if first.PublishEpoch() != second.PublishEpoch() {
+		return types.NodeID{}, fmt.Errorf("%w: atxs are published in different epochs %s/%s",
+			errInvalidProof, first.PublishEpoch(), second.PublishEpoch())
+	}
+	if ids[0] == ids[1] {
+		return types.NodeID{}, fmt.Errorf("%w: same atx %s", errInvalidProof, ids[0])
+	}
+	return nodes[0], nil
+}
+
+func validateMultipleBallots(proof *types.BallotProof) (types.NodeID, error) {
+	var ballots [2]types.Ballot
+	for i := range proof.Messages {
+		ballots[i] = types.Ballot{InnerBallot: proof.Messages[i].InnerBallot, Signature: proof.Messages[i].Signature}
+		if err := ballots[i].Initialize(); err != nil {
+			return types.NodeID{}, fmt.Errorf("%w: ballot %d: %v", errInvalidProof, i, err)
+		}
+	}
+	first, second := &ballots[0], &ballots[1]
+	if first.SmesherID() != second.SmesherID() {
+		return types.NodeID{}, fmt.Errorf("%w: ballots are signed by different identities", errInvalidProof)
+	}
+	if first.LayerIndex != second.LayerIndex {
+		return types.NodeID{}, fmt.Errorf("%w: ballots are published in different layers %s/%s",
+			errInvalidProof, first.LayerIndex, second.LayerIndex)
+	}
+	if first.ID() == second.ID() {
+		return types.NodeID{}, fmt.Errorf("%w: same ballot %s", errInvalidProof, first.ID())
+	}
+	return first.SmesherID(), nil
+}
+
+func validateHareEquivocation(proof *types.HareProof) (types.NodeID, error) {
+	var (
+		msgs  [2]hare.InnerMessage
+		nodes [2]types.NodeID
+	)
+	for i, msg := range proof.Messages {
+		if err := codec.Decode(msg.InnerMsg, &msgs[i]); err != nil {
+			return types.NodeID{}, fmt.Errorf("%w: decode hare message %d: %v", errInvalidProof, i, err)
+		}
+		node, err := types.ExtractNodeIDFromSig(msg.InnerMsg, msg.Signature)
+		if err != nil {
+			return types.NodeID{}, fmt.Errorf("%w: hare message %d: %v", errInvalidProof, i, err)
+		}
+		nodes[i] = node
+	}
+	first, second := &msgs[0], &msgs[1]
+	if nodes[0] != nodes[1] {
+		return types.NodeID{}, fmt.Errorf("%w: hare messages are signed by different identities", errInvalidProof)
+	}
+	if first.Layer != second.Layer || first.Round != second.Round {
+		return types.NodeID{}, fmt.Errorf("%w: hare messages are from different rounds %s/%d and %s/%d",
+			errInvalidProof, first.Layer, first.Round, second.Layer, second.Round)
+	}
+	if bytes.Equal(proof.Messages[0].InnerMsg, proof.Messages[1].InnerMsg) {
+		return types.NodeID{}, fmt.Errorf("%w: same hare message", errInvalidProof)
+	}
+	return nodes[0], nil
+}
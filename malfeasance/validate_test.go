@@ -0,0 +1,209 @@
+package malfeasance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+const layersPerEpoch = 3
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(layersPerEpoch)
+	res := m.Run()
+	os.Exit(res)
+}
+
+func signedAtx(tb testing.TB, signer *signing.EdSigner, lid types.LayerID, coinbase types.Address) types.ActivationTx {
+	tb.Helper()
+	nodeID := signer.NodeID()
+	atx := types.NewActivationTx(types.NIPostChallenge{PubLayerID: lid}, &nodeID, coinbase, nil, 1, nil, nil)
+	atx.Sig = signer.Sign(atx.SignedBytes())
+	return *atx
+}
+
+func signedBallot(tb testing.TB, signer *signing.EdSigner, lid types.LayerID, atxID types.ATXID) types.Ballot {
+	tb.Helper()
+	ballot := types.Ballot{InnerBallot: types.InnerBallot{LayerIndex: lid, AtxID: atxID}}
+	ballot.Signature = signer.Sign(ballot.SignedBytes())
+	return ballot
+}
+
+func signedHareMsg(tb testing.TB, signer *signing.EdSigner, lid types.LayerID, round uint32, values ...types.ProposalID) types.HareProofMsg {
+	tb.Helper()
+	inner := hare.InnerMessage{Layer: lid, Round: round, Values: values}
+	data, err := codec.Encode(&inner)
+	require.NoError(tb, err)
+	return types.HareProofMsg{InnerMsg: data, Signature: signer.Sign(data)}
+}
+
+func atxProof(first, second types.ActivationTx) *types.MalfeasanceProof {
+	return &types.MalfeasanceProof{
+		Layer:        second.PubLayerID,
+		MultipleATXs: &types.AtxProof{Messages: [2]types.ActivationTx{first, second}},
+	}
+}
+
+func ballotProof(first, second types.Ballot) *types.MalfeasanceProof {
+	return &types.MalfeasanceProof{
+		Layer:           second.LayerIndex,
+		MultipleBallots: &types.BallotProof{Messages: [2]types.Ballot{first, second}},
+	}
+}
+
+func hareProof(lid types.LayerID, first, second types.HareProofMsg) *types.MalfeasanceProof {
+	return &types.MalfeasanceProof{
+		Layer:            lid,
+		HareEquivocation: &types.HareProof{Messages: [2]types.HareProofMsg{first, second}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	other, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	lid := types.NewLayerID(10)
+	coinbase1 := types.GenerateAddress([]byte("aaaa"))
+	coinbase2 := types.GenerateAddress([]byte("bbbb"))
+
+	tampered := signedBallot(t, signer, lid, types.ATXID{2})
+	tampered.AtxID = types.ATXID{3}
+
+	for _, tc := range []struct {
+		desc  string
+		proof *types.MalfeasanceProof
+		valid bool
+	}{
+		{
+			desc: "multiple atxs",
+			proof: atxProof(
+				signedAtx(t, signer, lid, coinbase1),
+				signedAtx(t, signer, lid.Add(1), coinbase2),
+			),
+			valid: true,
+		},
+		{
+			desc: "same atx",
+			proof: atxProof(
+				signedAtx(t, signer, lid, coinbase1),
+				signedAtx(t, signer, lid, coinbase1),
+			),
+		},
+		{
+			desc: "atxs in different epochs",
+			proof: atxProof(
+				signedAtx(t, signer, lid, coinbase1),
+				signedAtx(t, signer, lid.Add(layersPerEpoch), coinbase2),
+			),
+		},
+		{
+			desc: "atxs from different identities",
+			proof: atxProof(
+				signedAtx(t, signer, lid, coinbase1),
+				signedAtx(t, other, lid, coinbase2),
+			),
+		},
+		{
+			desc: "multiple ballots",
+			proof: ballotProof(
+				signedBallot(t, signer, lid, types.ATXID{1}),
+				signedBallot(t, signer, lid, types.ATXID{2}),
+			),
+			valid: true,
+		},
+		{
+			desc: "same ballot",
+			proof: ballotProof(
+				signedBallot(t, signer, lid, types.ATXID{1}),
+				signedBallot(t, signer, lid, types.ATXID{1}),
+			),
+		},
+		{
+			desc: "ballots in different layers",
+			proof: ballotProof(
+				signedBallot(t, signer, lid, types.ATXID{1}),
+				signedBallot(t, signer, lid.Add(1), types.ATXID{2}),
+			),
+		},
+		{
+			desc: "ballots from different identities",
+			proof: ballotProof(
+				signedBallot(t, signer, lid, types.ATXID{1}),
+				signedBallot(t, other, lid, types.ATXID{2}),
+			),
+		},
+		{
+			desc: "tampered ballot",
+			proof: ballotProof(
+				signedBallot(t, signer, lid, types.ATXID{1}),
+				tampered,
+			),
+		},
+		{
+			desc: "hare equivocation",
+			proof: hareProof(lid,
+				signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+				signedHareMsg(t, signer, lid, 2, types.ProposalID{2}),
+			),
+			valid: true,
+		},
+		{
+			desc: "same hare message",
+			proof: hareProof(lid,
+				signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+				signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+			),
+		},
+		{
+			desc: "hare messages in different rounds",
+			proof: hareProof(lid,
+				signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+				signedHareMsg(t, signer, lid, 3, types.ProposalID{2}),
+			),
+		},
+		{
+			desc: "hare messages from different identities",
+			proof: hareProof(lid,
+				signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+				signedHareMsg(t, other, lid, 2, types.ProposalID{2}),
+			),
+		},
+		{
+			desc:  "no offense",
+			proof: &types.MalfeasanceProof{Layer: lid},
+		},
+		{
+			desc: "multiple offenses",
+			proof: &types.MalfeasanceProof{
+				Layer: lid,
+				MultipleBallots: &types.BallotProof{Messages: [2]types.Ballot{
+					signedBallot(t, signer, lid, types.ATXID{1}),
+					signedBallot(t, signer, lid, types.ATXID{2}),
+				}},
+				HareEquivocation: &types.HareProof{Messages: [2]types.HareProofMsg{
+					signedHareMsg(t, signer, lid, 2, types.ProposalID{1}),
+					signedHareMsg(t, signer, lid, 2, types.ProposalID{2}),
+				}},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			nodeID, err := Validate(tc.proof)
+			if tc.valid {
+				require.NoError(t, err)
+				require.Equal(t, signer.NodeID(), nodeID)
+			} else {
+				require.ErrorIs(t, err, errInvalidProof)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"go.uber.org/atomic"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -20,6 +21,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
 	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/sql/rewards"
 	"github.com/spacemeshos/go-spacemesh/system"
@@ -76,6 +78,16 @@ func NewMesh(cdb *datastore.CachedDB, trtl system.Tortoise, exec *Executor, stat
 	}
 
 	gLid := types.GetEffectiveGenesis()
+	// state imported from a checkpoint is applied after genesis without any ballots
+	applied, err := layers.GetLastApplied(cdb)
+	if err != nil {
+		return nil, fmt.Errorf("get last applied %w", err)
+	}
+	if applied.After(gLid) {
+		msh.recoverFromDB(applied)
+		return msh, nil
+	}
+
 	if err = cdb.WithTx(context.Background(), func(dbtx *sql.Tx) error {
 		for i := types.NewLayerID(1); !i.After(gLid); i = i.Add(1) {
 			if err = layers.SetProcessed(dbtx, i); err != nil {
@@ -127,6 +139,15 @@ func (msh *Mesh) LatestLayerInState() types.LayerID {
 	return msh.latestLayerInState.Load().(types.LayerID)
 }
 
+// PrunedBelow returns the layer below which ballots, proposals and states were pruned.
+func (msh *Mesh) PrunedBelow() types.LayerID {
+	lid, err := kvstore.GetPrunedBelow(msh.cdb)
+	if err != nil {
+		msh.logger.With().Error("failed to read pruned layer", log.Err(err))
+	}
+	return lid
+}
+
 // LatestLayer - returns the latest layer we saw from the network.
 func (msh *Mesh) LatestLayer() types.LayerID {
 	return msh.latestLayer.Load().(types.LayerID)
@@ -606,18 +627,20 @@ func (msh *Mesh) AddTXsFromProposal(ctx context.Context, layerID types.LayerID,
 	return nil
 }
 
-// AddBallot to the mesh.
-func (msh *Mesh) AddBallot(ctx context.Context, ballot *types.Ballot) error {
+// AddBallot to the mesh. If the smesher is found to publish more than one ballot in the layer
+// it is marked as malicious, and the returned proof should be gossiped to the network.
+func (msh *Mesh) AddBallot(ctx context.Context, ballot *types.Ballot) (*types.MalfeasanceProof, error) {
 	malicious, err := identities.IsMalicious(msh.cdb, ballot.SmesherID().Bytes())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if malicious {
 		ballot.SetMalicious()
 	}
+	var proof *types.MalfeasanceProof
 	// ballots.Add and ballots.Count should be atomic
 	// otherwise concurrent ballots.Add from the same smesher may not be noticed
-	return msh.cdb.WithTx(ctx, func(tx *sql.Tx) error {
+	if err := msh.cdb.WithTx(ctx, func(tx *sql.Tx) error {
 		if err := ballots.Add(tx, ballot); err != nil && !errors.Is(err, sql.ErrObjectExists) {
 			return err
 		}
@@ -627,7 +650,15 @@ func (msh *Mesh) AddBallot(ctx context.Context, ballot *types.Ballot) error {
 				return err
 			}
 			if count > 1 {
-				if err := identities.SetMalicious(tx, ballot.SmesherID().Bytes()); err != nil {
+				proof, err = ballotProof(tx, ballot)
+				if err != nil {
+					return err
+				}
+				encoded, err := codec.Encode(proof)
+				if err != nil {
+					msh.logger.With().Fatal("failed to encode malfeasance proof", log.Err(err))
+				}
+				if err := identities.SetMalfeasanceProof(tx, ballot.SmesherID().Bytes(), encoded); err != nil {
 					return err
 				}
 				ballot.SetMalicious()
@@ -638,7 +669,34 @@ func (msh *Mesh) AddBallot(ctx context.Context, ballot *types.Ballot) error {
 			}
 		}
 		return nil
-	})
+	}); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// ballotProof builds a proof from the ballot and another ballot of the same smesher in the layer.
+func ballotProof(db sql.Executor, ballot *types.Ballot) (*types.MalfeasanceProof, error) {
+	layer, err := ballots.Layer(db, ballot.LayerIndex)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range layer {
+		if other.SmesherID() != ballot.SmesherID() || other.ID() == ballot.ID() {
+			continue
+		}
+		// votes are not signed and not needed to prove that ballots conflict
+		return &types.MalfeasanceProof{
+			Layer: ballot.LayerIndex,
+			MultipleBallots: &types.BallotProof{
+				Messages: [2]types.Ballot{
+					{InnerBallot: other.InnerBallot, Signature: other.Signature},
+					{InnerBallot: ballot.InnerBallot, Signature: ballot.Signature},
+				},
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("conflicting ballot for %s in %s: %w", ballot.SmesherID(), ballot.LayerIndex, sql.ErrNotFound)
 }
 
 // AddBlockWithTXs adds the block and its TXs in into the database.
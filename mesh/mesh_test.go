@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk/wallet"
@@ -20,6 +21,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
@@ -147,7 +149,8 @@ func createLayerBallots(tb testing.TB, mesh *Mesh, lyrID types.LayerID) []*types
 	for i := 0; i < numBallots; i++ {
 		ballot := genLayerBallot(tb, lyrID)
 		blts = append(blts, ballot)
-		require.NoError(tb, mesh.AddBallot(context.Background(), ballot))
+		_, err := mesh.AddBallot(context.Background(), ballot)
+		require.NoError(tb, err)
 	}
 	return blts
 }
@@ -218,6 +221,22 @@ func TestMesh_WakeUp(t *testing.T) {
 	require.Equal(t, latestState, gotLS)
 }
 
+func TestMesh_WakeUpFromCheckpoint(t *testing.T) {
+	tm := createTestMesh(t)
+	checkpoint := types.NewLayerID(11)
+	require.NoError(t, layers.SetProcessed(tm.cdb, checkpoint))
+	require.NoError(t, layers.SetApplied(tm.cdb, checkpoint, types.RandomBlockID()))
+
+	tm.mockVM.EXPECT().Revert(checkpoint)
+	tm.mockState.EXPECT().RevertCache(checkpoint)
+	tm.mockVM.EXPECT().GetStateRoot()
+	msh, err := NewMesh(tm.cdb, tm.mockTortoise, tm.executor, tm.mockState, logtest.New(t))
+	require.NoError(t, err)
+	require.Equal(t, checkpoint, msh.LatestLayer())
+	require.Equal(t, checkpoint, msh.ProcessedLayer())
+	require.Equal(t, checkpoint, msh.LatestLayerInState())
+}
+
 func TestMesh_LayerHashes(t *testing.T) {
 	tm := createTestMesh(t)
 	gLyr := types.GetEffectiveGenesis()
@@ -770,10 +789,28 @@ func TestMesh_MaliciousBallots(t *testing.T) {
 		types.NewExistingBallot(types.BallotID{2}, nil, pub, types.InnerBallot{LayerIndex: lid}),
 		types.NewExistingBallot(types.BallotID{3}, nil, pub, types.InnerBallot{LayerIndex: lid}),
 	}
-	require.NoError(t, tm.AddBallot(context.Background(), &blts[0]))
+	proof, err := tm.AddBallot(context.Background(), &blts[0])
+	require.NoError(t, err)
+	require.Nil(t, proof)
 	require.False(t, blts[0].IsMalicious())
-	for _, ballot := range blts[1:] {
-		require.NoError(t, tm.AddBallot(context.Background(), &ballot))
-		require.True(t, ballot.IsMalicious())
-	}
+
+	proof, err = tm.AddBallot(context.Background(), &blts[1])
+	require.NoError(t, err)
+	require.True(t, blts[1].IsMalicious())
+	require.NotNil(t, proof)
+	require.Equal(t, lid, proof.Layer)
+	require.NotNil(t, proof.MultipleBallots)
+	require.Equal(t, blts[0].InnerBallot, proof.MultipleBallots.Messages[0].InnerBallot)
+	require.Equal(t, blts[1].InnerBallot, proof.MultipleBallots.Messages[1].InnerBallot)
+	stored, err := identities.GetMalfeasanceProof(tm.cdb, pub.Bytes())
+	require.NoError(t, err)
+	encoded, err := codec.Encode(proof)
+	require.NoError(t, err)
+	require.Equal(t, encoded, stored)
+
+	// proof is produced only once for the identity
+	proof, err = tm.AddBallot(context.Background(), &blts[2])
+	require.NoError(t, err)
+	require.Nil(t, proof)
+	require.True(t, blts[2].IsMalicious())
 }
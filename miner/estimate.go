@@ -0,0 +1,132 @@
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/spacemeshos/economics/rewards"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/proposals"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	rsql "github.com/spacemeshos/go-spacemesh/sql/rewards"
+)
+
+// RewardEstimate is an estimate of the rewards for the smesher in the target epoch.
+type RewardEstimate struct {
+	Epoch types.EpochID
+	// ATX is the atx that is expected to be used by the smesher in the target epoch.
+	ATX         types.ATXID
+	NumUnits    uint32
+	Weight      uint64
+	TotalWeight uint64
+	// Eligibilities is the expected number of proposals in the target epoch.
+	Eligibilities uint32
+	// Subsidy is the sum of layer subsidies over all layers in the target epoch.
+	Subsidy uint64
+	// Fees are the expected fees over all layers in the target epoch.
+	Fees uint64
+	// Amount is the expected reward for the smesher.
+	Amount uint64
+}
+
+// EstimateRewards estimates the rewards of the smesher in the target epoch.
+//
+// Expected reward is a share of the layer subsidies and fees in the target epoch,
+// proportional to the number of proposal eligibilities of the smesher out of layerSize eligibilities
+// in every layer. Eligibilities are computed in the same way as by the oracle, using the weight
+// of the smesher atx relative to the total weight of the epoch. Fees are extrapolated from the rewards
+// in the last epoch of applied layers.
+func (pb *ProposalBuilder) EstimateRewards(target types.EpochID) (*RewardEstimate, error) {
+	return estimateRewards(pb.cdb, pb.cfg.minerID, target, pb.cfg.layerSize, pb.cfg.layersPerEpoch)
+}
+
+func estimateRewards(cdb *datastore.CachedDB, nodeID types.NodeID, target types.EpochID, layerSize, layersPerEpoch uint32) (*RewardEstimate, error) {
+	if layerSize == 0 || layersPerEpoch == 0 {
+		return nil, fmt.Errorf("invalid layer size %d or layers per epoch %d", layerSize, layersPerEpoch)
+	}
+	estimate := &RewardEstimate{Epoch: target}
+	atxid, err := atxs.GetIDByEpochAndNodeID(cdb, target-1, nodeID)
+	if errors.Is(err, sql.ErrNotFound) {
+		// atx for the target epoch is not published yet, assume it will have the same weight as the last one
+		atxid, err = atxs.GetLastIDByNodeID(cdb, nodeID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get atx for node %s: %w", nodeID, err)
+	}
+	atx, err := cdb.GetAtxHeader(atxid)
+	if err != nil {
+		return nil, fmt.Errorf("get atx header %s: %w", atxid, err)
+	}
+	estimate.ATX = atxid
+	estimate.NumUnits = atx.NumUnits
+	estimate.Weight = atx.GetWeight()
+
+	// atxs targeting the epoch may still be published, so the weight of the target epoch
+	// is not expected to be lower than the weight of the previous epoch.
+	var counted bool
+	for _, epoch := range []types.EpochID{target, target - 1} {
+		if epoch > target {
+			continue // underflow
+		}
+		var (
+			total uint64
+			found bool
+		)
+		if err := cdb.IterateEpochATXHeaders(epoch, func(header *types.ActivationTxHeader) bool {
+			total += header.GetWeight()
+			found = found || header.ID == atxid
+			return true
+		}); err != nil {
+			return nil, fmt.Errorf("get epoch %s weight: %w", epoch, err)
+		}
+		if total > estimate.TotalWeight {
+			estimate.TotalWeight = total
+			counted = found
+		}
+	}
+	if !counted {
+		estimate.TotalWeight += estimate.Weight
+	}
+	estimate.Eligibilities, err = proposals.GetNumEligibleSlots(estimate.Weight, estimate.TotalWeight, layerSize, layersPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis := types.GetEffectiveGenesis()
+	for lid := target.FirstLayer(); lid.Before((target + 1).FirstLayer()); lid = lid.Add(1) {
+		if lid.After(genesis) {
+			estimate.Subsidy += rewards.TotalSubsidyAtLayer(lid.Difference(genesis))
+		}
+	}
+
+	applied, err := layers.GetLastApplied(cdb)
+	if err != nil {
+		return nil, err
+	}
+	from := types.NewLayerID(0)
+	if applied.Uint32() >= layersPerEpoch {
+		from = applied.Sub(layersPerEpoch - 1)
+	}
+	fees, rewarded, err := rsql.FeesInRange(cdb, from, applied)
+	if err != nil {
+		return nil, err
+	}
+	if rewarded > 0 {
+		estimate.Fees = fees / uint64(rewarded) * uint64(layersPerEpoch)
+	}
+
+	amount := new(big.Int).SetUint64(estimate.Subsidy)
+	amount.Add(amount, new(big.Int).SetUint64(estimate.Fees))
+	amount.Mul(amount, new(big.Int).SetUint64(uint64(estimate.Eligibilities)))
+	amount.Quo(amount, new(big.Int).SetUint64(uint64(layerSize)*uint64(layersPerEpoch)))
+	if !amount.IsUint64() {
+		return nil, fmt.Errorf("estimated reward %v overflows uint64", amount)
+	}
+	estimate.Amount = amount.Uint64()
+	return estimate, nil
+}
@@ -0,0 +1,91 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/spacemeshos/economics/rewards"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	rsql "github.com/spacemeshos/go-spacemesh/sql/rewards"
+)
+
+func TestEstimateRewards(t *testing.T) {
+	const (
+		layerSize      = 10
+		layersPerEpoch = 4
+		target         = types.EpochID(3)
+	)
+	types.SetLayersPerEpoch(layersPerEpoch)
+
+	subsidy := uint64(0)
+	for lid := target.FirstLayer(); lid.Before((target + 1).FirstLayer()); lid = lid.Add(1) {
+		subsidy += rewards.TotalSubsidyAtLayer(lid.Difference(types.GetEffectiveGenesis()))
+	}
+
+	setup := func(tb testing.TB) (*datastore.CachedDB, types.NodeID) {
+		cdb := datastore.NewCachedDB(sql.InMemory(), logtest.New(tb))
+		nodeID, _, _ := generateNodeIDAndSigner(tb)
+		// previous epoch has 2 atxs, target epoch has 4 atxs including node's atx
+		for i := 0; i < 2; i++ {
+			genMinerATX(tb, cdb, types.RandomATXID(), (target - 2).FirstLayer(), types.RandomNodeID())
+		}
+		for i := 0; i < 3; i++ {
+			genMinerATX(tb, cdb, types.RandomATXID(), (target - 1).FirstLayer(), types.RandomNodeID())
+		}
+		applied := (target - 1).FirstLayer().Add(1)
+		for i, fee := range []uint64{20, 40} {
+			lid := applied.Sub(uint32(i))
+			require.NoError(tb, rsql.Add(cdb, &types.Reward{
+				Layer:       lid,
+				Coinbase:    types.Address{byte(i)},
+				TotalReward: 100 + fee,
+				LayerReward: 100,
+			}))
+		}
+		// rewards from the layers before the last epoch are ignored
+		require.NoError(tb, rsql.Add(cdb, &types.Reward{
+			Layer:       applied.Sub(layersPerEpoch),
+			Coinbase:    types.Address{1},
+			TotalReward: 10_000,
+		}))
+		require.NoError(tb, layers.SetApplied(cdb, applied, types.BlockID{1}))
+		return cdb, nodeID
+	}
+
+	t.Run("published", func(t *testing.T) {
+		cdb, nodeID := setup(t)
+		atx := genMinerATX(t, cdb, types.RandomATXID(), (target - 1).FirstLayer(), nodeID)
+
+		estimate, err := estimateRewards(cdb, nodeID, target, layerSize, layersPerEpoch)
+		require.NoError(t, err)
+		require.Equal(t, target, estimate.Epoch)
+		require.Equal(t, atx.ID(), estimate.ATX)
+		require.EqualValues(t, defaultAtxWeight, estimate.NumUnits)
+		require.EqualValues(t, defaultAtxWeight, estimate.Weight)
+		require.EqualValues(t, 4*defaultAtxWeight, estimate.TotalWeight)
+		require.EqualValues(t, 10, estimate.Eligibilities)
+		require.Equal(t, subsidy, estimate.Subsidy)
+		require.EqualValues(t, 30*layersPerEpoch, estimate.Fees)
+		require.Equal(t, (subsidy+estimate.Fees)*10/(layerSize*layersPerEpoch), estimate.Amount)
+	})
+	t.Run("not published", func(t *testing.T) {
+		cdb, nodeID := setup(t)
+		atx := genMinerATX(t, cdb, types.RandomATXID(), (target - 3).FirstLayer(), nodeID)
+
+		estimate, err := estimateRewards(cdb, nodeID, target, layerSize, layersPerEpoch)
+		require.NoError(t, err)
+		require.Equal(t, atx.ID(), estimate.ATX)
+		require.EqualValues(t, 4*defaultAtxWeight, estimate.TotalWeight)
+		require.EqualValues(t, 10, estimate.Eligibilities)
+	})
+	t.Run("no atx", func(t *testing.T) {
+		cdb, nodeID := setup(t)
+		_, err := estimateRewards(cdb, nodeID, target, layerSize, layersPerEpoch)
+		require.ErrorIs(t, err, sql.ErrNotFound)
+	})
+}
@@ -402,6 +402,24 @@ func (a *AddrBook) GetAddresses() []*AddrInfo {
 	return addrs
 }
 
+// Buckets returns a snapshot of addresses in the new and tried buckets.
+func (a *AddrBook) Buckets() (newBuckets, triedBuckets [][]*AddrInfo) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return snapshotBuckets(a.addrNew), snapshotBuckets(a.addrTried)
+}
+
+func snapshotBuckets(buckets []map[peer.ID]*knownAddress) [][]*AddrInfo {
+	rst := make([][]*AddrInfo, len(buckets))
+	for i, bucket := range buckets {
+		rst[i] = make([]*AddrInfo, 0, len(bucket))
+		for _, ka := range bucket {
+			rst[i] = append(rst[i], ka.Addr)
+		}
+	}
+	return rst
+}
+
 // GetAddressesNotConnectedSince returns all the addresses which have not
 // been successfully connected to since `date`.
 func (a *AddrBook) GetAddressesNotConnectedSince(date time.Time) []*AddrInfo {
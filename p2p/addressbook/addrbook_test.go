@@ -393,3 +393,28 @@ func generateKnownAddress(t *testing.T, rng *rand.Rand, lastAttempt *time.Time)
 	}
 	return ka
 }
+
+func TestAddrBook_Buckets(t *testing.T) {
+	n := NewAddrBook(DefaultAddressBookConfigWithDataDir(""), logtest.New(t))
+	rng := rand.New(rand.NewSource(1001))
+	src := genRandomInfo(t, rng)
+	addrs := make([]*AddrInfo, 0, 10)
+	for i := 0; i < 10; i++ {
+		addrs = append(addrs, genRandomInfo(t, rng))
+	}
+	n.AddAddresses(addrs, src)
+	n.Good(addrs[0].ID)
+
+	newBuckets, triedBuckets := n.Buckets()
+	require.Len(t, newBuckets, int(n.cfg.NewBucketCount))
+	require.Len(t, triedBuckets, int(n.cfg.TriedBucketCount))
+	var inNew, inTried []*AddrInfo
+	for _, bucket := range newBuckets {
+		inNew = append(inNew, bucket...)
+	}
+	for _, bucket := range triedBuckets {
+		inTried = append(inTried, bucket...)
+	}
+	require.ElementsMatch(t, addrs[1:], inNew)
+	require.Equal(t, addrs[:1], inTried)
+}
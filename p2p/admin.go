@@ -0,0 +1,167 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/addressbook"
+	"github.com/spacemeshos/go-spacemesh/p2p/peerexchange"
+)
+
+// ConnectionInfo describes the connection with a peer.
+type ConnectionInfo struct {
+	ID        Peer
+	Direction network.Direction
+	Address   ma.Multiaddr
+	Opened    time.Time
+	// Protocols supported by the peer, as advertised in the handshake or learned by identify.
+	Protocols []string
+	// Score is the gossipsub score of the peer, zero if not known.
+	Score float64
+}
+
+// Connections returns info about every connection with peers, sorted by peer id.
+func (fh *Host) Connections() []ConnectionInfo {
+	conns := fh.Network().Conns()
+	rst := make([]ConnectionInfo, 0, len(conns))
+	for _, conn := range conns {
+		pid := conn.RemotePeer()
+		stat := conn.Stat()
+		info := ConnectionInfo{
+			ID:        pid,
+			Direction: stat.Direction,
+			Address:   conn.RemoteMultiaddr(),
+			Opened:    stat.Opened,
+		}
+		if hsinfo, exists := fh.PeerInfo(pid); exists && len(hsinfo.Protocols) > 0 {
+			info.Protocols = hsinfo.Protocols
+		} else if known, err := fh.Peerstore().GetProtocols(pid); err == nil {
+			info.Protocols = known
+		}
+		info.Score, _ = fh.PeerScore(pid)
+		rst = append(rst, info)
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		return rst[i].ID < rst[j].ID
+	})
+	return rst
+}
+
+// AddressBook returns addresses in the new and tried buckets of the address book.
+func (fh *Host) AddressBook() (newBuckets, triedBuckets [][]*addressbook.AddrInfo) {
+	return fh.discovery.Buckets()
+}
+
+// ConnectAddress connects to the peer with the address in multiaddr format, including the peer id.
+func (fh *Host) ConnectAddress(ctx context.Context, raw string) (Peer, error) {
+	info, err := peer.AddrInfoFromString(raw)
+	if err != nil {
+		return "", fmt.Errorf("parse address %s: %w", raw, err)
+	}
+	if err := fh.Host.Connect(ctx, *info); err != nil {
+		return "", fmt.Errorf("connect to %s: %w", raw, err)
+	}
+	return info.ID, nil
+}
+
+// Disconnect closes all connections with the peer.
+func (fh *Host) Disconnect(pid Peer) error {
+	return fh.Network().ClosePeer(pid)
+}
+
+// Bootnodes returns bootnodes from the config and bootnodes added at runtime.
+func (fh *Host) Bootnodes() (static, added []string) {
+	return fh.bootnodes.list()
+}
+
+// AddBootnode adds a bootnode, it is used in the same way as bootnodes from the config
+// and persisted across restarts. Connection with the bootnode is established in the background.
+func (fh *Host) AddBootnode(raw string) error {
+	info, err := addressbook.ParseAddrInfo(raw)
+	if err != nil {
+		return err
+	}
+	if err := fh.bootnodes.add(raw); err != nil {
+		return err
+	}
+	fh.ConnManager().Protect(info.ID, peerexchange.BootNodeTag)
+	if err := fh.discovery.AddBootnode(info); err != nil {
+		return err
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(fh.ctx, fh.cfg.BootstrapTimeout)
+		defer cancel()
+		if _, err := fh.ConnectAddress(ctx, raw); err != nil {
+			fh.logger.With().Warning("failed to connect to bootnode", log.String("address", raw), log.Err(err))
+		}
+	}()
+	return nil
+}
+
+// RemoveBootnode removes a bootnode that was added at runtime, including its address from the address book.
+func (fh *Host) RemoveBootnode(raw string) error {
+	info, err := addressbook.ParseAddrInfo(raw)
+	if err != nil {
+		return err
+	}
+	if err := fh.bootnodes.remove(raw); err != nil {
+		return err
+	}
+	fh.ConnManager().Unprotect(info.ID, peerexchange.BootNodeTag)
+	fh.discovery.RemoveBootnode(info.ID)
+	return nil
+}
+
+// Bans returns banned peers and ip ranges.
+func (fh *Host) Bans() (peers []Peer, nets []string) {
+	return fh.bans.List()
+}
+
+// BanPeer bans the peer and closes existing connections with it. The ban is persisted across restarts.
+func (fh *Host) BanPeer(pid Peer) error {
+	if err := fh.bans.BanPeer(pid); err != nil {
+		return err
+	}
+	return fh.Network().ClosePeer(pid)
+}
+
+// UnbanPeer removes the ban from the peer.
+func (fh *Host) UnbanPeer(pid Peer) error {
+	return fh.bans.UnbanPeer(pid)
+}
+
+// BanIPNet bans an ip range in CIDR notation and closes existing connections with addresses in that range.
+// The ban is persisted across restarts.
+func (fh *Host) BanIPNet(cidr string) error {
+	ipnet, err := fh.bans.BanNet(cidr)
+	if err != nil {
+		return err
+	}
+	for _, conn := range fh.Network().Conns() {
+		if inNet(ipnet, conn.RemoteMultiaddr()) {
+			if err := conn.Close(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnbanIPNet removes the ban from an ip range in CIDR notation.
+func (fh *Host) UnbanIPNet(cidr string) error {
+	return fh.bans.UnbanNet(cidr)
+}
+
+func inNet(ipnet *net.IPNet, addr ma.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	return err == nil && ipnet.Contains(ip)
+}
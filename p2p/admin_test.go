@@ -0,0 +1,160 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+func TestBans(t *testing.T) {
+	dir := t.TempDir()
+	b, err := newBans(dir)
+	require.NoError(t, err)
+
+	pid, err := peer.Decode("12D3KooWDS4mbE2Cqysjf6GBMtWnhcaoBYC6M3FNkTeZqCNFCNkf")
+	require.NoError(t, err)
+	require.NoError(t, b.BanPeer(pid))
+	_, err = b.BanNet("10.0.0.1/8")
+	require.NoError(t, err)
+	_, err = b.BanNet("invalid")
+	require.Error(t, err)
+
+	banned := ma.StringCast("/ip4/10.1.2.3/tcp/7513")
+	allowed := ma.StringCast("/ip4/192.168.1.1/tcp/7513")
+	require.False(t, b.InterceptPeerDial(pid))
+	require.True(t, b.InterceptPeerDial("other"))
+	require.False(t, b.InterceptAddrDial("other", banned))
+	require.True(t, b.InterceptAddrDial("other", allowed))
+	require.False(t, b.InterceptSecured(network.DirInbound, pid, nil))
+
+	// bans are loaded after restart
+	b, err = newBans(dir)
+	require.NoError(t, err)
+	peers, nets := b.List()
+	require.Equal(t, []peer.ID{pid}, peers)
+	require.Equal(t, []string{"10.0.0.0/8"}, nets)
+
+	require.NoError(t, b.UnbanPeer(pid))
+	require.NoError(t, b.UnbanNet("10.0.0.0/8"))
+	require.True(t, b.InterceptPeerDial(pid))
+	require.True(t, b.InterceptAddrDial("other", banned))
+
+	b, err = newBans(dir)
+	require.NoError(t, err)
+	peers, nets = b.List()
+	require.Empty(t, peers)
+	require.Empty(t, nets)
+}
+
+func TestBootnodes(t *testing.T) {
+	dir := t.TempDir()
+	static := []string{"/ip4/10.0.0.1/tcp/7513/p2p/12D3KooWDS4mbE2Cqysjf6GBMtWnhcaoBYC6M3FNkTeZqCNFCNkf"}
+	added := "/ip4/10.0.0.2/tcp/7513/p2p/12D3KooWRN5Jv6U2CbNZRFCHbGrfQ2m8tZkN8nxpBDNPu4cHRvJw"
+
+	b, err := loadBootnodes(dir, static)
+	require.NoError(t, err)
+	require.ErrorIs(t, b.add(static[0]), ErrBootnodeExists)
+	require.NoError(t, b.add(added))
+	require.ErrorIs(t, b.add(added), ErrBootnodeExists)
+	require.ErrorIs(t, b.remove(static[0]), ErrBootnodeNotFound)
+
+	// bootnodes added at runtime are loaded after restart
+	b, err = loadBootnodes(dir, static)
+	require.NoError(t, err)
+	require.Equal(t, append(static, added), b.all())
+
+	require.NoError(t, b.remove(added))
+	b, err = loadBootnodes(dir, static)
+	require.NoError(t, err)
+	require.Equal(t, static, b.all())
+}
+
+func TestHostAdmin(t *testing.T) {
+	mesh, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	hosts := make([]*Host, 0, len(mesh.Hosts()))
+	for _, h := range mesh.Hosts() {
+		cfg := DefaultConfig()
+		cfg.DataDir = t.TempDir()
+		fh, err := Upgrade(h, types.Hash20{1}, WithConfig(cfg), WithLog(logtest.New(t)))
+		require.NoError(t, err)
+		t.Cleanup(func() { fh.Stop() })
+		hosts = append(hosts, fh)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, h := range hosts[1:] {
+		addr := fmt.Sprintf("%s/p2p/%s", h.Addrs()[0], h.ID())
+		pid, err := hosts[0].ConnectAddress(ctx, addr)
+		require.NoError(t, err)
+		require.Equal(t, h.ID(), pid)
+	}
+
+	conns := hosts[0].Connections()
+	require.Len(t, conns, 2)
+	for _, conn := range conns {
+		require.Equal(t, network.DirOutbound, conn.Direction)
+	}
+
+	require.NoError(t, hosts[0].BanPeer(hosts[1].ID()))
+	require.Equal(t, network.NotConnected, hosts[0].Network().Connectedness(hosts[1].ID()))
+	peers, _ := hosts[0].Bans()
+	require.Equal(t, []Peer{hosts[1].ID()}, peers)
+
+	ip, err := manet.ToIP(hosts[2].Addrs()[0])
+	require.NoError(t, err)
+	cidr := fmt.Sprintf("%s/32", ip)
+	if ip.To4() == nil {
+		cidr = fmt.Sprintf("%s/128", ip)
+	}
+	require.NoError(t, hosts[0].BanIPNet(cidr))
+	require.Equal(t, network.NotConnected, hosts[0].Network().Connectedness(hosts[2].ID()))
+	require.Empty(t, hosts[0].Connections())
+}
+
+func TestHostRemoveBootnode(t *testing.T) {
+	mesh, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	hosts := make([]*Host, 0, len(mesh.Hosts()))
+	for _, h := range mesh.Hosts() {
+		cfg := DefaultConfig()
+		cfg.DataDir = t.TempDir()
+		fh, err := Upgrade(h, types.Hash20{1}, WithConfig(cfg), WithLog(logtest.New(t)))
+		require.NoError(t, err)
+		t.Cleanup(func() { fh.Stop() })
+		hosts = append(hosts, fh)
+	}
+	known := func(pid Peer) bool {
+		newBuckets, triedBuckets := hosts[0].AddressBook()
+		for _, bucket := range append(newBuckets, triedBuckets...) {
+			for _, info := range bucket {
+				if info.ID == pid {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	bootnode := fmt.Sprintf("%s/p2p/%s", hosts[1].Addrs()[0], hosts[1].ID())
+	require.NoError(t, hosts[0].AddBootnode(bootnode))
+	require.True(t, known(hosts[1].ID()))
+	_, added := hosts[0].Bootnodes()
+	require.Equal(t, []string{bootnode}, added)
+
+	require.NoError(t, hosts[0].RemoveBootnode(bootnode))
+	require.False(t, known(hosts[1].ID()))
+	_, added = hosts[0].Bootnodes()
+	require.Empty(t, added)
+}
@@ -0,0 +1,200 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	atomicfile "github.com/natefinch/atomic"
+)
+
+const bansFileName = "bans.json"
+
+type serializedBans struct {
+	Peers []peer.ID
+	Nets  []string
+}
+
+// bans is a list of banned peers and ip ranges. It is persisted in the data directory
+// and used as a connection gater, so that connections with banned peers are neither
+// accepted nor dialed.
+type bans struct {
+	path string
+
+	mu    sync.RWMutex
+	peers map[peer.ID]struct{}
+	nets  map[string]*net.IPNet
+}
+
+// newBans loads bans from the data directory. If dir is empty bans are not persisted.
+func newBans(dir string) (*bans, error) {
+	b := &bans{
+		peers: map[peer.ID]struct{}{},
+		nets:  map[string]*net.IPNet{},
+	}
+	if len(dir) == 0 {
+		return b, nil
+	}
+	b.path = filepath.Join(dir, bansFileName)
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", b.path, err)
+	}
+	var sb serializedBans
+	if err := json.Unmarshal(data, &sb); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", b.path, err)
+	}
+	for _, pid := range sb.Peers {
+		b.peers[pid] = struct{}{}
+	}
+	for _, raw := range sb.Nets {
+		_, ipnet, err := net.ParseCIDR(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", b.path, err)
+		}
+		b.nets[ipnet.String()] = ipnet
+	}
+	return b, nil
+}
+
+// persist saves bans to disk (non-thread-safe).
+func (b *bans) persist() error {
+	if len(b.path) == 0 {
+		return nil
+	}
+	peers, nets := b.list()
+	data, err := json.Marshal(serializedBans{Peers: peers, Nets: nets})
+	if err != nil {
+		return fmt.Errorf("encode bans: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("create dir for %s: %w", b.path, err)
+	}
+	if err := atomicfile.WriteFile(b.path, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("write %s: %w", b.path, err)
+	}
+	return nil
+}
+
+// list returns sorted banned peers and ip ranges (non-thread-safe).
+func (b *bans) list() ([]peer.ID, []string) {
+	peers := make([]peer.ID, 0, len(b.peers))
+	for pid := range b.peers {
+		peers = append(peers, pid)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i] < peers[j]
+	})
+	nets := make([]string, 0, len(b.nets))
+	for raw := range b.nets {
+		nets = append(nets, raw)
+	}
+	sort.Strings(nets)
+	return peers, nets
+}
+
+// List returns banned peers and ip ranges.
+func (b *bans) List() ([]peer.ID, []string) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.list()
+}
+
+func (b *bans) update(f func()) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	f()
+	return b.persist()
+}
+
+// BanPeer bans the peer.
+func (b *bans) BanPeer(pid peer.ID) error {
+	return b.update(func() {
+		b.peers[pid] = struct{}{}
+	})
+}
+
+// UnbanPeer removes the ban from the peer.
+func (b *bans) UnbanPeer(pid peer.ID) error {
+	return b.update(func() {
+		delete(b.peers, pid)
+	})
+}
+
+// BanNet bans all addresses in the ip range, in CIDR notation.
+func (b *bans) BanNet(cidr string) (*net.IPNet, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return ipnet, b.update(func() {
+		b.nets[ipnet.String()] = ipnet
+	})
+}
+
+// UnbanNet removes the ban from the ip range, in CIDR notation.
+func (b *bans) UnbanNet(cidr string) error {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+	return b.update(func() {
+		delete(b.nets, ipnet.String())
+	})
+}
+
+func (b *bans) isPeerBanned(pid peer.ID) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, exists := b.peers[pid]
+	return exists
+}
+
+func (b *bans) isAddrBanned(addr ma.Multiaddr) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ipnet := range b.nets {
+		if inNet(ipnet, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// InterceptPeerDial implements connmgr.ConnectionGater.
+func (b *bans) InterceptPeerDial(pid peer.ID) bool {
+	return !b.isPeerBanned(pid)
+}
+
+// InterceptAddrDial implements connmgr.ConnectionGater.
+func (b *bans) InterceptAddrDial(pid peer.ID, addr ma.Multiaddr) bool {
+	return !b.isPeerBanned(pid) && !b.isAddrBanned(addr)
+}
+
+// InterceptAccept implements connmgr.ConnectionGater.
+func (b *bans) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	return !b.isAddrBanned(addrs.RemoteMultiaddr())
+}
+
+// InterceptSecured implements connmgr.ConnectionGater.
+func (b *bans) InterceptSecured(_ network.Direction, pid peer.ID, _ network.ConnMultiaddrs) bool {
+	return !b.isPeerBanned(pid)
+}
+
+// InterceptUpgraded implements connmgr.ConnectionGater.
+func (b *bans) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
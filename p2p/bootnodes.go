@@ -0,0 +1,115 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	atomicfile "github.com/natefinch/atomic"
+)
+
+const bootnodesFileName = "bootnodes.json"
+
+var (
+	// ErrBootnodeExists is returned when bootnode is already known.
+	ErrBootnodeExists = errors.New("bootnode already exists")
+	// ErrBootnodeNotFound is returned when bootnode wasn't added at runtime.
+	ErrBootnodeNotFound = errors.New("bootnode not found")
+)
+
+// bootnodes tracks bootnodes from the config and bootnodes that were added at runtime.
+// The latter are persisted in the data directory and loaded when the node is restarted.
+type bootnodes struct {
+	path string
+
+	mu     sync.Mutex
+	static []string
+	added  []string
+}
+
+// loadBootnodes loads bootnodes that were added at runtime from the data directory.
+// If dir is empty bootnodes are not persisted.
+func loadBootnodes(dir string, static []string) (*bootnodes, error) {
+	b := &bootnodes{static: static}
+	if len(dir) == 0 {
+		return b, nil
+	}
+	b.path = filepath.Join(dir, bootnodesFileName)
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", b.path, err)
+	}
+	if err := json.Unmarshal(data, &b.added); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", b.path, err)
+	}
+	return b, nil
+}
+
+func (b *bootnodes) persist() error {
+	if len(b.path) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(b.added)
+	if err != nil {
+		return fmt.Errorf("encode bootnodes: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("create dir for %s: %w", b.path, err)
+	}
+	if err := atomicfile.WriteFile(b.path, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("write %s: %w", b.path, err)
+	}
+	return nil
+}
+
+// all returns bootnodes from the config followed by bootnodes added at runtime.
+func (b *bootnodes) all() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rst := make([]string, 0, len(b.static)+len(b.added))
+	rst = append(rst, b.static...)
+	return append(rst, b.added...)
+}
+
+// list returns bootnodes from the config and bootnodes added at runtime.
+func (b *bootnodes) list() (static, added []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.static...), append([]string(nil), b.added...)
+}
+
+func (b *bootnodes) add(raw string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, known := range b.static {
+		if known == raw {
+			return fmt.Errorf("%w: %s", ErrBootnodeExists, raw)
+		}
+	}
+	for _, known := range b.added {
+		if known == raw {
+			return fmt.Errorf("%w: %s", ErrBootnodeExists, raw)
+		}
+	}
+	b.added = append(b.added, raw)
+	return b.persist()
+}
+
+func (b *bootnodes) remove(raw string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, known := range b.added {
+		if known == raw {
+			b.added = append(b.added[:i], b.added[i+1:]...)
+			return b.persist()
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrBootnodeNotFound, raw)
+}
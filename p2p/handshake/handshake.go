@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"golang.org/x/sync/errgroup"
 
@@ -34,21 +35,79 @@ func WithLog(logger log.Log) Opt {
 	}
 }
 
+// WithInfo configures info that is advertised to peers.
+func WithInfo(info PeerInfo) Opt {
+	return func(hs *Handshake) {
+		hs.info = info
+	}
+}
+
 const (
-	hsprotocol    = "/handshake/1"
-	streamTimeout = 10 * time.Second
+	hsprotocol       = "/handshake/2"
+	legacyHsprotocol = "/handshake/1"
+	streamTimeout    = 10 * time.Second
+
+	peerstoreKey = "spacemesh/handshake"
 )
 
-//go:generate scalegen -types HandshakeMessage,HandshakeAck
+// PeerInfo is exchanged in the handshake and stored in the peerstore.
+type PeerInfo struct {
+	// Version of the node software.
+	Version string
+	// Protocols are stream protocols supported by the node.
+	Protocols []string
+	// Topics are gossip topics supported by the node.
+	Topics []string
+}
+
+// Supports returns true if protocol is in the list of supported protocols.
+func (info *PeerInfo) Supports(protocol string) bool {
+	for _, supported := range info.Protocols {
+		if supported == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPeerInfo returns info received from the peer in the handshake.
+// Returns false if peer didn't complete handshake or uses a version of the handshake
+// that doesn't exchange info.
+func GetPeerInfo(ps peerstore.Peerstore, pid peer.ID) (*PeerInfo, bool) {
+	val, err := ps.Get(pid, peerstoreKey)
+	if err != nil {
+		return nil, false
+	}
+	info, ok := val.(*PeerInfo)
+	return info, ok
+}
+
+//go:generate scalegen -types HandshakeMessage,HandshakeAck,LegacyHandshakeMessage,LegacyHandshakeAck
 
 // HandshakeMessage is a handshake message.
 type HandshakeMessage struct { // nolint
 	GenesisID types.Hash20
+	Version   string   `scale:"max=128"`
+	Protocols []string `scale:"max=64"`
+	Topics    []string `scale:"max=64"`
 }
 
 // HandshakeAck is a handshake ack.
 type HandshakeAck struct { // nolint
-	Error string
+	Error     string   `scale:"max=1024"`
+	Version   string   `scale:"max=128"`
+	Protocols []string `scale:"max=64"`
+	Topics    []string `scale:"max=64"`
+}
+
+// LegacyHandshakeMessage is a handshake message used by the nodes that don't exchange PeerInfo.
+type LegacyHandshakeMessage struct {
+	GenesisID types.Hash20
+}
+
+// LegacyHandshakeAck is a handshake ack used by the nodes that don't exchange PeerInfo.
+type LegacyHandshakeAck struct {
+	Error string `scale:"max=1024"`
 }
 
 // New instantiates handshake protocol for the host.
@@ -64,6 +123,7 @@ func New(h host.Host, genesisID types.Hash20, opts ...Opt) *Handshake {
 		opt(hs)
 	}
 	h.SetStreamHandler(protocol.ID(hsprotocol), hs.handler)
+	h.SetStreamHandler(protocol.ID(legacyHsprotocol), hs.legacyHandler)
 	emitter, err := h.EventBus().Emitter(new(EventHandshakeComplete))
 	if err != nil {
 		hs.logger.With().Panic("failed to initialize emitter for handshake", log.Err(err))
@@ -83,6 +143,7 @@ type Handshake struct {
 
 	emitter   event.Emitter
 	genesisID types.Hash20
+	info      PeerInfo
 	h         host.Host
 
 	cancel context.CancelFunc
@@ -129,16 +190,41 @@ func (h *Handshake) Stop() {
 	h.emitter.Close()
 }
 
-// Request handshake with a peer.
+// Request handshake with a peer. Peers that support only the legacy version
+// of the handshake are accepted, but their info is not stored.
 func (h *Handshake) Request(ctx context.Context, pid peer.ID) error {
-	stream, err := h.h.NewStream(network.WithNoDial(ctx, "existing connection"), pid, protocol.ID(hsprotocol))
+	stream, err := h.h.NewStream(network.WithNoDial(ctx, "existing connection"), pid,
+		protocol.ID(hsprotocol), protocol.ID(legacyHsprotocol))
 	if err != nil {
 		return fmt.Errorf("failed to init stream: %w", err)
 	}
 	defer stream.Close()
 	stream.SetDeadline(time.Now().Add(streamTimeout))
 	defer stream.SetDeadline(time.Time{})
-	if _, err = codec.EncodeTo(stream, &HandshakeMessage{GenesisID: h.genesisID}); err != nil {
+	if stream.Protocol() == protocol.ID(legacyHsprotocol) {
+		err = h.legacyRequest(stream)
+	} else {
+		err = h.request(stream)
+	}
+	if err != nil {
+		return err
+	}
+	if err := h.emitter.Emit(EventHandshakeComplete{
+		PID:       pid,
+		Direction: stream.Conn().Stat().Direction,
+	}); err != nil {
+		h.logger.With().Error("failed to emit handshake event", log.Err(err))
+	}
+	return nil
+}
+
+func (h *Handshake) request(stream network.Stream) error {
+	if _, err := codec.EncodeTo(stream, &HandshakeMessage{
+		GenesisID: h.genesisID,
+		Version:   h.info.Version,
+		Protocols: h.info.Protocols,
+		Topics:    h.info.Topics,
+	}); err != nil {
 		return fmt.Errorf("failed to send handshake msg: %w", err)
 	}
 	var ack HandshakeAck
@@ -148,15 +234,37 @@ func (h *Handshake) Request(ctx context.Context, pid peer.ID) error {
 	if len(ack.Error) > 0 {
 		return errors.New(ack.Error)
 	}
-	if err := h.emitter.Emit(EventHandshakeComplete{
-		PID:       pid,
-		Direction: stream.Conn().Stat().Direction,
-	}); err != nil {
-		h.logger.With().Error("failed to emit handshake event", log.Err(err))
+	h.store(stream.Conn().RemotePeer(), &PeerInfo{
+		Version:   ack.Version,
+		Protocols: ack.Protocols,
+		Topics:    ack.Topics,
+	})
+	return nil
+}
+
+func (h *Handshake) legacyRequest(stream network.Stream) error {
+	if _, err := codec.EncodeTo(stream, &LegacyHandshakeMessage{GenesisID: h.genesisID}); err != nil {
+		return fmt.Errorf("failed to send handshake msg: %w", err)
+	}
+	var ack LegacyHandshakeAck
+	if _, err := codec.DecodeFrom(stream, &ack); err != nil {
+		return fmt.Errorf("failed to receive handshake ack: %w", err)
+	}
+	if len(ack.Error) > 0 {
+		return errors.New(ack.Error)
 	}
 	return nil
 }
 
+func (h *Handshake) store(pid peer.ID, info *PeerInfo) {
+	if err := h.h.Peerstore().Put(pid, peerstoreKey, info); err != nil {
+		h.logger.With().Warning("failed to store peer info",
+			log.String("pid", pid.String()),
+			log.Err(err),
+		)
+	}
+}
+
 func (h *Handshake) handler(stream network.Stream) {
 	defer stream.Close()
 	stream.SetDeadline(time.Now().Add(streamTimeout))
@@ -165,16 +273,48 @@ func (h *Handshake) handler(stream network.Stream) {
 	if _, err := codec.DecodeFrom(stream, &msg); err != nil {
 		return
 	}
-	if h.genesisID != msg.GenesisID {
+	if !h.checkGenesis(stream, msg.GenesisID) {
+		return
+	}
+	h.store(stream.Conn().RemotePeer(), &PeerInfo{
+		Version:   msg.Version,
+		Protocols: msg.Protocols,
+		Topics:    msg.Topics,
+	})
+	if _, err := codec.EncodeTo(stream, &HandshakeAck{
+		Version:   h.info.Version,
+		Protocols: h.info.Protocols,
+		Topics:    h.info.Topics,
+	}); err != nil {
+		return
+	}
+}
+
+func (h *Handshake) legacyHandler(stream network.Stream) {
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(streamTimeout))
+	defer stream.SetDeadline(time.Time{})
+	var msg LegacyHandshakeMessage
+	if _, err := codec.DecodeFrom(stream, &msg); err != nil {
+		return
+	}
+	if !h.checkGenesis(stream, msg.GenesisID) {
+		return
+	}
+	if _, err := codec.EncodeTo(stream, &LegacyHandshakeAck{}); err != nil {
+		return
+	}
+}
+
+func (h *Handshake) checkGenesis(stream network.Stream, genesisID types.Hash20) bool {
+	if h.genesisID != genesisID {
 		h.logger.Warning("network id mismatch",
 			log.Stringer("genesis id", h.genesisID),
-			log.Stringer("peer genesis id", msg.GenesisID),
+			log.Stringer("peer genesis id", genesisID),
 			log.String("peer-id", stream.Conn().RemotePeer().String()),
 			log.String("peer-address", stream.Conn().LocalMultiaddr().String()),
 		)
-		return
-	}
-	if _, err := codec.EncodeTo(stream, &HandshakeAck{}); err != nil {
-		return
+		return false
 	}
+	return true
 }
@@ -15,6 +15,27 @@ func (t *HandshakeMessage) EncodeScale(enc *scale.Encoder) (total int, err error
 		}
 		total += n
 	}
+	{
+		n, err := scale.EncodeStringWithLimit(enc, string(t.Version), 128)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStringSliceWithLimit(enc, t.Protocols, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStringSliceWithLimit(enc, t.Topics, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
 	return total, nil
 }
 
@@ -26,12 +47,57 @@ func (t *HandshakeMessage) DecodeScale(dec *scale.Decoder) (total int, err error
 		}
 		total += n
 	}
+	{
+		field, n, err := scale.DecodeStringWithLimit(dec, 128)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Version = string(field)
+	}
+	{
+		field, n, err := scale.DecodeStringSliceWithLimit(dec, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Protocols = field
+	}
+	{
+		field, n, err := scale.DecodeStringSliceWithLimit(dec, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Topics = field
+	}
 	return total, nil
 }
 
 func (t *HandshakeAck) EncodeScale(enc *scale.Encoder) (total int, err error) {
 	{
-		n, err := scale.EncodeString(enc, string(t.Error))
+		n, err := scale.EncodeStringWithLimit(enc, string(t.Error), 1024)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStringWithLimit(enc, string(t.Version), 128)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStringSliceWithLimit(enc, t.Protocols, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStringSliceWithLimit(enc, t.Topics, 64)
 		if err != nil {
 			return total, err
 		}
@@ -42,7 +108,76 @@ func (t *HandshakeAck) EncodeScale(enc *scale.Encoder) (total int, err error) {
 
 func (t *HandshakeAck) DecodeScale(dec *scale.Decoder) (total int, err error) {
 	{
-		field, n, err := scale.DecodeString(dec)
+		field, n, err := scale.DecodeStringWithLimit(dec, 1024)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Error = string(field)
+	}
+	{
+		field, n, err := scale.DecodeStringWithLimit(dec, 128)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Version = string(field)
+	}
+	{
+		field, n, err := scale.DecodeStringSliceWithLimit(dec, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Protocols = field
+	}
+	{
+		field, n, err := scale.DecodeStringSliceWithLimit(dec, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Topics = field
+	}
+	return total, nil
+}
+
+func (t *LegacyHandshakeMessage) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeByteArray(enc, t.GenesisID[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *LegacyHandshakeMessage) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		n, err := scale.DecodeByteArray(dec, t.GenesisID[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *LegacyHandshakeAck) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeStringWithLimit(enc, string(t.Error), 1024)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *LegacyHandshakeAck) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeStringWithLimit(dec, 1024)
 		if err != nil {
 			return total, err
 		}
@@ -1,14 +1,18 @@
 package handshake
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/spacemeshos/go-scale"
 	"github.com/spacemeshos/go-scale/tester"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
 )
 
 func TestHandshake(t *testing.T) {
@@ -26,6 +30,43 @@ func TestHandshake(t *testing.T) {
 	require.Error(t, hs1.Request(context.TODO(), hs3.h.ID()))
 }
 
+func TestHandshakePeerInfo(t *testing.T) {
+	mesh, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	genesisID := types.Hash20{1}
+	info1 := PeerInfo{Version: "v1.1.0", Protocols: []string{"/ax/1"}, Topics: []string{"ax1"}}
+	info2 := PeerInfo{Version: "v1.2.0", Protocols: []string{"/ax/1", "/ld/1"}, Topics: []string{"ax1", "b1"}}
+	hs1 := New(mesh.Hosts()[0], genesisID, WithInfo(info1))
+	t.Cleanup(hs1.Stop)
+	hs2 := New(mesh.Hosts()[1], genesisID, WithInfo(info2))
+	t.Cleanup(hs2.Stop)
+	// node that wasn't upgraded serves only the legacy protocol
+	legacy := &Handshake{logger: log.NewNop(), genesisID: genesisID, h: mesh.Hosts()[2]}
+	legacy.h.SetStreamHandler(legacyHsprotocol, legacy.legacyHandler)
+	require.NoError(t, mesh.ConnectAllButSelf())
+
+	require.NoError(t, hs1.Request(context.TODO(), hs2.h.ID()))
+	received, exists := GetPeerInfo(hs1.h.Peerstore(), hs2.h.ID())
+	require.True(t, exists)
+	require.Equal(t, info2, *received)
+	received, exists = GetPeerInfo(hs2.h.Peerstore(), hs1.h.ID())
+	require.True(t, exists)
+	require.Equal(t, info1, *received)
+	require.True(t, received.Supports("/ax/1"))
+	require.False(t, received.Supports("/ld/1"))
+
+	require.NoError(t, hs1.Request(context.TODO(), legacy.h.ID()))
+	_, exists = GetPeerInfo(hs1.h.Peerstore(), legacy.h.ID())
+	require.False(t, exists)
+
+	stream, err := legacy.h.NewStream(context.TODO(), hs2.h.ID(), legacyHsprotocol)
+	require.NoError(t, err)
+	defer stream.Close()
+	require.NoError(t, legacy.legacyRequest(stream))
+	_, exists = GetPeerInfo(hs2.h.Peerstore(), legacy.h.ID())
+	require.False(t, exists)
+}
+
 func FuzzHandshakeAckConsistency(f *testing.F) {
 	tester.FuzzConsistency[HandshakeAck](f)
 }
@@ -41,3 +82,39 @@ func FuzzHandshakeMessageConsistency(f *testing.F) {
 func FuzzHandshakeMessageSafety(f *testing.F) {
 	tester.FuzzSafety[HandshakeMessage](f)
 }
+
+func FuzzLegacyHandshakeAckConsistency(f *testing.F) {
+	tester.FuzzConsistency[LegacyHandshakeAck](f)
+}
+
+func FuzzLegacyHandshakeAckSafety(f *testing.F) {
+	tester.FuzzSafety[LegacyHandshakeAck](f)
+}
+
+func FuzzLegacyHandshakeMessageConsistency(f *testing.F) {
+	tester.FuzzConsistency[LegacyHandshakeMessage](f)
+}
+
+func FuzzLegacyHandshakeMessageSafety(f *testing.F) {
+	tester.FuzzSafety[LegacyHandshakeMessage](f)
+}
+
+func TestHandshakeMessageLimits(t *testing.T) {
+	msg := HandshakeMessage{Protocols: make([]string, 65)}
+	buf, err := codec.Encode(&msg)
+	require.Error(t, err)
+	require.Nil(t, buf)
+
+	// peer that doesn't respect the limit
+	var b bytes.Buffer
+	enc := scale.NewEncoder(&b)
+	_, err = scale.EncodeByteArray(enc, msg.GenesisID[:])
+	require.NoError(t, err)
+	_, err = scale.EncodeString(enc, "")
+	require.NoError(t, err)
+	_, err = scale.EncodeStringSlice(enc, msg.Protocols)
+	require.NoError(t, err)
+	_, err = scale.EncodeStringSlice(enc, nil)
+	require.NoError(t, err)
+	require.Error(t, codec.Decode(b.Bytes(), &HandshakeMessage{}))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
 	"github.com/spacemeshos/go-spacemesh/p2p/peerexchange"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 )
 
 // DefaultConfig config.
@@ -30,6 +31,7 @@ func DefaultConfig() Config {
 		GracePeersShutdown:   30 * time.Second,
 		BootstrapTimeout:     10 * time.Second,
 		MaxMessageSize:       2 << 20,
+		GossipLimits:         pubsub.DefaultTopicLimits(),
 		CheckInterval:        3 * time.Minute,
 		CheckTimeout:         30 * time.Second,
 		CheckPeersNumber:     10,
@@ -55,6 +57,9 @@ type Config struct {
 	HighPeers        int      `mapstructure:"high-peers"`
 	AdvertiseAddress string   `mapstructure:"advertise-address"`
 
+	// GossipLimits are per-peer limits for gossip topics.
+	GossipLimits map[string]pubsub.TopicLimit `mapstructure:"gossip-limits"`
+
 	// Discovery book check section.
 	CheckInterval        time.Duration
 	CheckTimeout         time.Duration
@@ -84,6 +89,10 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 		}
 		cm.Protect(addr.ID, peerexchange.BootNodeTag)
 	}
+	bans, err := newBans(cfg.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("load bans: %w", err)
+	}
 	streamer := *yamux.DefaultTransport
 	ps, err := pstoremem.NewPeerstore()
 	if err != nil {
@@ -100,6 +109,7 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 		libp2p.Muxer("/yamux/1.0.0", &streamer),
 
 		libp2p.ConnectionManager(cm),
+		libp2p.ConnectionGater(bans),
 		libp2p.Peerstore(ps),
 		libp2p.BandwidthReporter(p2pmetrics.NewBandwidthCollector()),
 	}
@@ -117,6 +127,6 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 	)
 	// TODO(dshulyak) this is small mess. refactor to avoid this patching
 	// both New and Upgrade should use options.
-	opts = append(opts, WithConfig(cfg), WithLog(logger))
+	opts = append(opts, WithConfig(cfg), WithLog(logger), withBans(bans))
 	return Upgrade(h, genesisID, opts...)
 }
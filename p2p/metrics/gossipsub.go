@@ -25,6 +25,21 @@ var (
 		[]string{"protocol", "result"},
 		prometheus.ExponentialBuckets(1_000_000, 4, 10),
 	)
+	// ThrottledMessages is a number of messages dropped because peer exceeded limits of the topic.
+	// Labeled by protocol and the exceeded limit.
+	ThrottledMessages = metrics.NewCounter(
+		"throttled_messages",
+		subsystem,
+		"Number of messages dropped because peer exceeded limits of the topic",
+		[]string{"protocol", "limit"},
+	)
+	// TopicLimits are configured per-peer limits. Labeled by protocol and the limit.
+	TopicLimits = metrics.NewGauge(
+		"topic_limits",
+		subsystem,
+		"Per-peer limits of the topic",
+		[]string{"protocol", "limit"},
+	)
 	deliveredMessagesBytes = metrics.NewCounter(
 		"delivered_messages_bytes",
 		subsystem,
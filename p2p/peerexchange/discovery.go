@@ -11,6 +11,7 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
@@ -147,6 +148,27 @@ func (d *Discovery) Bootstrap(ctx context.Context) error {
 	return d.crawl.Bootstrap(ctx)
 }
 
+// Buckets returns addresses in the new and tried buckets of the address book.
+func (d *Discovery) Buckets() (newBuckets, triedBuckets [][]*addressbook.AddrInfo) {
+	return d.book.Buckets()
+}
+
+// AddBootnode adds bootnode to the address book.
+func (d *Discovery) AddBootnode(info *addressbook.AddrInfo) error {
+	best, err := bestHostAddress(d.host)
+	if err != nil {
+		return err
+	}
+	d.book.AddAddress(info, best)
+	return nil
+}
+
+// RemoveBootnode removes the bootnode from the address book, so that it is no longer
+// used for discovery and not shared with other peers.
+func (d *Discovery) RemoveBootnode(pid peer.ID) {
+	d.book.RemoveAddress(pid)
+}
+
 // AdvertisedAddress returns advertised address.
 func (d *Discovery) AdvertisedAddress() ma.Multiaddr {
 	return d.crawl.disc.AdvertisedAddress()
@@ -0,0 +1,209 @@
+package pubsub
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+
+	"github.com/spacemeshos/go-spacemesh/p2p/metrics"
+)
+
+const (
+	// throttlePenaltyWeight is an application specific score for every message
+	// that was dropped because peer exceeded limits of the topic.
+	throttlePenaltyWeight = -10
+	// throttlePenaltyDecay is applied to accumulated penalties every decayInterval.
+	throttlePenaltyDecay = 0.9
+	// throttlePenaltyDecayToZero is a value below which penalty is removed.
+	throttlePenaltyDecayToZero = 0.1
+	// throttlePenaltyMax caps accumulated penalty above GossipScoreThreshold.
+	// limits apply to the peer that relayed the message, which is not necessarily the origin of the spam,
+	// so throttling alone lowers peer priority in the mesh but doesn't make it ignored or graylisted.
+	throttlePenaltyMax = 40
+	// limiterIdleTimeout is a duration after which limits for an inactive peer are discarded.
+	limiterIdleTimeout = 10 * time.Minute
+	// decayInterval is an interval at which penalties are decayed and idle limiters are discarded.
+	decayInterval = time.Minute
+
+	limitMessages = "messages"
+	limitBytes    = "bytes"
+)
+
+// TopicLimit is a limit on the messages that a single peer may send in the topic.
+// Zero values disable the corresponding limit.
+type TopicLimit struct {
+	MessagesPerSecond float64 `mapstructure:"messages-per-second"`
+	MessagesBurst     int     `mapstructure:"messages-burst"`
+	// BytesBurst should not be lower than the max message size, otherwise large messages are always dropped.
+	BytesPerSecond float64 `mapstructure:"bytes-per-second"`
+	BytesBurst     int     `mapstructure:"bytes-burst"`
+}
+
+// DefaultTopicLimits returns per-peer limits for topics that are cheap to spam.
+func DefaultTopicLimits() map[string]TopicLimit {
+	return map[string]TopicLimit{
+		TxProtocol: {
+			MessagesPerSecond: 50,
+			MessagesBurst:     500,
+			BytesPerSecond:    512 << 10,
+			BytesBurst:        4 << 20,
+		},
+		HareProtocol: {
+			MessagesPerSecond: 200,
+			MessagesBurst:     2000,
+			BytesPerSecond:    2 << 20,
+			BytesBurst:        8 << 20,
+		},
+		ProposalProtocol: {
+			MessagesPerSecond: 10,
+			MessagesBurst:     200,
+			BytesPerSecond:    2 << 20,
+			BytesBurst:        16 << 20,
+		},
+		MalfeasanceProtocol: {
+			MessagesPerSecond: 1,
+			MessagesBurst:     50,
+			BytesPerSecond:    64 << 10,
+			BytesBurst:        2 << 20,
+		},
+	}
+}
+
+type peerLimiter struct {
+	messages, bytes *rate.Limiter
+	lastSeen        time.Time
+}
+
+// limiter tracks rate of the messages and bytes received from every peer in every limited topic.
+// Peers that exceed limits accumulate penalty, that is used as an application specific score in gossipsub.
+//
+// Messages are limited by inspecting rpcs before gossipsub marks them as seen. A message that was dropped
+// because one peer exceeded limits is still accepted when it is received from another peer.
+type limiter struct {
+	limits map[string]TopicLimit
+
+	mu        sync.Mutex
+	peers     map[string]map[peer.ID]*peerLimiter
+	penalties map[peer.ID]float64
+}
+
+func newLimiter(limits map[string]TopicLimit) *limiter {
+	l := &limiter{
+		limits:    limits,
+		peers:     map[string]map[peer.ID]*peerLimiter{},
+		penalties: map[peer.ID]float64{},
+	}
+	for topic, limit := range limits {
+		metrics.TopicLimits.WithLabelValues(topic, "messages_per_second").Set(limit.MessagesPerSecond)
+		metrics.TopicLimits.WithLabelValues(topic, "messages_burst").Set(float64(limit.MessagesBurst))
+		metrics.TopicLimits.WithLabelValues(topic, "bytes_per_second").Set(limit.BytesPerSecond)
+		metrics.TopicLimits.WithLabelValues(topic, "bytes_burst").Set(float64(limit.BytesBurst))
+	}
+	return l
+}
+
+func newRateLimiter(perSecond float64, burst int) *rate.Limiter {
+	if perSecond == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), burst)
+}
+
+// allow returns false if the peer exceeded limits of the topic.
+func (l *limiter) allow(topic string, pid peer.ID, size int, now time.Time) bool {
+	limit, exists := l.limits[topic]
+	if !exists {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	peers, exists := l.peers[topic]
+	if !exists {
+		peers = map[peer.ID]*peerLimiter{}
+		l.peers[topic] = peers
+	}
+	pl, exists := peers[pid]
+	if !exists {
+		pl = &peerLimiter{
+			messages: newRateLimiter(limit.MessagesPerSecond, limit.MessagesBurst),
+			bytes:    newRateLimiter(limit.BytesPerSecond, limit.BytesBurst),
+		}
+		peers[pid] = pl
+	}
+	pl.lastSeen = now
+	reason := ""
+	if !pl.messages.AllowN(now, 1) {
+		reason = limitMessages
+	} else if !pl.bytes.AllowN(now, size) {
+		reason = limitBytes
+	}
+	if len(reason) == 0 {
+		return true
+	}
+	l.penalties[pid] = math.Min(l.penalties[pid]+1, throttlePenaltyMax)
+	metrics.ThrottledMessages.WithLabelValues(topic, reason).Inc()
+	return false
+}
+
+// inspect removes messages that exceed limits from the rpc received from the peer.
+// Subscriptions and control messages are not limited.
+func (l *limiter) inspect(pid peer.ID, rpc *pubsub.RPC) error {
+	if len(rpc.Publish) == 0 {
+		return nil
+	}
+	now := time.Now()
+	allowed := rpc.Publish[:0]
+	for _, msg := range rpc.Publish {
+		if l.allow(msg.GetTopic(), pid, len(msg.Data), now) {
+			allowed = append(allowed, msg)
+		}
+	}
+	rpc.Publish = allowed
+	return nil
+}
+
+// score returns application specific score for the peer.
+func (l *limiter) score(pid peer.ID) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return throttlePenaltyWeight * l.penalties[pid]
+}
+
+// decay decreases penalties and discards limiters for peers that were idle for limiterIdleTimeout.
+func (l *limiter) decay(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for pid, penalty := range l.penalties {
+		penalty *= throttlePenaltyDecay
+		if penalty < throttlePenaltyDecayToZero {
+			delete(l.penalties, pid)
+		} else {
+			l.penalties[pid] = penalty
+		}
+	}
+	for _, peers := range l.peers {
+		for pid, pl := range peers {
+			if now.Sub(pl.lastSeen) > limiterIdleTimeout {
+				delete(peers, pid)
+			}
+		}
+	}
+}
+
+func (l *limiter) run(ctx context.Context) {
+	ticker := time.NewTicker(decayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.decay(now)
+		}
+	}
+}
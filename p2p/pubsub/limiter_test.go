@@ -0,0 +1,92 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	const topic = "test"
+	l := newLimiter(map[string]TopicLimit{
+		topic: {
+			MessagesPerSecond: 1,
+			MessagesBurst:     2,
+			BytesPerSecond:    100,
+			BytesBurst:        100,
+		},
+	})
+	spammer, honest := peer.ID("spammer"), peer.ID("honest")
+	now := time.Now()
+
+	require.True(t, l.allow(topic, spammer, 10, now))
+	require.True(t, l.allow(topic, spammer, 10, now))
+	require.False(t, l.allow(topic, spammer, 10, now), "message rate exceeded")
+	require.True(t, l.allow(topic, honest, 10, now), "limits are tracked per peer")
+	require.True(t, l.allow("unlimited", spammer, 1000, now), "topic without limits")
+	require.Equal(t, float64(throttlePenaltyWeight), l.score(spammer))
+	require.Zero(t, l.score(honest))
+
+	now = now.Add(2 * time.Second)
+	require.True(t, l.allow(topic, spammer, 80, now))
+	require.False(t, l.allow(topic, spammer, 80, now), "byte quota exceeded")
+	require.Equal(t, float64(2*throttlePenaltyWeight), l.score(spammer))
+
+	l.decay(now)
+	require.InDelta(t, 2*throttlePenaltyWeight*throttlePenaltyDecay, l.score(spammer), 1e-9)
+	for i := 0; i < 100; i++ {
+		l.decay(now)
+	}
+	require.Zero(t, l.score(spammer))
+
+	l.decay(now.Add(limiterIdleTimeout + time.Second))
+	require.Empty(t, l.peers[topic])
+}
+
+func TestLimiterNoLimit(t *testing.T) {
+	const topic = "test"
+	l := newLimiter(map[string]TopicLimit{
+		topic: {MessagesPerSecond: 1, MessagesBurst: 1},
+	})
+	now := time.Now()
+	require.True(t, l.allow(topic, "peer", 1<<30, now), "bytes are not limited")
+	require.False(t, l.allow(topic, "peer", 1, now))
+}
+
+func TestLimiterPenaltyCapped(t *testing.T) {
+	const topic = "test"
+	l := newLimiter(map[string]TopicLimit{
+		topic: {MessagesPerSecond: 1, MessagesBurst: 1},
+	})
+	now := time.Now()
+	for i := 0; i < 10*throttlePenaltyMax; i++ {
+		l.allow(topic, "peer", 1, now)
+	}
+	require.Equal(t, float64(throttlePenaltyWeight*throttlePenaltyMax), l.score("peer"))
+	require.Greater(t, l.score("peer"), float64(GossipScoreThreshold))
+}
+
+func TestLimiterInspect(t *testing.T) {
+	const topic = "test"
+	l := newLimiter(map[string]TopicLimit{
+		topic: {MessagesPerSecond: 1, MessagesBurst: 2},
+	})
+	limited, unlimited := topic, "unlimited"
+	rpc := &pubsub.RPC{}
+	for i := 0; i < 3; i++ {
+		rpc.Publish = append(rpc.Publish,
+			&pb.Message{Topic: &limited, Data: []byte{byte(i)}},
+			&pb.Message{Topic: &unlimited, Data: []byte{byte(i)}},
+		)
+	}
+	rpc.Control = &pb.ControlMessage{}
+	require.NoError(t, l.inspect("peer", rpc))
+	require.Len(t, rpc.Publish, 5)
+	require.Equal(t, []byte{1}, rpc.Publish[2].Data)
+	require.Equal(t, unlimited, rpc.Publish[4].GetTopic())
+	require.NotNil(t, rpc.Control, "control messages are not limited")
+}
@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -50,6 +51,9 @@ const (
 	// may select more peers with score above the median to opportunistically graft on the mesh.
 	OpportunisticGraftScoreThreshold = 3.5
 
+	// scoreInspectInterval is an interval at which peer scores are copied from gossipsub.
+	scoreInspectInterval = 10 * time.Second
+
 	// AtxProtocol is the protocol id for ATXs.
 	AtxProtocol = "ax1"
 	// ProposalProtocol is the protocol id for block proposals.
@@ -71,11 +75,28 @@ const (
 	BeaconFirstVotesProtocol = "bf1"
 	// BeaconFollowingVotesProtocol is the protocol id for beacon following votes.
 	BeaconFollowingVotesProtocol = "bo1"
+
+	// MalfeasanceProtocol is the protocol id for malfeasance proofs.
+	MalfeasanceProtocol = "mp1"
 )
 
+// Topics are all gossip topics used by the node.
+var Topics = []string{
+	AtxProtocol,
+	ProposalProtocol,
+	TxProtocol,
+	HareProtocol,
+	BlockCertify,
+	BeaconWeakCoinProtocol,
+	BeaconProposalProtocol,
+	BeaconFirstVotesProtocol,
+	BeaconFollowingVotesProtocol,
+	MalfeasanceProtocol,
+}
+
 // DefaultConfig for PubSub.
 func DefaultConfig() Config {
-	return Config{Flood: true}
+	return Config{Flood: true, Limits: DefaultTopicLimits()}
 }
 
 // Config for PubSub.
@@ -84,21 +105,31 @@ type Config struct {
 	Flood          bool
 	IsBootnode     bool
 	MaxMessageSize int
+	// Limits are per-peer limits for topics. Messages from peers that exceed limits are dropped
+	// before validation and peers are penalized in gossipsub scoring.
+	Limits map[string]TopicLimit
 }
 
 // New creates PubSub instance.
 func New(ctx context.Context, logger log.Log, h host.Host, cfg Config) (*PubSub, error) {
 	// TODO(dshulyak) refactor code to accept options
-	opts := getOptions(cfg)
+	wrapper := &PubSub{
+		logger:  logger,
+		topics:  map[string]*pubsub.Topic{},
+		limiter: newLimiter(cfg.Limits),
+	}
+	opts := getOptions(cfg, wrapper.limiter.score)
+	opts = append(opts,
+		pubsub.WithPeerScoreInspect(wrapper.inspectScores, scoreInspectInterval),
+		pubsub.WithAppSpecificRpcInspector(wrapper.limiter.inspect),
+	)
 	ps, err := pubsub.NewGossipSub(ctx, h, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize gossipsub instance: %w", err)
 	}
-	return &PubSub{
-		logger: logger,
-		pubsub: ps,
-		topics: map[string]*pubsub.Topic{},
-	}, nil
+	wrapper.pubsub = ps
+	go wrapper.limiter.run(ctx)
+	return wrapper, nil
 }
 
 //go:generate mockgen -package=mocks -destination=./mocks/publisher.go -source=./pubsub.go
@@ -136,6 +167,11 @@ const (
 	ValidationReject = pubsub.ValidationReject
 )
 
+// ErrValidationReject is wrapped by handler errors for data that is malformed or invalid
+// regardless of the local state. Such data is rejected on gossip, and a peer that serves it
+// during sync is penalized.
+var ErrValidationReject = errors.New("validation reject")
+
 // ChainGossipHandler helper to chain multiple GossipHandler together. Called synchronously and in the order.
 func ChainGossipHandler(handlers ...GossipHandler) GossipHandler {
 	return func(ctx context.Context, pid peer.ID, msg []byte) ValidationResult {
@@ -157,7 +193,7 @@ func msgID(msg *pb.Message) string {
 	return string(hasher.Sum(nil))
 }
 
-func getOptions(cfg Config) []pubsub.Option {
+func getOptions(cfg Config, appScore func(peer.ID) float64) []pubsub.Option {
 	options := []pubsub.Option{
 		// Gossipsubv1.1 configuration
 		pubsub.WithFloodPublish(cfg.Flood),
@@ -169,11 +205,9 @@ func getOptions(cfg Config) []pubsub.Option {
 		pubsub.WithRawTracer(p2pmetrics.NewGoSIPCollector()),
 		pubsub.WithPeerScore(
 			&pubsub.PeerScoreParams{
-				AppSpecificScore: func(p peer.ID) float64 {
-					// TODO: add application specific score to provide feedback to the pubsub system
-					//       based on observed behavior
-					return 0
-				},
+				// TODO: add application specific score to provide feedback to the pubsub system
+				//       based on observed behavior, besides exceeding topic limits
+				AppSpecificScore:  appScore,
 				AppSpecificWeight: 1,
 
 				// TODO: consider setting IP co-location threshold before applying penalties
@@ -203,7 +237,6 @@ func getOptions(cfg Config) []pubsub.Option {
 				OpportunisticGraftThreshold: OpportunisticGraftScoreThreshold,
 			},
 		),
-		// TODO: add peer scoring debugging with WithPeerScoreInspect
 	}
 
 	if cfg.MaxMessageSize != 0 {
@@ -49,3 +49,69 @@ func TestGossip(t *testing.T) {
 	}
 	require.Eventually(t, func() bool { return len(received) == count }, 5*time.Second, 10*time.Millisecond)
 }
+
+func TestThrottledDuplicateAcceptedFromOtherPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	mesh, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	topic := "test"
+	hosts := mesh.Hosts()
+	received := make(chan []byte, 10)
+
+	pubsubs := []*PubSub{}
+	for i, h := range hosts {
+		cfg := Config{Flood: true}
+		if i == 0 {
+			cfg.Limits = map[string]TopicLimit{
+				topic: {MessagesPerSecond: 0.001, MessagesBurst: 1},
+			}
+		}
+		ps, err := New(ctx, logtest.New(t), h, cfg)
+		require.NoError(t, err)
+		pubsubs = append(pubsubs, ps)
+		i := i
+		ps.Register(topic, func(ctx context.Context, pid peer.ID, msg []byte) ValidationResult {
+			if i == 0 {
+				received <- msg
+			}
+			return ValidationAccept
+		})
+	}
+	// both peers relay messages only to the receiver
+	_, err = mesh.ConnectPeers(hosts[0].ID(), hosts[1].ID())
+	require.NoError(t, err)
+	_, err = mesh.ConnectPeers(hosts[0].ID(), hosts[2].ID())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(pubsubs[0].ProtocolPeers(topic)) == 2 &&
+			len(pubsubs[1].ProtocolPeers(topic)) == 1 &&
+			len(pubsubs[2].ProtocolPeers(topic)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	first := []byte("first")
+	require.NoError(t, pubsubs[1].Publish(ctx, topic, first))
+	select {
+	case msg := <-received:
+		require.Equal(t, first, msg)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the first message")
+	}
+
+	// peer exhausted its limit, the message is dropped before it is marked as seen
+	dup := []byte("duplicate")
+	require.NoError(t, pubsubs[1].Publish(ctx, topic, dup))
+	require.Eventually(t, func() bool {
+		return pubsubs[0].limiter.score(hosts[1].ID()) < 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, received)
+
+	// the same message from another peer is accepted
+	require.NoError(t, pubsubs[2].Publish(ctx, topic, dup))
+	select {
+	case msg := <-received:
+		require.Equal(t, dup, msg)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "message from other peer was not accepted")
+	}
+}
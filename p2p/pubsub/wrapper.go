@@ -15,11 +15,29 @@ import (
 
 // PubSub is a spacemesh-specific wrapper around gossip protocol.
 type PubSub struct {
-	logger log.Log
-	pubsub *pubsub.PubSub
+	logger  log.Log
+	pubsub  *pubsub.PubSub
+	limiter *limiter
 
 	mu     sync.RWMutex
 	topics map[string]*pubsub.Topic
+
+	scoresMu sync.RWMutex
+	scores   map[peer.ID]float64
+}
+
+func (ps *PubSub) inspectScores(scores map[peer.ID]float64) {
+	ps.scoresMu.Lock()
+	defer ps.scoresMu.Unlock()
+	ps.scores = scores
+}
+
+// PeerScore returns the last known gossipsub score of the peer.
+func (ps *PubSub) PeerScore(pid peer.ID) (float64, bool) {
+	ps.scoresMu.RLock()
+	defer ps.scoresMu.RUnlock()
+	score, exists := ps.scores[pid]
+	return score, exists
 }
 
 // Register handler for topic.
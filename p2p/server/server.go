@@ -12,13 +12,22 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/spacemeshos/go-scale"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
-// ErrNotConnected is returned when peer is not connected.
-var ErrNotConnected = errors.New("peer is not connected")
+// MaxChunkSize is the maximal size of the chunk in the streamed response.
+const MaxChunkSize = 1 << 20
+
+var (
+	// ErrNotConnected is returned when peer is not connected.
+	ErrNotConnected = errors.New("peer is not connected")
+	// ErrChunkTooLarge is returned by send if chunk is larger than MaxChunkSize,
+	// and by StreamRequest if the peer sent such chunk.
+	ErrChunkTooLarge = errors.New("chunk is too large")
+)
 
 // Opt is a type to configure a server.
 type Opt func(s *Server)
@@ -47,9 +56,17 @@ func WithContext(ctx context.Context) Opt {
 // Handler is the handler to be defined by the application.
 type Handler func(context.Context, []byte) ([]byte, error)
 
+// StreamHandler is the handler for the streaming protocol. Handler writes the response
+// as a sequence of chunks using send. Send blocks until the chunk is written to the stream,
+// therefore the handler can't get ahead of the requester that consumes chunks.
+type StreamHandler func(ctx context.Context, req []byte, send func([]byte) error) error
+
 //go:generate scalegen -types Response
 
 // Response is a server response.
+//
+// Streamed response is a sequence of Response's with non-empty Data,
+// terminated by Response without Data and with an Error if handler failed.
 type Response struct {
 	Data  []byte
 	Error string
@@ -69,6 +86,7 @@ type Server struct {
 	logger   log.Log
 	protocol string
 	handler  Handler
+	stream   StreamHandler
 	timeout  time.Duration
 
 	h Host
@@ -93,17 +111,75 @@ func New(h Host, proto string, handler Handler, opts ...Opt) *Server {
 	return srv
 }
 
-func (s *Server) streamHandler(stream network.Stream) {
-	defer stream.Close()
-	_ = stream.SetDeadline(time.Now().Add(s.timeout))
-	defer stream.SetDeadline(time.Time{})
+// NewStreaming creates server for the streaming handler.
+// Requests to this server must be sent with StreamRequest.
+func NewStreaming(h Host, proto string, handler StreamHandler, opts ...Opt) *Server {
+	srv := &Server{
+		ctx:      context.Background(),
+		logger:   log.NewNop(),
+		protocol: proto,
+		stream:   handler,
+		h:        h,
+		timeout:  10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	h.SetStreamHandler(protocol.ID(proto), srv.chunkedStreamHandler)
+	return srv
+}
+
+func readRequest(stream network.Stream) ([]byte, error) {
 	rd := bufio.NewReader(stream)
 	size, err := binary.ReadUvarint(rd)
 	if err != nil {
-		return
+		return nil, err
 	}
 	buf := make([]byte, size)
 	_, err = io.ReadFull(rd, buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func writeRequest(stream network.Stream, req []byte) error {
+	wr := bufio.NewWriter(stream)
+	sz := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(sz, uint64(len(req)))
+	if _, err := wr.Write(sz[:n]); err != nil {
+		return err
+	}
+	if _, err := wr.Write(req); err != nil {
+		return err
+	}
+	return wr.Flush()
+}
+
+// readChunk reads Response of the streamed response. Data and Error are limited by MaxChunkSize,
+// so that the peer can't make the requester allocate more.
+func readChunk(rd io.Reader) (*Response, error) {
+	dec := scale.NewDecoder(rd)
+	data, _, err := scale.DecodeByteSliceWithLimit(dec, MaxChunkSize)
+	if errors.Is(err, scale.ErrDecodeTooManyElements) {
+		return nil, fmt.Errorf("%w: %v", ErrChunkTooLarge, err)
+	} else if err != nil {
+		return nil, err
+	}
+	msg, _, err := scale.DecodeStringWithLimit(dec, MaxChunkSize)
+	if errors.Is(err, scale.ErrDecodeTooManyElements) {
+		return nil, fmt.Errorf("%w: %v", ErrChunkTooLarge, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return &Response{Data: data, Error: msg}, nil
+}
+
+func (s *Server) streamHandler(stream network.Stream) {
+	defer stream.Close()
+	_ = stream.SetDeadline(time.Now().Add(s.timeout))
+	defer stream.SetDeadline(time.Time{})
+	buf, err := readRequest(stream)
 	if err != nil {
 		return
 	}
@@ -130,6 +206,51 @@ func (s *Server) streamHandler(stream network.Stream) {
 	}
 }
 
+func (s *Server) chunkedStreamHandler(stream network.Stream) {
+	defer stream.Close()
+	_ = stream.SetDeadline(time.Now().Add(s.timeout))
+	defer stream.SetDeadline(time.Time{})
+	req, err := readRequest(stream)
+	if err != nil {
+		return
+	}
+	wr := bufio.NewWriter(stream)
+	write := func(resp *Response) error {
+		// timeout applies to every chunk, so that the response may take longer than the timeout
+		// as long as the requester keeps consuming it
+		_ = stream.SetWriteDeadline(time.Now().Add(s.timeout))
+		if _, err := codec.EncodeTo(wr, resp); err != nil {
+			return err
+		}
+		return wr.Flush()
+	}
+	var chunks int
+	send := func(chunk []byte) error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if len(chunk) > MaxChunkSize {
+			return fmt.Errorf("%w: %d > %d", ErrChunkTooLarge, len(chunk), MaxChunkSize)
+		}
+		chunks++
+		return write(&Response{Data: chunk})
+	}
+	start := time.Now()
+	err = s.stream(log.WithNewRequestID(s.ctx), req, send)
+	s.logger.With().Debug("protocol stream handler execution time",
+		log.String("protocol", s.protocol),
+		log.Int("chunks", chunks),
+		log.Duration("duration", time.Since(start)),
+	)
+	var last Response
+	if err != nil {
+		last.Error = err.Error()
+	}
+	if err := write(&last); err != nil {
+		s.logger.With().Warning("failed to write response", log.Err(err))
+	}
+}
+
 // Request sends a binary request to the peer. Request is executed in the background, one of the callbacks
 // is guaranteed to be called on success/error.
 func (s *Server) Request(ctx context.Context, pid peer.ID, req []byte, resp func([]byte), failure func(error)) error {
@@ -155,20 +276,7 @@ func (s *Server) Request(ctx context.Context, pid peer.ID, req []byte, resp func
 		defer stream.SetDeadline(time.Time{})
 		_ = stream.SetDeadline(time.Now().Add(s.timeout))
 
-		wr := bufio.NewWriter(stream)
-		sz := make([]byte, binary.MaxVarintLen64)
-		n := binary.PutUvarint(sz, uint64(len(req)))
-		_, err = wr.Write(sz[:n])
-		if err != nil {
-			failure(err)
-			return
-		}
-		_, err = wr.Write(req)
-		if err != nil {
-			failure(err)
-			return
-		}
-		if err := wr.Flush(); err != nil {
+		if err := writeRequest(stream, req); err != nil {
 			failure(err)
 			return
 		}
@@ -187,3 +295,71 @@ func (s *Server) Request(ctx context.Context, pid peer.ID, req []byte, resp func
 	}()
 	return nil
 }
+
+// StreamRequest sends a binary request to the peer that serves a streaming protocol.
+// Request is executed in the background. Chunks of the response are passed to the chunk callback
+// in order. Next chunk is not read until the callback returns, so that the responder is paced by the requester.
+// If the chunk callback returns an error the request is aborted.
+// Done callback is guaranteed to be called once, after the last chunk or on the first error.
+func (s *Server) StreamRequest(ctx context.Context, pid peer.ID, req []byte, chunk func([]byte) error, done func(error)) error {
+	if s.h.Network().Connectedness(pid) != network.Connected {
+		return fmt.Errorf("%w: %s", ErrNotConnected, pid)
+	}
+	go func() {
+		start := time.Now()
+		var chunks int
+		defer func() {
+			s.logger.WithContext(ctx).With().Debug("stream request execution time",
+				log.String("protocol", s.protocol),
+				log.Int("chunks", chunks),
+				log.Duration("duration", time.Since(start)),
+			)
+		}()
+		sctx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+		stream, err := s.h.NewStream(network.WithNoDial(sctx, "existing connection"), pid, protocol.ID(s.protocol))
+		if err != nil {
+			done(err)
+			return
+		}
+		defer stream.Close()
+		defer stream.SetDeadline(time.Time{})
+		_ = stream.SetDeadline(time.Now().Add(s.timeout))
+
+		if err := writeRequest(stream, req); err != nil {
+			done(err)
+			return
+		}
+
+		rd := bufio.NewReader(stream)
+		for {
+			if err := ctx.Err(); err != nil {
+				stream.Reset()
+				done(err)
+				return
+			}
+			_ = stream.SetReadDeadline(time.Now().Add(s.timeout))
+			r, err := readChunk(rd)
+			if err != nil {
+				stream.Reset()
+				done(err)
+				return
+			}
+			if len(r.Data) == 0 {
+				if len(r.Error) > 0 {
+					done(errors.New(r.Error))
+				} else {
+					done(nil)
+				}
+				return
+			}
+			chunks++
+			if err := chunk(r.Data); err != nil {
+				stream.Reset()
+				done(err)
+				return
+			}
+		}
+	}()
+	return nil
+}
@@ -6,7 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/spacemeshos/go-scale"
 	"github.com/spacemeshos/go-scale/tester"
 	"github.com/stretchr/testify/require"
 )
@@ -88,3 +92,100 @@ func FuzzResponseConsistency(f *testing.F) {
 func FuzzResponseSafety(f *testing.F) {
 	tester.FuzzSafety[Response](f)
 }
+
+func TestServerStreaming(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	mesh, err := mocknet.FullMeshConnected(5)
+	require.NoError(t, err)
+	proto := "test"
+	testErr := errors.New("test error")
+
+	chunks := [][]byte{[]byte("first"), []byte("second"), []byte("third")}
+	handler := func(_ context.Context, req []byte, send func([]byte) error) error {
+		for _, chunk := range chunks {
+			if err := send(append(req, chunk...)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	errhandler := func(_ context.Context, req []byte, send func([]byte) error) error {
+		if err := send(chunks[0]); err != nil {
+			return err
+		}
+		return testErr
+	}
+	largehandler := func(_ context.Context, req []byte, send func([]byte) error) error {
+		return send(make([]byte, MaxChunkSize+1))
+	}
+	opts := []Opt{
+		WithTimeout(100 * time.Millisecond),
+		WithContext(ctx),
+	}
+	client := NewStreaming(mesh.Hosts()[0], proto, handler, opts...)
+	_ = NewStreaming(mesh.Hosts()[1], proto, handler, opts...)
+	_ = NewStreaming(mesh.Hosts()[2], proto, errhandler, opts...)
+	_ = NewStreaming(mesh.Hosts()[3], proto, largehandler, opts...)
+	// peer that announces a chunk larger than the limit
+	mesh.Hosts()[4].SetStreamHandler(protocol.ID(proto), func(stream network.Stream) {
+		defer stream.Close()
+		if _, err := readRequest(stream); err != nil {
+			return
+		}
+		_, _ = scale.EncodeCompact32(scale.NewEncoder(stream), MaxChunkSize+1)
+	})
+
+	request := func(tb testing.TB, pid peer.ID, onChunk func([]byte) error) ([][]byte, error) {
+		var received [][]byte
+		errch := make(chan error, 1)
+		require.NoError(tb, client.StreamRequest(ctx, pid, []byte("req-"), func(chunk []byte) error {
+			received = append(received, chunk)
+			return onChunk(chunk)
+		}, func(err error) {
+			errch <- err
+		}))
+		select {
+		case <-time.After(time.Second):
+			require.FailNow(tb, "timed out while waiting for response")
+		case err := <-errch:
+			return received, err
+		}
+		return nil, nil
+	}
+	accept := func([]byte) error { return nil }
+
+	t.Run("ReceiveChunks", func(t *testing.T) {
+		received, err := request(t, mesh.Hosts()[1].ID(), accept)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{
+			[]byte("req-first"), []byte("req-second"), []byte("req-third"),
+		}, received)
+	})
+	t.Run("ReceiveError", func(t *testing.T) {
+		received, err := request(t, mesh.Hosts()[2].ID(), accept)
+		require.Equal(t, testErr, err)
+		require.Equal(t, chunks[:1], received)
+	})
+	t.Run("ChunkTooLarge", func(t *testing.T) {
+		received, err := request(t, mesh.Hosts()[3].ID(), accept)
+		require.ErrorContains(t, err, ErrChunkTooLarge.Error())
+		require.Empty(t, received)
+	})
+	t.Run("ReceivedChunkTooLarge", func(t *testing.T) {
+		received, err := request(t, mesh.Hosts()[4].ID(), accept)
+		require.ErrorIs(t, err, ErrChunkTooLarge)
+		require.Empty(t, received)
+	})
+	t.Run("Abort", func(t *testing.T) {
+		received, err := request(t, mesh.Hosts()[1].ID(), func([]byte) error {
+			return testErr
+		})
+		require.ErrorIs(t, err, testErr)
+		require.Len(t, received, 1)
+	})
+	t.Run("NotConnected", func(t *testing.T) {
+		require.ErrorIs(t, client.StreamRequest(ctx, "unknown", nil, accept, func(error) {}), ErrNotConnected)
+	})
+}
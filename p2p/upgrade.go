@@ -47,6 +47,26 @@ func WithNodeReporter(reporter func()) Opt {
 	}
 }
 
+// WithNodeVersion sets the version of the node that is advertised to peers.
+func WithNodeVersion(version string) Opt {
+	return func(fh *Host) {
+		fh.version = version
+	}
+}
+
+// WithProtocols sets stream protocols that are advertised to peers as supported.
+func WithProtocols(protocols ...string) Opt {
+	return func(fh *Host) {
+		fh.protocols = protocols
+	}
+}
+
+func withBans(b *bans) Opt {
+	return func(fh *Host) {
+		fh.bans = b
+	}
+}
+
 // Host is a conveniency wrapper for all p2p related functionality required to run
 // a full spacemesh node.
 type Host struct {
@@ -58,11 +78,15 @@ type Host struct {
 	*pubsub.PubSub
 
 	nodeReporter func()
+	version      string
+	protocols    []string
 	*bootstrap.Peers
 
 	discovery *peerexchange.Discovery
 	hs        *handshake.Handshake
 	bootstrap *bootstrap.Bootstrap
+	bans      *bans
+	bootnodes *bootnodes
 }
 
 func isBootnode(h host.Host, bootnodes []string) (bool, error) {
@@ -90,6 +114,27 @@ func Upgrade(h host.Host, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 		opt(fh)
 	}
 	cfg := fh.cfg
+	var err error
+	if fh.bans == nil {
+		// bans are not enforced for new connections if host wasn't created with bans as a connection gater,
+		// but connections with peers are still closed when they are banned
+		if fh.bans, err = newBans(cfg.DataDir); err != nil {
+			return nil, fmt.Errorf("load bans: %w", err)
+		}
+	}
+	if fh.bootnodes, err = loadBootnodes(cfg.DataDir, cfg.Bootnodes); err != nil {
+		return nil, fmt.Errorf("load bootnodes: %w", err)
+	}
+	// bootnodes from the config are protected when host is created
+	_, added := fh.bootnodes.list()
+	for _, raw := range added {
+		info, err := addressbook.ParseAddrInfo(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bootstrap node: %w", err)
+		}
+		h.ConnManager().Protect(info.ID, peerexchange.BootNodeTag)
+	}
+	cfg.Bootnodes = fh.bootnodes.all()
 	bootnode, err := isBootnode(h, cfg.Bootnodes)
 	if err != nil {
 		return nil, fmt.Errorf("check node as bootnode: %w", err)
@@ -98,6 +143,7 @@ func Upgrade(h host.Host, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 		Flood:          cfg.Flood,
 		IsBootnode:     bootnode,
 		MaxMessageSize: cfg.MaxMessageSize,
+		Limits:         cfg.GossipLimits,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to initialize pubsub: %w", err)
 	}
@@ -124,10 +170,42 @@ func Upgrade(h host.Host, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 	}, fh, fh.discovery); err != nil {
 		return nil, fmt.Errorf("failed to initiliaze bootstrap: %w", err)
 	}
-	fh.hs = handshake.New(fh, genesisID, handshake.WithLog(fh.logger))
+	fh.hs = handshake.New(fh, genesisID,
+		handshake.WithLog(fh.logger),
+		handshake.WithInfo(handshake.PeerInfo{
+			Version:   fh.version,
+			Protocols: fh.protocols,
+			Topics:    pubsub.Topics,
+		}),
+	)
 	return fh, nil
 }
 
+// PeerInfo returns info that was received from the peer in the handshake.
+func (fh *Host) PeerInfo(p Peer) (*handshake.PeerInfo, bool) {
+	return handshake.GetPeerInfo(fh.Peerstore(), p)
+}
+
+// SupportsProtocol returns true if peer is expected to support the protocol.
+//
+// Peers that use legacy handshake, or didn't advertise protocols, are checked against protocols
+// learned by identify. If nothing is known about the peer it is assumed to support the protocol.
+func (fh *Host) SupportsProtocol(p Peer, protocol string) bool {
+	if info, exists := fh.PeerInfo(p); exists && len(info.Protocols) > 0 {
+		return info.Supports(protocol)
+	}
+	known, err := fh.Peerstore().GetProtocols(p)
+	if err != nil || len(known) == 0 {
+		return true
+	}
+	for _, supported := range known {
+		if supported == protocol {
+			return true
+		}
+	}
+	return false
+}
+
 // Stop background workers and release external resources.
 func (fh *Host) Stop() error {
 	fh.discovery.Stop()
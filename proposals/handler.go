@@ -24,22 +24,23 @@ import (
 )
 
 var (
-	errMalformedData         = errors.New("malformed data")
-	errInitialize            = errors.New("failed to initialize")
+	// errors that wrap pubsub.ErrValidationReject don't depend on the local state.
+	errMalformedData         = fmt.Errorf("%w: malformed data", pubsub.ErrValidationReject)
+	errInitialize            = fmt.Errorf("%w: failed to initialize", pubsub.ErrValidationReject)
 	errInvalidATXID          = errors.New("ballot has invalid ATXID")
-	errMissingEpochData      = errors.New("epoch data is missing in ref ballot")
-	errUnexpectedEpochData   = errors.New("non-ref ballot declares epoch data")
-	errEmptyActiveSet        = errors.New("ref ballot declares empty active set")
-	errMissingBeacon         = errors.New("beacon is missing in ref ballot")
+	errMissingEpochData      = fmt.Errorf("%w: epoch data is missing in ref ballot", pubsub.ErrValidationReject)
+	errUnexpectedEpochData   = fmt.Errorf("%w: non-ref ballot declares epoch data", pubsub.ErrValidationReject)
+	errEmptyActiveSet        = fmt.Errorf("%w: ref ballot declares empty active set", pubsub.ErrValidationReject)
+	errMissingBeacon         = fmt.Errorf("%w: beacon is missing in ref ballot", pubsub.ErrValidationReject)
 	errNotEligible           = errors.New("ballot not eligible")
 	errDoubleVoting          = errors.New("ballot doubly-voted in same layer")
-	errConflictingExceptions = errors.New("conflicting exceptions")
-	errExceptionsOverflow    = errors.New("too many exceptions")
-	errDuplicateTX           = errors.New("duplicate TxID in proposal")
-	errDuplicateATX          = errors.New("duplicate ATXID in active set")
+	errConflictingExceptions = fmt.Errorf("%w: conflicting exceptions", pubsub.ErrValidationReject)
+	errExceptionsOverflow    = fmt.Errorf("%w: too many exceptions", pubsub.ErrValidationReject)
+	errDuplicateTX           = fmt.Errorf("%w: duplicate TxID in proposal", pubsub.ErrValidationReject)
+	errDuplicateATX          = fmt.Errorf("%w: duplicate ATXID in active set", pubsub.ErrValidationReject)
 	errKnownProposal         = errors.New("known proposal")
 	errKnownBallot           = errors.New("known ballot")
-	errInvalidVote           = errors.New("invalid layer/height in the vote")
+	errInvalidVote           = fmt.Errorf("%w: invalid layer/height in the vote", pubsub.ErrValidationReject)
 )
 
 // Handler processes Proposal from gossip and, if deems it valid, propagates it to peers.
@@ -48,6 +49,7 @@ type Handler struct {
 	cfg    Config
 
 	cdb       *datastore.CachedDB
+	publisher pubsub.Publisher
 	fetcher   system.Fetcher
 	mesh      meshProvider
 	validator eligibilityValidator
@@ -95,14 +97,15 @@ func WithConfig(cfg Config) Opt {
 }
 
 // NewHandler creates new Handler.
-func NewHandler(cdb *datastore.CachedDB, f system.Fetcher, bc system.BeaconCollector, m meshProvider, decoder ballotDecoder, opts ...Opt) *Handler {
+func NewHandler(cdb *datastore.CachedDB, p pubsub.Publisher, f system.Fetcher, bc system.BeaconCollector, m meshProvider, decoder ballotDecoder, opts ...Opt) *Handler {
 	b := &Handler{
-		logger:  log.NewNop(),
-		cfg:     defaultConfig(),
-		cdb:     cdb,
-		fetcher: f,
-		mesh:    m,
-		decoder: decoder,
+		logger:    log.NewNop(),
+		cfg:       defaultConfig(),
+		cdb:       cdb,
+		publisher: p,
+		fetcher:   f,
+		mesh:      m,
+		decoder:   decoder,
 	}
 	for _, opt := range opts {
 		opt(b)
@@ -282,13 +285,17 @@ func (h *Handler) processBallot(ctx context.Context, logger log.Log, b *types.Ba
 	}
 
 	t1 := time.Now()
-	if err := h.mesh.AddBallot(ctx, b); err != nil {
+	proof, err := h.mesh.AddBallot(ctx, b)
+	if err != nil {
 		if errors.Is(err, sql.ErrObjectExists) {
 			return fmt.Errorf("%w: ballot %s", errKnownBallot, b.ID())
 		}
 		return fmt.Errorf("save ballot: %w", err)
 	}
 	ballotDuration.WithLabelValues(dbSave).Observe(float64(time.Since(t1)))
+	if proof != nil {
+		h.publishProof(ctx, logger, proof)
+	}
 	if err := h.decoder.StoreBallot(decoded); err != nil {
 		return fmt.Errorf("store decoded ballot %s: %w", decoded.ID(), err)
 	}
@@ -296,6 +303,16 @@ func (h *Handler) processBallot(ctx context.Context, logger log.Log, b *types.Ba
 	return nil
 }
 
+func (h *Handler) publishProof(ctx context.Context, logger log.Log, proof *types.MalfeasanceProof) {
+	encoded, err := codec.Encode(proof)
+	if err != nil {
+		logger.With().Fatal("failed to encode malfeasance proof", log.Err(err))
+	}
+	if err := h.publisher.Publish(ctx, pubsub.MalfeasanceProtocol, encoded); err != nil {
+		logger.With().Error("failed to broadcast malfeasance proof", log.Err(err))
+	}
+}
+
 func (h *Handler) checkBallotSyntacticValidity(ctx context.Context, logger log.Log, b *types.Ballot) (*tortoise.DecodedBallot, error) {
 	logger.With().Debug("checking proposal syntactic validity")
 
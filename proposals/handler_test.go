@@ -17,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
 	"github.com/spacemeshos/go-spacemesh/proposals/mocks"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -43,6 +44,7 @@ type mockSet struct {
 	mv   *mocks.MockeligibilityValidator
 	md   *mocks.MockballotDecoder
 	mvrf *mocks.MockvrfVerifier
+	mpub *pubsubmocks.MockPublisher
 }
 
 func (ms *mockSet) decodeAnyBallots() *mockSet {
@@ -65,6 +67,7 @@ func fullMockSet(tb testing.TB) *mockSet {
 		mv:   mocks.NewMockeligibilityValidator(ctrl),
 		md:   mocks.NewMockballotDecoder(ctrl),
 		mvrf: mocks.NewMockvrfVerifier(ctrl),
+		mpub: pubsubmocks.NewMockPublisher(ctrl),
 	}
 }
 
@@ -72,7 +75,7 @@ func createTestHandler(t *testing.T) *testHandler {
 	types.SetLayersPerEpoch(layersPerEpoch)
 	ms := fullMockSet(t)
 	return &testHandler{
-		Handler: NewHandler(datastore.NewCachedDB(sql.InMemory(), logtest.New(t)), ms.mf, ms.mbc, ms.mm, ms.md,
+		Handler: NewHandler(datastore.NewCachedDB(sql.InMemory(), logtest.New(t)), ms.mpub, ms.mf, ms.mbc, ms.mm, ms.md,
 			WithLogger(logtest.New(t)),
 			WithConfig(Config{
 				LayerSize:      layerAvgSize,
@@ -389,7 +392,45 @@ func TestBallot_BallotDoubleVotedOutsideHdist(t *testing.T) {
 			require.Equal(t, b.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil)
+	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil, nil)
+	require.NoError(t, th.HandleSyncedBallot(context.Background(), data))
+}
+
+func TestBallot_MalfeasanceProofPublished(t *testing.T) {
+	th := createTestHandlerNoopDecoder(t)
+	lid := types.NewLayerID(100)
+	supported := []*types.Block{
+		types.NewExistingBlock(types.BlockID{1}, types.InnerBlock{LayerIndex: lid.Sub(1)}),
+	}
+	b := createBallot(t,
+		withLayer(lid),
+		withSupportBlocks(supported...),
+	)
+	for _, blk := range supported {
+		require.NoError(t, blocks.Add(th.cdb, blk))
+	}
+	data := encodeBallot(t, b)
+	proof := &types.MalfeasanceProof{
+		Layer: b.LayerIndex,
+		MultipleBallots: &types.BallotProof{
+			Messages: [2]types.Ballot{*b, *b},
+		},
+	}
+
+	th.mf.EXPECT().AddPeersFromHash(b.ID().AsHash32(), collectHashes(*b))
+	th.mf.EXPECT().GetBallots(gomock.Any(), []types.BallotID{b.Votes.Base, b.RefBallot}).Return(nil).Times(1)
+	th.mf.EXPECT().GetAtxs(gomock.Any(), types.ATXIDList{b.AtxID}).Return(nil).Times(1)
+	th.mf.EXPECT().GetBlocks(gomock.Any(), types.ToBlockIDs(supported)).Return(nil).Times(1)
+	th.mv.EXPECT().CheckEligibility(gomock.Any(), gomock.Any()).Return(true, nil)
+	th.mm.EXPECT().AddBallot(context.Background(), b).Return(proof, nil)
+	th.mpub.EXPECT().Publish(gomock.Any(), pubsub.MalfeasanceProtocol, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, msg []byte) error {
+			var got types.MalfeasanceProof
+			require.NoError(t, codec.Decode(msg, &got))
+			require.Equal(t, proof.Layer, got.Layer)
+			require.Equal(t, types.MultipleBallots, got.Offense())
+			return nil
+		})
 	require.NoError(t, th.HandleSyncedBallot(context.Background(), data))
 }
 
@@ -654,7 +695,7 @@ func TestBallot_Success(t *testing.T) {
 			require.Equal(t, b.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil)
+	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil, nil)
 	decoded := &tortoise.DecodedBallot{Ballot: b}
 	th.md.EXPECT().DecodeBallot(b).Return(decoded, nil)
 	th.md.EXPECT().StoreBallot(decoded).Return(nil)
@@ -688,7 +729,7 @@ func TestBallot_RefBallot(t *testing.T) {
 			require.Equal(t, b.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil)
+	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil, nil)
 	require.NoError(t, th.HandleSyncedBallot(context.Background(), data))
 }
 
@@ -727,7 +768,7 @@ func TestBallot_DecodedStoreFailure(t *testing.T) {
 
 	decoded := &tortoise.DecodedBallot{Ballot: b}
 	th.md.EXPECT().DecodeBallot(b).Return(decoded, nil)
-	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil)
+	th.mm.EXPECT().AddBallot(context.Background(), b).Return(nil, nil)
 	th.md.EXPECT().StoreBallot(decoded).Return(expected)
 	require.ErrorIs(t, th.HandleSyncedBallot(context.Background(), data), expected)
 }
@@ -789,9 +830,9 @@ func TestProposal_DuplicateTXs(t *testing.T) {
 			return true, nil
 		})
 	th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).DoAndReturn(
-		func(_ context.Context, got *types.Ballot) error {
+		func(_ context.Context, got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
 	require.ErrorIs(t, th.HandleSyncedProposal(context.Background(), data), errDuplicateTX)
@@ -823,9 +864,9 @@ func TestProposal_TXsNotAvailable(t *testing.T) {
 			return true, nil
 		})
 	th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).DoAndReturn(
-		func(_ context.Context, got *types.Ballot) error {
+		func(_ context.Context, got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 
 	errUnknown := errors.New("unknown")
@@ -858,9 +899,9 @@ func TestProposal_FailedToAddProposalTXs(t *testing.T) {
 			return true, nil
 		})
 	th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).DoAndReturn(
-		func(_ context.Context, got *types.Ballot) error {
+		func(_ context.Context, got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).Times(1)
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
@@ -896,9 +937,9 @@ func TestProposal_ProposalGossip_Concurrent(t *testing.T) {
 			return true, nil
 		}).MinTimes(1).MaxTimes(2)
 	th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).DoAndReturn(
-		func(_ context.Context, got *types.Ballot) error {
+		func(_ context.Context, got *types.Ballot) (*types.MalfeasanceProof, error) {
 			_ = ballots.Add(th.cdb, got)
-			return nil
+			return nil, nil
 		}).MinTimes(1).MaxTimes(2)
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).MinTimes(1).MaxTimes(2)
 	th.mm.EXPECT().AddTXsFromProposal(gomock.Any(), p.LayerIndex, p.ID(), p.TxIDs).Return(nil).Times(1)
@@ -972,7 +1013,7 @@ func TestProposal_ProposalGossip_Fetched(t *testing.T) {
 					}
 					return true, nil
 				})
-			th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).Return(nil).Return(nil)
+			th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).Return(nil, nil)
 			th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil)
 			if tc.propFetched {
 				require.Equal(t, pubsub.ValidationIgnore, th.HandleProposal(context.Background(), p2p.NoPeer, data))
@@ -1009,9 +1050,9 @@ func TestProposal_ValidProposal(t *testing.T) {
 			return true, nil
 		})
 	th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).DoAndReturn(
-		func(_ context.Context, got *types.Ballot) error {
+		func(_ context.Context, got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).Times(1)
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
@@ -1044,9 +1085,9 @@ func TestMetrics(t *testing.T) {
 			return true, nil
 		})
 	th.mm.EXPECT().AddBallot(context.Background(), &p.Ballot).DoAndReturn(
-		func(_ context.Context, got *types.Ballot) error {
+		func(_ context.Context, got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).Times(1)
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
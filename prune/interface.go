@@ -0,0 +1,14 @@
+package prune
+
+import "github.com/spacemeshos/go-spacemesh/common/types"
+
+//go:generate mockgen -package=prune -destination=./mocks.go -source=./interface.go
+
+type layerClock interface {
+	AwaitLayer(layerID types.LayerID) chan struct{}
+	GetCurrentLayer() types.LayerID
+}
+
+type verifier interface {
+	LatestComplete() types.LayerID
+}
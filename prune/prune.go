@@ -0,0 +1,250 @@
+// Package prune removes historical data that is no longer needed by the consensus
+// protocols from the state database.
+//
+// Ballots, proposals and transaction references from proposals and blocks
+// are deleted once they fall behind the verified layer by more than the retention
+// window. Nodes of the state trie that are used only by the states of such layers
+// are deleted too. Blocks, layer results, accounts and rewards are kept.
+package prune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/proposals"
+	"github.com/spacemeshos/go-spacemesh/sql/statetrie"
+	"github.com/spacemeshos/go-spacemesh/sql/transactions"
+)
+
+const (
+	// layersPerBatch is the maximal number of layers that are pruned in one transaction.
+	layersPerBatch = 100
+	// nodesPerBatch is the maximal number of trie nodes that are checked in one transaction.
+	nodesPerBatch = 10000
+)
+
+// Config for Pruner.
+type Config struct {
+	// Retention is the number of layers behind the verified layer that are kept.
+	// Pruning is disabled if it is 0.
+	Retention uint32 `mapstructure:"prune-retention"`
+}
+
+// DefaultConfig for Pruner.
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// Opt for configuring Pruner.
+type Opt func(*Pruner)
+
+// WithContext modifies parent context for Pruner.
+func WithContext(ctx context.Context) Opt {
+	return func(p *Pruner) {
+		p.ctx = ctx
+	}
+}
+
+// WithConfig defines cfg for Pruner.
+func WithConfig(cfg Config) Opt {
+	return func(p *Pruner) {
+		p.cfg = cfg
+	}
+}
+
+// WithLogger defines logger for Pruner.
+func WithLogger(logger log.Log) Opt {
+	return func(p *Pruner) {
+		p.logger = logger
+	}
+}
+
+// Pruner periodically deletes data that is older than the retention window.
+type Pruner struct {
+	logger log.Log
+	cfg    Config
+	once   sync.Once
+	eg     errgroup.Group
+	ctx    context.Context
+	cancel func()
+
+	db         *sql.Database
+	layerClock layerClock
+	verifier   verifier
+}
+
+// New creates Pruner.
+func New(db *sql.Database, lc layerClock, v verifier, opts ...Opt) *Pruner {
+	p := &Pruner{
+		logger:     log.NewNop(),
+		cfg:        DefaultConfig(),
+		ctx:        context.Background(),
+		db:         db,
+		layerClock: lc,
+		verifier:   v,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.ctx, p.cancel = context.WithCancel(p.ctx)
+	return p
+}
+
+// Start the background goroutine for pruning. Does nothing if pruning is disabled.
+func (p *Pruner) Start() {
+	if p.cfg.Retention == 0 {
+		return
+	}
+	p.once.Do(func() {
+		p.eg.Go(func() error {
+			return p.run()
+		})
+	})
+}
+
+// Stop the background goroutine.
+func (p *Pruner) Stop() {
+	p.cancel()
+	err := p.eg.Wait()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		p.logger.With().Error("pruner task failure", log.Err(err))
+	}
+}
+
+func (p *Pruner) run() error {
+	for layer := p.layerClock.GetCurrentLayer(); ; layer = layer.Add(1) {
+		select {
+		case <-p.layerClock.AwaitLayer(layer):
+			if err := p.Prune(p.ctx); err != nil {
+				p.logger.With().Warning("failed to prune", layer, log.Err(err))
+			}
+		case <-p.ctx.Done():
+			return fmt.Errorf("context done: %w", p.ctx.Err())
+		}
+	}
+}
+
+// Prune deletes data below the retention window and advances the watermark.
+// Data is deleted in batches of layers, so that the database isn't locked for long,
+// and the watermark is advanced after every batch.
+func (p *Pruner) Prune(ctx context.Context) error {
+	verified := p.verifier.LatestComplete()
+	if verified.Uint32() <= p.cfg.Retention {
+		return nil
+	}
+	cutoff := verified.Sub(p.cfg.Retention)
+	if !cutoff.After(types.GetEffectiveGenesis()) {
+		return nil
+	}
+	pruned, err := kvstore.GetPrunedBelow(p.db)
+	if err != nil {
+		return err
+	}
+	if !cutoff.After(pruned) {
+		return nil
+	}
+	for below := pruned; below.Before(cutoff); {
+		below = below.Add(layersPerBatch)
+		if below.After(cutoff) {
+			below = cutoff
+		}
+		if err := p.db.WithTx(ctx, func(tx *sql.Tx) error {
+			if err := ballots.DeleteBefore(tx, below); err != nil {
+				return err
+			}
+			if err := proposals.DeleteBefore(tx, below); err != nil {
+				return err
+			}
+			if err := transactions.DeleteProposalTXsBefore(tx, below); err != nil {
+				return err
+			}
+			if err := transactions.DeleteBlockTXsBefore(tx, below); err != nil {
+				return err
+			}
+			return kvstore.SetPrunedBelow(tx, below)
+		}); err != nil {
+			return fmt.Errorf("prune below %s: %w", below, err)
+		}
+	}
+	// every node of the state is visited to find nodes that are not used by the retained layers,
+	// therefore the state is pruned once per epoch.
+	deleted := 0
+	if pruned == (types.LayerID{}) || cutoff.GetEpoch() > pruned.GetEpoch() {
+		if deleted, err = p.pruneState(ctx, cutoff); err != nil {
+			return fmt.Errorf("prune state below %s: %w", cutoff, err)
+		}
+	}
+	p.logger.With().Info("pruned historical data",
+		log.Stringer("below", cutoff),
+		log.Stringer("verified", verified),
+		log.Int("trie_nodes", deleted),
+	)
+	return nil
+}
+
+// pruneState deletes trie nodes that are not reachable from the state roots of the layers
+// starting from the cutoff or from the last applied layer.
+//
+// Roots are loaded again in every batch, so that nodes of the layers that were applied
+// after pruning started are kept.
+func (p *Pruner) pruneState(ctx context.Context, cutoff types.LayerID) (int, error) {
+	var (
+		reachable = map[types.Hash32]struct{}{}
+		deleted   int
+		last      types.Hash32
+	)
+	for {
+		var hashes []types.Hash32
+		if err := p.db.WithTx(ctx, func(tx *sql.Tx) error {
+			applied, err := layers.GetLastApplied(tx)
+			if err != nil {
+				return err
+			}
+			from := cutoff
+			if applied.Before(from) {
+				from = applied
+			}
+			roots, err := layers.GetStateHashesFrom(tx, from)
+			if err != nil {
+				return err
+			}
+			if len(roots) == 0 {
+				return nil
+			}
+			if err := trie.Mark(tx, reachable, roots...); err != nil {
+				return err
+			}
+			hashes, err = statetrie.Hashes(tx, last, nodesPerBatch)
+			if err != nil {
+				return err
+			}
+			for _, hash := range hashes {
+				if _, exists := reachable[hash]; exists {
+					continue
+				}
+				if err := statetrie.Delete(tx, hash); err != nil {
+					return err
+				}
+				deleted++
+			}
+			return nil
+		}); err != nil {
+			return deleted, err
+		}
+		if len(hashes) < nodesPerBatch {
+			return deleted, nil
+		}
+		last = hashes[len(hashes)-1]
+	}
+}
@@ -0,0 +1,117 @@
+package prune
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/transactions"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+	res := m.Run()
+	os.Exit(res)
+}
+
+func addLayer(tb testing.TB, db sql.Executor, lid types.LayerID) {
+	tb.Helper()
+	ballot := types.NewExistingBallot(types.BallotID{byte(lid.Uint32())}, nil, types.NodeID{1},
+		types.InnerBallot{LayerIndex: lid})
+	require.NoError(tb, ballots.Add(db, &ballot))
+	tx := types.Transaction{RawTx: types.NewRawTx([]byte{byte(lid.Uint32())})}
+	require.NoError(tb, transactions.Add(db, &tx, time.Now()))
+	require.NoError(tb, transactions.AddToBlock(db, tx.ID, lid, types.BlockID{byte(lid.Uint32())}))
+}
+
+func TestPrune(t *testing.T) {
+	db := sql.InMemory()
+	ctrl := gomock.NewController(t)
+	verifier := NewMockverifier(ctrl)
+	p := New(db, NewMocklayerClock(ctrl), verifier, WithConfig(Config{Retention: 5}))
+
+	for lid := types.NewLayerID(1); !lid.After(types.NewLayerID(20)); lid = lid.Add(1) {
+		addLayer(t, db, lid)
+	}
+
+	verifier.EXPECT().LatestComplete().Return(types.NewLayerID(4))
+	require.NoError(t, p.Prune(context.Background()))
+	pruned, err := kvstore.GetPrunedBelow(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID{}, pruned)
+
+	verifier.EXPECT().LatestComplete().Return(types.NewLayerID(15))
+	require.NoError(t, p.Prune(context.Background()))
+	pruned, err = kvstore.GetPrunedBelow(db)
+	require.NoError(t, err)
+	require.Equal(t, types.NewLayerID(10), pruned)
+
+	for lid := types.NewLayerID(1); !lid.After(types.NewLayerID(20)); lid = lid.Add(1) {
+		has, err := ballots.Has(db, types.BallotID{byte(lid.Uint32())})
+		require.NoError(t, err)
+		require.Equal(t, !lid.Before(pruned), has, "layer %s", lid)
+		tid := types.TransactionID(types.CalcHash32([]byte{byte(lid.Uint32())}))
+		has, err = transactions.HasBlockTX(db, types.BlockID{byte(lid.Uint32())}, tid)
+		require.NoError(t, err)
+		require.Equal(t, !lid.Before(pruned), has, "layer %s", lid)
+	}
+
+	// watermark never goes backwards
+	verifier.EXPECT().LatestComplete().Return(types.NewLayerID(12))
+	require.NoError(t, p.Prune(context.Background()))
+	pruned, err = kvstore.GetPrunedBelow(db)
+	require.NoError(t, err)
+	require.Equal(t, types.NewLayerID(10), pruned)
+}
+
+func TestPruneState(t *testing.T) {
+	db := sql.InMemory()
+	ctrl := gomock.NewController(t)
+	verifier := NewMockverifier(ctrl)
+	p := New(db, NewMocklayerClock(ctrl), verifier, WithConfig(Config{Retention: 5}))
+
+	account := &types.Account{Address: types.Address{1}}
+	other := &types.Account{Address: types.Address{2}, Balance: 100}
+	root, err := trie.Update(db, types.Hash32{}, []*types.Account{other})
+	require.NoError(t, err)
+	roots := map[types.LayerID]types.Hash32{}
+	for lid := types.NewLayerID(1); !lid.After(types.NewLayerID(20)); lid = lid.Add(1) {
+		account.Balance = uint64(lid.Uint32())
+		root, err = trie.Update(db, root, []*types.Account{account})
+		require.NoError(t, err)
+		roots[lid] = root
+		require.NoError(t, layers.SetApplied(db, lid, types.EmptyBlockID))
+		require.NoError(t, layers.UpdateStateHash(db, lid, root))
+	}
+
+	verifier.EXPECT().LatestComplete().Return(types.NewLayerID(15))
+	require.NoError(t, p.Prune(context.Background()))
+	for lid, root := range roots {
+		exists, err := trie.Has(db, root)
+		require.NoError(t, err)
+		require.Equal(t, !lid.Before(types.NewLayerID(10)), exists, "layer %s", lid)
+		if exists {
+			_, proof, err := trie.Prove(db, root, other.Address)
+			require.NoError(t, err)
+			require.True(t, proof.Verify(root, other))
+		}
+	}
+}
+
+func TestPruneDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	p := New(sql.InMemory(), NewMocklayerClock(ctrl), NewMockverifier(ctrl))
+	// no calls to the layer clock are expected
+	p.Start()
+	p.Stop()
+}
@@ -67,8 +67,27 @@ func Get(db sql.Executor, address types.Address, layer types.LayerID) (types.Acc
 
 // All returns all latest accounts.
 func All(db sql.Executor) ([]*types.Account, error) {
+	rst, err := all(db, "select address, balance, initialized, next_nonce, max(layer_updated), template, state from accounts group by address;", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all accounts %w", err)
+	}
+	return rst, nil
+}
+
+// Snapshot returns all accounts as they were at the specified layer.
+func Snapshot(db sql.Executor, layer types.LayerID) ([]*types.Account, error) {
+	rst, err := all(db, "select address, balance, initialized, next_nonce, max(layer_updated), template, state from accounts where layer_updated <= ?1 group by address;", func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(layer.Value))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts for layer %v: %w", layer, err)
+	}
+	return rst, nil
+}
+
+func all(db sql.Executor, query string, enc sql.Encoder) ([]*types.Account, error) {
 	var rst []*types.Account
-	_, err := db.Exec("select address, balance, initialized, next_nonce, max(layer_updated), template, state from accounts group by address;", nil, func(stmt *sql.Statement) bool {
+	_, err := db.Exec(query, enc, func(stmt *sql.Statement) bool {
 		var account types.Account
 		stmt.ColumnBytes(0, account.Address[:])
 		account.Balance = uint64(stmt.ColumnInt64(1))
@@ -86,7 +105,7 @@ func All(db sql.Executor) ([]*types.Account, error) {
 		return true
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load all accounts %w", err)
+		return nil, err
 	}
 	return rst, nil
 }
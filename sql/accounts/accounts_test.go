@@ -77,3 +77,26 @@ func TestAll(t *testing.T) {
 		require.EqualValues(t, n[i], accounts[i].Layer.Value)
 	}
 }
+
+func TestSnapshot(t *testing.T) {
+	db := sql.InMemory()
+	addresses := []types.Address{{1, 1}, {2, 2}, {3, 3}}
+	n := []int{10, 7, 20}
+	for i, address := range addresses {
+		for _, update := range genSeq(address, n[i]) {
+			require.NoError(t, Update(db, update))
+		}
+	}
+
+	accounts, err := Snapshot(db, types.NewLayerID(8))
+	require.NoError(t, err)
+	require.Len(t, accounts, len(addresses))
+	for i, expected := range []uint32{8, 7, 8} {
+		require.Equal(t, addresses[i], accounts[i].Address)
+		require.Equal(t, expected, accounts[i].Layer.Value)
+	}
+
+	accounts, err = Snapshot(db, types.NewLayerID(0))
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
@@ -178,3 +178,14 @@ func LatestLayer(db sql.Executor) (types.LayerID, error) {
 	}
 	return lid, nil
 }
+
+// DeleteBefore deletes ballots in layers before the specified layer.
+func DeleteBefore(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from ballots where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Value))
+		}, nil); err != nil {
+		return fmt.Errorf("delete ballots before %s: %w", lid, err)
+	}
+	return nil
+}
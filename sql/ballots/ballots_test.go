@@ -157,3 +157,18 @@ func TestGetRefBallot(t *testing.T) {
 	_, err = GetRefBallot(db, 1, pub4.Bytes())
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	pub := types.BytesToNodeID([]byte{1, 1})
+	for i := 1; i <= 4; i++ {
+		ballot := types.NewExistingBallot(types.BallotID{byte(i)}, nil, pub, types.InnerBallot{LayerIndex: types.NewLayerID(uint32(i))})
+		require.NoError(t, Add(db, &ballot))
+	}
+	require.NoError(t, DeleteBefore(db, types.NewLayerID(3)))
+	for i := 1; i <= 4; i++ {
+		has, err := Has(db, types.BallotID{byte(i)})
+		require.NoError(t, err)
+		require.Equal(t, i >= 3, has)
+	}
+}
@@ -17,6 +17,8 @@ var (
 	ErrNotFound = errors.New("database: not found")
 	// ErrObjectExists is returned if database constraints didn't allow to insert an object.
 	ErrObjectExists = errors.New("database: object exists")
+	// ErrPruned is returned if requested record was deleted by pruning.
+	ErrPruned = errors.New("database: pruned")
 )
 
 const (
@@ -202,6 +204,31 @@ func (db *Database) Exec(query string, encoder Encoder, decoder Decoder) (int, e
 	return exec(conn, query, encoder, decoder)
 }
 
+// Backup writes a consistent copy of the database to the file at path.
+//
+// Copy is made with sqlite online backup api, database remains
+// available for readers and writers while backup is in progress.
+// https://www.sqlite.org/backup.html
+func (db *Database) Backup(ctx context.Context, path string) error {
+	// pool may return an idle connection even if ctx is done
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	conn := db.pool.Get(ctx)
+	if conn == nil {
+		return ErrNoConnection
+	}
+	defer db.pool.Put(conn)
+	dst, err := conn.BackupToDB("", path)
+	if err != nil {
+		return fmt.Errorf("backup to %s: %w", path, err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("close backup %s: %w", path, err)
+	}
+	return nil
+}
+
 // Close closes all pooled connections.
 func (db *Database) Close() error {
 	if err := db.pool.Close(); err != nil {
@@ -3,13 +3,14 @@ package identities
 import (
 	"fmt"
 
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 // SetMalicious records identity as malicious.
 func SetMalicious(db sql.Executor, pubkey []byte) error {
-	_, err := db.Exec(`insert into identities (pubkey, malicious) 
-	values (?1, 1) 
+	_, err := db.Exec(`insert into identities (pubkey, malicious)
+	values (?1, 1)
 	on conflict do nothing;`,
 		func(stmt *sql.Statement) {
 			stmt.BindBytes(1, pubkey)
@@ -21,6 +22,23 @@ func SetMalicious(db sql.Executor, pubkey []byte) error {
 	return nil
 }
 
+// SetMalfeasanceProof records identity as malicious with the encoded proof.
+// Proof is not overwritten if identity already has one.
+func SetMalfeasanceProof(db sql.Executor, pubkey, proof []byte) error {
+	_, err := db.Exec(`insert into identities (pubkey, malicious, proof)
+	values (?1, 1, ?2)
+	on conflict(pubkey) do update set proof = ?2 where proof is null;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, pubkey)
+			stmt.BindBytes(2, proof)
+		}, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("set malfeasance proof 0x%x: %w", pubkey, err)
+	}
+	return nil
+}
+
 // IsMalicious returns true if identity is known to be malicious.
 func IsMalicious(db sql.Executor, pubkey []byte) (bool, error) {
 	rows, err := db.Exec("select 1 from identities where pubkey = ?1;",
@@ -32,3 +50,37 @@ func IsMalicious(db sql.Executor, pubkey []byte) (bool, error) {
 	}
 	return rows > 0, nil
 }
+
+// GetMalfeasanceProof returns the encoded proof for the identity.
+func GetMalfeasanceProof(db sql.Executor, pubkey []byte) (proof []byte, err error) {
+	rows, err := db.Exec("select proof from identities where pubkey = ?1 and proof is not null;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, pubkey)
+		}, func(stmt *sql.Statement) bool {
+			proof = make([]byte, stmt.ColumnLen(0))
+			stmt.ColumnBytes(0, proof)
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("get malfeasance proof 0x%x: %w", pubkey, err)
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("get malfeasance proof 0x%x: %w", pubkey, sql.ErrNotFound)
+	}
+	return proof, nil
+}
+
+// GetMalicious returns identities that have a malfeasance proof.
+func GetMalicious(db sql.Executor) (ids []types.NodeID, err error) {
+	if _, err = db.Exec("select pubkey from identities where proof is not null;",
+		nil,
+		func(stmt *sql.Statement) bool {
+			var id types.NodeID
+			stmt.ColumnBytes(0, id[:])
+			ids = append(ids, id)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("get malicious: %w", err)
+	}
+	return ids, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -21,3 +22,32 @@ func TestMalicious(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, mal)
 }
+
+func TestMalfeasanceProof(t *testing.T) {
+	db := sql.InMemory()
+
+	legacy := types.NodeID{1}
+	require.NoError(t, SetMalicious(db, legacy.Bytes()))
+	_, err := GetMalfeasanceProof(db, legacy.Bytes())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	proven := types.NodeID{2}
+	require.NoError(t, SetMalfeasanceProof(db, proven.Bytes(), []byte("first")))
+	mal, err := IsMalicious(db, proven.Bytes())
+	require.NoError(t, err)
+	require.True(t, mal)
+
+	require.NoError(t, SetMalfeasanceProof(db, proven.Bytes(), []byte("second")))
+	proof, err := GetMalfeasanceProof(db, proven.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), proof, "proof is not overwritten")
+
+	require.NoError(t, SetMalfeasanceProof(db, legacy.Bytes(), []byte("legacy")))
+	proof, err = GetMalfeasanceProof(db, legacy.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []byte("legacy"), proof)
+
+	ids, err := GetMalicious(db)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []types.NodeID{legacy, proven}, ids)
+}
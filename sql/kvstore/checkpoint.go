@@ -0,0 +1,39 @@
+package kvstore
+
+import (
+	"errors"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+const (
+	importedLayerKey   = "importedCheckpointLayer"
+	importedOpinionKey = "importedCheckpointOpinion"
+)
+
+// SetImportedCheckpoint records the layer and the opinion of the checkpoint that was imported into the database.
+func SetImportedCheckpoint(db sql.Executor, lid types.LayerID, opinion types.Hash32) error {
+	if err := addKeyValue(db, importedLayerKey, &lid); err != nil {
+		return err
+	}
+	return addKeyValue(db, importedOpinionKey, &opinion)
+}
+
+// GetImportedCheckpoint returns the layer and the opinion of the imported checkpoint.
+// Zero layer is returned if the database wasn't seeded from a checkpoint.
+func GetImportedCheckpoint(db sql.Executor) (types.LayerID, types.Hash32, error) {
+	var (
+		lid     types.LayerID
+		opinion types.Hash32
+	)
+	if err := getKeyValue(db, importedLayerKey, &lid); errors.Is(err, sql.ErrNotFound) {
+		return types.LayerID{}, types.Hash32{}, nil
+	} else if err != nil {
+		return types.LayerID{}, types.Hash32{}, err
+	}
+	if err := getKeyValue(db, importedOpinionKey, &opinion); err != nil {
+		return types.LayerID{}, types.Hash32{}, err
+	}
+	return lid, opinion, nil
+}
@@ -0,0 +1,26 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestImportedCheckpoint(t *testing.T) {
+	db := sql.InMemory()
+
+	lid, opinion, err := GetImportedCheckpoint(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID{}, lid)
+	require.Equal(t, types.Hash32{}, opinion)
+
+	expected := types.RandomHash()
+	require.NoError(t, SetImportedCheckpoint(db, types.NewLayerID(10), expected))
+	lid, opinion, err = GetImportedCheckpoint(db)
+	require.NoError(t, err)
+	require.Equal(t, types.NewLayerID(10), lid)
+	require.Equal(t, expected, opinion)
+}
@@ -1,27 +1,33 @@
 package kvstore
 
 import (
+	"fmt"
+
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 const nipostBuilderStateKey = "NIPostBuilderState"
 
-// AddNIPostBuilderState adds the data for nipost builder state to the key-value store.
-func AddNIPostBuilderState(db sql.Executor, state *types.NIPostBuilderState) error {
-	return addKeyValue(db, nipostBuilderStateKey, state)
+func nipostBuilderStateKeyForNode(nodeID types.NodeID) string {
+	return fmt.Sprintf("%s-%s", nipostBuilderStateKey, nodeID)
+}
+
+// AddNIPostBuilderState adds the data for nipost builder state of the node to the key-value store.
+func AddNIPostBuilderState(db sql.Executor, nodeID types.NodeID, state *types.NIPostBuilderState) error {
+	return addKeyValue(db, nipostBuilderStateKeyForNode(nodeID), state)
 }
 
-// GetNIPostBuilderState returns the data for nipost builder state from the key-value store.
-func GetNIPostBuilderState(db sql.Executor) (*types.NIPostBuilderState, error) {
+// GetNIPostBuilderState returns the data for nipost builder state of the node from the key-value store.
+func GetNIPostBuilderState(db sql.Executor, nodeID types.NodeID) (*types.NIPostBuilderState, error) {
 	res := &types.NIPostBuilderState{}
-	if err := getKeyValue(db, nipostBuilderStateKey, res); err != nil {
+	if err := getKeyValue(db, nipostBuilderStateKeyForNode(nodeID), res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-// ClearNIPostBuilderState clears the data for nipost builder state from the key-value store.
-func ClearNIPostBuilderState(db sql.Executor) error {
-	return clearKeyValue(db, nipostBuilderStateKey)
+// ClearNIPostBuilderState clears the data for nipost builder state of the node from the key-value store.
+func ClearNIPostBuilderState(db sql.Executor, nodeID types.NodeID) error {
+	return clearKeyValue(db, nipostBuilderStateKeyForNode(nodeID))
 }
@@ -12,6 +12,7 @@ import (
 func TestAddNIPostBuilderState(t *testing.T) {
 	// Arrange
 	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
 	state := &types.NIPostBuilderState{
 		PoetRequests: []types.PoetRequest{
 			{PoetRound: &types.PoetRound{ID: "asdf"}},
@@ -19,10 +20,10 @@ func TestAddNIPostBuilderState(t *testing.T) {
 	}
 
 	// Act
-	require.NoError(t, AddNIPostBuilderState(db, state))
+	require.NoError(t, AddNIPostBuilderState(db, nodeID, state))
 
 	// Assert
-	got, err := GetNIPostBuilderState(db)
+	got, err := GetNIPostBuilderState(db, nodeID)
 	require.NoError(t, err)
 	require.Equal(t, state, got)
 }
@@ -30,6 +31,7 @@ func TestAddNIPostBuilderState(t *testing.T) {
 func TestOverwriteNIPostBuilderState(t *testing.T) {
 	// Arrange
 	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
 	state := &types.NIPostBuilderState{
 		PoetRequests: []types.PoetRequest{
 			{PoetRound: &types.PoetRound{ID: "asdf"}},
@@ -42,11 +44,11 @@ func TestOverwriteNIPostBuilderState(t *testing.T) {
 	}
 
 	// Act
-	require.NoError(t, AddNIPostBuilderState(db, state))
-	require.NoError(t, AddNIPostBuilderState(db, newState))
+	require.NoError(t, AddNIPostBuilderState(db, nodeID, state))
+	require.NoError(t, AddNIPostBuilderState(db, nodeID, newState))
 
 	// Assert
-	got, err := GetNIPostBuilderState(db)
+	got, err := GetNIPostBuilderState(db, nodeID)
 	require.NoError(t, err)
 	require.Equal(t, newState, got)
 }
@@ -54,18 +56,49 @@ func TestOverwriteNIPostBuilderState(t *testing.T) {
 func TestClearNIPostBuilderState(t *testing.T) {
 	// Arrange
 	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
 	state := &types.NIPostBuilderState{
 		PoetRequests: []types.PoetRequest{
 			{PoetRound: &types.PoetRound{ID: "asdf"}},
 		},
 	}
-	require.NoError(t, AddNIPostBuilderState(db, state))
+	require.NoError(t, AddNIPostBuilderState(db, nodeID, state))
 
 	// Act
-	require.NoError(t, ClearNIPostBuilderState(db))
+	require.NoError(t, ClearNIPostBuilderState(db, nodeID))
 
 	// Assert
-	got, err := GetNIPostBuilderState(db)
+	got, err := GetNIPostBuilderState(db, nodeID)
 	require.ErrorIs(t, err, sql.ErrNotFound)
 	require.Nil(t, got)
 }
+
+func TestNIPostBuilderStateForOtherNodeID(t *testing.T) {
+	// Arrange
+	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
+	nodeID2 := types.NodeID{0x0, 0x2}
+	state := &types.NIPostBuilderState{
+		PoetRequests: []types.PoetRequest{
+			{PoetRound: &types.PoetRound{ID: "asdf"}},
+		},
+	}
+	state2 := &types.NIPostBuilderState{
+		PoetRequests: []types.PoetRequest{
+			{PoetRound: &types.PoetRound{ID: "1234"}},
+		},
+	}
+
+	// Act
+	require.NoError(t, AddNIPostBuilderState(db, nodeID, state))
+	require.NoError(t, AddNIPostBuilderState(db, nodeID2, state2))
+
+	// Assert
+	got, err := GetNIPostBuilderState(db, nodeID)
+	require.NoError(t, err)
+	require.Equal(t, state, got)
+
+	got, err = GetNIPostBuilderState(db, nodeID2)
+	require.NoError(t, err)
+	require.Equal(t, state2, got)
+}
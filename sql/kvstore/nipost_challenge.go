@@ -1,27 +1,33 @@
 package kvstore
 
 import (
+	"fmt"
+
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 const nipostChallengeKey = "NIPost"
 
-// AddNIPostChallenge adds the data for nipost to the key-value store.
-func AddNIPostChallenge(db sql.Executor, ch *types.NIPostChallenge) error {
-	return addKeyValue(db, nipostChallengeKey, ch)
+func nipostChallengeKeyForNode(nodeID types.NodeID) string {
+	return fmt.Sprintf("%s-%s", nipostChallengeKey, nodeID)
+}
+
+// AddNIPostChallenge adds the data for nipost of the node to the key-value store.
+func AddNIPostChallenge(db sql.Executor, nodeID types.NodeID, ch *types.NIPostChallenge) error {
+	return addKeyValue(db, nipostChallengeKeyForNode(nodeID), ch)
 }
 
-// GetNIPostChallenge returns the data for nipost from the key-value store.
-func GetNIPostChallenge(db sql.Executor) (*types.NIPostChallenge, error) {
+// GetNIPostChallenge returns the data for nipost of the node from the key-value store.
+func GetNIPostChallenge(db sql.Executor, nodeID types.NodeID) (*types.NIPostChallenge, error) {
 	res := &types.NIPostChallenge{}
-	if err := getKeyValue(db, nipostChallengeKey, res); err != nil {
+	if err := getKeyValue(db, nipostChallengeKeyForNode(nodeID), res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-// ClearNIPostChallenge clears the data for nipost from the key-value store.
-func ClearNIPostChallenge(db sql.Executor) error {
-	return clearKeyValue(db, nipostChallengeKey)
+// ClearNIPostChallenge clears the data for nipost of the node from the key-value store.
+func ClearNIPostChallenge(db sql.Executor, nodeID types.NodeID) error {
+	return clearKeyValue(db, nipostChallengeKeyForNode(nodeID))
 }
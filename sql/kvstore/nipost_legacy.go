@@ -0,0 +1,33 @@
+package kvstore
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// MigrateLegacyNIPost moves the nipost challenge and builder state that were stored
+// under the keys shared by the whole node to the keys of the node identity.
+// Values that are already stored for the identity are not overwritten, legacy values are deleted.
+// It must be called with the primary identity of the node, which used the legacy keys.
+func MigrateLegacyNIPost(db sql.Executor, nodeID types.NodeID) error {
+	for legacy, key := range map[string]string{
+		nipostChallengeKey:    nipostChallengeKeyForNode(nodeID),
+		nipostBuilderStateKey: nipostBuilderStateKeyForNode(nodeID),
+	} {
+		if _, err := db.Exec(`
+			update kvstore set id = ?2 where id = ?1
+			and not exists (select 1 from kvstore where id = ?2);`,
+			func(stmt *sql.Statement) {
+				stmt.BindBytes(1, []byte(legacy))
+				stmt.BindBytes(2, []byte(key))
+			}, nil); err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", legacy, err)
+		}
+		if err := clearKeyValue(db, legacy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestMigrateLegacyNIPost(t *testing.T) {
+	db := sql.InMemory()
+	primary := types.NodeID{0x0, 0x1}
+	other := types.NodeID{0x0, 0x2}
+	challenge := &types.NIPostChallenge{
+		Sequence:       1,
+		PositioningATX: types.RandomATXID(),
+	}
+	state := &types.NIPostBuilderState{
+		Challenge: types.RandomHash(),
+	}
+	require.NoError(t, addKeyValue(db, nipostChallengeKey, challenge))
+	require.NoError(t, addKeyValue(db, nipostBuilderStateKey, state))
+
+	require.NoError(t, MigrateLegacyNIPost(db, primary))
+
+	gotChallenge, err := GetNIPostChallenge(db, primary)
+	require.NoError(t, err)
+	require.Equal(t, challenge, gotChallenge)
+	gotState, err := GetNIPostBuilderState(db, primary)
+	require.NoError(t, err)
+	require.Equal(t, state, gotState)
+
+	_, err = GetNIPostChallenge(db, other)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	_, err = GetNIPostBuilderState(db, other)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	require.ErrorIs(t, getKeyValue(db, nipostChallengeKey, &types.NIPostChallenge{}), sql.ErrNotFound)
+	require.ErrorIs(t, getKeyValue(db, nipostBuilderStateKey, &types.NIPostBuilderState{}), sql.ErrNotFound)
+
+	// migration is a noop once legacy keys are gone
+	require.NoError(t, MigrateLegacyNIPost(db, primary))
+	gotChallenge, err = GetNIPostChallenge(db, primary)
+	require.NoError(t, err)
+	require.Equal(t, challenge, gotChallenge)
+}
+
+func TestMigrateLegacyNIPostKeepsCurrent(t *testing.T) {
+	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
+	legacy := &types.NIPostChallenge{Sequence: 1, PositioningATX: types.RandomATXID()}
+	current := &types.NIPostChallenge{Sequence: 2, PositioningATX: types.RandomATXID()}
+	require.NoError(t, addKeyValue(db, nipostChallengeKey, legacy))
+	require.NoError(t, AddNIPostChallenge(db, nodeID, current))
+
+	require.NoError(t, MigrateLegacyNIPost(db, nodeID))
+
+	got, err := GetNIPostChallenge(db, nodeID)
+	require.NoError(t, err)
+	require.Equal(t, current, got)
+	require.ErrorIs(t, getKeyValue(db, nipostChallengeKey, &types.NIPostChallenge{}), sql.ErrNotFound)
+}
@@ -0,0 +1,25 @@
+package kvstore
+
+import (
+	"errors"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+const prunedBelowKey = "prunedBelow"
+
+// SetPrunedBelow records that historical data in layers before lid was pruned.
+func SetPrunedBelow(db sql.Executor, lid types.LayerID) error {
+	return addKeyValue(db, prunedBelowKey, &lid)
+}
+
+// GetPrunedBelow returns the layer before which historical data was pruned.
+// Zero layer is returned if nothing was pruned.
+func GetPrunedBelow(db sql.Executor) (types.LayerID, error) {
+	var lid types.LayerID
+	if err := getKeyValue(db, prunedBelowKey, &lid); err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return types.LayerID{}, err
+	}
+	return lid, nil
+}
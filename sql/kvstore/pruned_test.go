@@ -0,0 +1,25 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestPrunedBelow(t *testing.T) {
+	db := sql.InMemory()
+
+	got, err := GetPrunedBelow(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID{}, got)
+
+	for _, lid := range []types.LayerID{types.NewLayerID(10), types.NewLayerID(20)} {
+		require.NoError(t, SetPrunedBelow(db, lid))
+		got, err = GetPrunedBelow(db)
+		require.NoError(t, err)
+		require.Equal(t, lid, got)
+	}
+}
@@ -0,0 +1,25 @@
+package kvstore
+
+import (
+	"github.com/spacemeshos/go-scale"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+const tortoiseCheckpointKey = "tortoiseCheckpoint"
+
+// SetTortoiseCheckpoint stores the latest checkpoint of the tortoise state.
+func SetTortoiseCheckpoint(db sql.Executor, checkpoint scale.Encodable) error {
+	return addKeyValue(db, tortoiseCheckpointKey, checkpoint)
+}
+
+// GetTortoiseCheckpoint decodes the latest checkpoint of the tortoise state.
+// sql.ErrNotFound is returned if checkpoint wasn't stored.
+func GetTortoiseCheckpoint(db sql.Executor, checkpoint scale.Decodable) error {
+	return getKeyValue(db, tortoiseCheckpointKey, checkpoint)
+}
+
+// ClearTortoiseCheckpoint removes the checkpoint of the tortoise state.
+func ClearTortoiseCheckpoint(db sql.Executor) error {
+	return clearKeyValue(db, tortoiseCheckpointKey)
+}
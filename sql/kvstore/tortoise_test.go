@@ -0,0 +1,26 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestTortoiseCheckpoint(t *testing.T) {
+	db := sql.InMemory()
+
+	var got types.LayerID
+	require.ErrorIs(t, GetTortoiseCheckpoint(db, &got), sql.ErrNotFound)
+
+	for _, lid := range []types.LayerID{types.NewLayerID(10), types.NewLayerID(20)} {
+		require.NoError(t, SetTortoiseCheckpoint(db, &lid))
+		require.NoError(t, GetTortoiseCheckpoint(db, &got))
+		require.Equal(t, lid, got)
+	}
+
+	require.NoError(t, ClearTortoiseCheckpoint(db))
+	require.ErrorIs(t, GetTortoiseCheckpoint(db, &got), sql.ErrNotFound)
+}
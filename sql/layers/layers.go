@@ -76,6 +76,17 @@ func UnsetAppliedFrom(db sql.Executor, lid types.LayerID) error {
 	return nil
 }
 
+// UnsetStateHashFrom updates the state hash to nil for layer >= `lid`.
+func UnsetStateHashFrom(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("update layers set state_hash = null where id >= ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Value))
+		}, nil); err != nil {
+		return fmt.Errorf("unset state hash %s: %w", lid, err)
+	}
+	return nil
+}
+
 // UpdateStateHash for the layer.
 func UpdateStateHash(db sql.Executor, lid types.LayerID, hash types.Hash32) error {
 	if _, err := db.Exec(`insert into layers (id, state_hash) values (?1, ?2) 
@@ -125,6 +136,23 @@ func GetStateHash(db sql.Executor, lid types.LayerID) (rst types.Hash32, err err
 	return rst, err
 }
 
+// GetStateHashesFrom loads state hashes of the layers starting from lid.
+func GetStateHashesFrom(db sql.Executor, lid types.LayerID) (rst []types.Hash32, err error) {
+	if _, err := db.Exec("select state_hash from layers where id >= ?1 and state_hash is not null;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Value))
+		},
+		func(stmt *sql.Statement) bool {
+			var hash types.Hash32
+			stmt.ColumnBytes(0, hash[:])
+			rst = append(rst, hash)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("failed to load state roots from %v: %w", lid, err)
+	}
+	return rst, nil
+}
+
 // GetApplied for the applied block for layer.
 func GetApplied(db sql.Executor, lid types.LayerID) (rst types.BlockID, err error) {
 	if rows, err := db.Exec("select applied_block from layers where id = ?1;",
@@ -90,10 +90,21 @@ func TestStateHash(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, hashes[1], latest)
 
+	from, err := GetStateHashesFrom(db, types.NewLayerID(9))
+	require.NoError(t, err)
+	require.ElementsMatch(t, hashes[:2], from)
+
 	require.NoError(t, UnsetAppliedFrom(db, types.NewLayerID(layers[1])))
 	latest, err = GetLatestStateHash(db)
 	require.NoError(t, err)
 	require.Equal(t, hashes[0], latest)
+
+	require.NoError(t, UnsetStateHashFrom(db, types.NewLayerID(layers[2])))
+	latest, err = GetLatestStateHash(db)
+	require.NoError(t, err)
+	require.Equal(t, hashes[3], latest)
+	_, err = GetStateHash(db, types.NewLayerID(layers[0]))
+	require.ErrorIs(t, err, sql.ErrNotFound)
 }
 
 func TestSetHashes(t *testing.T) {
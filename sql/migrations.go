@@ -4,83 +4,391 @@ import (
 	"bufio"
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
 	"path"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/hash"
 )
 
 //go:embed migrations/*.sql
 var embedded embed.FS
 
-type migration struct {
-	order   int
-	name    string
-	content *bufio.Scanner
-}
+const downSuffix = ".down.sql"
+
+var (
+	// ErrMigrationTampered is returned if applied migration doesn't match the checksum
+	// of the same migration known to the node.
+	ErrMigrationTampered = errors.New("database: applied migration was modified")
+	// ErrMigrationUnknown is returned if database has applied migrations that are not known to the node.
+	ErrMigrationUnknown = errors.New("database: unknown migration")
+	// ErrNoRollback is returned if migration doesn't have paired down-migration.
+	ErrNoRollback = errors.New("database: migration can't be rolled back")
+)
 
 // Migrations is interface for migrations provider.
 type Migrations func(Executor) error
 
-func embeddedMigrations(db Executor) error {
+// Migration is a single versioned step of the database schema.
+type Migration interface {
+	Order() int
+	Name() string
+	// Checksum of the migration, stored when migration is applied
+	// and compared with the stored value on every start.
+	Checksum() [32]byte
+	Apply(Executor) error
+	// Rollback reverts changes made by Apply. Returns ErrNoRollback if it is not supported.
+	Rollback(Executor) error
+}
+
+type sqlMigration struct {
+	order    int
+	name     string
+	up, down []byte
+}
+
+func (m *sqlMigration) Order() int {
+	return m.order
+}
+
+func (m *sqlMigration) Name() string {
+	return m.name
+}
+
+// Checksum covers only the up script, so that the down script can be added
+// or fixed after the migration was released.
+func (m *sqlMigration) Checksum() [32]byte {
+	return hash.Sum(m.up)
+}
+
+func (m *sqlMigration) Apply(db Executor) error {
+	return execScript(db, m.up)
+}
+
+func (m *sqlMigration) Rollback(db Executor) error {
+	if m.down == nil {
+		return fmt.Errorf("%w: %s", ErrNoRollback, m.name)
+	}
+	return execScript(db, m.down)
+}
+
+func execScript(db Executor, script []byte) error {
+	scanner := bufio.NewScanner(bytes.NewBuffer(script))
+	scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if i := bytes.Index(data, []byte(";")); i >= 0 {
+			return i + 1, data[0 : i+1], nil
+		}
+		return 0, nil, nil
+	})
+	for scanner.Scan() {
+		if _, err := db.Exec(scanner.Text(), nil, nil); err != nil {
+			return fmt.Errorf("exec %s: %w", scanner.Text(), err)
+		}
+	}
+	return nil
+}
+
+type codeMigration struct {
+	order         int
+	name          string
+	apply, revert func(Executor) error
+}
+
+// NewMigration creates a migration that is implemented in go. Use it for data
+// transformations that can't be expressed in sql, such as re-encoding blobs.
+// Revert may be nil if migration can't be rolled back.
+//
+// Checksum of the go migration is computed from its order and name,
+// therefore name must be changed if migration is changed after release.
+func NewMigration(order int, name string, apply, revert func(Executor) error) Migration {
+	return &codeMigration{order: order, name: name, apply: apply, revert: revert}
+}
+
+func (m *codeMigration) Order() int {
+	return m.order
+}
+
+func (m *codeMigration) Name() string {
+	return m.name
+}
+
+func (m *codeMigration) Checksum() [32]byte {
+	return hash.Sum([]byte(strconv.Itoa(m.order)), []byte(m.name))
+}
+
+func (m *codeMigration) Apply(db Executor) error {
+	return m.apply(db)
+}
+
+func (m *codeMigration) Rollback(db Executor) error {
+	if m.revert == nil {
+		return fmt.Errorf("%w: %s", ErrNoRollback, m.name)
+	}
+	return m.revert(db)
+}
+
+// codeMigrations are go migrations that are applied together with embedded sql migrations.
+var codeMigrations []Migration
+
+// RegisterMigration adds go migration to the migrations that are applied together
+// with embedded sql migrations. Order of the migration must be unique among all migrations.
+//
+// It is not safe for concurrent use and is expected to be called from init.
+func RegisterMigration(m Migration) {
+	codeMigrations = append(codeMigrations, m)
+}
+
+// EmbeddedMigrations returns sql migrations embedded into the binary together
+// with go migrations, sorted by order.
+func EmbeddedMigrations() ([]Migration, error) {
 	files, err := embedded.ReadDir("migrations")
 	if err != nil {
-		return fmt.Errorf("readdir migrations: %w", err)
+		return nil, fmt.Errorf("readdir migrations: %w", err)
 	}
-	var migrations []migration
+	byorder := map[int]*sqlMigration{}
 	for _, file := range files {
 		parts := strings.Split(file.Name(), "_")
 		if len(parts) < 1 {
-			return fmt.Errorf("invalid migration %s", file.Name())
+			return nil, fmt.Errorf("invalid migration %s", file.Name())
 		}
 		order, err := strconv.Atoi(parts[0])
 		if err != nil {
-			return fmt.Errorf("invalid migration %s: %w", file.Name(), err)
+			return nil, fmt.Errorf("invalid migration %s: %w", file.Name(), err)
 		}
 		fpath := path.Join("migrations", file.Name())
 		content, err := embedded.ReadFile(fpath)
 		if err != nil {
-			return fmt.Errorf("readfile %s: %w", fpath, err)
+			return nil, fmt.Errorf("readfile %s: %w", fpath, err)
+		}
+		m, exists := byorder[order]
+		if !exists {
+			m = &sqlMigration{order: order}
+			byorder[order] = m
 		}
-		scanner := bufio.NewScanner(bytes.NewBuffer(content))
-		scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-			if i := bytes.Index(data, []byte(";")); i >= 0 {
-				return i + 1, data[0 : i+1], nil
+		if strings.HasSuffix(file.Name(), downSuffix) {
+			m.down = content
+		} else {
+			if m.up != nil {
+				return nil, fmt.Errorf("duplicate migration %d: %s and %s", order, m.name, file.Name())
 			}
-			return 0, nil, nil
-		})
-		migrations = append(migrations, migration{
-			order:   order,
-			name:    file.Name(),
-			content: scanner,
-		})
+			m.name = file.Name()
+			m.up = content
+		}
 	}
+	migrations := make([]Migration, 0, len(byorder)+len(codeMigrations))
+	for _, m := range byorder {
+		if m.up == nil {
+			return nil, fmt.Errorf("down migration %d without up migration", m.order)
+		}
+		migrations = append(migrations, m)
+	}
+	migrations = append(migrations, codeMigrations...)
 	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].order < migrations[j].order
+		return migrations[i].Order() < migrations[j].Order()
 	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Order() == migrations[i-1].Order() {
+			return nil, fmt.Errorf("duplicate migration %d: %s and %s",
+				migrations[i].Order(), migrations[i-1].Name(), migrations[i].Name())
+		}
+	}
+	return migrations, nil
+}
 
-	var current int
+func embeddedMigrations(db Executor) error {
+	migrations, err := EmbeddedMigrations()
+	if err != nil {
+		return err
+	}
+	return Migrate(db, migrations)
+}
 
+// AppliedMigration is a record about migration applied to the database.
+type AppliedMigration struct {
+	Order     int
+	Name      string
+	Checksum  [32]byte
+	AppliedAt time.Time
+}
+
+// ensureBookkeeping creates table that stores applied migrations.
+// Databases created before the table was introduced track version only in user_version,
+// migrations up to that version are recorded with checksums known to the node.
+func ensureBookkeeping(db Executor, migrations []Migration) error {
+	if _, err := db.Exec(`create table if not exists migrations
+	(
+		version    INT PRIMARY KEY,
+		name       VARCHAR,
+		checksum   CHAR(32),
+		applied_at INT
+	) WITHOUT ROWID;`, nil, nil); err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+	applied, err := Applied(db)
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		return nil
+	}
+	version, err := userVersion(db)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Order() > version {
+			break
+		}
+		if err := recordMigration(db, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func userVersion(db Executor) (int, error) {
+	var current int
 	if _, err := db.Exec("PRAGMA user_version;", nil, func(stmt *Statement) bool {
 		current = stmt.ColumnInt(0)
 		return true
 	}); err != nil {
-		return fmt.Errorf("read user_version %w", err)
+		return 0, fmt.Errorf("read user_version %w", err)
 	}
+	return current, nil
+}
+
+func setUserVersion(db Executor, version int) error {
+	// binding values in pragma statement is not allowed
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d;", version), nil, nil); err != nil {
+		return fmt.Errorf("update user_version to %d: %w", version, err)
+	}
+	return nil
+}
+
+func recordMigration(db Executor, m Migration) error {
+	checksum := m.Checksum()
+	if _, err := db.Exec(`insert into migrations (version, name, checksum, applied_at)
+	values (?1, ?2, ?3, ?4);`, func(stmt *Statement) {
+		stmt.BindInt64(1, int64(m.Order()))
+		stmt.BindText(2, m.Name())
+		stmt.BindBytes(3, checksum[:])
+		stmt.BindInt64(4, time.Now().Unix())
+	}, nil); err != nil {
+		return fmt.Errorf("record migration %s: %w", m.Name(), err)
+	}
+	return nil
+}
 
+// Applied returns migrations applied to the database, sorted by order.
+func Applied(db Executor) ([]AppliedMigration, error) {
+	var rst []AppliedMigration
+	if _, err := db.Exec("select version, name, checksum, applied_at from migrations order by version;", nil,
+		func(stmt *Statement) bool {
+			m := AppliedMigration{
+				Order:     stmt.ColumnInt(0),
+				Name:      stmt.ColumnText(1),
+				AppliedAt: time.Unix(stmt.ColumnInt64(3), 0),
+			}
+			stmt.ColumnBytes(2, m.Checksum[:])
+			rst = append(rst, m)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("load applied migrations: %w", err)
+	}
+	return rst, nil
+}
+
+// Pending verifies integrity of the applied migrations and returns migrations
+// that are not applied yet.
+func Pending(db Executor, migrations []Migration) ([]Migration, error) {
+	if err := ensureBookkeeping(db, migrations); err != nil {
+		return nil, err
+	}
+	applied, err := Applied(db)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int]Migration, len(migrations))
 	for _, m := range migrations {
-		if m.order <= current {
-			continue
+		known[m.Order()] = m
+	}
+	done := make(map[int]struct{}, len(applied))
+	for _, a := range applied {
+		m, exists := known[a.Order]
+		if !exists {
+			return nil, fmt.Errorf("%w: %d %s", ErrMigrationUnknown, a.Order, a.Name)
 		}
-		for m.content.Scan() {
-			if _, err := db.Exec(m.content.Text(), nil, nil); err != nil {
-				return fmt.Errorf("exec %s: %w", m.content.Text(), err)
-			}
+		if m.Checksum() != a.Checksum {
+			return nil, fmt.Errorf("%w: %d %s", ErrMigrationTampered, a.Order, a.Name)
+		}
+		done[a.Order] = struct{}{}
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if _, exists := done[m.Order()]; !exists {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies all pending migrations in order.
+func Migrate(db Executor, migrations []Migration) error {
+	pending, err := Pending(db, migrations)
+	if err != nil {
+		return err
+	}
+	for _, m := range pending {
+		if err := m.Apply(db); err != nil {
+			return fmt.Errorf("apply %s: %w", m.Name(), err)
+		}
+		if err := recordMigration(db, m); err != nil {
+			return err
+		}
+		if err := setUserVersion(db, m.Order()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback applies down-migrations for all migrations with order higher than version,
+// in reverse order.
+func Rollback(db Executor, migrations []Migration, version int) error {
+	if _, err := Pending(db, migrations); err != nil {
+		return err
+	}
+	applied, err := Applied(db)
+	if err != nil {
+		return err
+	}
+	known := make(map[int]Migration, len(migrations))
+	for _, m := range migrations {
+		known[m.Order()] = m
+	}
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i].Order <= version {
+			break
+		}
+		m := known[applied[i].Order]
+		if err := m.Rollback(db); err != nil {
+			return fmt.Errorf("rollback %s: %w", m.Name(), err)
+		}
+		if _, err := db.Exec("delete from migrations where version = ?1;", func(stmt *Statement) {
+			stmt.BindInt64(1, int64(m.Order()))
+		}, nil); err != nil {
+			return fmt.Errorf("delete migration record %s: %w", m.Name(), err)
+		}
+		previous := 0
+		if i > 0 {
+			previous = applied[i-1].Order
 		}
-		// binding values in pragma statement is not allowed
-		if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d;", m.order), nil, nil); err != nil {
-			return fmt.Errorf("update user_version to %d: %w", m.order, err)
+		if err := setUserVersion(db, previous); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -0,0 +1,5 @@
+CREATE TABLE state_trie
+(
+    hash CHAR(32) PRIMARY KEY,
+    node BLOB
+) WITHOUT ROWID;
@@ -0,0 +1,2 @@
+DROP INDEX proposal_transactions_by_layer;
+DROP INDEX block_transactions_by_layer;
@@ -0,0 +1,2 @@
+CREATE INDEX proposal_transactions_by_layer ON proposal_transactions (layer);
+CREATE INDEX block_transactions_by_layer ON block_transactions (layer);
@@ -0,0 +1 @@
+ALTER TABLE identities ADD COLUMN proof BLOB;
@@ -0,0 +1,2 @@
+DROP INDEX poet_submissions_by_service_id_by_round_id;
+DROP TABLE poet_submissions;
@@ -0,0 +1,10 @@
+CREATE TABLE poet_submissions
+(
+    node_id    CHAR(32) NOT NULL,
+    challenge  CHAR(32) NOT NULL,
+    service_id VARCHAR NOT NULL,
+    round_id   VARCHAR NOT NULL,
+    round_end  INT NOT NULL,
+    PRIMARY KEY (node_id, challenge, service_id)
+) WITHOUT ROWID;
+CREATE INDEX poet_submissions_by_service_id_by_round_id ON poet_submissions (service_id, round_id);
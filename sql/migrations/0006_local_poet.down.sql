@@ -0,0 +1,2 @@
+DROP INDEX local_poet_members_by_round_end;
+DROP TABLE local_poet_members;
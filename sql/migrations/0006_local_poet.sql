@@ -0,0 +1,9 @@
+CREATE TABLE local_poet_members
+(
+    service_id VARCHAR NOT NULL,
+    round_id   VARCHAR NOT NULL,
+    member     CHAR(32) NOT NULL,
+    round_end  INT NOT NULL,
+    PRIMARY KEY (service_id, round_id, member)
+) WITHOUT ROWID;
+CREATE INDEX local_poet_members_by_round_end ON local_poet_members (round_end);
@@ -15,5 +15,179 @@ func TestMigrationsAppliedOnce(t *testing.T) {
 		return true
 	})
 	require.NoError(t, err)
-	require.Equal(t, version, 1)
+	require.Equal(t, version, 6)
+
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	pending, err := Pending(db, migrations)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	applied, err := Applied(db)
+	require.NoError(t, err)
+	require.Len(t, applied, len(migrations))
+	for i, m := range migrations {
+		require.Equal(t, m.Order(), applied[i].Order)
+		require.Equal(t, m.Checksum(), applied[i].Checksum)
+	}
+}
+
+func TestMigrationsPending(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	require.Len(t, migrations, 6)
+
+	db := InMemory(WithMigrations(func(Executor) error { return nil }))
+	pending, err := Pending(db, migrations)
+	require.NoError(t, err)
+	require.Equal(t, migrations, pending)
+
+	require.NoError(t, Migrate(db, migrations[:1]))
+	pending, err = Pending(db, migrations)
+	require.NoError(t, err)
+	require.Equal(t, migrations[1:], pending)
+}
+
+func TestMigrationsTampered(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	db := InMemory()
+
+	tampered := append([]Migration{}, migrations...)
+	last := migrations[len(migrations)-1].(*sqlMigration)
+	tampered[len(tampered)-1] = &sqlMigration{
+		order: last.order,
+		name:  last.name,
+		up:    append([]byte("-- modified\n"), last.up...),
+		down:  last.down,
+	}
+	_, err = Pending(db, tampered)
+	require.ErrorIs(t, err, ErrMigrationTampered)
+
+	// down script can be added or fixed after the migration was applied
+	withDown := append([]Migration{}, migrations...)
+	withDown[len(withDown)-1] = &sqlMigration{
+		order: last.order,
+		name:  last.name,
+		up:    last.up,
+		down:  []byte("select 1;"),
+	}
+	_, err = Pending(db, withDown)
+	require.NoError(t, err)
+
+	_, err = Pending(db, migrations[:len(migrations)-1])
+	require.ErrorIs(t, err, ErrMigrationUnknown)
+}
+
+func TestMigrationsBackfill(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	db := InMemory(WithMigrations(func(db Executor) error {
+		// database created before migrations were recorded
+		for _, m := range migrations {
+			if err := m.Apply(db); err != nil {
+				return err
+			}
+		}
+		return setUserVersion(db, migrations[len(migrations)-1].Order())
+	}))
+	pending, err := Pending(db, migrations)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+	applied, err := Applied(db)
+	require.NoError(t, err)
+	require.Len(t, applied, len(migrations))
+}
+
+func TestMigrationsRollback(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	db := InMemory()
+
+	_, err = db.Exec("select count(*) from state_trie;", nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, Rollback(db, migrations, 1))
+	_, err = db.Exec("select count(*) from state_trie;", nil, nil)
+	require.Error(t, err)
+	version, err := userVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	require.NoError(t, Migrate(db, migrations))
+	_, err = db.Exec("select count(*) from state_trie;", nil, nil)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, Rollback(db, migrations, 0), ErrNoRollback)
+}
+
+func TestCodeMigration(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	db := InMemory()
+
+	applied := 0
+	reverted := 0
+	migrations = append(migrations, NewMigration(100, "code",
+		func(Executor) error {
+			applied++
+			return nil
+		},
+		func(Executor) error {
+			reverted++
+			return nil
+		},
+	))
+	require.NoError(t, Migrate(db, migrations))
+	require.NoError(t, Migrate(db, migrations))
+	require.Equal(t, 1, applied)
+	version, err := userVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 100, version)
+
+	require.NoError(t, Rollback(db, migrations, 2))
+	require.Equal(t, 1, reverted)
+	version, err = userVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+}
+
+func TestRegisterMigration(t *testing.T) {
+	registered := codeMigrations
+	t.Cleanup(func() { codeMigrations = registered })
+
+	applied := 0
+	reverted := 0
+	RegisterMigration(NewMigration(100, "registered",
+		func(db Executor) error {
+			applied++
+			_, err := db.Exec("create table registered (id INT);", nil, nil)
+			return err
+		},
+		func(db Executor) error {
+			reverted++
+			_, err := db.Exec("drop table registered;", nil, nil)
+			return err
+		},
+	))
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+	require.Equal(t, 100, migrations[len(migrations)-1].Order())
+
+	db := InMemory()
+	require.Equal(t, 1, applied)
+	_, err = db.Exec("select count(*) from registered;", nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, Rollback(db, migrations, 6))
+	require.Equal(t, 1, reverted)
+	_, err = db.Exec("select count(*) from registered;", nil, nil)
+	require.Error(t, err)
+	version, err := userVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 6, version)
+
+	RegisterMigration(NewMigration(6, "duplicate", func(Executor) error { return nil }, nil))
+	_, err = EmbeddedMigrations()
+	require.Error(t, err)
 }
@@ -2,7 +2,9 @@ package poets
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -82,3 +84,153 @@ func GetRef(db sql.Executor, poetID []byte, roundID string) (ref []byte, err err
 
 	return ref, nil
 }
+
+// AddSubmission records the challenge of the node submitted to the poet round.
+func AddSubmission(db sql.Executor, nodeID types.NodeID, challenge types.Hash32, req *types.PoetRequest) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, nodeID.Bytes())
+		stmt.BindBytes(2, challenge.Bytes())
+		stmt.BindBytes(3, req.PoetServiceID)
+		stmt.BindBytes(4, []byte(req.PoetRound.ID))
+		stmt.BindInt64(5, req.PoetRound.End.IntoTime().UnixNano())
+	}
+	_, err := db.Exec(`
+		insert into poet_submissions (node_id, challenge, service_id, round_id, round_end)
+		values (?1, ?2, ?3, ?4, ?5)
+		on conflict (node_id, challenge, service_id) do
+		update set round_id = ?4, round_end = ?5;`, enc, nil)
+	if err != nil {
+		return fmt.Errorf("add submission: %w", err)
+	}
+	return nil
+}
+
+// Submissions returns poet rounds that the challenge of the node was submitted to.
+func Submissions(db sql.Executor, nodeID types.NodeID, challenge types.Hash32) ([]types.PoetRequest, error) {
+	var reqs []types.PoetRequest
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, nodeID.Bytes())
+		stmt.BindBytes(2, challenge.Bytes())
+	}
+	dec := func(stmt *sql.Statement) bool {
+		req := types.PoetRequest{
+			PoetServiceID: make([]byte, stmt.ColumnLen(0)),
+			PoetRound: &types.PoetRound{
+				ChallengeHash: challenge,
+				End:           types.RoundEnd(time.Unix(0, stmt.ColumnInt64(2))),
+			},
+		}
+		stmt.ColumnBytes(0, req.PoetServiceID)
+		round := make([]byte, stmt.ColumnLen(1))
+		stmt.ColumnBytes(1, round)
+		req.PoetRound.ID = string(round)
+		reqs = append(reqs, req)
+		return true
+	}
+	_, err := db.Exec(`
+		select service_id, round_id, round_end from poet_submissions
+		where node_id = ?1 and challenge = ?2
+		order by service_id;`, enc, dec)
+	if err != nil {
+		return nil, fmt.Errorf("submissions: %w", err)
+	}
+	return reqs, nil
+}
+
+// CountRoundSubmissions returns the number of challenges submitted to the poet round.
+func CountRoundSubmissions(db sql.Executor, serviceID []byte, roundID string) (int, error) {
+	var count int
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, serviceID)
+		stmt.BindBytes(2, []byte(roundID))
+	}
+	dec := func(stmt *sql.Statement) bool {
+		count = int(stmt.ColumnInt64(0))
+		return true
+	}
+	if _, err := db.Exec(`
+		select count(*) from poet_submissions
+		where service_id = ?1 and round_id = ?2;`, enc, dec); err != nil {
+		return 0, fmt.Errorf("count round submissions: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteSubmissions deletes submissions of the challenge of the node.
+func DeleteSubmissions(db sql.Executor, nodeID types.NodeID, challenge types.Hash32) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, nodeID.Bytes())
+		stmt.BindBytes(2, challenge.Bytes())
+	}
+	if _, err := db.Exec(`
+		delete from poet_submissions
+		where node_id = ?1 and challenge = ?2;`, enc, nil); err != nil {
+		return fmt.Errorf("delete submissions: %w", err)
+	}
+	return nil
+}
+
+// DeleteOtherSubmissions deletes submissions of the node for challenges other than the one specified.
+func DeleteOtherSubmissions(db sql.Executor, nodeID types.NodeID, challenge types.Hash32) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, nodeID.Bytes())
+		stmt.BindBytes(2, challenge.Bytes())
+	}
+	if _, err := db.Exec(`
+		delete from poet_submissions
+		where node_id = ?1 and challenge != ?2;`, enc, nil); err != nil {
+		return fmt.Errorf("delete other submissions: %w", err)
+	}
+	return nil
+}
+
+// AddLocalMember records the challenge submitted to the round of the in-process poet.
+func AddLocalMember(db sql.Executor, serviceID []byte, roundID string, member types.Hash32, end time.Time) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, serviceID)
+		stmt.BindBytes(2, []byte(roundID))
+		stmt.BindBytes(3, member.Bytes())
+		stmt.BindInt64(4, end.UnixNano())
+	}
+	if _, err := db.Exec(`
+		insert into local_poet_members (service_id, round_id, member, round_end)
+		values (?1, ?2, ?3, ?4)
+		on conflict do nothing;`, enc, nil); err != nil {
+		return fmt.Errorf("add local member: %w", err)
+	}
+	return nil
+}
+
+// LocalMembers returns challenges submitted to the round of the in-process poet, ordered by bytes.
+func LocalMembers(db sql.Executor, serviceID []byte, roundID string) ([][]byte, error) {
+	var members [][]byte
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, serviceID)
+		stmt.BindBytes(2, []byte(roundID))
+	}
+	dec := func(stmt *sql.Statement) bool {
+		member := make([]byte, stmt.ColumnLen(0))
+		stmt.ColumnBytes(0, member)
+		members = append(members, member)
+		return true
+	}
+	if _, err := db.Exec(`
+		select member from local_poet_members
+		where service_id = ?1 and round_id = ?2
+		order by member;`, enc, dec); err != nil {
+		return nil, fmt.Errorf("local members: %w", err)
+	}
+	return members, nil
+}
+
+// DeleteLocalRounds deletes members of the in-process poet rounds that ended before the time.
+func DeleteLocalRounds(db sql.Executor, before time.Time) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, before.UnixNano())
+	}
+	if _, err := db.Exec(`
+		delete from local_poet_members where round_end < ?1;`, enc, nil); err != nil {
+		return fmt.Errorf("delete local rounds: %w", err)
+	}
+	return nil
+}
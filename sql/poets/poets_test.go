@@ -2,10 +2,12 @@ package poets
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -149,3 +151,100 @@ func TestGetRef(t *testing.T) {
 	_, err := GetRef(db, []byte("sid0"), "rid0")
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
+
+func TestSubmissions(t *testing.T) {
+	db := sql.InMemory()
+
+	nodeID := types.NodeID{1}
+	challenge := types.Hash32{2}
+	end := time.Unix(0, 1000)
+	reqs := []types.PoetRequest{
+		{
+			PoetServiceID: []byte("sid1"),
+			PoetRound:     &types.PoetRound{ID: "rid1", ChallengeHash: challenge, End: types.RoundEnd(end)},
+		},
+		{
+			PoetServiceID: []byte("sid2"),
+			PoetRound:     &types.PoetRound{ID: "rid7", ChallengeHash: challenge, End: types.RoundEnd(end)},
+		},
+	}
+	for i := range reqs {
+		require.NoError(t, AddSubmission(db, nodeID, challenge, &reqs[i]))
+	}
+	// the same challenge of another node
+	require.NoError(t, AddSubmission(db, types.NodeID{3}, challenge, &reqs[0]))
+
+	got, err := Submissions(db, nodeID, challenge)
+	require.NoError(t, err)
+	require.Equal(t, reqs, got)
+
+	got, err = Submissions(db, nodeID, types.Hash32{4})
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	count, err := CountRoundSubmissions(db, []byte("sid1"), "rid1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	require.NoError(t, DeleteSubmissions(db, nodeID, challenge))
+	got, err = Submissions(db, nodeID, challenge)
+	require.NoError(t, err)
+	require.Empty(t, got)
+	count, err = CountRoundSubmissions(db, []byte("sid1"), "rid1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestDeleteOtherSubmissions(t *testing.T) {
+	db := sql.InMemory()
+
+	nodeID := types.NodeID{1}
+	current, discarded := types.Hash32{2}, types.Hash32{3}
+	req := types.PoetRequest{
+		PoetServiceID: []byte("sid1"),
+		PoetRound:     &types.PoetRound{ID: "rid1", End: types.RoundEnd(time.Unix(0, 1000))},
+	}
+	require.NoError(t, AddSubmission(db, nodeID, current, &req))
+	require.NoError(t, AddSubmission(db, nodeID, discarded, &req))
+	require.NoError(t, AddSubmission(db, types.NodeID{4}, discarded, &req))
+
+	require.NoError(t, DeleteOtherSubmissions(db, nodeID, current))
+	got, err := Submissions(db, nodeID, current)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	got, err = Submissions(db, nodeID, discarded)
+	require.NoError(t, err)
+	require.Empty(t, got)
+	got, err = Submissions(db, types.NodeID{4}, discarded)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
+func TestLocalMembers(t *testing.T) {
+	db := sql.InMemory()
+
+	sid := []byte("local")
+	end := time.Unix(100, 0)
+	members := []types.Hash32{{3}, {1}, {2}}
+	for _, member := range members {
+		require.NoError(t, AddLocalMember(db, sid, "1", member, end))
+	}
+	require.NoError(t, AddLocalMember(db, sid, "1", members[0], end))
+	require.NoError(t, AddLocalMember(db, sid, "2", types.Hash32{4}, end.Add(time.Second)))
+
+	got, err := LocalMembers(db, sid, "1")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{members[1].Bytes(), members[2].Bytes(), members[0].Bytes()}, got)
+
+	got, err = LocalMembers(db, []byte("other"), "1")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, DeleteLocalRounds(db, end.Add(time.Second)))
+	got, err = LocalMembers(db, sid, "1")
+	require.NoError(t, err)
+	require.Empty(t, got)
+	got, err = LocalMembers(db, sid, "2")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
@@ -208,3 +208,14 @@ func decodeProposal(stmt *sql.Statement) (*types.Proposal, error) {
 
 	return proposal, nil
 }
+
+// DeleteBefore deletes proposals in layers before the specified layer.
+func DeleteBefore(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from proposals where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Value))
+		}, nil); err != nil {
+		return fmt.Errorf("delete proposals before %s: %w", lid, err)
+	}
+	return nil
+}
@@ -83,3 +83,24 @@ func TestGet(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, proposal, got)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	pub := types.BytesToNodeID([]byte{1, 1})
+	for i := 1; i <= 4; i++ {
+		ballot := types.NewExistingBallot(types.BallotID{byte(i)}, []byte{1, 1}, pub, types.InnerBallot{LayerIndex: types.NewLayerID(uint32(i))})
+		require.NoError(t, ballots.Add(db, &ballot))
+		proposal := &types.Proposal{
+			InnerProposal: types.InnerProposal{Ballot: ballot},
+			Signature:     []byte{5, 6},
+		}
+		proposal.SetID(types.ProposalID{byte(i)})
+		require.NoError(t, Add(db, proposal))
+	}
+	require.NoError(t, DeleteBefore(db, types.NewLayerID(3)))
+	for i := 1; i <= 4; i++ {
+		has, err := Has(db, types.ProposalID{byte(i)})
+		require.NoError(t, err)
+		require.Equal(t, i >= 3, has)
+	}
+}
@@ -54,3 +54,26 @@ func List(db sql.Executor, coinbase types.Address) (rst []*types.Reward, err err
 		})
 	return
 }
+
+// FeesInRange returns the sum of fees paid in rewards in the layers from the range [from, to]
+// and the number of layers in that range that have rewards.
+func FeesInRange(db sql.Executor, from, to types.LayerID) (fees uint64, layers uint32, err error) {
+	var last int64 = -1
+	_, err = db.Exec(`select layer, total_reward, layer_reward from rewards
+		where layer between ?1 and ?2 order by layer;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(from.Uint32()))
+			stmt.BindInt64(2, int64(to.Uint32()))
+		}, func(stmt *sql.Statement) bool {
+			if lid := stmt.ColumnInt64(0); lid != last {
+				last = lid
+				layers++
+			}
+			fees += uint64(stmt.ColumnInt64(1)) - uint64(stmt.ColumnInt64(2))
+			return true
+		})
+	if err != nil {
+		return 0, 0, fmt.Errorf("fees in range %s-%s: %w", from, to, err)
+	}
+	return fees, layers, nil
+}
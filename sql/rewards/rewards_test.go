@@ -94,3 +94,25 @@ func TestRewards(t *testing.T) {
 	require.Equal(t, part, got[0].TotalReward)
 	require.Equal(t, lyrReward, got[0].LayerReward)
 }
+
+func TestFeesInRange(t *testing.T) {
+	db := sql.InMemory()
+	for _, reward := range []types.Reward{
+		{Layer: types.NewLayerID(1), Coinbase: types.Address{1}, TotalReward: 110, LayerReward: 100},
+		{Layer: types.NewLayerID(2), Coinbase: types.Address{1}, TotalReward: 105, LayerReward: 100},
+		{Layer: types.NewLayerID(2), Coinbase: types.Address{2}, TotalReward: 103, LayerReward: 100},
+		{Layer: types.NewLayerID(4), Coinbase: types.Address{1}, TotalReward: 120, LayerReward: 100},
+	} {
+		require.NoError(t, Add(db, &reward))
+	}
+
+	fees, layers, err := FeesInRange(db, types.NewLayerID(2), types.NewLayerID(4))
+	require.NoError(t, err)
+	require.EqualValues(t, 28, fees)
+	require.EqualValues(t, 2, layers)
+
+	fees, layers, err = FeesInRange(db, types.NewLayerID(5), types.NewLayerID(10))
+	require.NoError(t, err)
+	require.Zero(t, fees)
+	require.Zero(t, layers)
+}
@@ -0,0 +1,82 @@
+package statetrie
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Has checks if a trie node exists by the given hash.
+func Has(db sql.Executor, hash types.Hash32) (bool, error) {
+	rows, err := db.Exec("select 1 from state_trie where hash = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, hash[:])
+		}, nil,
+	)
+	if err != nil {
+		return false, fmt.Errorf("has trie node %v: %w", hash, err)
+	}
+	return rows > 0, nil
+}
+
+// Get loads encoded trie node by its hash.
+func Get(db sql.Executor, hash types.Hash32) (node []byte, err error) {
+	rows, err := db.Exec("select node from state_trie where hash = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, hash[:])
+		}, func(stmt *sql.Statement) bool {
+			node = make([]byte, stmt.ColumnLen(0))
+			stmt.ColumnBytes(0, node)
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("get trie node %v: %w", hash, err)
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("%w: trie node %v", sql.ErrNotFound, hash)
+	}
+	return node, nil
+}
+
+// Add encoded trie node. Nodes are addressed by content, adding the same node twice is a no-op.
+func Add(db sql.Executor, hash types.Hash32, node []byte) error {
+	if _, err := db.Exec(`insert into state_trie (hash, node) values (?1, ?2)
+		on conflict (hash) do nothing;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, hash[:])
+			stmt.BindBytes(2, node)
+		}, nil); err != nil {
+		return fmt.Errorf("add trie node %v: %w", hash, err)
+	}
+	return nil
+}
+
+// Hashes returns up to limit hashes of the nodes, ordered and greater than after.
+func Hashes(db sql.Executor, after types.Hash32, limit int) ([]types.Hash32, error) {
+	var rst []types.Hash32
+	if _, err := db.Exec("select hash from state_trie where hash > ?1 order by hash limit ?2;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, after[:])
+			stmt.BindInt64(2, int64(limit))
+		}, func(stmt *sql.Statement) bool {
+			var hash types.Hash32
+			stmt.ColumnBytes(0, hash[:])
+			rst = append(rst, hash)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("trie nodes after %v: %w", after, err)
+	}
+	return rst, nil
+}
+
+// Delete trie node by its hash.
+func Delete(db sql.Executor, hash types.Hash32) error {
+	if _, err := db.Exec("delete from state_trie where hash = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, hash[:])
+		}, nil); err != nil {
+		return fmt.Errorf("delete trie node %v: %w", hash, err)
+	}
+	return nil
+}
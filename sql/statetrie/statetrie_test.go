@@ -0,0 +1,55 @@
+package statetrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestAddGet(t *testing.T) {
+	db := sql.InMemory()
+	hash := types.Hash32{1, 2, 3}
+	node := []byte("node")
+
+	exists, err := Has(db, hash)
+	require.NoError(t, err)
+	require.False(t, exists)
+	_, err = Get(db, hash)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, Add(db, hash, node))
+	require.NoError(t, Add(db, hash, node))
+
+	exists, err = Has(db, hash)
+	require.NoError(t, err)
+	require.True(t, exists)
+	got, err := Get(db, hash)
+	require.NoError(t, err)
+	require.Equal(t, node, got)
+}
+
+func TestHashesDelete(t *testing.T) {
+	db := sql.InMemory()
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, Add(db, types.Hash32{byte(i)}, []byte{byte(i)}))
+	}
+
+	got, err := Hashes(db, types.Hash32{1}, 2)
+	require.NoError(t, err)
+	require.Equal(t, []types.Hash32{{2}, {3}}, got)
+
+	require.NoError(t, Delete(db, types.Hash32{2}))
+	exists, err := Has(db, types.Hash32{2})
+	require.NoError(t, err)
+	require.False(t, exists)
+	got, err = Hashes(db, types.Hash32{1}, 2)
+	require.NoError(t, err)
+	require.Equal(t, []types.Hash32{{3}, {4}}, got)
+
+	got, err = Hashes(db, types.Hash32{5}, 2)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
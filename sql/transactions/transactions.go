@@ -72,6 +72,18 @@ func HasProposalTX(db sql.Executor, pid types.ProposalID, tid types.TransactionI
 	return rows > 0, nil
 }
 
+// DeleteProposalTXsBefore deletes associations between transactions and proposals
+// in layers before the specified layer.
+func DeleteProposalTXsBefore(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from proposal_transactions where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Value))
+		}, nil); err != nil {
+		return fmt.Errorf("delete proposal txs before %s: %w", lid, err)
+	}
+	return nil
+}
+
 // AddToBlock associates a transaction with a block.
 func AddToBlock(db sql.Executor, tid types.TransactionID, lid types.LayerID, bid types.BlockID) error {
 	if _, err := db.Exec(`
@@ -87,6 +99,18 @@ func AddToBlock(db sql.Executor, tid types.TransactionID, lid types.LayerID, bid
 	return nil
 }
 
+// DeleteBlockTXsBefore deletes associations between transactions and blocks
+// in layers before the specified layer.
+func DeleteBlockTXsBefore(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from block_transactions where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Value))
+		}, nil); err != nil {
+		return fmt.Errorf("delete block txs before %s: %w", lid, err)
+	}
+	return nil
+}
+
 // HasBlockTX returns true if the given transaction is included in the given block.
 func HasBlockTX(db sql.Executor, bid types.BlockID, tid types.TransactionID) (bool, error) {
 	rows, err := db.Exec("select 1 from block_transactions where bid = ?1 and tid = ?2",
@@ -100,6 +124,30 @@ func HasBlockTX(db sql.Executor, bid types.BlockID, tid types.TransactionID) (bo
 	return rows > 0, nil
 }
 
+// DeletePending deletes transaction that is not applied and not included in any proposal or block.
+// Returns false if transaction doesn't exist or can't be deleted.
+func DeletePending(db sql.Executor, tid types.TransactionID) (bool, error) {
+	rows, err := db.Exec(`select 1 from transactions where id = ?1 and result is null
+		and not exists (select 1 from proposal_transactions where tid = ?1)
+		and not exists (select 1 from block_transactions where tid = ?1)`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, tid.Bytes())
+		}, nil)
+	if err != nil {
+		return false, fmt.Errorf("check pending %s: %w", tid, err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	if _, err := db.Exec("delete from transactions where id = ?1",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, tid.Bytes())
+		}, nil); err != nil {
+		return false, fmt.Errorf("delete pending %s: %w", tid, err)
+	}
+	return true, nil
+}
+
 // GetAppliedLayer returns layer when transaction was applied.
 func GetAppliedLayer(db sql.Executor, tid types.TransactionID) (types.LayerID, error) {
 	var rst types.LayerID
@@ -178,6 +178,74 @@ func TestAddToBlock(t *testing.T) {
 	require.False(t, has)
 }
 
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+
+	rng := rand.New(rand.NewSource(1001))
+	signer, err := signing.NewEdSigner(signing.WithKeyFromRand(rng))
+	require.NoError(t, err)
+	tx := createTX(t, signer, types.Address{1}, 1, 191, 1)
+	require.NoError(t, transactions.Add(db, tx, time.Now()))
+
+	for i := 1; i <= 4; i++ {
+		lid := types.NewLayerID(uint32(i))
+		require.NoError(t, transactions.AddToProposal(db, tx.ID, lid, types.ProposalID{byte(i)}))
+		require.NoError(t, transactions.AddToBlock(db, tx.ID, lid, types.BlockID{byte(i)}))
+	}
+	require.NoError(t, transactions.DeleteProposalTXsBefore(db, types.NewLayerID(3)))
+	require.NoError(t, transactions.DeleteBlockTXsBefore(db, types.NewLayerID(3)))
+	for i := 1; i <= 4; i++ {
+		has, err := transactions.HasProposalTX(db, types.ProposalID{byte(i)}, tx.ID)
+		require.NoError(t, err)
+		require.Equal(t, i >= 3, has)
+		has, err = transactions.HasBlockTX(db, types.BlockID{byte(i)}, tx.ID)
+		require.NoError(t, err)
+		require.Equal(t, i >= 3, has)
+	}
+	// transaction itself is kept
+	has, err := transactions.Has(db, tx.ID)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestDeletePending(t *testing.T) {
+	db := sql.InMemory()
+
+	rng := rand.New(rand.NewSource(1001))
+	signer, err := signing.NewEdSigner(signing.WithKeyFromRand(rng))
+	require.NoError(t, err)
+	pending := createTX(t, signer, types.Address{1}, 1, 191, 1)
+	proposed := createTX(t, signer, types.Address{1}, 2, 191, 1)
+	applied := createTX(t, signer, types.Address{1}, 3, 191, 1)
+	for _, tx := range []*types.Transaction{pending, proposed, applied} {
+		require.NoError(t, transactions.Add(db, tx, time.Now()))
+	}
+	require.NoError(t, transactions.AddToProposal(db, proposed.ID, types.NewLayerID(10), types.ProposalID{1}))
+	require.NoError(t, db.WithTx(context.Background(), func(dbtx *sql.Tx) error {
+		return transactions.AddResult(dbtx, applied.ID, &types.TransactionResult{Layer: types.NewLayerID(10)})
+	}))
+
+	for _, tc := range []struct {
+		tx      *types.Transaction
+		deleted bool
+	}{
+		{tx: pending, deleted: true},
+		{tx: proposed},
+		{tx: applied},
+		{tx: pending},
+	} {
+		deleted, err := transactions.DeletePending(db, tc.tx.ID)
+		require.NoError(t, err)
+		require.Equal(t, tc.deleted, deleted)
+	}
+	has, err := transactions.Has(db, pending.ID)
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = transactions.Has(db, proposed.ID)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
 func TestApply_AlreadyApplied(t *testing.T) {
 	db := sql.InMemory()
 
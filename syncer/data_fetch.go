@@ -22,6 +22,8 @@ type peerResult[T any] struct {
 	peer p2p.Peer
 	data *T
 	err  error
+	// done is set in the last result from the peer, if the peer responds in chunks.
+	done bool
 }
 
 type request[T any, R any] struct {
@@ -66,7 +68,7 @@ func NewDataFetch(msh meshProvider, fetch fetcher, lg log.Log) *DataFetch {
 // PollLayerData polls all peers for data in the specified layer.
 func (d *DataFetch) PollLayerData(ctx context.Context, lid types.LayerID, peers ...p2p.Peer) error {
 	if len(peers) == 0 {
-		peers = d.fetcher.GetPeers()
+		peers = d.fetcher.GetPeersWithProtocol(fetch.LyrDataProtocol)
 	}
 	if len(peers) == 0 {
 		return errNoPeers
@@ -82,13 +84,13 @@ func (d *DataFetch) PollLayerData(ctx context.Context, lid types.LayerID, peers
 		},
 		ch: make(chan peerResult[fetch.LayerData], len(peers)),
 	}
-	okFunc := func(data []byte, peer p2p.Peer) {
-		d.receiveData(ctx, req, peer, data, nil)
+	chunkFunc := func(data []byte, peer p2p.Peer) {
+		d.receiveData(ctx, req, peer, data)
 	}
-	errFunc := func(err error, peer p2p.Peer) {
-		d.receiveData(ctx, req, peer, nil, err)
+	doneFunc := func(err error, peer p2p.Peer) {
+		d.receiveDone(ctx, req, peer, err)
 	}
-	if err := d.fetcher.GetLayerData(ctx, peers, lid, okFunc, errFunc); err != nil {
+	if err := d.fetcher.GetLayerData(ctx, peers, lid, chunkFunc, doneFunc); err != nil {
 		return err
 	}
 
@@ -96,17 +98,31 @@ func (d *DataFetch) PollLayerData(ctx context.Context, lid types.LayerID, peers
 	var (
 		success      bool
 		candidateErr error
+		// chunks are fetched as they are received, error in any chunk fails the peer response.
+		chunkErrs = map[p2p.Peer]error{}
 	)
 	for {
 		select {
 		case res := <-req.ch:
+			if !res.done {
+				if res.err != nil {
+					if _, exists := chunkErrs[res.peer]; !exists {
+						chunkErrs[res.peer] = res.err
+					}
+					break
+				}
+				logger.Debug("fetching layer data")
+				fetchLayerData(ctx, logger, d.fetcher, req, res.data)
+				logger.Debug("fetched layer data")
+				break
+			}
 			logger.Debug("received layer data")
+			if res.err == nil {
+				res.err = chunkErrs[res.peer]
+			}
 			req.peerResults[res.peer] = res
 			if res.err == nil {
 				success = true
-				logger.Debug("fetching layer data")
-				fetchLayerData(ctx, logger, d.fetcher, req, res.data)
-				logger.Debug("fetched layer data")
 			} else if candidateErr == nil {
 				candidateErr = res.err
 			}
@@ -128,16 +144,14 @@ func (d *DataFetch) PollLayerData(ctx context.Context, lid types.LayerID, peers
 	}
 }
 
-func (d *DataFetch) receiveData(ctx context.Context, req *dataRequest, peer p2p.Peer, data []byte, peerErr error) {
+func (d *DataFetch) receiveData(ctx context.Context, req *dataRequest, peer p2p.Peer, data []byte) {
 	logger := d.logger.WithContext(ctx).WithFields(req.lid, log.Stringer("peer", peer))
-	logger.Debug("received layer data from peer")
+	logger.Debug("received layer data chunk from peer")
 	var (
-		result = peerResult[fetch.LayerData]{peer: peer, err: peerErr}
+		result = peerResult[fetch.LayerData]{peer: peer}
 		ld     fetch.LayerData
 	)
-	if peerErr != nil {
-		logger.With().Debug("received peer error for layer data", req.lid, log.Err(peerErr))
-	} else if result.err = codec.Decode(data, &ld); result.err != nil {
+	if result.err = codec.Decode(data, &ld); result.err != nil {
 		logger.With().Debug("error converting bytes to LayerData", log.Err(result.err))
 	} else {
 		result.data = &ld
@@ -150,6 +164,18 @@ func (d *DataFetch) receiveData(ctx context.Context, req *dataRequest, peer p2p.
 	}
 }
 
+func (d *DataFetch) receiveDone(ctx context.Context, req *dataRequest, peer p2p.Peer, peerErr error) {
+	logger := d.logger.WithContext(ctx).WithFields(req.lid, log.Stringer("peer", peer))
+	if peerErr != nil {
+		logger.With().Debug("received peer error for layer data", req.lid, log.Err(peerErr))
+	}
+	select {
+	case req.ch <- peerResult[fetch.LayerData]{peer: peer, err: peerErr, done: true}:
+	case <-ctx.Done():
+		logger.Warning("request timed out")
+	}
+}
+
 // registerLayerHashes registers hashes with the peer that provides these hashes.
 func registerLayerHashes(fetcher fetcher, peer p2p.Peer, data *fetch.LayerData) {
 	if data == nil {
@@ -219,7 +245,7 @@ func fetchLayerData(ctx context.Context, logger log.Log, fetcher fetcher, req *d
 
 // PollLayerOpinions polls all peers for opinions in the specified layer.
 func (d *DataFetch) PollLayerOpinions(ctx context.Context, lid types.LayerID) ([]*fetch.LayerOpinion, error) {
-	peers := d.fetcher.GetPeers()
+	peers := d.fetcher.GetPeersWithProtocol(fetch.LyrOpnsProtocol)
 	if len(peers) == 0 {
 		return nil, errNoPeers
 	}
@@ -292,20 +318,39 @@ func (d *DataFetch) receiveOpinions(ctx context.Context, req *opinionRequest, pe
 	}
 }
 
-// GetEpochATXs fetches all ATXs in the specified epoch from a peer.
+// GetEpochATXs fetches all ATXs in the specified epoch from a peer,
+// together with malfeasance proofs known to that peer.
+// ATXs are fetched as soon as every chunk of the epoch info is received.
 func (d *DataFetch) GetEpochATXs(ctx context.Context, epoch types.EpochID) error {
-	peers := d.fetcher.GetPeers()
+	peers := d.fetcher.GetPeersWithProtocol(fetch.AtxProtocol)
 	if len(peers) == 0 {
 		return errNoPeers
 	}
 	peer := peers[rand.Intn(len(peers))]
-	ed, err := d.fetcher.PeerEpochInfo(ctx, peer, epoch)
-	if err != nil {
+	var fetchErr error
+	if err := d.fetcher.PeerEpochInfo(ctx, peer, epoch, func(ed *fetch.EpochData) error {
+		if err := d.fetcher.GetAtxs(ctx, ed.AtxIDs); err != nil {
+			fetchErr = fmt.Errorf("get ATXs: %w", err)
+			return fetchErr
+		}
+		return nil
+	}); fetchErr != nil {
+		return fetchErr
+	} else if err != nil {
 		return fmt.Errorf("get epoch info (peer %v): %w", peer, err)
 	}
-	d.fetcher.RegisterPeerHashes(peer, types.ATXIDsToHashes(ed.AtxIDs))
-	if err := d.fetcher.GetAtxs(ctx, ed.AtxIDs); err != nil {
-		return fmt.Errorf("get ATXs: %w", err)
+	if err := d.getMalfeasanceProofs(ctx, peer); err != nil {
+		return fmt.Errorf("get malfeasance proofs (peer %v): %w", peer, err)
 	}
 	return nil
 }
+
+func (d *DataFetch) getMalfeasanceProofs(ctx context.Context, peer p2p.Peer) error {
+	ids, err := d.fetcher.PeerMalfeasanceIDs(ctx, peer)
+	if errors.Is(err, fetch.ErrProtocolNotSupported) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return d.fetcher.GetMalfeasanceProofs(ctx, ids)
+}
@@ -88,6 +88,14 @@ func GenPeers(num int) []p2p.Peer {
 	return peers
 }
 
+// respond delivers chunks of layer data from the peer and completes the response.
+func respond(chunkCB func([]byte, p2p.Peer), doneCB func(error, p2p.Peer), peer p2p.Peer, chunks ...[]byte) {
+	for _, chunk := range chunks {
+		chunkCB(chunk, peer)
+	}
+	doneCB(nil, peer)
+}
+
 func Test_PollLayerData(t *testing.T) {
 	numPeers := 4
 	peers := GenPeers(numPeers)
@@ -96,11 +104,11 @@ func Test_PollLayerData(t *testing.T) {
 	t.Run("all peers have zero blocks", func(t *testing.T) {
 		t.Parallel()
 		td := newTestDataFetch(t)
-		td.mFetcher.EXPECT().GetPeers().Return(peers)
+		td.mFetcher.EXPECT().GetPeersWithProtocol(fetch.LyrDataProtocol).Return(peers)
 		td.mFetcher.EXPECT().GetLayerData(gomock.Any(), peers, layerID, gomock.Any(), gomock.Any()).DoAndReturn(
-			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
+			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, chunkCB func([]byte, p2p.Peer), doneCB func(error, p2p.Peer)) error {
 				for _, peer := range peers {
-					okCB(generateEmptyLayer(t), peer)
+					go respond(chunkCB, doneCB, peer, generateEmptyLayer(t))
 				}
 				return nil
 			})
@@ -109,12 +117,13 @@ func Test_PollLayerData(t *testing.T) {
 	})
 	newTestDataFetchWithMocks := func(*testing.T) *testDataFetch {
 		td := newTestDataFetch(t)
-		td.mFetcher.EXPECT().GetPeers().Return(peers)
+		td.mFetcher.EXPECT().GetPeersWithProtocol(fetch.LyrDataProtocol).Return(peers)
 		td.mFetcher.EXPECT().GetLayerData(gomock.Any(), peers, layerID, gomock.Any(), gomock.Any()).DoAndReturn(
-			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
+			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, chunkCB func([]byte, p2p.Peer), doneCB func(error, p2p.Peer)) error {
 				for _, peer := range peers {
-					td.mFetcher.EXPECT().RegisterPeerHashes(peer, gomock.Any())
-					okCB(generateLayerContent(t), peer)
+					td.mFetcher.EXPECT().RegisterPeerHashes(peer, gomock.Any()).Times(2)
+					// layer data is received in two chunks
+					go respond(chunkCB, doneCB, peer, generateLayerContent(t), generateLayerContent(t))
 				}
 				return nil
 			})
@@ -123,24 +132,24 @@ func Test_PollLayerData(t *testing.T) {
 	t.Run("all peers have layer data", func(t *testing.T) {
 		t.Parallel()
 		td := newTestDataFetchWithMocks(t)
-		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(numPeers)
-		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(numPeers)
+		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2 * numPeers)
+		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2 * numPeers)
 		require.NoError(t, td.PollLayerData(context.TODO(), layerID))
 	})
 	t.Run("ballots failure ignored", func(t *testing.T) {
 		t.Parallel()
 		td := newTestDataFetchWithMocks(t)
 		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(errUnknown)
-		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(numPeers - 1)
-		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(numPeers)
+		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2*numPeers - 1)
+		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2 * numPeers)
 		require.NoError(t, td.PollLayerData(context.TODO(), layerID))
 	})
 	t.Run("blocks failure ignored", func(t *testing.T) {
 		t.Parallel()
 		td := newTestDataFetchWithMocks(t)
-		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(numPeers)
+		td.mFetcher.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2 * numPeers)
 		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(errUnknown)
-		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(numPeers - 1)
+		td.mFetcher.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(2*numPeers - 1)
 		require.NoError(t, td.PollLayerData(context.TODO(), layerID))
 	})
 }
@@ -152,13 +161,13 @@ func Test_PollLayerData_PeerErrors(t *testing.T) {
 	t.Run("only one peer has data", func(t *testing.T) {
 		t.Parallel()
 		td := newTestDataFetch(t)
-		td.mFetcher.EXPECT().GetPeers().Return(peers)
+		td.mFetcher.EXPECT().GetPeersWithProtocol(fetch.LyrDataProtocol).Return(peers)
 		td.mFetcher.EXPECT().GetLayerData(gomock.Any(), peers, layerID, gomock.Any(), gomock.Any()).DoAndReturn(
-			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
+			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, chunkCB func([]byte, p2p.Peer), doneCB func(error, p2p.Peer)) error {
 				td.mFetcher.EXPECT().RegisterPeerHashes(peers[0], gomock.Any())
-				okCB(generateLayerContent(t), peers[0])
+				go respond(chunkCB, doneCB, peers[0], generateLayerContent(t))
 				for i := 1; i < numPeers; i++ {
-					errCB(errors.New("not available"), peers[i])
+					doneCB(errors.New("not available"), peers[i])
 				}
 				return nil
 			})
@@ -169,12 +178,12 @@ func Test_PollLayerData_PeerErrors(t *testing.T) {
 	t.Run("only one peer has empty layer", func(t *testing.T) {
 		t.Parallel()
 		td := newTestDataFetch(t)
-		td.mFetcher.EXPECT().GetPeers().Return(peers)
+		td.mFetcher.EXPECT().GetPeersWithProtocol(fetch.LyrDataProtocol).Return(peers)
 		td.mFetcher.EXPECT().GetLayerData(gomock.Any(), peers, layerID, gomock.Any(), gomock.Any()).DoAndReturn(
-			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
-				okCB(generateEmptyLayer(t), peers[0])
+			func(_ context.Context, _ []p2p.Peer, _ types.LayerID, chunkCB func([]byte, p2p.Peer), doneCB func(error, p2p.Peer)) error {
+				go respond(chunkCB, doneCB, peers[0], generateEmptyLayer(t))
 				for i := 1; i < numPeers; i++ {
-					errCB(errors.New("not available"), peers[i])
+					doneCB(errors.New("not available"), peers[i])
 				}
 				return nil
 			})
@@ -214,7 +223,7 @@ func Test_PollLayerOpinions(t *testing.T) {
 			t.Parallel()
 
 			td := newTestDataFetch(t)
-			td.mFetcher.EXPECT().GetPeers().Return(peers)
+			td.mFetcher.EXPECT().GetPeersWithProtocol(fetch.LyrOpnsProtocol).Return(peers)
 			td.mFetcher.EXPECT().GetLayerOpinions(gomock.Any(), peers, lid, gomock.Any(), gomock.Any()).DoAndReturn(
 				func(_ context.Context, _ []p2p.Peer, _ types.LayerID, okCB func([]byte, p2p.Peer), errCB func(error, p2p.Peer)) error {
 					for i, peer := range peers {
@@ -242,9 +251,11 @@ func Test_GetEpochATXs(t *testing.T) {
 	epoch := types.EpochID(11)
 	errGetID := errors.New("err get id")
 	errFetch := errors.New("err fetch")
+	errMal := errors.New("err malfeasance")
 	tt := []struct {
-		name                  string
-		err, getErr, fetchErr error
+		name                          string
+		err, getErr, fetchErr, malErr error
+		malUnsupported                bool
 	}{
 		{
 			name: "success",
@@ -259,6 +270,15 @@ func Test_GetEpochATXs(t *testing.T) {
 			fetchErr: errFetch,
 			err:      errFetch,
 		},
+		{
+			name:   "malfeasance failure",
+			malErr: errMal,
+			err:    errMal,
+		},
+		{
+			name:           "malfeasance not supported",
+			malUnsupported: true,
+		},
 	}
 
 	for _, tc := range tt {
@@ -270,17 +290,36 @@ func Test_GetEpochATXs(t *testing.T) {
 			ed := &fetch.EpochData{
 				AtxIDs: types.RandomActiveSet(11),
 			}
-			td.mFetcher.EXPECT().GetPeers().Return(peers)
-			td.mFetcher.EXPECT().PeerEpochInfo(gomock.Any(), gomock.Any(), epoch).DoAndReturn(
-				func(_ context.Context, peer p2p.Peer, _ types.EpochID) (*fetch.EpochData, error) {
+			malicious := []types.NodeID{types.RandomNodeID()}
+			td.mFetcher.EXPECT().GetPeersWithProtocol(fetch.AtxProtocol).Return(peers)
+			td.mFetcher.EXPECT().PeerEpochInfo(gomock.Any(), gomock.Any(), epoch, gomock.Any()).DoAndReturn(
+				func(_ context.Context, peer p2p.Peer, _ types.EpochID, chunkCB func(*fetch.EpochData) error) error {
 					require.Contains(t, peers, peer)
 					if tc.getErr != nil {
-						return nil, tc.getErr
-					} else {
-						td.mFetcher.EXPECT().RegisterPeerHashes(peer, types.ATXIDsToHashes(ed.AtxIDs))
-						td.mFetcher.EXPECT().GetAtxs(gomock.Any(), ed.AtxIDs).Return(tc.fetchErr)
-						return ed, nil
+						return tc.getErr
+					}
+					// atxs are fetched for every chunk
+					chunks := []*fetch.EpochData{{AtxIDs: ed.AtxIDs[:5]}, {AtxIDs: ed.AtxIDs[5:]}}
+					if tc.fetchErr != nil {
+						td.mFetcher.EXPECT().GetAtxs(gomock.Any(), chunks[0].AtxIDs).Return(tc.fetchErr)
+						return chunkCB(chunks[0])
 					}
+					for _, chunk := range chunks {
+						td.mFetcher.EXPECT().GetAtxs(gomock.Any(), chunk.AtxIDs).Return(nil)
+						if err := chunkCB(chunk); err != nil {
+							return err
+						}
+					}
+					switch {
+					case tc.malUnsupported:
+						td.mFetcher.EXPECT().PeerMalfeasanceIDs(gomock.Any(), peer).Return(nil, fetch.ErrProtocolNotSupported)
+					case tc.malErr != nil:
+						td.mFetcher.EXPECT().PeerMalfeasanceIDs(gomock.Any(), peer).Return(nil, tc.malErr)
+					default:
+						td.mFetcher.EXPECT().PeerMalfeasanceIDs(gomock.Any(), peer).Return(malicious, nil)
+						td.mFetcher.EXPECT().GetMalfeasanceProofs(gomock.Any(), malicious).Return(nil)
+					}
+					return nil
 				})
 			require.ErrorIs(t, td.GetEpochATXs(context.TODO(), epoch), tc.err)
 		})
@@ -37,11 +37,14 @@ type fetcher interface {
 	GetAtxs(context.Context, []types.ATXID) error
 	GetBallots(context.Context, []types.BallotID) error
 	GetBlocks(context.Context, []types.BlockID) error
+	GetMalfeasanceProofs(context.Context, []types.NodeID) error
 	RegisterPeerHashes(peer p2p.Peer, hashes []types.Hash32)
 
 	GetPeers() []p2p.Peer
-	PeerEpochInfo(context.Context, p2p.Peer, types.EpochID) (*fetch.EpochData, error)
+	GetPeersWithProtocol(string) []p2p.Peer
+	PeerEpochInfo(context.Context, p2p.Peer, types.EpochID, func(*fetch.EpochData) error) error
 	PeerMeshHashes(context.Context, p2p.Peer, *fetch.MeshHashRequest) (*fetch.MeshHashes, error)
+	PeerMalfeasanceIDs(context.Context, p2p.Peer) ([]types.NodeID, error)
 }
 
 type layerPatrol interface {
@@ -194,6 +194,20 @@ func (mr *MockfetchLogicMockRecorder) GetLayerOpinions(arg0, arg1, arg2, arg3, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerOpinions", reflect.TypeOf((*MockfetchLogic)(nil).GetLayerOpinions), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetMalfeasanceProofs mocks base method.
+func (m *MockfetchLogic) GetMalfeasanceProofs(arg0 context.Context, arg1 []types.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMalfeasanceProofs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMalfeasanceProofs indicates an expected call of GetMalfeasanceProofs.
+func (mr *MockfetchLogicMockRecorder) GetMalfeasanceProofs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMalfeasanceProofs", reflect.TypeOf((*MockfetchLogic)(nil).GetMalfeasanceProofs), arg0, arg1)
+}
+
 // GetPeers mocks base method.
 func (m *MockfetchLogic) GetPeers() []p2p.Peer {
 	m.ctrl.T.Helper()
@@ -208,19 +222,47 @@ func (mr *MockfetchLogicMockRecorder) GetPeers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*MockfetchLogic)(nil).GetPeers))
 }
 
+// GetPeersWithProtocol mocks base method.
+func (m *MockfetchLogic) GetPeersWithProtocol(arg0 string) []p2p.Peer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeersWithProtocol", arg0)
+	ret0, _ := ret[0].([]p2p.Peer)
+	return ret0
+}
+
+// GetPeersWithProtocol indicates an expected call of GetPeersWithProtocol.
+func (mr *MockfetchLogicMockRecorder) GetPeersWithProtocol(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeersWithProtocol", reflect.TypeOf((*MockfetchLogic)(nil).GetPeersWithProtocol), arg0)
+}
+
 // PeerEpochInfo mocks base method.
-func (m *MockfetchLogic) PeerEpochInfo(arg0 context.Context, arg1 p2p.Peer, arg2 types.EpochID) (*fetch.EpochData, error) {
+func (m *MockfetchLogic) PeerEpochInfo(arg0 context.Context, arg1 p2p.Peer, arg2 types.EpochID, arg3 func(*fetch.EpochData) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PeerEpochInfo", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*fetch.EpochData)
+	ret := m.ctrl.Call(m, "PeerEpochInfo", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PeerEpochInfo indicates an expected call of PeerEpochInfo.
+func (mr *MockfetchLogicMockRecorder) PeerEpochInfo(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerEpochInfo", reflect.TypeOf((*MockfetchLogic)(nil).PeerEpochInfo), arg0, arg1, arg2, arg3)
+}
+
+// PeerMalfeasanceIDs mocks base method.
+func (m *MockfetchLogic) PeerMalfeasanceIDs(arg0 context.Context, arg1 p2p.Peer) ([]types.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerMalfeasanceIDs", arg0, arg1)
+	ret0, _ := ret[0].([]types.NodeID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PeerEpochInfo indicates an expected call of PeerEpochInfo.
-func (mr *MockfetchLogicMockRecorder) PeerEpochInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+// PeerMalfeasanceIDs indicates an expected call of PeerMalfeasanceIDs.
+func (mr *MockfetchLogicMockRecorder) PeerMalfeasanceIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerEpochInfo", reflect.TypeOf((*MockfetchLogic)(nil).PeerEpochInfo), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerMalfeasanceIDs", reflect.TypeOf((*MockfetchLogic)(nil).PeerMalfeasanceIDs), arg0, arg1)
 }
 
 // PeerMeshHashes mocks base method.
@@ -377,6 +419,20 @@ func (mr *MockfetcherMockRecorder) GetLayerOpinions(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerOpinions", reflect.TypeOf((*Mockfetcher)(nil).GetLayerOpinions), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetMalfeasanceProofs mocks base method.
+func (m *Mockfetcher) GetMalfeasanceProofs(arg0 context.Context, arg1 []types.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMalfeasanceProofs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMalfeasanceProofs indicates an expected call of GetMalfeasanceProofs.
+func (mr *MockfetcherMockRecorder) GetMalfeasanceProofs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMalfeasanceProofs", reflect.TypeOf((*Mockfetcher)(nil).GetMalfeasanceProofs), arg0, arg1)
+}
+
 // GetPeers mocks base method.
 func (m *Mockfetcher) GetPeers() []p2p.Peer {
 	m.ctrl.T.Helper()
@@ -391,19 +447,47 @@ func (mr *MockfetcherMockRecorder) GetPeers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*Mockfetcher)(nil).GetPeers))
 }
 
+// GetPeersWithProtocol mocks base method.
+func (m *Mockfetcher) GetPeersWithProtocol(arg0 string) []p2p.Peer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeersWithProtocol", arg0)
+	ret0, _ := ret[0].([]p2p.Peer)
+	return ret0
+}
+
+// GetPeersWithProtocol indicates an expected call of GetPeersWithProtocol.
+func (mr *MockfetcherMockRecorder) GetPeersWithProtocol(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeersWithProtocol", reflect.TypeOf((*Mockfetcher)(nil).GetPeersWithProtocol), arg0)
+}
+
 // PeerEpochInfo mocks base method.
-func (m *Mockfetcher) PeerEpochInfo(arg0 context.Context, arg1 p2p.Peer, arg2 types.EpochID) (*fetch.EpochData, error) {
+func (m *Mockfetcher) PeerEpochInfo(arg0 context.Context, arg1 p2p.Peer, arg2 types.EpochID, arg3 func(*fetch.EpochData) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PeerEpochInfo", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*fetch.EpochData)
+	ret := m.ctrl.Call(m, "PeerEpochInfo", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PeerEpochInfo indicates an expected call of PeerEpochInfo.
+func (mr *MockfetcherMockRecorder) PeerEpochInfo(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerEpochInfo", reflect.TypeOf((*Mockfetcher)(nil).PeerEpochInfo), arg0, arg1, arg2, arg3)
+}
+
+// PeerMalfeasanceIDs mocks base method.
+func (m *Mockfetcher) PeerMalfeasanceIDs(arg0 context.Context, arg1 p2p.Peer) ([]types.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerMalfeasanceIDs", arg0, arg1)
+	ret0, _ := ret[0].([]types.NodeID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PeerEpochInfo indicates an expected call of PeerEpochInfo.
-func (mr *MockfetcherMockRecorder) PeerEpochInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+// PeerMalfeasanceIDs indicates an expected call of PeerMalfeasanceIDs.
+func (mr *MockfetcherMockRecorder) PeerMalfeasanceIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerEpochInfo", reflect.TypeOf((*Mockfetcher)(nil).PeerEpochInfo), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerMalfeasanceIDs", reflect.TypeOf((*Mockfetcher)(nil).PeerMalfeasanceIDs), arg0, arg1)
 }
 
 // PeerMeshHashes mocks base method.
@@ -217,10 +217,7 @@ func (s *Syncer) ensureMeshAgreement(
 		return fmt.Errorf("mesh hash check previous: %w", err)
 	}
 
-	var (
-		fork types.LayerID
-		ed   *fetch.EpochData
-	)
+	var fork types.LayerID
 	for _, opn := range opinions {
 		if opn.PrevAggHash == (types.Hash32{}) {
 			continue
@@ -248,22 +245,26 @@ func (s *Syncer) ensureMeshAgreement(
 		}
 
 		// getting the atx IDs targeting this epoch
-		ed, err = s.dataFetcher.PeerEpochInfo(ctx, peer, diffLayer.GetEpoch()-1)
-		if err != nil {
+		var (
+			missing = make(map[types.ATXID]struct{})
+			total   int
+		)
+		if err = s.dataFetcher.PeerEpochInfo(ctx, peer, diffLayer.GetEpoch()-1, func(ed *fetch.EpochData) error {
+			total += len(ed.AtxIDs)
+			for _, id := range ed.AtxIDs {
+				if _, ok := missing[id]; ok {
+					continue
+				}
+				hdr, _ := s.cdb.GetAtxHeader(id)
+				if hdr == nil {
+					missing[id] = struct{}{}
+				}
+			}
+			return nil
+		}); err != nil {
 			logger.With().Warning("failed to get epoch info", log.Err(err))
 			continue
 		}
-		missing := make(map[types.ATXID]struct{})
-		for _, id := range ed.AtxIDs {
-			if _, ok := missing[id]; ok {
-				continue
-			}
-			hdr, _ := s.cdb.GetAtxHeader(id)
-			if hdr == nil {
-				missing[id] = struct{}{}
-				continue
-			}
-		}
 		if len(missing) > 0 {
 			toFetch := maps.Keys(missing)
 			logger.With().Info("fetching missing atxs from peer",
@@ -289,7 +290,7 @@ func (s *Syncer) ensureMeshAgreement(
 			}
 		} else {
 			logger.With().Info("peer does not have unknown atx",
-				log.Int("num_atxs", len(ed.AtxIDs)))
+				log.Int("num_atxs", total))
 		}
 
 		// find the divergent layer and adopt the peer's mesh from there
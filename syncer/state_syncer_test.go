@@ -31,6 +31,12 @@ func opinions(prevHash types.Hash32) []*fetch.LayerOpinion {
 	}
 }
 
+func epochInfo(ed *fetch.EpochData) func(context.Context, p2p.Peer, types.EpochID, func(*fetch.EpochData) error) error {
+	return func(_ context.Context, _ p2p.Peer, _ types.EpochID, chunkCB func(*fetch.EpochData) error) error {
+		return chunkCB(ed)
+	}
+}
+
 func TestProcessLayers_MultiLayers(t *testing.T) {
 	gLid := types.GetEffectiveGenesis()
 	ts := newSyncerWithoutSyncTimer(t)
@@ -331,11 +337,11 @@ func TestProcessLayers_MeshHashDiverged(t *testing.T) {
 		}
 	}
 
-	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[0].Peer(), epoch-1).Return(eds[0], nil)
-	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[2].Peer(), epoch-1).Return(eds[2], nil)
-	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[3].Peer(), epoch-1).Return(eds[3], nil)
-	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[4].Peer(), epoch-1).Return(nil, errUnknown)
-	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[5].Peer(), epoch-1).Return(eds[5], nil)
+	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[0].Peer(), epoch-1, gomock.Any()).DoAndReturn(epochInfo(eds[0]))
+	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[2].Peer(), epoch-1, gomock.Any()).DoAndReturn(epochInfo(eds[2]))
+	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[3].Peer(), epoch-1, gomock.Any()).DoAndReturn(epochInfo(eds[3]))
+	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[4].Peer(), epoch-1, gomock.Any()).Return(errUnknown)
+	ts.mDataFetcher.EXPECT().PeerEpochInfo(gomock.Any(), opns[5].Peer(), epoch-1, gomock.Any()).DoAndReturn(epochInfo(eds[5]))
 	ts.mDataFetcher.EXPECT().GetAtxs(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(_ context.Context, got []types.ATXID) error {
 			require.ElementsMatch(t, eds[0].AtxIDs, got)
@@ -16,6 +16,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/mesh"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -193,6 +194,13 @@ func NewSyncer(
 	s.targetSyncedLayer.Store(types.LayerID{})
 	s.lastLayerSynced.Store(s.mesh.ProcessedLayer())
 	s.lastATXsSynced.Store(types.EpochID(0))
+	// activations published before the epoch that precedes the imported checkpoint
+	// are not in the checkpoint and are not needed to continue from it.
+	if lid, _, err := kvstore.GetImportedCheckpoint(cdb); err != nil {
+		s.logger.With().Error("failed to read imported checkpoint", log.Err(err))
+	} else if epoch := lid.GetEpoch(); epoch > 1 {
+		s.lastATXsSynced.Store(epoch - 2)
+	}
 	return s
 }
 
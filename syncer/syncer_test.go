@@ -23,6 +23,8 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
 	"github.com/spacemeshos/go-spacemesh/syncer/mocks"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
@@ -222,6 +224,53 @@ func TestSynchronize_AllGood(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSynchronize_ResumeFromCheckpoint(t *testing.T) {
+	ts := newSyncerWithoutSyncTimer(t)
+	checkpoint := types.GetEffectiveGenesis().Add(4 * layersPerEpoch)
+	require.NoError(t, layers.SetProcessed(ts.cdb, checkpoint))
+	require.NoError(t, layers.SetApplied(ts.cdb, checkpoint, types.EmptyBlockID))
+
+	lg := logtest.New(t)
+	ts.mVm.EXPECT().Revert(checkpoint)
+	ts.mConState.EXPECT().RevertCache(checkpoint)
+	ts.mVm.EXPECT().GetStateRoot()
+	var err error
+	ts.msh, err = mesh.NewMesh(ts.cdb, ts.mTortoise, mesh.NewExecutor(ts.cdb, ts.mVm, ts.mConState, lg), ts.mConState, lg)
+	require.NoError(t, err)
+	restart := func() {
+		ts.syncer = NewSyncer(ts.cdb, ts.mTicker, ts.mBeacon, ts.msh, nil, ts.mLyrPatrol, ts.mCertHdr,
+			WithContext(context.Background()),
+			WithConfig(Config{SyncInterval: never, SyncCertDistance: 4, HareDelayLayers: 5}),
+			WithLogger(lg),
+			withDataFetcher(ts.mDataFetcher),
+			withForkFinder(ts.mForkFinder))
+		ts.syncer.syncTimer.Stop()
+		ts.syncer.validateTimer.Stop()
+	}
+	// database that wasn't seeded from a checkpoint syncs all activations
+	restart()
+	require.Equal(t, checkpoint, ts.syncer.getLastSyncedLayer())
+	require.Equal(t, types.EpochID(0), ts.syncer.getLastSyncedATXs())
+
+	require.NoError(t, kvstore.SetImportedCheckpoint(ts.cdb, checkpoint, types.RandomHash()))
+	restart()
+	require.Equal(t, checkpoint, ts.syncer.getLastSyncedLayer())
+	require.Equal(t, checkpoint.GetEpoch()-2, ts.syncer.getLastSyncedATXs())
+
+	current := checkpoint.Add(layersPerEpoch + 1)
+	ts.mTicker.advanceToLayer(current)
+	// activations published before the epoch that precedes the checkpoint are not requested
+	for epoch := checkpoint.GetEpoch() - 1; epoch <= current.GetEpoch(); epoch++ {
+		ts.mDataFetcher.EXPECT().GetEpochATXs(gomock.Any(), epoch).Return(nil)
+	}
+	for lid := checkpoint.Add(1); lid.Before(current); lid = lid.Add(1) {
+		ts.mDataFetcher.EXPECT().PollLayerData(gomock.Any(), lid).Return(nil)
+	}
+	require.True(t, ts.syncer.synchronize(context.Background()))
+	require.Equal(t, current.Sub(1), ts.syncer.getLastSyncedLayer())
+	require.Equal(t, current.GetEpoch(), ts.syncer.getLastSyncedATXs())
+}
+
 func TestSynchronize_FetchLayerDataFailed(t *testing.T) {
 	ts := newSyncerWithoutSyncTimer(t)
 	gLayer := types.GetEffectiveGenesis()
@@ -0,0 +1,147 @@
+package tortoise
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+// againstAll votes against every block in the previous layer.
+func againstAll(rng *rand.Rand, layers []*types.Layer, i int) sim.Voting {
+	votes := sim.PerfectVoting(rng, layers, i)
+	votes.Support = nil
+	return votes
+}
+
+// runAdversarial runs tortoise for every state of the generator and records decisions.
+func runAdversarial(tb testing.TB, s *sim.Generator, states int, cfg Config, seqs ...sim.Sequence) *sim.Outcome {
+	tb.Helper()
+	ctx := context.Background()
+	tortoises := make([]*Tortoise, states)
+	for i := range tortoises {
+		tortoises[i] = tortoiseFromSimState(s.GetState(i), WithConfig(cfg), WithLogger(logtest.New(tb)))
+	}
+	outcome := sim.NewOutcome(states)
+	for _, seq := range seqs {
+		for i := 0; i < seq.Length; i++ {
+			last := s.Next(seq.Opts...)
+			for j, trtl := range tortoises {
+				for _, ballot := range s.Released(j) {
+					trtl.OnBallot(ballot)
+				}
+				trtl.TallyVotes(ctx, last)
+				verified, updates := trtl.Updates()
+				outcome.Update(j, last, verified, updates)
+			}
+		}
+	}
+	return outcome
+}
+
+func TestAdversarial(t *testing.T) {
+	const size = 10
+	for _, tc := range []struct {
+		desc    string
+		states  int
+		seqs    []sim.Sequence
+		maxLag  uint32
+		recover uint32
+	}{
+		{
+			desc:    "honest",
+			states:  1,
+			seqs:    []sim.Sequence{sim.WithSequence(20)},
+			maxLag:  1,
+			recover: 1,
+		},
+		{
+			// equivocating ballots are not counted by verifying tortoise,
+			// remaining weight crosses the threshold with a delay
+			desc:   "equivocate",
+			states: 2,
+			seqs: []sim.Sequence{
+				sim.WithSequence(20, sim.WithAdversary(sim.Equivocate(3, sim.PerfectVoting, againstAll))),
+			},
+			maxLag:  5,
+			recover: 5,
+		},
+		{
+			// expected weight of the layer is not reached until withheld ballots are released
+			desc:   "withhold",
+			states: 1,
+			seqs: []sim.Sequence{
+				sim.WithSequence(20, sim.WithAdversary(sim.Withhold(3, 3))),
+			},
+			maxLag:  4,
+			recover: 4,
+		},
+		{
+			desc:   "wrong beacon",
+			states: 1,
+			seqs: []sim.Sequence{
+				sim.WithSequence(20, sim.WithAdversary(sim.WrongBeacon(3))),
+			},
+			maxLag:  5,
+			recover: 5,
+		},
+		{
+			desc:   "balance",
+			states: 1,
+			seqs: []sim.Sequence{
+				sim.WithSequence(5),
+				sim.WithSequence(10,
+					sim.WithVoteGenerator(splitVoting(size)),
+					sim.WithAdversary(sim.Balance(3, size, splitVoting(size))),
+				),
+				sim.WithSequence(20),
+			},
+			maxLag:  30,
+			recover: 1,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := defaultTestConfig()
+			cfg.LayerSize = size
+			s := sim.New(sim.WithLayerSize(size), sim.WithStates(tc.states))
+			s.Setup(sim.WithSetupMinerRange(size, size))
+			outcome := runAdversarial(t, s, tc.states, cfg, tc.seqs...)
+			require.NoError(t, outcome.Safety())
+			require.NoError(t, outcome.Liveness(tc.maxLag))
+			require.NoError(t, outcome.Recovered(tc.recover))
+		})
+	}
+}
+
+// TestAdversarialResilience logs how liveness degrades with the growing share of adversarial miners.
+func TestAdversarialResilience(t *testing.T) {
+	const size = 10
+	for miners := 1; miners <= size/2; miners++ {
+		for _, tc := range []struct {
+			desc   string
+			states int
+			adv    sim.Adversary
+		}{
+			{"equivocate", 2, sim.Equivocate(miners, sim.PerfectVoting, againstAll)},
+			{"withhold", 1, sim.Withhold(miners, 3)},
+			{"wrong beacon", 1, sim.WrongBeacon(miners)},
+		} {
+			cfg := defaultTestConfig()
+			cfg.LayerSize = size
+			s := sim.New(sim.WithLayerSize(size), sim.WithStates(tc.states))
+			s.Setup(sim.WithSetupMinerRange(size, size))
+			outcome := runAdversarial(t, s, tc.states, cfg, sim.WithSequence(30, sim.WithAdversary(tc.adv)))
+			t.Logf("%s: adversarial miners %d/%d, max lag %d, reverted %d",
+				tc.desc, miners, size, outcome.MaxLag(), outcome.Reverted())
+			if miners*3 < size {
+				require.NoError(t, outcome.Safety(), tc.desc)
+			}
+		}
+	}
+}
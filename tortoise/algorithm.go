@@ -2,6 +2,7 @@ package tortoise
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -11,7 +12,10 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -23,6 +27,11 @@ type Config struct {
 	WindowSize    uint32 `mapstructure:"tortoise-window-size"`    // size of the tortoise sliding window (in layers)
 	MaxExceptions int    `mapstructure:"tortoise-max-exceptions"` // if candidate for base block has more than max exceptions it will be ignored
 
+	// CheckpointInterval is a number of processed layers between checkpoints of the state.
+	// Checkpoint is used to restore the state on restart instead of rebuilding it from the mesh.
+	// Zero disables checkpoints, a checkpoint seeded from the checkpoint of the node is still used.
+	CheckpointInterval uint32 `mapstructure:"tortoise-checkpoint-interval"`
+
 	LayerSize                uint32
 	BadBeaconVoteDelayLayers uint32 // number of layers to delay votes for blocks with bad beacon values during self-healing
 }
@@ -36,6 +45,7 @@ func DefaultConfig() Config {
 		WindowSize:               1000,
 		BadBeaconVoteDelayLayers: 6,
 		MaxExceptions:            30 * 100, // 100 layers of average size
+		CheckpointInterval:       100,
 	}
 }
 
@@ -44,6 +54,7 @@ type Tortoise struct {
 	logger log.Log
 	ctx    context.Context
 	cfg    Config
+	cdb    *datastore.CachedDB
 
 	eg     errgroup.Group
 	cancel context.CancelFunc
@@ -56,6 +67,8 @@ type Tortoise struct {
 
 	mu   sync.Mutex
 	trtl *turtle
+	// checkpointed is the processed layer in the last checkpoint.
+	checkpointed types.LayerID
 }
 
 // Opt for configuring tortoise.
@@ -85,6 +98,7 @@ func WithConfig(cfg Config) Opt {
 // New creates Tortoise instance.
 func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, opts ...Opt) *Tortoise {
 	t := &Tortoise{
+		cdb:    cdb,
 		ctx:    context.Background(),
 		logger: log.NewNop(),
 		cfg:    DefaultConfig(),
@@ -110,6 +124,14 @@ func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, opts ...Opt) *Tor
 			log.Err(err),
 		)
 	}
+	// state imported from the checkpoint of the node may have no ballots
+	applied, err := layers.GetLastApplied(cdb)
+	if err != nil {
+		t.logger.With().Panic("failed to load last applied layer",
+			log.Err(err),
+		)
+	}
+	latest = maxLayer(latest, applied)
 	needsRecovery := latest.After(types.GetEffectiveGenesis())
 
 	t.trtl = newTurtle(
@@ -123,7 +145,11 @@ func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, opts ...Opt) *Tor
 			log.Stringer("last layer", latest),
 		)
 		t.eg.Go(func() error {
-			for lid := types.GetEffectiveGenesis().Add(1); !lid.After(latest); lid = lid.Add(1) {
+			start := types.GetEffectiveGenesis().Add(1)
+			if t.restore(ctx) {
+				start = t.trtl.processed.Add(1)
+			}
+			for lid := start; !lid.After(latest); lid = lid.Add(1) {
 				err := t.trtl.onLayer(ctx, lid)
 				if err != nil {
 					t.ready <- err
@@ -140,6 +166,83 @@ func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, opts ...Opt) *Tor
 	return t
 }
 
+// restore state from the checkpoint. If checkpoint is missing or can't be used
+// state is expected to be rebuilt from the mesh.
+//
+// If checkpoints are disabled only the checkpoint seeded from the imported checkpoint of the node
+// is restored, as the state before that layer can't be rebuilt from the mesh.
+func (t *Tortoise) restore(ctx context.Context) bool {
+	start := time.Now()
+	var cp Checkpoint
+	if err := kvstore.GetTortoiseCheckpoint(t.cdb, &cp); err != nil {
+		if !errors.Is(err, sql.ErrNotFound) {
+			t.logger.With().Warning("failed to load checkpoint. rebuilding state from the mesh", log.Err(err))
+		}
+		return false
+	}
+	if t.cfg.CheckpointInterval == 0 {
+		imported, _, err := kvstore.GetImportedCheckpoint(t.cdb)
+		if err != nil {
+			t.logger.With().Warning("failed to load imported checkpoint. rebuilding state from the mesh", log.Err(err))
+			return false
+		}
+		if imported == (types.LayerID{}) || cp.Processed != imported {
+			return false
+		}
+	}
+	err := t.trtl.restore(&cp)
+	if err == nil {
+		err = t.trtl.reconcile(ctx)
+	}
+	if err != nil {
+		t.logger.With().Warning("checkpoint can't be used. rebuilding state from the mesh",
+			log.Stringer("processed", cp.Processed),
+			log.Err(err),
+		)
+		if err := kvstore.ClearTortoiseCheckpoint(t.cdb); err != nil {
+			t.logger.With().Error("failed to clear checkpoint", log.Err(err))
+		}
+		t.trtl = newTurtle(t.logger, t.cdb, t.trtl.beacons, t.cfg)
+		return false
+	}
+	t.checkpointed = t.trtl.processed
+	t.logger.With().Info("restored state from checkpoint",
+		log.Stringer("processed", t.trtl.processed),
+		log.Stringer("verified", t.trtl.verified),
+		log.Duration("duration", time.Since(start)),
+	)
+	return true
+}
+
+// checkpoint returns checkpoint of the state if at least CheckpointInterval layers were processed
+// since the last checkpoint. Must be called while holding the lock.
+func (t *Tortoise) checkpoint() *Checkpoint {
+	if t.cfg.CheckpointInterval == 0 || t.trtl.processed.Before(t.checkpointed.Add(t.cfg.CheckpointInterval)) {
+		return nil
+	}
+	start := time.Now()
+	t.checkpointed = t.trtl.processed
+	cp := t.trtl.checkpoint()
+	buildCheckpointDuration.Observe(float64(time.Since(start).Nanoseconds()))
+	return cp
+}
+
+func (t *Tortoise) persistCheckpoint(cp *Checkpoint) {
+	start := time.Now()
+	if err := kvstore.SetTortoiseCheckpoint(t.cdb, cp); err != nil {
+		errorsCounter.Inc()
+		t.logger.With().Error("failed to persist checkpoint", cp.Processed, log.Err(err))
+		return
+	}
+	persistCheckpointDuration.Observe(float64(time.Since(start).Nanoseconds()))
+	t.logger.With().Debug("persisted checkpoint",
+		log.Stringer("processed", cp.Processed),
+		log.Int("ballots", len(cp.Ballots)),
+		log.Int("votes", len(cp.Votes)),
+		log.Duration("duration", time.Since(start)),
+	)
+}
+
 // LatestComplete returns the latest verified layer.
 func (t *Tortoise) LatestComplete() types.LayerID {
 	t.mu.Lock()
@@ -197,7 +300,6 @@ func (t *Tortoise) EncodeVotes(ctx context.Context, opts ...EncodeVotesOpts) (*t
 func (t *Tortoise) TallyVotes(ctx context.Context, lid types.LayerID) {
 	start := time.Now()
 	t.mu.Lock()
-	defer t.mu.Unlock()
 	waitTallyVotes.Observe(float64(time.Since(start).Nanoseconds()))
 	start = time.Now()
 	if err := t.trtl.onLayer(ctx, lid); err != nil {
@@ -205,6 +307,11 @@ func (t *Tortoise) TallyVotes(ctx context.Context, lid types.LayerID) {
 		t.logger.With().Error("failed on layer", lid, log.Err(err))
 	}
 	executeTallyVotes.Observe(float64(time.Since(start).Nanoseconds()))
+	cp := t.checkpoint()
+	t.mu.Unlock()
+	if cp != nil {
+		t.persistCheckpoint(cp)
+	}
 }
 
 // OnAtx is expected to be called before ballots that use this atx.
@@ -300,6 +407,20 @@ func (t *Tortoise) OnHareOutput(lid types.LayerID, bid types.BlockID) {
 	t.trtl.onHareOutput(lid, bid)
 }
 
+// Explain returns weights, thresholds and votes that tortoise uses to decide on the layer.
+func (t *Tortoise) Explain(lid types.LayerID) (*LayerExplanation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trtl.explain(lid)
+}
+
+// Progress returns distance between processed and verified layers, current mode and healing counters.
+func (t *Tortoise) Progress() Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trtl.progress()
+}
+
 // WaitReady waits until state will be reloaded from disk.
 func (t *Tortoise) WaitReady(ctx context.Context) error {
 	select {
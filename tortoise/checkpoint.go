@@ -0,0 +1,665 @@
+package tortoise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/spacemeshos/fixed"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+)
+
+//go:generate scalegen -types Checkpoint,EpochCheckpoint,LayerCheckpoint,BlockCheckpoint,ReferenceCheckpoint,VoteCheckpoint,BallotCheckpoint,DelayedCheckpoint,ValidityCheckpoint
+
+var errInconsistentCheckpoint = errors.New("checkpoint is inconsistent with the mesh")
+
+// Checkpoint is a serialized tortoise state within the sliding window.
+//
+// Layer votes of ballots are shared between ballots in memory, therefore they are
+// stored once in Votes and referenced by index. Index 0 is reserved for nil.
+type Checkpoint struct {
+	// protocol parameters that were used to build the state.
+	Hdist          uint32
+	Zdist          uint32
+	WindowSize     uint32
+	LayersPerEpoch uint32
+	Genesis        types.LayerID
+
+	Last            types.LayerID
+	Processed       types.LayerID
+	Verified        types.LayerID
+	Evicted         types.LayerID
+	Counted         types.LayerID
+	IsFull          bool
+	ChangedMin      types.LayerID
+	ChangedMax      types.LayerID
+	LocalThreshold  []byte
+	TotalGoodWeight []byte
+	// EvictedOpinion is an opinion of the last evicted layer.
+	EvictedOpinion types.Hash32
+	// progress stats are stored so that they are not reset on restart.
+	FullSince    types.LayerID
+	ModeSwitches uint64
+	Healed       uint64
+	Coinflips    uint64
+
+	Epochs     []EpochCheckpoint
+	Layers     []LayerCheckpoint
+	References []ReferenceCheckpoint
+	Votes      []VoteCheckpoint
+	Ballots    []BallotCheckpoint
+	Delayed    []DelayedCheckpoint
+	// Updated are validity updates that weren't consumed by the mesh yet.
+	Updated []ValidityCheckpoint
+}
+
+// EpochCheckpoint stores reference height of the epoch. Weight is recomputed from atxs.
+type EpochCheckpoint struct {
+	Epoch  uint32
+	Height uint64
+}
+
+// LayerCheckpoint stores layer and blocks in the layer.
+type LayerCheckpoint struct {
+	Layer           types.LayerID
+	Empty           []byte
+	HareTerminated  bool
+	GoodUncounted   []byte
+	ReferenceHeight uint64
+	Opinion         types.Hash32
+	Coinflip        bool
+	Blocks          []BlockCheckpoint
+}
+
+// BlockCheckpoint stores block with hare output, validity and counted margin.
+type BlockCheckpoint struct {
+	ID       types.BlockID
+	Height   uint64
+	Hare     uint8
+	Margin   []byte
+	Validity uint8
+	Emitted  uint8
+}
+
+// ReferenceCheckpoint stores weight per eligibility, height and beacon from the reference ballot.
+type ReferenceCheckpoint struct {
+	WeightNum   []byte
+	WeightDenom []byte
+	Height      uint64
+	Beacon      types.Beacon
+}
+
+// VoteCheckpoint stores votes of the ballot for a single layer.
+type VoteCheckpoint struct {
+	Layer     types.LayerID
+	Vote      uint8
+	Supported []types.BlockID
+	Opinion   types.Hash32
+	Prev      uint32
+}
+
+// BallotCheckpoint stores decoded ballot.
+type BallotCheckpoint struct {
+	ID        types.BallotID
+	Layer     types.LayerID
+	BaseID    types.BallotID
+	BaseLayer types.LayerID
+	Malicious bool
+	Weight    []byte
+	Reference uint32
+	BadBeacon bool
+	Votes     uint32
+}
+
+// DelayedCheckpoint stores ballots that will be counted in the layer.
+type DelayedCheckpoint struct {
+	Layer   types.LayerID
+	Ballots []types.BallotID
+}
+
+// ValidityCheckpoint is a block validity update.
+type ValidityCheckpoint struct {
+	ID       types.BlockID
+	Layer    types.LayerID
+	Validity bool
+}
+
+func encodeSign(s sign) uint8 {
+	return uint8(s + 1)
+}
+
+func decodeSign(s uint8) (sign, error) {
+	if s > 2 {
+		return 0, fmt.Errorf("%w: invalid sign %d", errInconsistentCheckpoint, s)
+	}
+	return sign(s) - 1, nil
+}
+
+func encodeWeight(w weight) []byte {
+	return w.Bytes()
+}
+
+func decodeWeight(b []byte) weight {
+	if len(b) == 0 {
+		return weight{}
+	}
+	return fixed.FromBytes(b)
+}
+
+// checkpoint serializes the state.
+func (t *turtle) checkpoint() *Checkpoint {
+	cp := &Checkpoint{
+		Hdist:           t.Hdist,
+		Zdist:           t.Zdist,
+		WindowSize:      t.WindowSize,
+		LayersPerEpoch:  types.GetLayersPerEpoch(),
+		Genesis:         types.GetEffectiveGenesis(),
+		Last:            t.last,
+		Processed:       t.processed,
+		Verified:        t.verified,
+		Evicted:         t.evicted,
+		Counted:         t.full.counted,
+		IsFull:          t.isFull,
+		ChangedMin:      t.changedOpinion.min,
+		ChangedMax:      t.changedOpinion.max,
+		LocalThreshold:  encodeWeight(t.localThreshold),
+		TotalGoodWeight: encodeWeight(t.verifying.totalGoodWeight),
+		FullSince:       t.stats.fullSince,
+		ModeSwitches:    t.stats.modeSwitches,
+		Healed:          t.stats.healed,
+		Coinflips:       t.stats.coinflips,
+		Votes:           []VoteCheckpoint{{}},
+	}
+	for epoch, einfo := range t.epochs {
+		cp.Epochs = append(cp.Epochs, EpochCheckpoint{Epoch: uint32(epoch), Height: einfo.height})
+	}
+	sort.Slice(cp.Epochs, func(i, j int) bool {
+		return cp.Epochs[i].Epoch < cp.Epochs[j].Epoch
+	})
+	if evicted, exists := t.layers[t.evicted]; exists {
+		cp.EvictedOpinion = evicted.opinion
+	} else if first, exists := t.layers[t.evicted.Add(1)]; exists && first.prevOpinion != nil {
+		cp.EvictedOpinion = *first.prevOpinion
+	}
+	var (
+		references = map[*referenceInfo]uint32{}
+		votes      = map[*layerVote]uint32{}
+	)
+	for lid := t.evicted.Add(1); !lid.After(t.last); lid = lid.Add(1) {
+		layer, exists := t.layers[lid]
+		if !exists {
+			continue
+		}
+		lcp := LayerCheckpoint{
+			Layer:           lid,
+			Empty:           encodeWeight(layer.empty),
+			HareTerminated:  layer.hareTerminated,
+			GoodUncounted:   encodeWeight(layer.verifying.goodUncounted),
+			ReferenceHeight: layer.verifying.referenceHeight,
+			Opinion:         layer.opinion,
+			Coinflip:        layer.coinflip,
+		}
+		for _, block := range layer.blocks {
+			lcp.Blocks = append(lcp.Blocks, BlockCheckpoint{
+				ID:       block.id,
+				Height:   block.height,
+				Hare:     encodeSign(block.hare),
+				Margin:   encodeWeight(block.margin),
+				Validity: encodeSign(block.validity),
+				Emitted:  encodeSign(block.emitted),
+			})
+		}
+		cp.Layers = append(cp.Layers, lcp)
+	}
+	for lid := t.evicted.Add(1); !lid.After(t.last); lid = lid.Add(1) {
+		for _, ballot := range t.ballots[lid] {
+			ref, exists := references[ballot.reference]
+			if !exists {
+				ref = uint32(len(cp.References))
+				references[ballot.reference] = ref
+				cp.References = append(cp.References, ReferenceCheckpoint{
+					WeightNum:   ballot.reference.weight.Num().Bytes(),
+					WeightDenom: ballot.reference.weight.Denom().Bytes(),
+					Height:      ballot.reference.height,
+					Beacon:      ballot.reference.beacon,
+				})
+			}
+			cp.Ballots = append(cp.Ballots, BallotCheckpoint{
+				ID:        ballot.id,
+				Layer:     ballot.layer,
+				BaseID:    ballot.base.id,
+				BaseLayer: ballot.base.layer,
+				Malicious: ballot.malicious,
+				Weight:    encodeWeight(ballot.weight),
+				Reference: ref,
+				BadBeacon: ballot.conditions.badBeacon,
+				Votes:     t.checkpointVote(cp, votes, ballot.votes.tail),
+			})
+		}
+	}
+	for lid, delayed := range t.full.delayed {
+		dcp := DelayedCheckpoint{Layer: lid}
+		for _, ballot := range delayed {
+			dcp.Ballots = append(dcp.Ballots, ballot.id)
+		}
+		cp.Delayed = append(cp.Delayed, dcp)
+	}
+	sort.Slice(cp.Delayed, func(i, j int) bool {
+		return cp.Delayed[i].Layer.Before(cp.Delayed[j].Layer)
+	})
+	for _, update := range t.updated {
+		cp.Updated = append(cp.Updated, ValidityCheckpoint{
+			ID:       update.ID,
+			Layer:    update.Layer,
+			Validity: update.Validity,
+		})
+	}
+	return cp
+}
+
+// checkpointVote stores the vote and all previous votes that weren't stored yet.
+// Votes for evicted layers are stored only with their opinion, as they are never copied or counted.
+func (t *turtle) checkpointVote(cp *Checkpoint, stored map[*layerVote]uint32, vote *layerVote) uint32 {
+	if vote == nil {
+		return 0
+	}
+	if idx, exists := stored[vote]; exists {
+		return idx
+	}
+	// iterative to avoid deep recursion on long chains
+	var chain []*layerVote
+	for current := vote; current != nil; current = current.prev {
+		if _, exists := stored[current]; exists {
+			break
+		}
+		chain = append(chain, current)
+		if !current.lid.After(t.evicted) {
+			break
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		current := chain[i]
+		vcp := VoteCheckpoint{
+			Layer:   current.lid,
+			Vote:    encodeSign(current.vote),
+			Opinion: current.opinion,
+		}
+		if current.lid.After(t.evicted) {
+			for _, block := range current.supported {
+				vcp.Supported = append(vcp.Supported, block.id)
+			}
+			if current.prev != nil {
+				vcp.Prev = stored[current.prev]
+			}
+		}
+		stored[current] = uint32(len(cp.Votes))
+		cp.Votes = append(cp.Votes, vcp)
+	}
+	return stored[vote]
+}
+
+// restore state from the checkpoint. State is expected to be initialized by newTurtle.
+func (t *turtle) restore(cp *Checkpoint) error {
+	if cp.Hdist != t.Hdist || cp.Zdist != t.Zdist || cp.WindowSize != t.WindowSize ||
+		cp.LayersPerEpoch != types.GetLayersPerEpoch() || cp.Genesis != types.GetEffectiveGenesis() {
+		return fmt.Errorf("%w: protocol parameters changed", errInconsistentCheckpoint)
+	}
+	t.layers = map[types.LayerID]*layerInfo{}
+	t.epochs = map[types.EpochID]*epochInfo{}
+
+	t.last = cp.Last
+	t.processed = cp.Processed
+	t.verified = cp.Verified
+	t.evicted = cp.Evicted
+	t.full.counted = cp.Counted
+	t.isFull = cp.IsFull
+	t.changedOpinion.min = cp.ChangedMin
+	t.changedOpinion.max = cp.ChangedMax
+	t.localThreshold = decodeWeight(cp.LocalThreshold)
+	t.verifying.totalGoodWeight = decodeWeight(cp.TotalGoodWeight)
+	t.stats = progressStats{
+		fullSince:    cp.FullSince,
+		modeSwitches: cp.ModeSwitches,
+		healed:       cp.Healed,
+		coinflips:    cp.Coinflips,
+	}
+
+	// local threshold is updated if atxs for the epoch of the last layer were added after the checkpoint
+	for _, ecp := range cp.Epochs {
+		epoch := types.EpochID(ecp.Epoch)
+		t.epoch(epoch).height = ecp.Height
+		if err := t.cdb.IterateEpochATXHeaders(epoch, func(header *types.ActivationTxHeader) bool {
+			t.onAtx(header)
+			return true
+		}); err != nil {
+			return fmt.Errorf("load atxs for epoch %d: %w", epoch, err)
+		}
+	}
+	evictedOpinion := cp.EvictedOpinion
+	prevOpinion := &evictedOpinion
+	for _, lcp := range cp.Layers {
+		if !lcp.Layer.After(t.evicted) {
+			return fmt.Errorf("%w: layer %s is evicted", errInconsistentCheckpoint, lcp.Layer)
+		}
+		layer := t.layer(lcp.Layer)
+		layer.empty = decodeWeight(lcp.Empty)
+		layer.hareTerminated = lcp.HareTerminated
+		layer.verifying.goodUncounted = decodeWeight(lcp.GoodUncounted)
+		layer.verifying.referenceHeight = lcp.ReferenceHeight
+		layer.opinion = lcp.Opinion
+		layer.coinflip = lcp.Coinflip
+		if !lcp.Layer.After(t.processed) && lcp.Layer != types.GetEffectiveGenesis() {
+			if prev, exists := t.layers[lcp.Layer.Sub(1)]; exists {
+				layer.prevOpinion = &prev.opinion
+			} else if lcp.Layer == t.evicted.Add(1) {
+				layer.prevOpinion = prevOpinion
+			}
+		}
+		for _, bcp := range lcp.Blocks {
+			block := &blockInfo{
+				id:     bcp.ID,
+				layer:  lcp.Layer,
+				height: bcp.Height,
+				margin: decodeWeight(bcp.Margin),
+			}
+			var err error
+			if block.hare, err = decodeSign(bcp.Hare); err != nil {
+				return err
+			}
+			if block.validity, err = decodeSign(bcp.Validity); err != nil {
+				return err
+			}
+			if block.emitted, err = decodeSign(bcp.Emitted); err != nil {
+				return err
+			}
+			blocksNumber.Inc()
+			layer.blocks = append(layer.blocks, block)
+			t.blockRefs[block.id] = block
+		}
+	}
+	if _, exists := t.layers[t.processed]; !exists {
+		return fmt.Errorf("%w: processed layer %s is missing", errInconsistentCheckpoint, t.processed)
+	}
+
+	references := make([]*referenceInfo, 0, len(cp.References))
+	for _, rcp := range cp.References {
+		denom := new(big.Int).SetBytes(rcp.WeightDenom)
+		if denom.Sign() == 0 {
+			return fmt.Errorf("%w: zero denominator in reference weight", errInconsistentCheckpoint)
+		}
+		references = append(references, &referenceInfo{
+			weight: new(big.Rat).SetFrac(new(big.Int).SetBytes(rcp.WeightNum), denom),
+			height: rcp.Height,
+			beacon: rcp.Beacon,
+		})
+	}
+	decoded := make([]*layerVote, len(cp.Votes))
+	for i := 1; i < len(cp.Votes); i++ {
+		vcp := cp.Votes[i]
+		vote, err := decodeSign(vcp.Vote)
+		if err != nil {
+			return err
+		}
+		lvote := &layerVote{vote: vote, opinion: vcp.Opinion}
+		if vcp.Layer.After(t.evicted) {
+			lvote.layerInfo = t.layer(vcp.Layer)
+		} else {
+			lvote.layerInfo = &layerInfo{lid: vcp.Layer}
+		}
+		for _, bid := range vcp.Supported {
+			block, exists := t.blockRefs[bid]
+			if !exists {
+				return fmt.Errorf("%w: supported block %s is missing", errInconsistentCheckpoint, bid)
+			}
+			lvote.supported = append(lvote.supported, block)
+		}
+		if vcp.Prev != 0 {
+			if vcp.Prev >= uint32(i) {
+				return fmt.Errorf("%w: vote references vote that wasn't decoded", errInconsistentCheckpoint)
+			}
+			lvote.prev = decoded[vcp.Prev]
+		}
+		decoded[i] = lvote
+	}
+	for _, bcp := range cp.Ballots {
+		if int(bcp.Reference) >= len(references) || int(bcp.Votes) >= len(decoded) {
+			return fmt.Errorf("%w: invalid reference in ballot %s", errInconsistentCheckpoint, bcp.ID)
+		}
+		t.addBallot(&ballotInfo{
+			id:    bcp.ID,
+			layer: bcp.Layer,
+			base: baseInfo{
+				id:    bcp.BaseID,
+				layer: bcp.BaseLayer,
+			},
+			malicious:  bcp.Malicious,
+			weight:     decodeWeight(bcp.Weight),
+			reference:  references[bcp.Reference],
+			votes:      votes{tail: decoded[bcp.Votes]},
+			conditions: conditions{badBeacon: bcp.BadBeacon},
+		})
+	}
+	for _, dcp := range cp.Delayed {
+		for _, id := range dcp.Ballots {
+			ballot, exists := t.ballotRefs[id]
+			if !exists {
+				return fmt.Errorf("%w: delayed ballot %s is missing", errInconsistentCheckpoint, id)
+			}
+			delayedBallots.Inc()
+			t.full.delayed[dcp.Layer] = append(t.full.delayed[dcp.Layer], ballot)
+		}
+	}
+	for _, ucp := range cp.Updated {
+		t.updated = append(t.updated, types.BlockContextualValidity{
+			ID:       ucp.ID,
+			Layer:    ucp.Layer,
+			Validity: ucp.Validity,
+		})
+	}
+	return nil
+}
+
+// reconcile adds data that was stored in the database after the checkpoint was created
+// and returns an error if data that was counted in the checkpoint is missing in the database.
+func (t *turtle) reconcile(ctx context.Context) error {
+	pruned, err := kvstore.GetPrunedBelow(t.cdb)
+	if err != nil {
+		return err
+	}
+	for lid := t.evicted.Add(1); !lid.After(t.processed); lid = lid.Add(1) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := t.reconcileBlocks(lid); err != nil {
+			return err
+		}
+		if err := t.loadHare(lid); err != nil {
+			return err
+		}
+		if lid.Before(pruned) {
+			continue
+		}
+		if err := t.reconcileBallots(lid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *turtle) reconcileBlocks(lid types.LayerID) error {
+	ids, err := blocks.IDsInLayer(t.cdb, lid)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return fmt.Errorf("read blocks for layer %s: %w", lid, err)
+	}
+	known := map[types.BlockID]struct{}{}
+	for _, id := range ids {
+		known[id] = struct{}{}
+		if _, exists := t.blockRefs[id]; exists {
+			continue
+		}
+		block, err := blocks.Get(t.cdb, id)
+		if err != nil {
+			return fmt.Errorf("read block %s: %w", id, err)
+		}
+		if err := t.onBlock(lid, block); err != nil {
+			return err
+		}
+		valid, err := blocks.IsValid(t.cdb, id)
+		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+			return err
+		} else if err == nil && valid {
+			t.blockRefs[id].validity = support
+		} else if err == nil {
+			t.blockRefs[id].validity = against
+		}
+	}
+	for _, block := range t.layer(lid).blocks {
+		if _, exists := known[block.id]; !exists {
+			return fmt.Errorf("%w: block %s/%s is not in the mesh", errInconsistentCheckpoint, lid, block.id)
+		}
+	}
+	return nil
+}
+
+func (t *turtle) reconcileBallots(lid types.LayerID) error {
+	ids, err := ballots.IDsInLayer(t.cdb, lid)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return fmt.Errorf("read ballots for layer %s: %w", lid, err)
+	}
+	known := map[types.BallotID]struct{}{}
+	for _, id := range ids {
+		known[id] = struct{}{}
+		if _, exists := t.ballotRefs[id]; exists {
+			continue
+		}
+		ballot, err := ballots.Get(t.cdb, id)
+		if err != nil {
+			return fmt.Errorf("read ballot %s: %w", id, err)
+		}
+		if err := t.onBallot(ballot); err != nil {
+			t.logger.With().Error("failed to add ballot to the state", log.Err(err), log.Inline(ballot))
+		}
+	}
+	for _, ballot := range t.ballots[lid] {
+		if _, exists := known[ballot.id]; !exists {
+			return fmt.Errorf("%w: ballot %s/%s is not in the mesh", errInconsistentCheckpoint, lid, ballot.id)
+		}
+	}
+	return nil
+}
+
+// Seed stores the checkpoint of the state at the layer that was imported from the checkpoint
+// of the node, so that the tortoise continues from that layer instead of genesis.
+//
+// Ballots and blocks of the layer are expected to be in the database. Layers before it
+// are treated as evicted, ballots of the layer vote for them only with their opinion,
+// and the local opinion about them is the opinion from the checkpoint.
+func Seed(cdb *datastore.CachedDB, cfg Config, lid types.LayerID, opinion types.Hash32) error {
+	if !lid.After(types.GetEffectiveGenesis()) {
+		return fmt.Errorf("checkpoint layer %s is not after genesis", lid)
+	}
+	t := newTurtle(log.NewNop(), cdb, nil, cfg)
+	genesis := types.GetEffectiveGenesis()
+	delete(t.layers, genesis)
+	delete(t.epochs, genesis.GetEpoch())
+
+	t.last = lid
+	t.processed = lid
+	t.verified = lid
+	t.evicted = lid.Sub(1)
+	t.full.counted = lid
+	if err := t.loadAtxs(lid.GetEpoch()); err != nil {
+		return err
+	}
+
+	layer := t.layer(lid)
+	layer.hareTerminated = true
+	layer.prevOpinion = &opinion
+	blts, err := blocks.Layer(cdb, lid)
+	if err != nil {
+		return fmt.Errorf("read blocks for layer %s: %w", lid, err)
+	}
+	for _, block := range blts {
+		binfo := &blockInfo{
+			id:       block.ID(),
+			layer:    lid,
+			height:   block.TickHeight,
+			hare:     against,
+			validity: against,
+			emitted:  against,
+		}
+		valid, err := blocks.IsValid(cdb, block.ID())
+		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+			return err
+		}
+		if valid {
+			binfo.hare = support
+			binfo.validity = support
+			binfo.emitted = support
+		}
+		t.addBlock(binfo)
+	}
+	layer.computeOpinion(t.Hdist, t.last)
+
+	beacon, err := beacons.Get(cdb, lid.GetEpoch())
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return err
+	}
+	layerBallots, err := ballots.Layer(cdb, lid)
+	if err != nil {
+		return fmt.Errorf("read ballots for layer %s: %w", lid, err)
+	}
+	for _, ballot := range layerBallots {
+		var refinfo *referenceInfo
+		if ballot.EpochData != nil {
+			refinfo, err = t.decodeReference(ballot)
+		} else {
+			refinfo, err = t.loadReference(ballot.RefBallot)
+		}
+		if err != nil {
+			return err
+		}
+		if refinfo == nil {
+			return fmt.Errorf("ref ballot %s for ballot %s is missing", ballot.RefBallot, ballot.ID())
+		}
+		binfo := &ballotInfo{
+			id:    ballot.ID(),
+			layer: lid,
+			base: baseInfo{
+				id:    ballot.Votes.Base,
+				layer: t.evicted,
+			},
+			reference: refinfo,
+			votes: votes{tail: &layerVote{
+				layerInfo: &layerInfo{lid: t.evicted},
+				vote:      against,
+				opinion:   ballot.OpinionHash,
+			}},
+			conditions: conditions{badBeacon: refinfo.beacon != beacon},
+		}
+		if ballot.IsMalicious() {
+			binfo.malicious = true
+		} else {
+			binfo.weight = fixed.DivUint64(
+				refinfo.weight.Num().Uint64(),
+				refinfo.weight.Denom().Uint64(),
+			).Mul(fixed.New(len(ballot.EligibilityProofs)))
+		}
+		t.addBallot(binfo)
+		if !binfo.conditions.badBeacon && binfo.opinion() == opinion {
+			layer.verifying.goodUncounted = layer.verifying.goodUncounted.Add(binfo.weight)
+			t.verifying.totalGoodWeight = t.verifying.totalGoodWeight.Add(binfo.weight)
+		}
+	}
+	return kvstore.SetTortoiseCheckpoint(cdb, t.checkpoint())
+}
@@ -0,0 +1,176 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestCheckpointEncoding(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.WindowSize = 10
+	tortoise := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+	for _, last := range sim.GenLayers(s,
+		sim.WithSequence(20),
+		sim.WithSequence(5, sim.WithVoteGenerator(splitVoting(size))),
+		sim.WithSequence(5),
+	) {
+		tortoise.TallyVotes(ctx, last)
+	}
+
+	cp := tortoise.trtl.checkpoint()
+	buf, err := codec.Encode(cp)
+	require.NoError(t, err)
+	var decoded Checkpoint
+	require.NoError(t, codec.Decode(buf, &decoded))
+
+	restored := newTurtle(logtest.New(t), s.GetState(0).DB, s.GetState(0).Beacons, cfg)
+	require.NoError(t, restored.restore(&decoded))
+	require.NoError(t, restored.reconcile(ctx))
+	require.Equal(t, cp, restored.checkpoint())
+
+	current := s.Next()
+	tortoise.TallyVotes(ctx, current)
+	require.NoError(t, restored.onLayer(ctx, current))
+	require.Equal(t, tortoise.trtl.verified, restored.verified)
+
+	expected, err := tortoise.EncodeVotes(ctx)
+	require.NoError(t, err)
+	opinion, err := restored.EncodeVotes(ctx, &encodeConf{})
+	require.NoError(t, err)
+	require.Equal(t, expected, opinion)
+}
+
+func TestCheckpointRecovery(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.CheckpointInterval = 10
+	tortoise := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+	var last types.LayerID
+	for i := 0; i < 25; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), tortoise.LatestComplete())
+
+	var cp Checkpoint
+	require.NoError(t, kvstore.GetTortoiseCheckpoint(s.GetState(0).DB, &cp))
+	require.True(t, cp.Processed.After(last.Sub(cfg.CheckpointInterval+1)))
+	require.False(t, cp.Processed.After(last))
+
+	restore := func(tb testing.TB, cfg Config) *Tortoise {
+		tb.Helper()
+		restored := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(tb)), WithConfig(cfg))
+		initctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		require.NoError(tb, restored.WaitReady(initctx))
+		return restored
+	}
+	tally := func(tb testing.TB, restored *Tortoise) {
+		tb.Helper()
+		restored.TallyVotes(ctx, last)
+		require.Equal(tb, last.Sub(1), restored.LatestComplete())
+	}
+
+	t.Run("from checkpoint", func(t *testing.T) {
+		restored := restore(t, cfg)
+		require.Equal(t, cp.Processed, restored.checkpointed)
+		tally(t, restored)
+
+		expected, err := tortoise.EncodeVotes(ctx)
+		require.NoError(t, err)
+		opinion, err := restored.EncodeVotes(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expected, opinion)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := cfg
+		disabled.CheckpointInterval = 0
+		restored := restore(t, disabled)
+		require.Equal(t, types.LayerID{}, restored.checkpointed)
+		tally(t, restored)
+
+		// checkpoint that was seeded from the imported checkpoint is restored
+		require.NoError(t, kvstore.SetImportedCheckpoint(s.GetState(0).DB, cp.Processed, types.Hash32{}))
+		restored = restore(t, disabled)
+		require.Equal(t, cp.Processed, restored.checkpointed)
+		tally(t, restored)
+	})
+
+	t.Run("inconsistent with the mesh", func(t *testing.T) {
+		tampered := cp
+		tampered.Layers = append([]LayerCheckpoint(nil), cp.Layers...)
+		tampered.Layers[0].Blocks = append(tampered.Layers[0].Blocks, BlockCheckpoint{ID: types.BlockID{1}})
+		require.NoError(t, kvstore.SetTortoiseCheckpoint(s.GetState(0).DB, &tampered))
+
+		restored := restore(t, cfg)
+		require.Equal(t, types.LayerID{}, restored.checkpointed)
+		require.ErrorIs(t, kvstore.GetTortoiseCheckpoint(s.GetState(0).DB, &Checkpoint{}), sql.ErrNotFound)
+		tally(t, restored)
+	})
+
+	t.Run("parameters changed", func(t *testing.T) {
+		changed := cp
+		changed.Hdist++
+		require.NoError(t, kvstore.SetTortoiseCheckpoint(s.GetState(0).DB, &changed))
+
+		restored := restore(t, cfg)
+		require.Equal(t, types.LayerID{}, restored.checkpointed)
+		tally(t, restored)
+	})
+}
+
+func TestCheckpointProgress(t *testing.T) {
+	const size = 4
+	ctx := context.Background()
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Zdist = 2
+	cfg.Hdist = 2
+
+	s := sim.New(sim.WithLayerSize(cfg.LayerSize))
+	s.Setup(sim.WithSetupMinerRange(size, size))
+	tortoise := tortoiseFromSimState(s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+	var last types.LayerID
+	for i := 0; i < 3; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+	}
+	for i := 0; i <= int(cfg.Hdist); i++ {
+		last = s.Next(sim.WithNumBlocks(1), sim.WithEmptyHareOutput())
+	}
+	tortoise.TallyVotes(ctx, last)
+	progress := tortoise.Progress()
+	require.Equal(t, modeFull, progress.Mode)
+	require.Equal(t, last, progress.FullSince)
+
+	buf, err := codec.Encode(tortoise.trtl.checkpoint())
+	require.NoError(t, err)
+	var decoded Checkpoint
+	require.NoError(t, codec.Decode(buf, &decoded))
+	restored := newTurtle(logtest.New(t), s.GetState(0).DB, s.GetState(0).Beacons, cfg)
+	require.NoError(t, restored.restore(&decoded))
+	require.NoError(t, restored.reconcile(ctx))
+	require.Equal(t, progress, restored.progress())
+}
@@ -0,0 +1,129 @@
+package tortoise
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+const (
+	modeVerifying = "verifying"
+	modeFull      = "full"
+)
+
+// ErrNotTracked is returned when explanation is requested for a layer outside of the sliding window.
+var ErrNotTracked = errors.New("layer is not tracked by tortoise")
+
+// LayerExplanation describes inputs that tortoise uses to decide on validity of blocks in the layer.
+type LayerExplanation struct {
+	Layer    types.LayerID
+	Verified bool
+	// Mode is the current mode of the tortoise, either verifying or full.
+	Mode           string
+	HareTerminated bool
+	Opinion        types.Hash32
+
+	ExpectedWeight  float64
+	LocalThreshold  float64
+	GlobalThreshold float64
+	// GoodWeight is a weight of good ballots that vote for the layer, used in verifying mode.
+	GoodWeight float64
+	// EmptyWeight is a margin of votes for empty layer, used in full mode.
+	EmptyWeight float64
+
+	Blocks []BlockExplanation
+}
+
+// BlockExplanation describes votes on a single block and the local vote.
+type BlockExplanation struct {
+	ID     types.BlockID
+	Height uint64
+	// Hare, Validity and Vote are one of support, against or abstain.
+	Hare     string
+	Validity string
+	// For and Against are weights of good ballots, as defined by the current mode,
+	// that voted for and against the block.
+	For     float64
+	Against float64
+	// Vote is a local vote on the block and Reason is why the vote was chosen.
+	Vote   string
+	Reason string
+	Error  string
+}
+
+// explain builds explanation for the layer.
+func (t *turtle) explain(lid types.LayerID) (*LayerExplanation, error) {
+	if !lid.After(t.evicted) || lid.After(t.processed) {
+		return nil, fmt.Errorf("%w: %s not in (%s, %s]", ErrNotTracked, lid, t.evicted, t.processed)
+	}
+	layer := t.layer(lid)
+	rst := &LayerExplanation{
+		Layer:           lid,
+		Verified:        !lid.After(t.verified),
+		Mode:            modeVerifying,
+		HareTerminated:  layer.hareTerminated,
+		Opinion:         layer.opinion,
+		ExpectedWeight:  t.expectedWeight(t.Config, lid).Float(),
+		LocalThreshold:  t.localThreshold.Float(),
+		GlobalThreshold: t.globalThreshold(t.Config, lid).Float(),
+		GoodWeight:      t.verifying.totalGoodWeight.Sub(layer.verifying.goodUncounted).Float(),
+		EmptyWeight:     layer.empty.Float(),
+	}
+	if t.isFull {
+		rst.Mode = modeFull
+	}
+	index := map[types.BlockID]int{}
+	for i, block := range layer.blocks {
+		index[block.id] = i
+		explained := BlockExplanation{
+			ID:       block.id,
+			Height:   block.height,
+			Hare:     block.hare.String(),
+			Validity: block.validity.String(),
+		}
+		vote, reason, err := t.fullVote(t.verified, t.last.Add(1), block)
+		if err != nil {
+			explained.Error = err.Error()
+		} else {
+			explained.Vote = vote.String()
+			explained.Reason = reason.String()
+		}
+		rst.Blocks = append(rst.Blocks, explained)
+	}
+	for voting := lid.Add(1); !voting.After(t.processed); voting = voting.Add(1) {
+		for _, ballot := range t.ballots[voting] {
+			if !t.isGood(ballot) {
+				continue
+			}
+			lvote := ballot.votes.tail
+			for lvote != nil && lvote.lid.After(lid) {
+				lvote = lvote.prev
+			}
+			if lvote == nil || lvote.lid != lid || lvote.vote == abstain {
+				continue
+			}
+			for _, block := range lvote.blocks {
+				if block.height > ballot.reference.height {
+					continue
+				}
+				explained := &rst.Blocks[index[block.id]]
+				switch lvote.getVote(block.id) {
+				case support:
+					explained.For += ballot.weight.Float()
+				case against:
+					explained.Against += ballot.weight.Float()
+				}
+			}
+		}
+	}
+	return rst, nil
+}
+
+// isGood returns true if ballot is counted by the current mode.
+func (t *turtle) isGood(ballot *ballotInfo) bool {
+	if t.isFull {
+		return !ballot.malicious && !t.full.isDelayed(ballot)
+	}
+	return t.verifying.isGood(ballot)
+}
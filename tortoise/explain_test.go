@@ -0,0 +1,85 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestExplain(t *testing.T) {
+	const size = 4
+	ctx := context.Background()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Zdist = 2
+	cfg.Hdist = 2
+
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup(sim.WithSetupMinerRange(size, size))
+	tortoise := tortoiseFromSimState(s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+
+	var last types.LayerID
+	for i := 0; i < 4; i++ {
+		last = s.Next(sim.WithNumBlocks(1))
+		tortoise.TallyVotes(ctx, last)
+	}
+	target := last.Sub(2)
+
+	t.Run("verifying", func(t *testing.T) {
+		rst, err := tortoise.Explain(target)
+		require.NoError(t, err)
+		require.Equal(t, target, rst.Layer)
+		require.Equal(t, modeVerifying, rst.Mode)
+		require.True(t, rst.Verified)
+		require.True(t, rst.HareTerminated)
+		require.Greater(t, rst.GlobalThreshold, rst.LocalThreshold)
+		require.Greater(t, rst.GoodWeight, rst.GlobalThreshold)
+		require.Len(t, rst.Blocks, 1)
+
+		block := rst.Blocks[0]
+		require.Equal(t, support.String(), block.Hare)
+		require.Equal(t, support.String(), block.Validity)
+		require.Equal(t, support.String(), block.Vote)
+		require.Equal(t, reasonValidity.String(), block.Reason)
+		require.Equal(t, rst.GoodWeight, block.For)
+		require.Zero(t, block.Against)
+	})
+
+	t.Run("full", func(t *testing.T) {
+		for i := 0; i <= int(cfg.Hdist); i++ {
+			last = s.Next(sim.WithNumBlocks(1), sim.WithEmptyHareOutput())
+		}
+		tortoise.TallyVotes(ctx, last)
+		require.True(t, tortoise.trtl.isFull)
+
+		// local hare output is empty, but ballots from other smeshers support the block
+		target := last.Sub(1)
+		rst, err := tortoise.Explain(target)
+		require.NoError(t, err)
+		require.Equal(t, modeFull, rst.Mode)
+		require.True(t, rst.Verified)
+		require.Zero(t, rst.GoodWeight)
+		require.Len(t, rst.Blocks, 1)
+
+		block := rst.Blocks[0]
+		require.Equal(t, against.String(), block.Hare)
+		require.Equal(t, support.String(), block.Validity)
+		require.Equal(t, support.String(), block.Vote)
+		require.Equal(t, reasonValidity.String(), block.Reason)
+		require.Greater(t, block.For, rst.GlobalThreshold)
+		require.Zero(t, block.Against)
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		_, err := tortoise.Explain(last.Add(1))
+		require.ErrorIs(t, err, ErrNotTracked)
+		_, err = tortoise.Explain(types.GetEffectiveGenesis().Sub(1))
+		require.ErrorIs(t, err, ErrNotTracked)
+	})
+}
@@ -151,13 +151,10 @@ func (f *full) verify(logger log.Log, lid types.LayerID) bool {
 }
 
 func (f *full) shouldBeDelayed(logger log.Log, ballot *ballotInfo) bool {
-	if !ballot.conditions.badBeacon {
+	if !f.isDelayed(ballot) {
 		return false
 	}
 	delay := ballot.layer.Add(f.BadBeaconVoteDelayLayers)
-	if !delay.After(f.last) {
-		return false
-	}
 	logger.With().Debug("ballot is delayed",
 		log.Stringer("id", ballot.id),
 		log.Uint32("ballot lid", ballot.layer.Value),
@@ -167,3 +164,8 @@ func (f *full) shouldBeDelayed(logger log.Log, ballot *ballotInfo) bool {
 	f.delayed[delay] = append(f.delayed[delay], ballot)
 	return true
 }
+
+// isDelayed returns true if ballot has a bad beacon and can't be counted until BadBeaconVoteDelayLayers pass.
+func (f *full) isDelayed(ballot *ballotInfo) bool {
+	return ballot.conditions.badBeacon && ballot.layer.Add(f.BadBeaconVoteDelayLayers).After(f.last)
+}
@@ -61,6 +61,32 @@ var modeGauge = metrics.NewGauge(
 	[]string{},
 ).WithLabelValues()
 
+var (
+	verifiedLag = metrics.NewGauge(
+		"verified_lag",
+		namespace,
+		"Number of processed layers that are not verified",
+		[]string{},
+	).WithLabelValues()
+	modeSwitches = metrics.NewCounter(
+		"mode_switches",
+		namespace,
+		"Number of switches to the mode",
+		[]string{"mode"},
+	)
+	switchedToFull      = modeSwitches.WithLabelValues(modeFull)
+	switchedToVerifying = modeSwitches.WithLabelValues(modeVerifying)
+	decidedLayers       = metrics.NewCounter(
+		"decided_layers",
+		namespace,
+		"Number of layers decided by verifying tortoise, by full tortoise (healing) or by the weak coin",
+		[]string{"by"},
+	)
+	verifyingLayers = decidedLayers.WithLabelValues(modeVerifying)
+	healedLayers    = decidedLayers.WithLabelValues("healing")
+	coinflipLayers  = decidedLayers.WithLabelValues("coinflip")
+)
+
 var errorsCounter = metrics.NewCounter(
 	"errors",
 	namespace,
@@ -142,3 +168,15 @@ var (
 	waitEncodeVotes    = encodeVotesHist.WithLabelValues("wait")
 	executeEncodeVotes = encodeVotesHist.WithLabelValues("execute")
 )
+
+var (
+	checkpointHist = metrics.NewHistogramWithBuckets(
+		"tortoise_checkpoint_ns",
+		namespace,
+		"Time to build and persist checkpoint of the state in ns.",
+		[]string{"step"},
+		prometheus.ExponentialBuckets(1_000_000, 2, 12),
+	)
+	buildCheckpointDuration   = checkpointHist.WithLabelValues("build")
+	persistCheckpointDuration = checkpointHist.WithLabelValues("persist")
+)
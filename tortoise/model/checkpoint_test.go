@@ -0,0 +1,133 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+)
+
+// copyLayer copies data that the node receives when it syncs the layer.
+func copyLayer(tb testing.TB, from, to sql.Executor, lid types.LayerID) {
+	tb.Helper()
+	if lid.FirstInEpoch() {
+		ids, err := atxs.GetIDsByEpoch(from, lid.GetEpoch()-1)
+		require.NoError(tb, err)
+		for _, id := range ids {
+			if has, err := atxs.Has(to, id); err == nil && has {
+				continue
+			}
+			atx, err := atxs.Get(from, id)
+			require.NoError(tb, err)
+			timestamp, err := atxs.GetTimestamp(from, id)
+			require.NoError(tb, err)
+			require.NoError(tb, atxs.Add(to, atx, timestamp))
+		}
+	}
+	blts, err := ballots.Layer(from, lid)
+	require.NoError(tb, err)
+	for _, ballot := range blts {
+		require.NoError(tb, ballots.Add(to, ballot))
+	}
+	bls, err := blocks.Layer(from, lid)
+	require.NoError(tb, err)
+	for _, block := range bls {
+		require.NoError(tb, blocks.Add(to, block))
+	}
+	if output, err := certificates.GetHareOutput(from, lid); err == nil {
+		require.NoError(tb, certificates.SetHareOutput(to, lid, output))
+	}
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	types.SetLayersPerEpoch(4)
+	const (
+		numLayers   = 22
+		numSmeshers = 10
+	)
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1001))
+	c := newCluster(logtest.New(t), rng)
+	for i := 0; i < numSmeshers; i++ {
+		c.addCore()
+	}
+	c.addHare().addBeacon()
+	r := newFailingRunner(c, &reliableMessenger{}, nil, rng, [2]int{0, 100})
+
+	// checkpoint is exported by the first node after the layer was applied
+	src := c.models[0].(*core)
+	lid := types.NewLayerID(14)
+	for i := 0; i < numLayers; i++ {
+		r.next()
+		_, updates := src.tortoise.Updates()
+		for _, update := range updates {
+			if update.Validity {
+				require.NoError(t, blocks.SetValid(src.cdb, update.ID))
+			} else {
+				require.NoError(t, blocks.SetInvalid(src.cdb, update.ID))
+			}
+		}
+	}
+	last := r.lid
+	require.Equal(t, last.Sub(1), src.tortoise.LatestComplete())
+
+	output, err := certificates.GetHareOutput(src.cdb, lid)
+	require.NoError(t, err)
+	root, err := trie.Update(src.cdb, types.Hash32{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, layers.SetApplied(src.cdb, lid, output))
+	require.NoError(t, layers.UpdateStateHash(src.cdb, lid, root))
+	require.NoError(t, layers.SetHashes(src.cdb, lid, types.Hash32{1}, types.Hash32{2}))
+	for _, epoch := range []types.EpochID{lid.GetEpoch(), lid.GetEpoch() + 1} {
+		beacon, err := src.beacons.GetBeacon(epoch)
+		require.NoError(t, err)
+		require.NoError(t, beacons.Add(src.cdb, epoch, beacon))
+	}
+
+	var buf bytes.Buffer
+	header, err := checkpoint.Export(src.cdb, lid, types.Hash20{1}, &buf)
+	require.NoError(t, err)
+	require.NotZero(t, header.Ballots)
+
+	imported := datastore.NewCachedDB(sql.InMemory(), logtest.New(t))
+	_, err = checkpoint.Import(ctx, imported.Database, types.Hash20{1}, root, &buf)
+	require.NoError(t, err)
+	cfg := tortoise.DefaultConfig()
+	cfg.LayerSize = layerSize
+	require.NoError(t, tortoise.Seed(imported, cfg, lid, header.Opinion))
+
+	trtl := tortoise.New(imported, src.beacons, tortoise.WithLogger(logtest.New(t)), tortoise.WithConfig(cfg))
+	initctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, trtl.WaitReady(initctx))
+	require.Equal(t, lid, trtl.LatestComplete())
+
+	for current := lid.Add(1); !current.After(last); current = current.Add(1) {
+		copyLayer(t, src.cdb, imported, current)
+		trtl.TallyVotes(ctx, current)
+	}
+	require.Equal(t, src.tortoise.LatestComplete(), trtl.LatestComplete())
+
+	expected, err := src.tortoise.EncodeVotes(ctx)
+	require.NoError(t, err)
+	opinion, err := trtl.EncodeVotes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, opinion)
+}
@@ -20,14 +20,12 @@ type hare struct {
 func (h *hare) OnMessage(m Messenger, event Message) {
 	switch ev := event.(type) {
 	case MessageLayerStart:
-		id := types.BlockID{}
-		h.rng.Read(id[:])
-		block := types.NewExistingBlock(
-			id,
-			types.InnerBlock{
+		block := &types.Block{
+			InnerBlock: types.InnerBlock{
 				LayerIndex: ev.LayerID,
 			},
-		)
+		}
+		block.Initialize()
 		// head and tails are at equal probability.
 		m.Send(
 			MessageCoinflip{LayerID: ev.LayerID, Coinflip: h.rng.Int()%2 == 0},
@@ -0,0 +1,105 @@
+package tortoise
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+)
+
+// Progress summarizes how far verification is behind and whether tortoise relies on healing.
+// Counters are accumulated since the state was built from the mesh and survive restarts
+// when the state is restored from the checkpoint.
+type Progress struct {
+	Last      types.LayerID
+	Processed types.LayerID
+	Verified  types.LayerID
+	// Lag is a number of processed layers that are not verified.
+	Lag uint32
+	// Mode is the current mode of the tortoise, either verifying or full.
+	Mode string
+	// FullSince is a layer that was processed when tortoise switched to full mode.
+	// Zero in verifying mode.
+	FullSince types.LayerID
+
+	ModeSwitches uint64
+	// Healed is a number of layers verified in full mode.
+	Healed uint64
+	// Coinflips is a number of layers where local votes were decided by the weak coin.
+	Coinflips uint64
+}
+
+type progressStats struct {
+	fullSince    types.LayerID
+	modeSwitches uint64
+	healed       uint64
+	coinflips    uint64
+}
+
+func (t *turtle) progress() Progress {
+	rst := Progress{
+		Last:         t.last,
+		Processed:    t.processed,
+		Verified:     t.verified,
+		Lag:          t.lag(),
+		Mode:         modeVerifying,
+		ModeSwitches: t.stats.modeSwitches,
+		Healed:       t.stats.healed,
+		Coinflips:    t.stats.coinflips,
+	}
+	if t.isFull {
+		rst.Mode = modeFull
+		rst.FullSince = t.stats.fullSince
+	}
+	return rst
+}
+
+func (t *turtle) lag() uint32 {
+	if !t.processed.After(t.verified) {
+		return 0
+	}
+	return t.processed.Difference(t.verified)
+}
+
+func (t *turtle) reportProgress(kind events.TortoiseEventKind, lid types.LayerID) {
+	events.ReportTortoise(events.EventTortoise{
+		Kind:      kind,
+		Layer:     lid,
+		Processed: t.processed,
+		Verified:  t.verified,
+	})
+}
+
+func (t *turtle) onModeSwitch() {
+	t.stats.modeSwitches++
+	if t.isFull {
+		t.stats.fullSince = t.processed
+		switchedToFull.Inc()
+		t.reportProgress(events.TortoiseFull, t.processed)
+	} else {
+		t.stats.fullSince = types.LayerID{}
+		switchedToVerifying.Inc()
+		t.reportProgress(events.TortoiseVerifying, t.processed)
+	}
+}
+
+// onVerified is called when the layer is verified for the first time.
+func (t *turtle) onVerified(lid types.LayerID) {
+	if !t.isFull {
+		verifyingLayers.Inc()
+		return
+	}
+	t.stats.healed++
+	healedLayers.Inc()
+	t.reportProgress(events.TortoiseHealed, lid)
+}
+
+// onCoinflip is called when local votes for blocks in the layer are decided by the weak coin.
+// Layer is accounted only once.
+func (t *turtle) onCoinflip(layer *layerInfo) {
+	if layer.coinflip {
+		return
+	}
+	layer.coinflip = true
+	t.stats.coinflips++
+	coinflipLayers.Inc()
+	t.reportProgress(events.TortoiseCoinflip, layer.lid)
+}
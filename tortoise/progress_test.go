@@ -0,0 +1,96 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestProgress(t *testing.T) {
+	const size = 4
+	ctx := context.Background()
+
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+	sub, err := events.Subscribe[events.EventTortoise]()
+	require.NoError(t, err)
+	t.Cleanup(sub.Close)
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Zdist = 2
+	cfg.Hdist = 2
+
+	s := sim.New(sim.WithLayerSize(cfg.LayerSize))
+	s.Setup(sim.WithSetupMinerRange(size, size))
+	tortoise := tortoiseFromSimState(s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+
+	var last types.LayerID
+	for i := 0; i < 3; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+	}
+	progress := tortoise.Progress()
+	require.Equal(t, modeVerifying, progress.Mode)
+	require.Equal(t, last.Sub(1), progress.Verified)
+	require.EqualValues(t, 1, progress.Lag)
+	require.Zero(t, progress.ModeSwitches)
+	require.Zero(t, progress.Healed)
+
+	// verifying tortoise can't make progress if hare output is empty
+	for i := 0; i <= int(cfg.Hdist); i++ {
+		last = s.Next(sim.WithNumBlocks(1), sim.WithEmptyHareOutput())
+	}
+	tortoise.TallyVotes(ctx, last)
+	progress = tortoise.Progress()
+	require.Equal(t, modeFull, progress.Mode)
+	require.Equal(t, last, progress.FullSince)
+	require.EqualValues(t, 1, progress.ModeSwitches)
+
+	for i := 0; i <= int(cfg.Hdist); i++ {
+		last = s.Next(sim.WithNumBlocks(1))
+		tortoise.TallyVotes(ctx, last)
+	}
+	tortoise.TallyVotes(ctx, last)
+	progress = tortoise.Progress()
+	require.Equal(t, modeVerifying, progress.Mode)
+	require.Equal(t, types.LayerID{}, progress.FullSince)
+	require.GreaterOrEqual(t, progress.ModeSwitches, uint64(2))
+	require.Positive(t, progress.Healed)
+	require.Equal(t, last.Sub(1), progress.Verified)
+
+	var received []events.EventTortoise
+	timeout := time.After(time.Second)
+	for len(received) < int(progress.ModeSwitches+progress.Healed) {
+		select {
+		case ev := <-sub.Out():
+			received = append(received, ev)
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for events", "received %d", len(received))
+		}
+	}
+	counts := map[events.TortoiseEventKind]int{}
+	var modes []events.TortoiseEventKind
+	for _, ev := range received {
+		counts[ev.Kind]++
+		if ev.Kind != events.TortoiseHealed {
+			modes = append(modes, ev.Kind)
+		}
+	}
+	require.EqualValues(t, progress.Healed, counts[events.TortoiseHealed])
+	require.EqualValues(t, progress.ModeSwitches, len(modes))
+	for i, kind := range modes {
+		if i%2 == 0 {
+			require.Equal(t, events.TortoiseFull, kind)
+		} else {
+			require.Equal(t, events.TortoiseVerifying, kind)
+		}
+	}
+}
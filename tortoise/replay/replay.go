@@ -0,0 +1,255 @@
+// Package replay feeds data from an existing state database into a fresh tortoise
+// and compares validity computed by tortoise with validity stored in the database.
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/system"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+)
+
+// Opt for configuring replay.
+type Opt func(*replay)
+
+// WithLogger configures logger.
+func WithLogger(logger log.Log) Opt {
+	return func(r *replay) {
+		r.logger = logger
+	}
+}
+
+// WithConfig configures tortoise. Checkpoints are always disabled.
+func WithConfig(cfg tortoise.Config) Opt {
+	return func(r *replay) {
+		r.cfg = cfg
+	}
+}
+
+// WithLast configures the last replayed layer. By default it is the latest layer with ballots.
+func WithLast(lid types.LayerID) Opt {
+	return func(r *replay) {
+		r.last = lid
+	}
+}
+
+// WithBeacons overwrites beacons that are read from the database.
+func WithBeacons(beacons system.BeaconGetter) Opt {
+	return func(r *replay) {
+		r.beacons = beacons
+	}
+}
+
+// Divergence is a block with validity computed by tortoise that is different from the stored validity.
+type Divergence struct {
+	Block    types.BlockID
+	Layer    types.LayerID
+	Stored   bool
+	Replayed bool
+	// At is the last layer that was fed into tortoise when validity was computed.
+	At types.LayerID
+}
+
+// Report is a result of replay.
+type Report struct {
+	Last     types.LayerID
+	Verified types.LayerID
+	// Compared is a number of validity updates that were compared with the database.
+	Compared int
+	// Unknown is a number of validity updates for blocks without stored validity.
+	Unknown     int
+	Divergences []Divergence
+}
+
+type replay struct {
+	logger  log.Log
+	cfg     tortoise.Config
+	last    types.LayerID
+	beacons system.BeaconGetter
+
+	src  *datastore.CachedDB
+	dst  *datastore.CachedDB
+	trtl *tortoise.Tortoise
+}
+
+// Run replays layers from the src database into the fresh tortoise, layer by layer.
+// Database is expected to be opened in read-only mode, it is never modified.
+//
+// For every layer atxs (in the first layer of the epoch), ballots, blocks, weak coin and hare output
+// are copied into the in-memory database and passed to tortoise in the same order as they are passed
+// by the node. Validity updates after every layer are compared with the blocks table.
+func Run(ctx context.Context, src *sql.Database, opts ...Opt) (*Report, error) {
+	r := &replay{
+		logger: log.NewNop(),
+		cfg:    tortoise.DefaultConfig(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.cfg.CheckpointInterval = 0
+	r.src = datastore.NewCachedDB(src, r.logger)
+	if r.beacons == nil {
+		r.beacons = dbBeacons{db: src}
+	}
+	if r.last == (types.LayerID{}) {
+		last, err := ballots.LatestLayer(src)
+		if err != nil {
+			return nil, fmt.Errorf("latest layer: %w", err)
+		}
+		r.last = last
+	}
+	dst := sql.InMemory()
+	defer dst.Close()
+	r.dst = datastore.NewCachedDB(dst, r.logger)
+	r.trtl = tortoise.New(r.dst, r.beacons,
+		tortoise.WithContext(ctx),
+		tortoise.WithLogger(r.logger),
+		tortoise.WithConfig(r.cfg),
+	)
+	defer r.trtl.Stop()
+	return r.run(ctx)
+}
+
+func (r *replay) run(ctx context.Context) (*Report, error) {
+	report := &Report{Last: r.last}
+	start := types.GetEffectiveGenesis().Add(1)
+	for lid := start; !lid.After(r.last); lid = lid.Add(1) {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if lid == start || lid.FirstInEpoch() {
+			if err := r.atxs(lid.GetEpoch()); err != nil {
+				return report, err
+			}
+		}
+		if err := r.layer(lid); err != nil {
+			return report, err
+		}
+		r.trtl.TallyVotes(ctx, lid)
+		verified, updates := r.trtl.Updates()
+		report.Verified = verified
+		for _, update := range updates {
+			stored, err := blocks.IsValid(r.src, update.ID)
+			if errors.Is(err, sql.ErrNotFound) {
+				report.Unknown++
+				continue
+			} else if err != nil {
+				return report, fmt.Errorf("validity of block %s: %w", update.ID, err)
+			}
+			report.Compared++
+			if stored == update.Validity {
+				continue
+			}
+			divergence := Divergence{
+				Block:    update.ID,
+				Layer:    update.Layer,
+				Stored:   stored,
+				Replayed: update.Validity,
+				At:       lid,
+			}
+			report.Divergences = append(report.Divergences, divergence)
+			r.logger.With().Warning("validity diverged",
+				divergence.Layer,
+				divergence.Block,
+				log.Bool("stored", divergence.Stored),
+				log.Bool("replayed", divergence.Replayed),
+				log.Stringer("at", divergence.At),
+			)
+		}
+		r.logger.With().Info("replayed layer",
+			lid,
+			log.Stringer("verified", verified),
+			log.Int("updates", len(updates)),
+		)
+	}
+	return report, nil
+}
+
+// atxs copies atxs that target the epoch and passes them to tortoise.
+func (r *replay) atxs(epoch types.EpochID) error {
+	return r.src.IterateEpochATXHeaders(epoch, func(header *types.ActivationTxHeader) bool {
+		if err := r.copyAtx(header.ID); err != nil {
+			r.logger.With().Error("failed to copy atx", header.ID, log.Err(err))
+			return true
+		}
+		r.trtl.OnAtx(header)
+		return true
+	})
+}
+
+func (r *replay) copyAtx(id types.ATXID) error {
+	atx, err := atxs.Get(r.src, id)
+	if err != nil {
+		return err
+	}
+	timestamp, err := atxs.GetTimestamp(r.src, id)
+	if err != nil {
+		return err
+	}
+	if err := atxs.Add(r.dst, atx, timestamp); err != nil && !errors.Is(err, sql.ErrObjectExists) {
+		return err
+	}
+	return nil
+}
+
+// layer copies ballots, blocks, weak coin and hare output of the layer and passes them to tortoise.
+func (r *replay) layer(lid types.LayerID) error {
+	blts, err := ballots.Layer(r.src, lid)
+	if err != nil {
+		return fmt.Errorf("ballots in %s: %w", lid, err)
+	}
+	for _, ballot := range blts {
+		if err := ballots.Add(r.dst, ballot); err != nil && !errors.Is(err, sql.ErrObjectExists) {
+			return fmt.Errorf("copy ballot %s: %w", ballot.ID(), err)
+		}
+		r.trtl.OnBallot(ballot)
+	}
+	blks, err := blocks.Layer(r.src, lid)
+	if err != nil {
+		return fmt.Errorf("blocks in %s: %w", lid, err)
+	}
+	for _, block := range blks {
+		if err := blocks.Add(r.dst, block); err != nil && !errors.Is(err, sql.ErrObjectExists) {
+			return fmt.Errorf("copy block %s: %w", block.ID(), err)
+		}
+		r.trtl.OnBlock(block)
+	}
+	coin, err := layers.GetWeakCoin(r.src, lid)
+	if err == nil {
+		if err := layers.SetWeakCoin(r.dst, lid, coin); err != nil {
+			return fmt.Errorf("copy weak coin in %s: %w", lid, err)
+		}
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return fmt.Errorf("weak coin in %s: %w", lid, err)
+	}
+	output, err := certificates.GetHareOutput(r.src, lid)
+	if err == nil {
+		if err := certificates.SetHareOutput(r.dst, lid, output); err != nil {
+			return fmt.Errorf("copy hare output in %s: %w", lid, err)
+		}
+		r.trtl.OnHareOutput(lid, output)
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return fmt.Errorf("hare output in %s: %w", lid, err)
+	}
+	return nil
+}
+
+type dbBeacons struct {
+	db sql.Executor
+}
+
+func (b dbBeacons) GetBeacon(epoch types.EpochID) (types.Beacon, error) {
+	return beacons.Get(b.db, epoch)
+}
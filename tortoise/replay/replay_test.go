@@ -0,0 +1,104 @@
+package replay
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+
+	res := m.Run()
+	os.Exit(res)
+}
+
+func TestReplay(t *testing.T) {
+	const size = 10
+	ctx := context.Background()
+
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+	state := s.GetState(0)
+
+	cfg := tortoise.DefaultConfig()
+	cfg.LayerSize = size
+	cfg.Hdist = 4
+	cfg.Zdist = 2
+
+	// node persists validity that is computed by tortoise
+	trtl := tortoise.New(state.DB, state.Beacons, tortoise.WithConfig(cfg), tortoise.WithLogger(logtest.New(t)))
+	var last types.LayerID
+	for i := 0; i < 20; i++ {
+		last = s.Next()
+		trtl.TallyVotes(ctx, last)
+		_, updates := trtl.Updates()
+		for _, update := range updates {
+			if update.Validity {
+				require.NoError(t, blocks.SetValid(state.DB, update.ID))
+			} else {
+				require.NoError(t, blocks.SetInvalid(state.DB, update.ID))
+			}
+		}
+	}
+	for epoch := types.GetEffectiveGenesis().GetEpoch(); epoch <= last.GetEpoch(); epoch++ {
+		beacon, err := state.Beacons.GetBeacon(epoch)
+		if err == nil {
+			require.NoError(t, beacons.Add(state.DB, epoch, beacon))
+		}
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		report, err := Run(ctx, state.DB.Database, WithConfig(cfg), WithLogger(logtest.New(t)))
+		require.NoError(t, err)
+		require.Equal(t, last, report.Last)
+		require.Equal(t, last.Sub(1), report.Verified)
+		require.Positive(t, report.Compared)
+		require.Zero(t, report.Unknown)
+		require.Empty(t, report.Divergences)
+	})
+
+	t.Run("last", func(t *testing.T) {
+		report, err := Run(ctx, state.DB.Database, WithConfig(cfg), WithLast(last.Sub(5)))
+		require.NoError(t, err)
+		require.Equal(t, last.Sub(6), report.Verified)
+		require.Empty(t, report.Divergences)
+	})
+
+	t.Run("diverged", func(t *testing.T) {
+		target := last.Sub(5)
+		ids, err := blocks.IDsInLayer(state.DB, target)
+		require.NoError(t, err)
+		require.NotEmpty(t, ids)
+		valid, err := blocks.IsValid(state.DB, ids[0])
+		require.NoError(t, err)
+		setValidity := func(valid bool) {
+			if valid {
+				require.NoError(t, blocks.SetValid(state.DB, ids[0]))
+			} else {
+				require.NoError(t, blocks.SetInvalid(state.DB, ids[0]))
+			}
+		}
+		setValidity(!valid)
+		t.Cleanup(func() { setValidity(valid) })
+
+		report, err := Run(ctx, state.DB.Database, WithConfig(cfg), WithLogger(logtest.New(t)))
+		require.NoError(t, err)
+		require.Equal(t, []Divergence{{
+			Block:    ids[0],
+			Layer:    target,
+			Stored:   !valid,
+			Replayed: valid,
+			At:       target.Add(1),
+		}}, report.Divergences)
+	})
+}
@@ -0,0 +1,144 @@
+package sim
+
+import (
+	"math/rand"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// Publication is a ballot that is published by the miner in the generated layer.
+type Publication struct {
+	Votes  Voting
+	Beacon types.Beacon
+	// States is a list of state indexes that receive the ballot.
+	// Ballot is received by every state if the list is empty.
+	States []int
+	// Delay is a number of layers after which ballot is released.
+	Delay uint32
+}
+
+// Adversary controls ballots of the subset of miners.
+type Adversary interface {
+	// Controls returns true if the miner with the index is controlled by the adversary.
+	Controls(miner int) bool
+	// Publish receives ballot that would be published by the honest miner, and returns
+	// ballots that will be published instead.
+	Publish(rng *rand.Rand, layers []*types.Layer, miner int, honest Publication) []Publication
+}
+
+// controlled is a set of miners with index lower than n.
+type controlled int
+
+func (c controlled) Controls(miner int) bool {
+	return miner < int(c)
+}
+
+// Equivocate creates an adversary that publishes ballot with different votes for every state.
+// Votes for state i are generated by gens[i], number of generators must be equal to the number of states.
+//
+// Ballots are not delivered to every state, therefore they are never used as a base ballot by the honest miners.
+func Equivocate(miners int, gens ...VotesGenerator) Adversary {
+	return &equivocate{controlled: controlled(miners), gens: gens}
+}
+
+type equivocate struct {
+	controlled
+	gens []VotesGenerator
+}
+
+func (e *equivocate) Publish(rng *rand.Rand, layers []*types.Layer, miner int, honest Publication) []Publication {
+	rst := make([]Publication, 0, len(e.gens))
+	for i, gen := range e.gens {
+		rst = append(rst, Publication{
+			Votes:  gen(rng, layers, miner),
+			Beacon: honest.Beacon,
+			States: []int{i},
+		})
+	}
+	return rst
+}
+
+// Withhold creates an adversary that publishes ballots with honest votes, but releases them
+// with a delay of the specified number of layers.
+func Withhold(miners int, delay uint32) Adversary {
+	return &withhold{controlled: controlled(miners), delay: delay}
+}
+
+type withhold struct {
+	controlled
+	delay uint32
+}
+
+func (w *withhold) Publish(_ *rand.Rand, _ []*types.Layer, _ int, honest Publication) []Publication {
+	honest.Delay = w.delay
+	return []Publication{honest}
+}
+
+// WrongBeacon creates an adversary that publishes ballots with honest votes and a random beacon.
+func WrongBeacon(miners int) Adversary {
+	return &wrongBeacon{controlled: controlled(miners)}
+}
+
+type wrongBeacon struct {
+	controlled
+}
+
+func (w *wrongBeacon) Publish(rng *rand.Rand, _ []*types.Layer, _ int, honest Publication) []Publication {
+	rng.Read(honest.Beacon[:])
+	return []Publication{honest}
+}
+
+// Balance creates an adversary that tries to keep margin of every block in the previous layer
+// close to zero, so that votes never cross the threshold.
+//
+// Adversary knows how honest miners vote by running honest generator for miners in range [miners, total).
+// Miners are assumed to have equal weight.
+func Balance(miners, total int, honest VotesGenerator) Adversary {
+	return &balance{controlled: controlled(miners), total: total, honest: honest}
+}
+
+type balance struct {
+	controlled
+	total  int
+	honest VotesGenerator
+}
+
+func (b *balance) Publish(_ *rand.Rand, layers []*types.Layer, miner int, honest Publication) []Publication {
+	// private rng so that simulated honest votes don't change the sequence of the generator
+	rng := rand.New(rand.NewSource(0))
+	margins := map[types.BlockID]int{}
+	for i := int(b.controlled); i < b.total; i++ {
+		supported := map[types.BlockID]struct{}{}
+		for _, vote := range b.honest(rng, layers, i).Support {
+			supported[vote.ID] = struct{}{}
+		}
+		for _, block := range layers[len(layers)-1].Blocks() {
+			if _, exist := supported[block.ID()]; exist {
+				margins[block.ID()]++
+			} else {
+				margins[block.ID()]--
+			}
+		}
+	}
+	votes := Voting{Base: honest.Votes.Base}
+	for _, block := range layers[len(layers)-1].Blocks() {
+		// votes of the adversarial miners that published ballots before this one
+		margin := margins[block.ID()]
+		for i := 0; i < miner; i++ {
+			if margin < 0 {
+				margin++
+			} else {
+				margin--
+			}
+		}
+		if margin < 0 {
+			votes.Support = append(votes.Support, types.Vote{
+				ID:      block.ID(),
+				LayerID: block.LayerIndex,
+				Height:  block.TickHeight,
+			})
+		}
+	}
+	honest.Votes = votes
+	return []Publication{honest}
+}
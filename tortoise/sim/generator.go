@@ -84,6 +84,7 @@ func New(opts ...GenOpt) *Generator {
 		conf:      defaults(),
 		logger:    log.NewNop(),
 		reordered: map[types.LayerID]types.LayerID{},
+		withheld:  map[types.LayerID][]withheld{},
 	}
 	for _, opt := range opts {
 		opt(g)
@@ -106,8 +107,12 @@ type Generator struct {
 	nextLayer types.LayerID
 	// key is when to return => value is the layer to return
 	reordered map[types.LayerID]types.LayerID
-	layers    []*types.Layer
-	units     [2]int
+	// withheld ballots by the layer when they are released.
+	withheld map[types.LayerID][]withheld
+	// released ballots in the last generated layer by the state index.
+	released map[int][]*types.Ballot
+	layers   []*types.Layer
+	units    [2]int
 
 	activations []types.ATXID
 	ticksRange  [2]int
@@ -31,6 +31,7 @@ type nextConf struct {
 	NumBlocks        int
 	BlockTickHeights []uint64
 	VoteGen          VotesGenerator
+	Adversary        Adversary
 }
 
 // WithNextReorder configures when reordered layer should be returned.
@@ -103,6 +104,13 @@ func WithHareOutputIndex(i int) NextOpt {
 	}
 }
 
+// WithAdversary declares adversary that controls ballots of the subset of miners.
+func WithAdversary(adv Adversary) NextOpt {
+	return func(c *nextConf) {
+		c.Adversary = adv
+	}
+}
+
 // Next generates the next layer.
 func (g *Generator) Next(opts ...NextOpt) types.LayerID {
 	cfg := nextConfDefaults()
@@ -148,6 +156,8 @@ func (g *Generator) genLayer(cfg nextConf) types.LayerID {
 		g.genBeacon()
 	}
 
+	g.release(g.nextLayer)
+
 	layer := types.NewLayer(g.nextLayer)
 	size := int(g.conf.LayerSize)
 	if cfg.LayerSize >= 0 {
@@ -173,26 +183,38 @@ func (g *Generator) genLayer(cfg nextConf) types.LayerID {
 		if err != nil {
 			g.logger.With().Panic("failed to get a beacon", log.Err(err))
 		}
-		ballot := &types.Ballot{
-			InnerBallot: types.InnerBallot{
-				AtxID:             atxid,
-				EligibilityProofs: proofs,
-				LayerIndex:        g.nextLayer,
-				EpochData: &types.EpochData{
-					ActiveSet: activeset,
-					Beacon:    beacon,
-				},
-			},
-			Votes: voting,
-		}
-		ballot.Signature = signer.Sign(ballot.SignedBytes())
-		if err = ballot.Initialize(); err != nil {
-			g.logger.With().Panic("failed to init ballot", log.Err(err))
+		publications := []Publication{{Votes: voting, Beacon: beacon}}
+		if cfg.Adversary != nil && cfg.Adversary.Controls(miner) {
+			publications = cfg.Adversary.Publish(g.rng, g.layers, miner, publications[0])
 		}
-		for _, state := range g.states {
-			state.OnBallot(ballot)
+		for _, pub := range publications {
+			ballot := &types.Ballot{
+				InnerBallot: types.InnerBallot{
+					AtxID:             atxid,
+					EligibilityProofs: proofs,
+					LayerIndex:        g.nextLayer,
+					EpochData: &types.EpochData{
+						ActiveSet: activeset,
+						Beacon:    pub.Beacon,
+					},
+				},
+				Votes: pub.Votes,
+			}
+			ballot.Signature = signer.Sign(ballot.SignedBytes())
+			if err = ballot.Initialize(); err != nil {
+				g.logger.With().Panic("failed to init ballot", log.Err(err))
+			}
+			if pub.Delay > 0 {
+				release := g.nextLayer.Add(pub.Delay)
+				g.withheld[release] = append(g.withheld[release], withheld{ballot: ballot, states: pub.States})
+				continue
+			}
+			g.deliver(ballot, pub.States)
+			// ballots that are not delivered to every state can't be used as a base ballot by honest miners
+			if len(pub.States) == 0 {
+				layer.AddBallot(ballot)
+			}
 		}
-		layer.AddBallot(ballot)
 	}
 	if len(cfg.BlockTickHeights) < cfg.NumBlocks {
 		g.logger.With().Panic("BlockTickHeights should be atleast to NumBlocks",
@@ -226,3 +248,48 @@ func (g *Generator) genLayer(cfg nextConf) types.LayerID {
 	g.nextLayer = g.nextLayer.Add(1)
 	return layer.Index()
 }
+
+type withheld struct {
+	ballot *types.Ballot
+	states []int
+}
+
+// deliver ballot to the states with specified indexes, or to all states if none are specified.
+func (g *Generator) deliver(ballot *types.Ballot, states []int) {
+	if len(states) == 0 {
+		for _, state := range g.states {
+			state.OnBallot(ballot)
+		}
+		return
+	}
+	for _, i := range states {
+		g.states[i].OnBallot(ballot)
+	}
+}
+
+// release withheld ballots that are scheduled for the layer.
+func (g *Generator) release(lid types.LayerID) {
+	g.released = map[int][]*types.Ballot{}
+	for _, w := range g.withheld[lid] {
+		g.deliver(w.ballot, w.states)
+		if len(w.states) == 0 {
+			for i := range g.states {
+				g.released[i] = append(g.released[i], w.ballot)
+			}
+		}
+		for _, i := range w.states {
+			g.released[i] = append(g.released[i], w.ballot)
+		}
+	}
+	delete(g.withheld, lid)
+}
+
+// Released returns ballots that were withheld by adversary and released to the state
+// in the last generated layer.
+//
+// Tortoise loads ballots of the layer from the database only when the layer is processed,
+// therefore late ballots need to be passed to tortoise directly, as it is done by the node
+// when ballots are received.
+func (g *Generator) Released(i int) []*types.Ballot {
+	return g.released[i]
+}
@@ -0,0 +1,109 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// Outcome collects decisions of tortoise instances, one for every state, to check
+// safety and liveness of the scenario.
+type Outcome struct {
+	validity []map[types.BlockID]bool
+	reverted []int
+	lag      []uint32
+	maxLag   []uint32
+}
+
+// NewOutcome creates Outcome for the number of states.
+func NewOutcome(states int) *Outcome {
+	o := &Outcome{
+		validity: make([]map[types.BlockID]bool, states),
+		reverted: make([]int, states),
+		lag:      make([]uint32, states),
+		maxLag:   make([]uint32, states),
+	}
+	for i := range o.validity {
+		o.validity[i] = map[types.BlockID]bool{}
+	}
+	return o
+}
+
+// Update records validity updates emitted by tortoise of the state after tallying votes up to the last layer.
+func (o *Outcome) Update(state int, last, verified types.LayerID, updates []types.BlockContextualValidity) {
+	for _, update := range updates {
+		prev, exist := o.validity[state][update.ID]
+		if exist && prev != update.Validity {
+			o.reverted[state]++
+		}
+		o.validity[state][update.ID] = update.Validity
+	}
+	o.lag[state] = 0
+	if last.After(verified) {
+		o.lag[state] = last.Difference(verified)
+	}
+	if o.lag[state] > o.maxLag[state] {
+		o.maxLag[state] = o.lag[state]
+	}
+}
+
+// Safety returns an error if any state changed validity of the block after it was decided,
+// or if two states decided differently on the same block.
+func (o *Outcome) Safety() error {
+	for i, reverted := range o.reverted {
+		if reverted > 0 {
+			return fmt.Errorf("state %d reverted validity of %d blocks", i, reverted)
+		}
+	}
+	for i := 1; i < len(o.validity); i++ {
+		for id, valid := range o.validity[i] {
+			other, exist := o.validity[0][id]
+			if exist && other != valid {
+				return fmt.Errorf("states 0 and %d decided differently on block %s", i, id)
+			}
+		}
+	}
+	return nil
+}
+
+// Liveness returns an error if at any point the distance between the last and verified layer
+// in any of the states was larger than maxLag.
+func (o *Outcome) Liveness(maxLag uint32) error {
+	for i, lag := range o.maxLag {
+		if lag > maxLag {
+			return fmt.Errorf("state %d verified layer lagged by %d layers (max %d)", i, lag, maxLag)
+		}
+	}
+	return nil
+}
+
+// Recovered returns an error if the last recorded distance between the last and verified layer
+// in any of the states is larger than maxLag.
+func (o *Outcome) Recovered(maxLag uint32) error {
+	for i, lag := range o.lag {
+		if lag > maxLag {
+			return fmt.Errorf("state %d didn't recover, verified layer lags by %d layers (max %d)", i, lag, maxLag)
+		}
+	}
+	return nil
+}
+
+// MaxLag returns the largest distance between the last and verified layer across all states.
+func (o *Outcome) MaxLag() uint32 {
+	var rst uint32
+	for _, lag := range o.maxLag {
+		if lag > rst {
+			rst = lag
+		}
+	}
+	return rst
+}
+
+// Reverted returns the number of blocks with changed validity across all states.
+func (o *Outcome) Reverted() int {
+	var rst int
+	for _, reverted := range o.reverted {
+		rst += reverted
+	}
+	return rst
+}
@@ -166,6 +166,8 @@ type layerInfo struct {
 	hareTerminated bool
 	blocks         []*blockInfo
 	verifying      verifyingInfo
+	// coinflip is true if local votes for blocks in the layer were decided by the weak coin.
+	coinflip bool
 
 	opinion types.Hash32
 	// a pointer to the value stored on the previous layerInfo object
@@ -36,6 +36,8 @@ type turtle struct {
 
 	isFull bool
 	full   *full
+
+	stats progressStats
 }
 
 // newTurtle creates a new verifying tortoise algorithm instance.
@@ -282,6 +284,15 @@ func (t *turtle) encodeVotes(
 // outside of hdist. if opinion is undecided according to the votes it will use coinflip recorded
 // in the current layer.
 func (t *turtle) getFullVote(verified, current types.LayerID, block *blockInfo) (sign, voteReason, error) {
+	vote, reason, err := t.fullVote(verified, current, block)
+	if err == nil && reason == reasonCoinflip {
+		t.onCoinflip(t.layer(block.layer))
+	}
+	return vote, reason, err
+}
+
+// fullVote is getFullVote without accounting for coinflips.
+func (t *turtle) fullVote(verified, current types.LayerID, block *blockInfo) (sign, voteReason, error) {
 	vote, reason := getLocalVote(t.Config, verified, current, block)
 	if !(vote == abstain && reason == reasonValidity) {
 		return vote, reason, nil
@@ -365,6 +376,7 @@ func (t *turtle) switchModes(logger log.Log) {
 	} else {
 		modeGauge.Set(0)
 	}
+	t.onModeSwitch()
 	logger.With().Debug("switching tortoise mode",
 		log.Uint32("hdist", t.Hdist),
 		log.Stringer("processed_layer", t.processed),
@@ -423,6 +435,9 @@ func (t *turtle) verifyLayers() error {
 			break
 		}
 		verified = target
+		if target.After(t.verified) {
+			t.onVerified(target)
+		}
 		for _, block := range t.layers[target].blocks {
 			if block.emitted == block.validity {
 				continue
@@ -458,6 +473,7 @@ func (t *turtle) verifyLayers() error {
 	}
 	t.verified = verified
 	verifiedLayer.Set(float64(t.verified.Value))
+	verifiedLag.Set(float64(t.lag()))
 	return nil
 }
 
@@ -684,32 +700,28 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 	}
 
 	if ballot.EpochData != nil {
-		beacon := ballot.EpochData.Beacon
-		height, err := getBallotHeight(t.cdb, ballot)
+		var err error
+		refinfo, err = t.decodeReference(ballot)
 		if err != nil {
 			return nil, err
 		}
-		refweight, err := putil.ComputeWeightPerEligibility(t.cdb, ballot, t.LayerSize, types.GetLayersPerEpoch())
+	} else if ref, exists := t.state.ballotRefs[ballot.RefBallot]; exists {
+		if ref.reference == nil {
+			return nil, fmt.Errorf("invalid ballot use as a reference %s", ballot.RefBallot)
+		}
+		refinfo = ref.reference
+	} else {
+		var err error
+		refinfo, err = t.loadReference(ballot.RefBallot)
 		if err != nil {
 			return nil, err
 		}
-		refinfo = &referenceInfo{
-			height: height,
-			beacon: beacon,
-			weight: refweight,
-		}
-	} else {
-		ref, exists := t.state.ballotRefs[ballot.RefBallot]
-		if !exists {
+		if refinfo == nil {
 			t.logger.With().Warning("ref ballot not in state",
 				log.Stringer("ref", ballot.RefBallot),
 			)
 			return nil, nil
 		}
-		if ref.reference == nil {
-			return nil, fmt.Errorf("invalid ballot use as a reference %s", ballot.RefBallot)
-		}
-		refinfo = ref.reference
 	}
 
 	binfo := &ballotInfo{
@@ -752,6 +764,41 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 	return binfo, nil
 }
 
+func (t *turtle) decodeReference(ballot *types.Ballot) (*referenceInfo, error) {
+	height, err := getBallotHeight(t.cdb, ballot)
+	if err != nil {
+		return nil, err
+	}
+	refweight, err := putil.ComputeWeightPerEligibility(t.cdb, ballot, t.LayerSize, types.GetLayersPerEpoch())
+	if err != nil {
+		return nil, err
+	}
+	return &referenceInfo{
+		height: height,
+		beacon: ballot.EpochData.Beacon,
+		weight: refweight,
+	}, nil
+}
+
+// loadReference decodes reference ballot from an evicted layer. Such ballots are not in the state,
+// in particular when the state was seeded from the checkpoint of the node.
+// Nil is returned if the ballot is not in the database or its layer is not evicted.
+func (t *turtle) loadReference(id types.BallotID) (*referenceInfo, error) {
+	ballot, err := ballots.Get(t.cdb, id)
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read ref ballot %s: %w", id, err)
+	}
+	if ballot.LayerIndex.After(t.evicted) {
+		return nil, nil
+	}
+	if ballot.EpochData == nil {
+		return nil, fmt.Errorf("invalid ballot use as a reference %s", id)
+	}
+	return t.decodeReference(ballot)
+}
+
 func (t *turtle) storeBallot(ballot *ballotInfo) error {
 	if !ballot.layer.After(t.evicted) {
 		return nil
@@ -1241,6 +1241,12 @@ func TestWeakCoinVoting(t *testing.T) {
 	block, err := blocks.Get(s.GetState(0).DB, votes.Support[0].ID)
 	require.NoError(t, err)
 	require.Equal(t, block.LayerIndex, genesis.Add(2))
+	// both layers outside of hdist are decided by the coin
+	require.EqualValues(t, 2, tortoise.Progress().Coinflips)
+
+	_, err = tortoise.EncodeVotes(ctx, EncodeVotesWithCurrent(last.Add(1)))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, tortoise.Progress().Coinflips, "layer is accounted once")
 
 	for i := 0; i < 10; i++ {
 		last = s.Next(sim.WithVoteGenerator(tortoiseVoting(tortoise)))
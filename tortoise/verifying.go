@@ -36,9 +36,7 @@ func (v *verifying) countBallot(logger log.Log, ballot *ballotInfo) {
 	start := time.Now()
 
 	prev := v.layer(ballot.layer.Sub(1))
-	counted := !(ballot.conditions.badBeacon ||
-		prev.opinion != ballot.opinion() ||
-		prev.verifying.referenceHeight > ballot.reference.height)
+	counted := v.isGood(ballot)
 	logger.With().Debug("count ballot in verifying mode",
 		ballot.layer,
 		ballot.id,
@@ -62,6 +60,14 @@ func (v *verifying) countBallot(logger log.Log, ballot *ballotInfo) {
 	vcountBallotDuration.Observe(float64(time.Since(start).Nanoseconds()))
 }
 
+// isGood returns true if ballot votes consistently with the local opinion and can be counted in verifying mode.
+func (v *verifying) isGood(ballot *ballotInfo) bool {
+	prev := v.layer(ballot.layer.Sub(1))
+	return !(ballot.conditions.badBeacon ||
+		prev.opinion != ballot.opinion() ||
+		prev.verifying.referenceHeight > ballot.reference.height)
+}
+
 func (v *verifying) countVotes(logger log.Log, ballots []*ballotInfo) {
 	for _, ballot := range ballots {
 		v.countBallot(logger, ballot)
@@ -1,6 +1,7 @@
 package txs
 
 import (
+	"container/heap"
 	"container/list"
 	"context"
 	"errors"
@@ -20,12 +21,16 @@ import (
 const (
 	maxTXsPerAcct  = 100
 	maxTXsPerNonce = 100
+
+	defaultReplaceBump = 10
 )
 
 var (
 	errBadNonce            = errors.New("bad nonce")
 	errInsufficientBalance = errors.New("insufficient balance")
 	errTooManyNonce        = errors.New("account has too many nonce pending")
+	errUnderpriced         = errors.New("replacement transaction underpriced")
+	errMempoolFull         = errors.New("mempool is full")
 	errLayerNotInOrder     = errors.New("layers not applied in order")
 )
 
@@ -151,6 +156,7 @@ func (ac *accountCache) accept(logger log.Log, ntx *NanoTX, blockSeed []byte) er
 	ac.cachedTXs[ntx.ID] = ntx
 
 	if replaced != nil {
+		mempoolEvictCount.WithLabelValues(evictReplaced).Inc()
 		logger.With().Debug("better transaction replaced for nonce",
 			log.Stringer("better", ntx.ID),
 			log.Stringer("replaced", replaced.ID),
@@ -187,6 +193,7 @@ func (ac *accountCache) accept(logger log.Log, ntx *NanoTX, blockSeed []byte) er
 		next = next.Next()
 		removed := ac.txsByNonce.Remove(rm).(*candidate)
 		delete(ac.cachedTXs, removed.id())
+		mempoolEvictCount.WithLabelValues(evictInfeasible).Inc()
 		logger.With().Debug("tx made infeasible by new/better transaction",
 			removed.id(),
 			log.Uint64("nonce", removed.nonce()),
@@ -279,13 +286,56 @@ func findBest(ntxs []*NanoTX, balance uint64, blockSeed []byte) *NanoTX {
 	return best
 }
 
+func (ac *accountCache) getByNonce(nonce uint64) *candidate {
+	for e := ac.txsByNonce.Back(); e != nil; e = e.Prev() {
+		cand := e.Value.(*candidate)
+		if cand.nonce() == nonce {
+			return cand
+		} else if cand.nonce() < nonce {
+			break
+		}
+	}
+	return nil
+}
+
+// replaces returns true if the gas price of a replacement transaction is higher
+// than the gas price of the pending transaction by at least bump percents.
+func replaces(pending, replacement *NanoTX, bump uint64) bool {
+	if replacement.GasPrice <= pending.GasPrice {
+		return false
+	}
+	return (replacement.GasPrice-pending.GasPrice)*100 >= pending.GasPrice*bump
+}
+
+// checkReplacement returns errUnderpriced if the account already has a pending transaction
+// with the same nonce, and the new one doesn't replace it.
+func (ac *accountCache) checkReplacement(logger log.Log, ntx *NanoTX, bump uint64) error {
+	pending := ac.getByNonce(ntx.Nonce)
+	if pending == nil {
+		return nil
+	}
+	if ntx.Better(pending.best, nil) && replaces(pending.best, ntx, bump) {
+		return nil
+	}
+	logger.With().Debug("replacement transaction underpriced",
+		ntx.ID,
+		log.Stringer("pending", pending.id()),
+		log.Uint64("nonce", ntx.Nonce),
+		log.Uint64("gas_price", ntx.GasPrice),
+		log.Uint64("pending_gas_price", pending.best.GasPrice),
+		log.Uint64("bump", bump))
+	return fmt.Errorf("%w: gas price %d, pending %d, required bump %d%%",
+		errUnderpriced, ntx.GasPrice, pending.best.GasPrice, bump)
+}
+
 // adding a tx to the account cache. possible outcomes:
 //   - nonce is smaller than the next nonce in state: reject from cache
 //   - too many txs present: reject from cache
 //   - nonce already exists in the cache:
-//     if it is better than the best candidate in that nonce group, swap
+//     if it is better than the best candidate in that nonce group and its gas price
+//     is higher by at least the bump percents, swap. otherwise reject as underpriced
 //   - nonce not present: add to cache.
-func (ac *accountCache) add(logger log.Log, tx *types.Transaction, received time.Time) error {
+func (ac *accountCache) add(logger log.Log, tx *types.Transaction, received time.Time, bump uint64) error {
 	if tx.Nonce < ac.startNonce {
 		logger.With().Warning("nonce too small",
 			tx.ID,
@@ -301,12 +351,21 @@ func (ac *accountCache) add(logger log.Log, tx *types.Transaction, received time
 		BlockID:     types.EmptyBlockID,
 	})
 
-	err := ac.accept(logger, ntx, nil)
+	// balance is checked first, so that infeasible transactions are stored and reconsidered later
+	_, _, err := ac.precheck(logger, ntx)
+	if err == nil {
+		err = ac.checkReplacement(logger, ntx, bump)
+	}
+	if err == nil {
+		err = ac.accept(logger, ntx, nil)
+	}
 	if err != nil {
 		if errors.Is(err, errTooManyNonce) {
 			mempoolTxCount.WithLabelValues(tooManyNonce).Inc()
 		} else if errors.Is(err, errInsufficientBalance) {
 			mempoolTxCount.WithLabelValues(balanceTooSmall).Inc()
+		} else if errors.Is(err, errUnderpriced) {
+			mempoolTxCount.WithLabelValues(underpriced).Inc()
 		}
 		return err
 	}
@@ -314,6 +373,28 @@ func (ac *accountCache) add(logger log.Log, tx *types.Transaction, received time
 	return nil
 }
 
+// evictable returns the candidate that can be removed from the account without
+// breaking nonce order: the last one, if it is not yet packed in a proposal/block.
+func (ac *accountCache) evictable() *candidate {
+	back := ac.txsByNonce.Back()
+	if back == nil {
+		return nil
+	}
+	cand := back.Value.(*candidate)
+	if cand.layer() != (types.LayerID{}) {
+		return nil
+	}
+	return cand
+}
+
+// evictLast removes the last candidate from the account. the transaction stays in the database
+// and is reconsidered when the cache has room for it.
+func (ac *accountCache) evictLast() *candidate {
+	removed := ac.txsByNonce.Remove(ac.txsByNonce.Back()).(*candidate)
+	delete(ac.cachedTXs, removed.id())
+	return removed
+}
+
 func (ac *accountCache) addPendingFromNonce(logger log.Log, db *sql.Database, nonce uint64, applied types.LayerID) error {
 	mtxs, err := transactions.GetAcctPendingFromNonce(db, ac.addr, nonce)
 	if err != nil {
@@ -408,22 +489,78 @@ func (ac *accountCache) shouldEvict() bool {
 
 type stateFunc func(types.Address) (uint64, uint64)
 
+// CacheOpt for configuring Cache.
+type CacheOpt func(*Cache)
+
+// WithReplaceBump sets the minimal increase of the gas price, in percents, required
+// to replace a pending transaction with the same nonce.
+func WithReplaceBump(percent uint64) CacheOpt {
+	return func(c *Cache) {
+		c.replaceBump = percent
+	}
+}
+
+// WithMaxSize sets the maximal number of transactions in the cache.
+// Transactions with the lowest gas price are evicted when the size is exceeded.
+// The size is not limited if it is 0.
+func WithMaxSize(size int) CacheOpt {
+	return func(c *Cache) {
+		c.maxSize = size
+	}
+}
+
 type Cache struct {
-	logger log.Log
-	stateF stateFunc
+	logger      log.Log
+	stateF      stateFunc
+	replaceBump uint64
+	maxSize     int
 
 	mu        sync.Mutex
 	pending   map[types.Address]*accountCache
 	cachedTXs map[types.TransactionID]*NanoTX // shared with accountCache instances
+	// tails is a min-heap of the evictable candidates by gas price. entries are not removed
+	// when the account changes, instead they are validated when popped.
+	tails evictionHeap
+	// evicted is a set of accounts with transactions that were evicted due to the size cap.
+	// they are reloaded from the database only when the cache has room for them.
+	evicted map[types.Address]struct{}
+}
+
+type evictionEntry struct {
+	acct     *accountCache
+	cand     *candidate
+	gasPrice uint64
+}
+
+type evictionHeap []evictionEntry
+
+func (h evictionHeap) Len() int           { return len(h) }
+func (h evictionHeap) Less(i, j int) bool { return h[i].gasPrice < h[j].gasPrice }
+func (h evictionHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *evictionHeap) Push(x any)        { *h = append(*h, x.(evictionEntry)) }
+
+func (h *evictionHeap) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = evictionEntry{}
+	*h = old[:n-1]
+	return entry
 }
 
-func NewCache(s stateFunc, logger log.Log) *Cache {
-	return &Cache{
-		logger:    logger,
-		stateF:    s,
-		pending:   make(map[types.Address]*accountCache),
-		cachedTXs: make(map[types.TransactionID]*NanoTX),
+func NewCache(s stateFunc, logger log.Log, opts ...CacheOpt) *Cache {
+	c := &Cache{
+		logger:      logger,
+		stateF:      s,
+		replaceBump: defaultReplaceBump,
+		pending:     make(map[types.Address]*accountCache),
+		cachedTXs:   make(map[types.TransactionID]*NanoTX),
+		evicted:     make(map[types.Address]struct{}),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func groupTXsByPrincipal(logger log.Log, mtxs []*types.MeshTransaction) map[types.Address]map[uint64][]*NanoTX {
@@ -487,6 +624,8 @@ func (c *Cache) BuildFromTXs(rst []*types.MeshTransaction, blockSeed []byte) err
 	defer c.mu.Unlock()
 
 	c.pending = make(map[types.Address]*accountCache)
+	c.tails = nil
+	c.evicted = make(map[types.Address]struct{})
 	toCleanup := make(map[types.Address]struct{})
 	for _, tx := range rst {
 		toCleanup[tx.Principal] = struct{}{}
@@ -500,6 +639,7 @@ func (c *Cache) BuildFromTXs(rst []*types.MeshTransaction, blockSeed []byte) err
 		if err := c.pending[principal].addBatch(c.logger, nonce2TXs, blockSeed); err != nil {
 			return err
 		}
+		c.trackEvictable(c.pending[principal])
 		if c.pending[principal].shouldEvict() {
 			c.logger.With().Warning("account has pending txs but none feasible",
 				principal,
@@ -509,6 +649,7 @@ func (c *Cache) BuildFromTXs(rst []*types.MeshTransaction, blockSeed []byte) err
 		}
 	}
 	c.logger.Info("added pending tx for %d accounts", acctsAdded)
+	c.evict(c.logger)
 	return nil
 }
 
@@ -530,6 +671,9 @@ func (c *Cache) createAcctIfNotPresent(addr types.Address) {
 }
 
 func (c *Cache) MoreInDB(addr types.Address) bool {
+	if _, ok := c.evicted[addr]; ok {
+		return true
+	}
 	acct, ok := c.pending[addr]
 	if !ok {
 		return false
@@ -552,8 +696,91 @@ func (c *Cache) cleanupAccounts(accounts map[types.Address]struct{}) {
 //     re-evaluate it after each layer is applied.
 //   - errTooManyNonce: when a principal has way too many nonces, we don't want to blow up the memory. they should
 //     be stored in db and retrieved after each earlier nonce is applied.
+//   - errMempoolFull: the tx has the lowest gas price in the full cache. same as errTooManyNonce, it is
+//     stored in db and reconsidered after a layer is applied.
 func acceptable(err error) bool {
-	return err == nil || errors.Is(err, errInsufficientBalance) || errors.Is(err, errTooManyNonce)
+	return err == nil ||
+		errors.Is(err, errInsufficientBalance) ||
+		errors.Is(err, errTooManyNonce) ||
+		errors.Is(err, errMempoolFull)
+}
+
+// trackEvictable adds the evictable candidate of the account to the eviction heap.
+// it must be called after every change of the account that may change its last candidate.
+func (c *Cache) trackEvictable(acct *accountCache) {
+	if c.maxSize == 0 {
+		return
+	}
+	cand := acct.evictable()
+	if cand == nil {
+		return
+	}
+	heap.Push(&c.tails, evictionEntry{acct: acct, cand: cand, gasPrice: cand.best.GasPrice})
+	// stale entries are collected once they outnumber the accounts
+	if len(c.tails) > 2*len(c.pending)+maxTXsPerAcct {
+		c.rebuildEvictable()
+	}
+}
+
+func (c *Cache) rebuildEvictable() {
+	c.tails = c.tails[:0]
+	for _, acct := range c.pending {
+		if cand := acct.evictable(); cand != nil {
+			c.tails = append(c.tails, evictionEntry{acct: acct, cand: cand, gasPrice: cand.best.GasPrice})
+		}
+	}
+	heap.Init(&c.tails)
+}
+
+// popEvictable returns the account with the evictable candidate with the lowest gas price.
+func (c *Cache) popEvictable() *accountCache {
+	for c.tails.Len() > 0 {
+		entry := heap.Pop(&c.tails).(evictionEntry)
+		if c.pending[entry.acct.addr] != entry.acct ||
+			entry.acct.evictable() != entry.cand ||
+			entry.cand.best.GasPrice != entry.gasPrice {
+			continue
+		}
+		return entry.acct
+	}
+	return nil
+}
+
+// evict removes transactions with the lowest gas price until the cache fits into max size.
+// only the highest nonce of an account can be evicted, so that the nonce order is preserved.
+// returns true if anything was evicted.
+func (c *Cache) evict(logger log.Log) bool {
+	if c.maxSize == 0 {
+		return false
+	}
+	evicted := false
+	rebuilt := false
+	for len(c.cachedTXs) > c.maxSize {
+		lowest := c.popEvictable()
+		if lowest == nil && !rebuilt {
+			// candidates may become evictable without being tracked, e.g. when they are
+			// unlinked from an empty layer
+			c.rebuildEvictable()
+			rebuilt = true
+			continue
+		}
+		if lowest == nil {
+			// everything is packed in proposals/blocks and will be retired when layers are applied
+			return evicted
+		}
+		removed := lowest.evictLast()
+		c.evicted[lowest.addr] = struct{}{}
+		c.trackEvictable(lowest)
+		evicted = true
+		mempoolEvictCount.WithLabelValues(evictSizeCap).Inc()
+		logger.With().Debug("evicted tx from full mempool",
+			removed.id(),
+			lowest.addr,
+			log.Uint64("nonce", removed.nonce()),
+			log.Uint64("gas_price", removed.best.GasPrice),
+			log.Int("max_size", c.maxSize))
+	}
+	return evicted
 }
 
 func (c *Cache) Add(ctx context.Context, db *sql.Database, tx *types.Transaction, received time.Time, mustPersist bool) error {
@@ -563,10 +790,25 @@ func (c *Cache) Add(ctx context.Context, db *sql.Database, tx *types.Transaction
 	c.createAcctIfNotPresent(principal)
 	defer c.cleanupAccounts(map[types.Address]struct{}{principal: {}})
 	logger := c.logger.WithContext(ctx).WithFields(principal)
-	err := c.pending[principal].add(logger, tx, received)
+	err := c.pending[principal].add(logger, tx, received, c.replaceBump)
+	if err == nil {
+		c.trackEvictable(c.pending[principal])
+		if c.evict(logger) && !c.has(tx.ID) {
+			mempoolTxCount.WithLabelValues(mempoolFull).Inc()
+			err = errMempoolFull
+		}
+	}
 	if acceptable(err) {
+		// tx evicted as the cheapest one is already counted as full
+		if !errors.Is(err, errMempoolFull) {
+			mempoolTxCount.WithLabelValues(accepted).Inc()
+		}
+		err = nil
+	}
+	if mustPersist && errors.Is(err, errUnderpriced) {
+		// replace-by-fee applies only to the mempool. tx that lost to a pending tx with the same nonce
+		// may still be referenced by a proposal or a block and must be stored.
 		err = nil
-		mempoolTxCount.WithLabelValues(accepted).Inc()
 	}
 	if err == nil || mustPersist {
 		if dbErr := transactions.Add(db, tx, received); dbErr != nil {
@@ -646,6 +888,9 @@ func (c *Cache) applyEmptyLayer(db *sql.Database, lid types.LayerID) error {
 				return err
 			}
 			ntx.UpdateLayer(nbid, nlid)
+			if acct, ok := c.pending[ntx.Principal]; ok {
+				c.trackEvictable(acct)
+			}
 		}
 	}
 	return nil
@@ -733,8 +978,7 @@ func (c *Cache) ApplyLayer(
 			log.Uint64("nonce", nextNonce),
 			log.Uint64("balance", balance))
 		t0 := time.Now()
-		if err := c.pending[principal].resetAfterApply(logger, db, nextNonce, balance, lid); err != nil {
-			logger.With().Error("failed to reset cache for principal", principal, log.Err(err))
+		if err := c.resetAccount(logger, db, principal, nextNonce, balance, lid); err != nil {
 			return err
 		}
 		acctResetDuration.Observe(float64(time.Since(t0)))
@@ -748,15 +992,40 @@ func (c *Cache) ApplyLayer(
 			toReset[principal] = struct{}{}
 		}
 	}
+	// transactions evicted due to the size cap are reconsidered only if some of them may fit,
+	// otherwise they would be loaded and evicted again after every layer
+	if c.maxSize == 0 || len(c.cachedTXs) < c.maxSize {
+		for principal := range c.evicted {
+			if _, ok := toCleanup[principal]; ok {
+				continue
+			}
+			c.createAcctIfNotPresent(principal)
+			toReset[principal] = struct{}{}
+			toCleanup[principal] = struct{}{}
+		}
+	}
 	for principal := range toReset {
 		nextNonce, balance := c.stateF(principal)
 		t2 := time.Now()
-		if err := c.pending[principal].resetAfterApply(logger, db, nextNonce, balance, lid); err != nil {
-			logger.With().Error("failed to reset cache for principal", principal, log.Err(err))
+		if err := c.resetAccount(logger, db, principal, nextNonce, balance, lid); err != nil {
 			return err
 		}
 		acctResetDuration.Observe(float64(time.Since(t2)))
 	}
+	c.evict(logger)
+	return nil
+}
+
+// resetAccount reloads pending transactions of the account from the database, including
+// the ones that were evicted due to the size cap.
+func (c *Cache) resetAccount(logger log.Log, db *sql.Database, principal types.Address, nextNonce, balance uint64, applied types.LayerID) error {
+	acct := c.pending[principal]
+	if err := acct.resetAfterApply(logger, db, nextNonce, balance, applied); err != nil {
+		logger.With().Error("failed to reset cache for principal", principal, log.Err(err))
+		return err
+	}
+	delete(c.evicted, principal)
+	c.trackEvictable(acct)
 	return nil
 }
 
@@ -800,6 +1069,86 @@ func (c *Cache) GetMempool(logger log.Log) map[types.Address][]*NanoTX {
 	return all
 }
 
+// Drop removes pending transactions from the cache and the database.
+// Transactions that are applied or included in proposals/blocks are not removed.
+// Returns ids of the removed transactions.
+func (c *Cache) Drop(ctx context.Context, db *sql.Database, tids []types.TransactionID) ([]types.TransactionID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.drop(ctx, db, tids)
+}
+
+// DropPrincipal removes all pending transactions of the principal from the cache and the database.
+// Transactions that are included in proposals/blocks are not removed.
+// Returns ids of the removed transactions.
+func (c *Cache) DropPrincipal(ctx context.Context, db *sql.Database, addr types.Address) ([]types.TransactionID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	mtxs, err := transactions.GetAcctPendingFromNonce(db, addr, 0)
+	if err != nil {
+		return nil, err
+	}
+	tids := make([]types.TransactionID, 0, len(mtxs))
+	for _, mtx := range mtxs {
+		tids = append(tids, mtx.ID)
+	}
+	return c.drop(ctx, db, tids)
+}
+
+func (c *Cache) drop(ctx context.Context, db *sql.Database, tids []types.TransactionID) ([]types.TransactionID, error) {
+	logger := c.logger.WithContext(ctx)
+	var (
+		dropped    []types.TransactionID
+		principals = make(map[types.Address]struct{})
+	)
+	if err := db.WithTx(ctx, func(dbtx *sql.Tx) error {
+		for _, tid := range tids {
+			mtx, err := transactions.Get(dbtx, tid)
+			if errors.Is(err, sql.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			deleted, err := transactions.DeletePending(dbtx, tid)
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				logger.With().Info("tx is not dropped as it is applied or included", tid)
+				continue
+			}
+			dropped = append(dropped, tid)
+			if mtx.TxHeader != nil {
+				principals[mtx.Principal] = struct{}{}
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("drop txs: %w", err)
+	}
+	if len(dropped) == 0 {
+		return nil, nil
+	}
+	mempoolEvictCount.WithLabelValues(evictDropped).Add(float64(len(dropped)))
+	applied, err := layers.GetLastApplied(db)
+	if err != nil {
+		return nil, fmt.Errorf("drop txs: get last applied %w", err)
+	}
+	defer c.cleanupAccounts(principals)
+	for principal := range principals {
+		if _, ok := c.pending[principal]; !ok {
+			continue
+		}
+		nextNonce, balance := c.stateF(principal)
+		if err := c.resetAccount(logger, db, principal, nextNonce, balance, applied); err != nil {
+			return nil, err
+		}
+	}
+	c.evict(logger)
+	logger.With().Info("dropped pending txs", log.Int("num_txs", len(dropped)), log.Int("num_accounts", len(principals)))
+	return dropped, nil
+}
+
 // checkApplyOrder returns an error if layers were not applied in order.
 func checkApplyOrder(logger log.Log, db *sql.Database, toApply types.LayerID) error {
 	lastApplied, err := layers.GetLastApplied(db)
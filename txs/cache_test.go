@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -626,6 +627,132 @@ func TestCache_Account_Add_SuperiorReplacesInferior_EvictLaterNonce(t *testing.T
 	checkTXStateFromDB(t, tc.db, append(mtxs, better), types.MEMPOOL)
 }
 
+func TestCache_Account_Add_ReplaceByFee(t *testing.T) {
+	tc, ta := createSingleAccountTestCache(t)
+	tc.replaceBump = 50
+	pending := &types.MeshTransaction{
+		Transaction: *newTx(t, ta.nonce, defaultAmount, defaultFee, ta.signer),
+		Received:    time.Now(),
+	}
+	require.NoError(t, transactions.Add(tc.db, &pending.Transaction, pending.Received))
+	buildSingleAccountCache(t, tc, ta, []*types.MeshTransaction{pending})
+
+	for _, fee := range []uint64{defaultFee, defaultFee + 1} {
+		underpriced := newTx(t, ta.nonce, defaultAmount, fee, ta.signer)
+		require.ErrorIs(t, tc.Add(context.Background(), tc.db, underpriced, time.Now(), false), errUnderpriced)
+		checkNoTX(t, tc.Cache, underpriced.ID)
+		checkTXNotInDB(t, tc.db, underpriced.ID)
+	}
+	checkTX(t, tc.Cache, pending.ID, types.LayerID{}, types.EmptyBlockID)
+
+	bumped := &types.MeshTransaction{
+		Transaction: *newTx(t, ta.nonce, defaultAmount, defaultFee*3/2, ta.signer),
+		Received:    time.Now(),
+	}
+	require.NoError(t, tc.Add(context.Background(), tc.db, &bumped.Transaction, bumped.Received, false))
+	checkTX(t, tc.Cache, bumped.ID, types.LayerID{}, types.EmptyBlockID)
+	checkNoTX(t, tc.Cache, pending.ID)
+	checkProjection(t, tc.Cache, ta.principal, ta.nonce+1, ta.balance-bumped.Spending())
+	checkMempool(t, tc.Cache, map[types.Address][]*types.MeshTransaction{ta.principal: {bumped}})
+}
+
+func TestCache_Add_MaxSize(t *testing.T) {
+	tc, accounts := createCache(t, 4)
+	tc.maxSize = 2
+	var all []*testAcct
+	for _, ta := range accounts {
+		all = append(all, ta)
+	}
+
+	add := func(ta *testAcct, nonce, fee uint64) *types.Transaction {
+		tx := newTx(t, nonce, defaultAmount, fee, ta.signer)
+		require.NoError(t, tc.Add(context.Background(), tc.db, tx, time.Now(), false))
+		return tx
+	}
+	low := add(all[0], all[0].nonce, defaultFee)
+	high := add(all[1], all[1].nonce, defaultFee*3)
+	// evicts the tx with the lowest gas price
+	mid := add(all[2], all[2].nonce, defaultFee*2)
+	checkNoTX(t, tc.Cache, low.ID)
+	checkTX(t, tc.Cache, high.ID, types.LayerID{}, types.EmptyBlockID)
+	checkTX(t, tc.Cache, mid.ID, types.LayerID{}, types.EmptyBlockID)
+	require.True(t, tc.MoreInDB(all[0].principal))
+	checkTXStateFromDB(t, tc.db, []*types.MeshTransaction{{Transaction: *low}}, types.MEMPOOL)
+
+	// the new tx is the cheapest, it is stored in db only
+	cheapest := add(all[3], all[3].nonce, defaultFee-1)
+	checkNoTX(t, tc.Cache, cheapest.ID)
+	require.True(t, tc.MoreInDB(all[3].principal))
+	checkTXStateFromDB(t, tc.db, []*types.MeshTransaction{{Transaction: *cheapest}}, types.MEMPOOL)
+
+	// only the highest nonce of an account is evicted
+	next := add(all[1], all[1].nonce+1, defaultFee*3)
+	checkTX(t, tc.Cache, high.ID, types.LayerID{}, types.EmptyBlockID)
+	checkTX(t, tc.Cache, next.ID, types.LayerID{}, types.EmptyBlockID)
+	checkNoTX(t, tc.Cache, mid.ID)
+}
+
+func TestCache_Add_MaxSize_Metrics(t *testing.T) {
+	tc, accounts := createCache(t, 3)
+	tc.maxSize = 2
+	var all []*testAcct
+	for _, ta := range accounts {
+		all = append(all, ta)
+	}
+	for i, ta := range all[:2] {
+		tx := newTx(t, ta.nonce, defaultAmount, defaultFee*uint64(i+2), ta.signer)
+		require.NoError(t, tc.Add(context.Background(), tc.db, tx, time.Now(), false))
+	}
+
+	ok := testutil.ToFloat64(mempoolTxCount.WithLabelValues(accepted))
+	full := testutil.ToFloat64(mempoolTxCount.WithLabelValues(mempoolFull))
+	cheapest := newTx(t, all[2].nonce, defaultAmount, defaultFee, all[2].signer)
+	require.NoError(t, tc.Add(context.Background(), tc.db, cheapest, time.Now(), false))
+	checkNoTX(t, tc.Cache, cheapest.ID)
+	require.Equal(t, ok, testutil.ToFloat64(mempoolTxCount.WithLabelValues(accepted)))
+	require.Equal(t, full+1, testutil.ToFloat64(mempoolTxCount.WithLabelValues(mempoolFull)))
+}
+
+func TestCache_Evicted_ReloadedWithRoom(t *testing.T) {
+	tc, accounts := createCache(t, 3)
+	tc.maxSize = 2
+	var all []*testAcct
+	for _, ta := range accounts {
+		all = append(all, ta)
+	}
+	add := func(ta *testAcct, fee uint64) *types.Transaction {
+		tx := newTx(t, ta.nonce, defaultAmount, fee, ta.signer)
+		require.NoError(t, tc.Add(context.Background(), tc.db, tx, time.Now(), false))
+		return tx
+	}
+	low := add(all[0], defaultFee)
+	high := add(all[1], defaultFee*3)
+	mid := add(all[2], defaultFee*2)
+	checkNoTX(t, tc.Cache, low.ID)
+	require.True(t, tc.MoreInDB(all[0].principal))
+
+	// the cache is still full, the evicted tx is not reloaded
+	lid := types.NewLayerID(97)
+	require.NoError(t, layers.SetApplied(tc.db, lid.Sub(1), types.RandomBlockID()))
+	require.NoError(t, tc.ApplyLayer(context.Background(), tc.db, lid, types.BlockID{1, 2, 3}, nil, nil))
+	checkNoTX(t, tc.Cache, low.ID)
+	checkTX(t, tc.Cache, high.ID, types.LayerID{}, types.EmptyBlockID)
+	checkTX(t, tc.Cache, mid.ID, types.LayerID{}, types.EmptyBlockID)
+	require.True(t, tc.MoreInDB(all[0].principal))
+
+	dropped, err := tc.Drop(context.Background(), tc.db, []types.TransactionID{mid.ID})
+	require.NoError(t, err)
+	require.Equal(t, []types.TransactionID{mid.ID}, dropped)
+
+	// there is room for the evicted tx after the next layer is applied
+	lid = lid.Add(1)
+	require.NoError(t, layers.SetApplied(tc.db, lid.Sub(1), types.RandomBlockID()))
+	require.NoError(t, tc.ApplyLayer(context.Background(), tc.db, lid, types.BlockID{2, 3, 4}, nil, nil))
+	checkTX(t, tc.Cache, low.ID, types.LayerID{}, types.EmptyBlockID)
+	checkTX(t, tc.Cache, high.ID, types.LayerID{}, types.EmptyBlockID)
+	require.False(t, tc.MoreInDB(all[0].principal))
+}
+
 func TestCache_Account_Add_UpdateHeader(t *testing.T) {
 	tc, ta := createSingleAccountTestCache(t)
 	buildSingleAccountCache(t, tc, ta, nil)
@@ -1359,3 +1486,33 @@ func TestCache_GetProjection(t *testing.T) {
 		require.Equal(t, expectedBalance, balance)
 	}
 }
+
+func TestCache_Drop(t *testing.T) {
+	tc, ta := createSingleAccountTestCache(t)
+	mtxs := genAndSaveTXs(t, tc.db, ta.signer, ta.nonce, ta.nonce+4, time.Now())
+	buildSingleAccountCache(t, tc, ta, mtxs)
+	lid := types.NewLayerID(10)
+	require.NoError(t, layers.SetApplied(tc.db, lid.Sub(1), types.RandomBlockID()))
+	require.NoError(t, tc.LinkTXsWithProposal(tc.db, lid, types.ProposalID{1}, []types.TransactionID{mtxs[0].ID}))
+
+	dropped, err := tc.Drop(context.Background(), tc.db, []types.TransactionID{mtxs[0].ID, mtxs[4].ID, types.RandomTransactionID()})
+	require.NoError(t, err)
+	require.Equal(t, []types.TransactionID{mtxs[4].ID}, dropped)
+	checkTX(t, tc.Cache, mtxs[0].ID, lid, types.EmptyBlockID)
+	for _, mtx := range mtxs[1:4] {
+		checkTX(t, tc.Cache, mtx.ID, types.LayerID{}, types.EmptyBlockID)
+	}
+	checkNoTX(t, tc.Cache, mtxs[4].ID)
+	checkTXNotInDB(t, tc.db, mtxs[4].ID)
+	checkProjection(t, tc.Cache, ta.principal, ta.nonce+4, ta.balance-4*mtxs[0].Spending())
+
+	dropped, err = tc.DropPrincipal(context.Background(), tc.db, ta.principal)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []types.TransactionID{mtxs[1].ID, mtxs[2].ID, mtxs[3].ID}, dropped)
+	checkTX(t, tc.Cache, mtxs[0].ID, lid, types.EmptyBlockID)
+	for _, mtx := range mtxs[1:] {
+		checkNoTX(t, tc.Cache, mtx.ID)
+		checkTXNotInDB(t, tc.db, mtx.ID)
+	}
+	checkProjection(t, tc.Cache, ta.principal, ta.nonce+1, ta.balance-mtxs[0].Spending())
+}
@@ -20,12 +20,18 @@ import (
 type CSConfig struct {
 	BlockGasLimit     uint64
 	NumTXsPerProposal int
+	// ReplaceBump is the minimal increase of the gas price, in percents,
+	// for a transaction to replace a pending one with the same nonce.
+	ReplaceBump uint64
+	// MaxMempoolSize is the maximal number of transactions in the mempool. Not limited if 0.
+	MaxMempoolSize int
 }
 
 func defaultCSConfig() CSConfig {
 	return CSConfig{
 		BlockGasLimit:     math.MaxUint64,
 		NumTXsPerProposal: 100,
+		ReplaceBump:       defaultReplaceBump,
 	}
 }
 
@@ -68,7 +74,10 @@ func NewConservativeState(state vmState, db *sql.Database, opts ...ConservativeS
 	for _, opt := range opts {
 		opt(cs)
 	}
-	cs.cache = NewCache(cs.getState, cs.logger)
+	cs.cache = NewCache(cs.getState, cs.logger,
+		WithReplaceBump(cs.cfg.ReplaceBump),
+		WithMaxSize(cs.cfg.MaxMempoolSize),
+	)
 	return cs
 }
 
@@ -112,9 +121,10 @@ func (cs *ConservativeState) Validation(raw types.RawTx) system.ValidationReques
 }
 
 // AddToCache adds the provided transaction to the conservative cache.
-func (cs *ConservativeState) AddToCache(ctx context.Context, tx *types.Transaction) error {
+// If mustPersist is true, the transaction is stored in the database even if it is not accepted to the mempool.
+func (cs *ConservativeState) AddToCache(ctx context.Context, tx *types.Transaction, mustPersist bool) error {
 	received := time.Now()
-	if err := cs.cache.Add(ctx, cs.db, tx, received, false); err != nil {
+	if err := cs.cache.Add(ctx, cs.db, tx, received, mustPersist); err != nil {
 		return err
 	}
 	events.ReportNewTx(types.LayerID{}, tx)
@@ -148,6 +158,40 @@ func (cs *ConservativeState) GetProjection(addr types.Address) (uint64, uint64)
 	return cs.cache.GetProjection(addr)
 }
 
+// GetMempool returns transactions that are eligible for a proposal/block, grouped by principal.
+func (cs *ConservativeState) GetMempool() map[types.Address][]*types.MeshTransaction {
+	mempool := cs.cache.GetMempool(cs.logger)
+	rst := make(map[types.Address][]*types.MeshTransaction, len(mempool))
+	for addr, ntxs := range mempool {
+		mtxs := make([]*types.MeshTransaction, 0, len(ntxs))
+		for _, ntx := range ntxs {
+			header := ntx.TxHeader
+			mtxs = append(mtxs, &types.MeshTransaction{
+				Transaction: types.Transaction{
+					RawTx:    types.RawTx{ID: ntx.ID},
+					TxHeader: &header,
+				},
+				LayerID:  ntx.Layer,
+				BlockID:  ntx.Block,
+				Received: ntx.Received,
+				State:    types.MEMPOOL,
+			})
+		}
+		rst[addr] = mtxs
+	}
+	return rst
+}
+
+// DropTXs removes pending transactions from the mempool and the database.
+func (cs *ConservativeState) DropTXs(ctx context.Context, tids []types.TransactionID) ([]types.TransactionID, error) {
+	return cs.cache.Drop(ctx, cs.db, tids)
+}
+
+// DropPrincipal removes pending transactions of the principal from the mempool and the database.
+func (cs *ConservativeState) DropPrincipal(ctx context.Context, addr types.Address) ([]types.TransactionID, error) {
+	return cs.cache.DropPrincipal(ctx, cs.db, addr)
+}
+
 // LinkTXsWithProposal associates the transactions to a proposal.
 func (cs *ConservativeState) LinkTXsWithProposal(lid types.LayerID, pid types.ProposalID, tids []types.TransactionID) error {
 	return cs.cache.LinkTXsWithProposal(cs.db, lid, pid, tids)
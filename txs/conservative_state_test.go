@@ -105,7 +105,7 @@ func addBatch(tb testing.TB, tcs *testConState, numTXs int) ([]types.Transaction
 		tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 		tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
 		tx := newTx(tb, nonce+5, defaultAmount, defaultFee, signer)
-		require.NoError(tb, tcs.AddToCache(context.Background(), tx))
+		require.NoError(tb, tcs.AddToCache(context.Background(), tx, false))
 		ids = append(ids, tx.ID)
 		txs = append(txs, tx)
 	}
@@ -124,11 +124,11 @@ func TestSelectProposalTXs(t *testing.T) {
 		tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 		tcs.mvm.EXPECT().GetNonce(addr).Return(uint64(1), nil).Times(1)
 		tx1 := newTx(t, 4, defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx1))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx1, false))
 		// all the TXs with nonce 1 are pending in database
 		require.NoError(t, tcs.LinkTXsWithBlock(lid, bid, []types.TransactionID{tx1.ID}))
 		tx2 := newTx(t, 6, defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx2))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx2, false))
 	}
 
 	got := tcs.SelectProposalTXs(lid, 1)
@@ -156,11 +156,11 @@ func TestSelectProposalTXs_ExhaustGas(t *testing.T) {
 		tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 		tcs.mvm.EXPECT().GetNonce(addr).Return(uint64(0), nil).Times(1)
 		tx1 := newTx(t, 0, defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx1))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx1, false))
 		// all the TXs with nonce 0 are pending in database
 		require.NoError(t, tcs.LinkTXsWithBlock(lid, bid, []types.TransactionID{tx1.ID}))
 		tx2 := newTx(t, 1, defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx2))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx2, false))
 	}
 	got := tcs.SelectProposalTXs(lid, 1)
 	require.Len(t, got, expSize)
@@ -182,11 +182,11 @@ func TestSelectProposalTXs_ExhaustMemPool(t *testing.T) {
 		tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 		tcs.mvm.EXPECT().GetNonce(addr).Return(uint64(0), nil).Times(1)
 		tx1 := newTx(t, 0, defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx1))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx1, false))
 		// all the TXs with nonce 0 are pending in database
 		require.NoError(t, tcs.LinkTXsWithBlock(lid, bid, []types.TransactionID{tx1.ID}))
 		tx2 := newTx(t, 1, defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx2))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx2, false))
 		expected = append(expected, tx2.ID)
 	}
 	got := tcs.SelectProposalTXs(lid, 1)
@@ -211,13 +211,13 @@ func TestSelectProposalTXs_SamePrincipal(t *testing.T) {
 	tcs.mvm.EXPECT().GetNonce(addr).Return(uint64(0), nil).Times(1)
 	for i := 0; i < numInBlock; i++ {
 		tx := newTx(t, uint64(i), defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 		require.NoError(t, tcs.LinkTXsWithBlock(lid, bid, []types.TransactionID{tx.ID}))
 	}
 	expected := make([]types.TransactionID, 0, numTXsInProposal)
 	for i := 0; i < numTXs; i++ {
 		tx := newTx(t, uint64(numInBlock+i), defaultAmount, defaultFee, signer)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 		if i < numTXsInProposal {
 			expected = append(expected, tx.ID)
 		}
@@ -248,20 +248,20 @@ func TestSelectProposalTXs_TwoPrincipals(t *testing.T) {
 	allTXs := make(map[types.TransactionID]*types.Transaction)
 	for i := 0; i < numInDBs; i++ {
 		tx := newTx(t, uint64(i), defaultAmount, defaultFee, signer1)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 		require.NoError(t, tcs.LinkTXsWithBlock(lid, bid, []types.TransactionID{tx.ID}))
 		allTXs[tx.ID] = tx
 		tx = newTx(t, uint64(i), defaultAmount, defaultFee, signer2)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 		require.NoError(t, tcs.LinkTXsWithBlock(lid, bid, []types.TransactionID{tx.ID}))
 		allTXs[tx.ID] = tx
 	}
 	for i := 0; i < numTXs; i++ {
 		tx := newTx(t, uint64(numInDBs+i), defaultAmount, defaultFee, signer1)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 		allTXs[tx.ID] = tx
 		tx = newTx(t, uint64(numInDBs+i), defaultAmount, defaultFee, signer2)
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 		allTXs[tx.ID] = tx
 	}
 	got := tcs.SelectProposalTXs(lid, 1)
@@ -291,10 +291,10 @@ func TestGetProjection(t *testing.T) {
 	tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 	tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
 	tx1 := newTx(t, nonce, defaultAmount, defaultFee, signer)
-	require.NoError(t, tcs.AddToCache(context.Background(), tx1))
+	require.NoError(t, tcs.AddToCache(context.Background(), tx1, false))
 	require.NoError(t, tcs.LinkTXsWithBlock(types.NewLayerID(10), types.BlockID{100}, []types.TransactionID{tx1.ID}))
 	tx2 := newTx(t, nonce+1, defaultAmount, defaultFee, signer)
-	require.NoError(t, tcs.AddToCache(context.Background(), tx2))
+	require.NoError(t, tcs.AddToCache(context.Background(), tx2, false))
 
 	got, balance := tcs.GetProjection(addr)
 	require.EqualValues(t, nonce+2, got)
@@ -309,7 +309,7 @@ func TestAddToCache(t *testing.T) {
 	tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 	tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
 	tx := newTx(t, nonce, defaultAmount, defaultFee, signer)
-	require.NoError(t, tcs.AddToCache(context.Background(), tx))
+	require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 	has := tcs.cache.Has(tx.ID)
 	require.True(t, has)
 	got, err := transactions.Get(tcs.db, tx.ID)
@@ -328,10 +328,42 @@ func TestAddToCache_BadNonceNotPersisted(t *testing.T) {
 	}
 	tcs.mvm.EXPECT().GetBalance(tx.Principal).Return(defaultBalance, nil).Times(1)
 	tcs.mvm.EXPECT().GetNonce(tx.Principal).Return(tx.Nonce+1, nil).Times(1)
-	require.ErrorIs(t, tcs.AddToCache(context.Background(), tx), errBadNonce)
+	require.ErrorIs(t, tcs.AddToCache(context.Background(), tx, false), errBadNonce)
 	checkTXNotInDB(t, tcs.db, tx.ID)
 }
 
+func TestHandleProposalTransaction_UnderpricedPersisted(t *testing.T) {
+	tcs := createConservativeState(t)
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	addr := types.GenerateAddress(signer.PublicKey().Bytes())
+	tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
+	tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
+	pending := newTx(t, nonce, defaultAmount, defaultFee, signer)
+	require.NoError(t, tcs.AddToCache(context.Background(), pending, false))
+
+	validation := func(tx *types.Transaction) {
+		req := smocks.NewMockValidationRequest(gomock.NewController(t))
+		req.EXPECT().Parse().Return(tx.TxHeader, nil)
+		req.EXPECT().Verify().Return(true)
+		tcs.mvm.EXPECT().Validation(tx.RawTx).Return(req)
+	}
+	// same nonce and gas price, it doesn't replace the pending tx
+	gossiped := newTx(t, nonce, defaultAmount, defaultFee, signer)
+	validation(gossiped)
+	require.Equal(t, pubsub.ValidationIgnore, tcs.handler().HandleGossipTransaction(context.Background(), "", gossiped.Raw))
+	checkTXNotInDB(t, tcs.db, gossiped.ID)
+
+	referenced := newTx(t, nonce, defaultAmount, defaultFee, signer)
+	validation(referenced)
+	require.NoError(t, tcs.handler().HandleProposalTransaction(context.Background(), referenced.Raw))
+	got, err := transactions.Get(tcs.db, referenced.ID)
+	require.NoError(t, err)
+	require.Equal(t, *referenced, got.Transaction)
+	require.False(t, tcs.cache.Has(referenced.ID))
+	require.True(t, tcs.cache.Has(pending.ID))
+}
+
 func TestAddToCache_NonceGap(t *testing.T) {
 	tcs := createConservativeState(t)
 	tx := &types.Transaction{
@@ -343,7 +375,7 @@ func TestAddToCache_NonceGap(t *testing.T) {
 	}
 	tcs.mvm.EXPECT().GetBalance(tx.Principal).Return(defaultBalance, nil).Times(1)
 	tcs.mvm.EXPECT().GetNonce(tx.Principal).Return(tx.Nonce-2, nil).Times(1)
-	require.NoError(t, tcs.AddToCache(context.Background(), tx))
+	require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 	require.True(t, tcs.cache.Has(tx.ID))
 	require.False(t, tcs.cache.MoreInDB(tx.Principal))
 	checkTXStateFromDB(t, tcs.db, []*types.MeshTransaction{{Transaction: *tx}}, types.MEMPOOL)
@@ -357,7 +389,7 @@ func TestAddToCache_InsufficientBalance(t *testing.T) {
 	tcs.mvm.EXPECT().GetBalance(addr).Return(defaultAmount, nil).Times(1)
 	tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
 	tx := newTx(t, nonce, defaultAmount, defaultFee, signer)
-	require.NoError(t, tcs.AddToCache(context.Background(), tx))
+	require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 	checkNoTX(t, tcs.cache, tx.ID)
 	require.True(t, tcs.cache.MoreInDB(addr))
 	checkTXStateFromDB(t, tcs.db, []*types.MeshTransaction{{Transaction: *tx}}, types.MEMPOOL)
@@ -374,7 +406,7 @@ func TestAddToCache_TooManyForOneAccount(t *testing.T) {
 	for i := 0; i <= maxTXsPerAcct; i++ {
 		tx := newTx(t, nonce+uint64(i), defaultAmount, defaultFee, signer)
 		mtxs = append(mtxs, &types.MeshTransaction{Transaction: *tx})
-		require.NoError(t, tcs.AddToCache(context.Background(), tx))
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 	}
 	require.True(t, tcs.cache.MoreInDB(addr))
 	checkTXStateFromDB(t, tcs.db, mtxs, types.MEMPOOL)
@@ -388,7 +420,7 @@ func TestGetMeshTransaction(t *testing.T) {
 	tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
 	tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
 	tx := newTx(t, nonce, defaultAmount, defaultFee, signer)
-	require.NoError(t, tcs.AddToCache(context.Background(), tx))
+	require.NoError(t, tcs.AddToCache(context.Background(), tx, false))
 	mtx, err := tcs.GetMeshTransaction(tx.ID)
 	require.NoError(t, err)
 	require.Equal(t, types.MEMPOOL, mtx.State)
@@ -16,8 +16,8 @@ import (
 
 var (
 	errDuplicateTX = errors.New("tx already exists")
-	errParse       = errors.New("failed to parse tx")
-	errVerify      = errors.New("failed to verify tx")
+	errParse       = fmt.Errorf("%w: failed to parse tx", pubsub.ErrValidationReject)
+	errVerify      = fmt.Errorf("%w: failed to verify tx", pubsub.ErrValidationReject)
 )
 
 // TxHandler handles the transactions received via gossip or sync.
@@ -46,6 +46,8 @@ func updateMetrics(err error, counter *prometheus.CounterVec) {
 		counter.WithLabelValues(cantParse).Inc()
 	case errors.Is(err, errVerify):
 		counter.WithLabelValues(cantVerify).Inc()
+	case errors.Is(err, errUnderpriced):
+		counter.WithLabelValues(underpriced).Inc()
 	default:
 		counter.WithLabelValues(rejectedInternalErr).Inc()
 	}
@@ -53,7 +55,7 @@ func updateMetrics(err error, counter *prometheus.CounterVec) {
 
 // HandleGossipTransaction handles data received on the transactions gossip channel.
 func (th *TxHandler) HandleGossipTransaction(ctx context.Context, _ p2p.Peer, msg []byte) pubsub.ValidationResult {
-	err := th.handleTransaction(ctx, msg)
+	err := th.handleTransaction(ctx, msg, false)
 	defer updateMetrics(err, gossipTxCount)
 	if err != nil {
 		th.logger.WithContext(ctx).With().Warning("failed to handle tx", log.Err(err))
@@ -63,8 +65,9 @@ func (th *TxHandler) HandleGossipTransaction(ctx context.Context, _ p2p.Peer, ms
 }
 
 // HandleProposalTransaction handles data received on the transactions synced as a part of proposal.
+// Transaction is stored even if it is not accepted to the mempool, as it is referenced by the proposal.
 func (th *TxHandler) HandleProposalTransaction(ctx context.Context, msg []byte) error {
-	err := th.handleTransaction(ctx, msg)
+	err := th.handleTransaction(ctx, msg, true)
 	defer updateMetrics(err, proposalTxCount)
 	if err == nil || errors.Is(err, errDuplicateTX) {
 		return nil
@@ -72,7 +75,7 @@ func (th *TxHandler) HandleProposalTransaction(ctx context.Context, msg []byte)
 	return err
 }
 
-func (th *TxHandler) handleTransaction(ctx context.Context, msg []byte) error {
+func (th *TxHandler) handleTransaction(ctx context.Context, msg []byte, mustPersist bool) error {
 	raw := types.NewRawTx(msg)
 	tx, err := th.state.GetMeshTransaction(raw.ID)
 	if err != nil && !errors.Is(err, sql.ErrNotFound) {
@@ -93,7 +96,7 @@ func (th *TxHandler) handleTransaction(ctx context.Context, msg []byte) error {
 	if !req.Verify() {
 		return fmt.Errorf("%w: %s", errVerify, raw.ID)
 	}
-	if err := th.state.AddToCache(ctx, &types.Transaction{RawTx: raw, TxHeader: header}); err != nil {
+	if err := th.state.AddToCache(ctx, &types.Transaction{RawTx: raw, TxHeader: header}, mustPersist); err != nil {
 		th.logger.WithContext(ctx).With().Warning("failed to add tx to conservative cache",
 			raw.ID,
 			log.Err(err))
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
@@ -13,7 +15,7 @@ import (
 type conservativeState interface {
 	HasTx(types.TransactionID) (bool, error)
 	Validation(types.RawTx) system.ValidationRequest
-	AddToCache(context.Context, *types.Transaction) error
+	AddToCache(context.Context, *types.Transaction, bool) error
 	AddToDB(*types.Transaction) error
 	GetMeshTransaction(types.TransactionID) (*types.MeshTransaction, error)
 }
@@ -24,6 +26,9 @@ type vmState interface {
 	GetLayerStateRoot(types.LayerID) (types.Hash32, error)
 	GetLayerApplied(types.TransactionID) (types.LayerID, error)
 	GetAllAccounts() ([]*types.Account, error)
+	GetAccountWithProof(types.Address, types.LayerID) (*types.Account, *trie.Proof, error)
+	Simulate(types.LayerID, []types.RawTx) ([]types.TransactionWithResult, error)
+	EstimateGas(types.LayerID, types.RawTx) (*vm.GasEstimate, error)
 	GetBalance(types.Address) (uint64, error)
 	GetNonce(types.Address) (types.Nonce, error)
 }
@@ -24,7 +24,15 @@ const (
 	mempool         = "mempool"
 	balanceTooSmall = "balance"
 	tooManyNonce    = "too_many"
+	underpriced     = "underpriced"
+	mempoolFull     = "full"
 	accepted        = "ok"
+
+	// labels for the reason a tx was evicted from the mempool.
+	evictReplaced   = "replaced"
+	evictInfeasible = "infeasible"
+	evictSizeCap    = "size_cap"
+	evictDropped    = "dropped"
 )
 
 var (
@@ -58,6 +66,12 @@ var (
 		"number of transactions added to the mempool",
 		[]string{"outcome"},
 	)
+	mempoolEvictCount = metrics.NewCounter(
+		"mempool_evictions",
+		namespace,
+		"number of transactions evicted from the mempool",
+		[]string{"reason"},
+	)
 )
 
 var (
@@ -10,6 +10,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/spacemeshos/go-spacemesh/common/types"
+	genvm "github.com/spacemeshos/go-spacemesh/genvm"
+	trie "github.com/spacemeshos/go-spacemesh/genvm/trie"
 	log "github.com/spacemeshos/go-spacemesh/log"
 	system "github.com/spacemeshos/go-spacemesh/system"
 )
@@ -38,17 +40,17 @@ func (m *MockconservativeState) EXPECT() *MockconservativeStateMockRecorder {
 }
 
 // AddToCache mocks base method.
-func (m *MockconservativeState) AddToCache(arg0 context.Context, arg1 *types.Transaction) error {
+func (m *MockconservativeState) AddToCache(arg0 context.Context, arg1 *types.Transaction, arg2 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToCache", arg0, arg1)
+	ret := m.ctrl.Call(m, "AddToCache", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddToCache indicates an expected call of AddToCache.
-func (mr *MockconservativeStateMockRecorder) AddToCache(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockconservativeStateMockRecorder) AddToCache(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCache", reflect.TypeOf((*MockconservativeState)(nil).AddToCache), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCache", reflect.TypeOf((*MockconservativeState)(nil).AddToCache), arg0, arg1, arg2)
 }
 
 // AddToDB mocks base method.
@@ -132,6 +134,37 @@ func (m *MockvmState) EXPECT() *MockvmStateMockRecorder {
 	return m.recorder
 }
 
+// EstimateGas mocks base method.
+func (m *MockvmState) EstimateGas(arg0 types.LayerID, arg1 types.RawTx) (*genvm.GasEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", arg0, arg1)
+	ret0, _ := ret[0].(*genvm.GasEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockvmStateMockRecorder) EstimateGas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockvmState)(nil).EstimateGas), arg0, arg1)
+}
+
+// GetAccountWithProof mocks base method.
+func (m *MockvmState) GetAccountWithProof(arg0 types.Address, arg1 types.LayerID) (*types.Account, *trie.Proof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountWithProof", arg0, arg1)
+	ret0, _ := ret[0].(*types.Account)
+	ret1, _ := ret[1].(*trie.Proof)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAccountWithProof indicates an expected call of GetAccountWithProof.
+func (mr *MockvmStateMockRecorder) GetAccountWithProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountWithProof", reflect.TypeOf((*MockvmState)(nil).GetAccountWithProof), arg0, arg1)
+}
+
 // GetAllAccounts mocks base method.
 func (m *MockvmState) GetAllAccounts() ([]*types.Account, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +255,21 @@ func (mr *MockvmStateMockRecorder) GetStateRoot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockvmState)(nil).GetStateRoot))
 }
 
+// Simulate mocks base method.
+func (m *MockvmState) Simulate(arg0 types.LayerID, arg1 []types.RawTx) ([]types.TransactionWithResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", arg0, arg1)
+	ret0, _ := ret[0].([]types.TransactionWithResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockvmStateMockRecorder) Simulate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockvmState)(nil).Simulate), arg0, arg1)
+}
+
 // Validation mocks base method.
 func (m *MockvmState) Validation(arg0 types.RawTx) system.ValidationRequest {
 	m.ctrl.T.Helper()